@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+)
+
+type command struct {
+	description string
+	// Defines the flags of the command on the flag set, parses the arguments and runs the command
+	run func(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"list-repos": {description: "List the repositories accessible by the token", run: runListRepositories},
+	"create-pr":  {description: "Create a pull request", run: runCreatePullRequest},
+	"comment":    {description: "Add a comment to a pull request", run: runComment},
+	"set-status": {description: "Set the status of a commit", run: runSetCommitStatus},
+	"download":   {description: "Download a repository to a local directory", run: runDownload},
+}
+
+var commitStatuses = map[string]vcsclient.CommitStatus{
+	"pass":        vcsclient.Pass,
+	"fail":        vcsclient.Fail,
+	"error":       vcsclient.Error,
+	"in-progress": vcsclient.InProgress,
+}
+
+func getCommandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// repositoryFlags are the flags of the commands running on a repository
+type repositoryFlags struct {
+	owner      string
+	repository string
+}
+
+func (repositoryFlags *repositoryFlags) define(flags *flag.FlagSet) {
+	flags.StringVar(&repositoryFlags.owner, "owner", "", "The owner of the repository, such as the organization, the workspace or the project key. Unused on Azure Repos")
+	flags.StringVar(&repositoryFlags.repository, "repo", "", "The name of the repository")
+}
+
+// parseFlags parses the arguments, and fails if any of the required flags isn't set
+func parseFlags(flags *flag.FlagSet, args []string, required ...string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var missing []string
+	for _, name := range required {
+		if flags.Lookup(name).Value.String() == "" {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s: missing required flags: %s", flags.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func runListRepositories(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	var fullNames []string
+	for owner, names := range repositories {
+		for _, name := range names {
+			fullNames = append(fullNames, owner+"/"+name)
+		}
+	}
+	sort.Strings(fullNames)
+	for _, fullName := range fullNames {
+		fmt.Fprintln(stdout, fullName)
+	}
+	return nil
+}
+
+func runCreatePullRequest(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, _ io.Writer) error {
+	var repository repositoryFlags
+	repository.define(flags)
+	sourceBranch := flags.String("source", "", "The source branch")
+	targetBranch := flags.String("target", "", "The target branch")
+	title := flags.String("title", "", "The title of the pull request")
+	description := flags.String("description", "", "The description of the pull request")
+	if err := parseFlags(flags, args, "repo", "source", "target", "title"); err != nil {
+		return err
+	}
+	return client.CreatePullRequest(ctx, repository.owner, repository.repository, *sourceBranch, *targetBranch, *title, *description)
+}
+
+func runComment(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, _ io.Writer) error {
+	var repository repositoryFlags
+	repository.define(flags)
+	pullRequestID := flags.Int("pr", 0, "The ID of the pull request")
+	content := flags.String("content", "", "The content of the comment")
+	if err := parseFlags(flags, args, "repo", "content"); err != nil {
+		return err
+	}
+	if *pullRequestID <= 0 {
+		return fmt.Errorf("%s: missing required flags: --pr", flags.Name())
+	}
+	return client.AddPullRequestComment(ctx, repository.owner, repository.repository, *content, *pullRequestID)
+}
+
+func runSetCommitStatus(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, _ io.Writer) error {
+	var repository repositoryFlags
+	repository.define(flags)
+	ref := flags.String("ref", "", "The SHA of the commit")
+	state := flags.String("state", "", "The status of the commit: pass, fail, error or in-progress")
+	title := flags.String("title", "", "The title of the status, identifying it among the statuses of the commit")
+	description := flags.String("description", "", "The description of the status")
+	detailsURL := flags.String("details-url", "", "The URL of the details of the status")
+	if err := parseFlags(flags, args, "repo", "ref", "state", "title"); err != nil {
+		return err
+	}
+	commitStatus, exists := commitStatuses[*state]
+	if !exists {
+		return fmt.Errorf("%s: unknown state %q, expected one of: pass, fail, error, in-progress", flags.Name(), *state)
+	}
+	return client.SetCommitStatus(ctx, commitStatus, repository.owner, repository.repository, *ref, *title, *description, *detailsURL)
+}
+
+func runDownload(ctx context.Context, client vcsclient.VcsClient, flags *flag.FlagSet, args []string, _ io.Writer) error {
+	var repository repositoryFlags
+	repository.define(flags)
+	branch := flags.String("branch", "", "The branch to download")
+	localPath := flags.String("path", ".", "The local directory to download the repository to")
+	if err := parseFlags(flags, args, "repo", "branch"); err != nil {
+		return err
+	}
+	return client.DownloadRepository(ctx, repository.owner, repository.repository, *branch, *localPath)
+}
@@ -0,0 +1,101 @@
+// Command froggit is a thin command-line tool wrapping the froggit-go VCS clients.
+// It's useful for smoke-testing the credentials of a VCS provider, and for scripting without writing Go.
+//
+// Usage:
+//
+//	froggit --provider github --token $TOKEN list-repos
+//	froggit --provider gitlab --api-endpoint https://gitlab.example.com/api/v4 create-pr --owner jfrog --repo froggit-go --source feature --target main --title "Add feature"
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// The environment variable of the access token, used if the token flag isn't set
+const tokenEnvVar = "FROGGIT_TOKEN"
+
+var providers = map[string]vcsutils.VcsProvider{
+	"github":          vcsutils.GitHub,
+	"gitlab":          vcsutils.GitLab,
+	"bitbucketserver": vcsutils.BitbucketServer,
+	"bitbucketcloud":  vcsutils.BitbucketCloud,
+	"azurerepos":      vcsutils.AzureRepos,
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "froggit:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags, builds the client and runs the command
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("froggit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	provider := flags.String("provider", "", "The VCS provider: "+strings.Join(getProviderNames(), ", "))
+	apiEndpoint := flags.String("api-endpoint", "", "The API endpoint of the VCS provider. Required on self-hosted servers")
+	username := flags.String("username", "", "The username, required on Bitbucket Server and Bitbucket Cloud")
+	token := flags.String("token", "", "The access token. Defaults to the "+tokenEnvVar+" environment variable")
+	project := flags.String("project", "", "The project, required on Azure Repos")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: froggit [flags] <command> [command flags]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		for _, name := range getCommandNames() {
+			fmt.Fprintf(stderr, "  %-12s %s\n", name, commands[name].description)
+		}
+		fmt.Fprintln(stderr, "\nFlags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("no command was provided")
+	}
+	cmd, exists := commands[flags.Arg(0)]
+	if !exists {
+		return fmt.Errorf("unknown command %q, expected one of: %s", flags.Arg(0), strings.Join(getCommandNames(), ", "))
+	}
+	vcsProvider, exists := providers[strings.ToLower(*provider)]
+	if !exists {
+		return fmt.Errorf("unknown provider %q, expected one of: %s", *provider, strings.Join(getProviderNames(), ", "))
+	}
+	if *token == "" {
+		*token = os.Getenv(tokenEnvVar)
+	}
+	client, err := vcsclient.NewClientBuilder(vcsProvider).
+		ApiEndpoint(*apiEndpoint).
+		Username(*username).
+		Token(*token).
+		Project(*project).
+		Build()
+	if err != nil {
+		return err
+	}
+	commandFlags := flag.NewFlagSet(flags.Arg(0), flag.ContinueOnError)
+	commandFlags.SetOutput(stderr)
+	return cmd.run(ctx, client, commandFlags, flags.Args()[1:], stdout)
+}
+
+func getProviderNames() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	var createdPullRequest map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user/repos":
+			_, err := w.Write([]byte(`[{"name": "repo-2", "owner": {"login": "jfrog"}}, {"name": "repo-1", "owner": {"login": "jfrog"}}, {"name": "frogbot", "owner": {"login": "frogger"}}]`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/jfrog/repo-1/pulls":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&createdPullRequest))
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"number": 1}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request", r.Method, r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	globalFlags := []string{"--provider", "github", "--api-endpoint", server.URL, "--token", "secret"}
+
+	var stdout, stderr bytes.Buffer
+	err := run(ctx, append(globalFlags, "list-repos"), &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "frogger/frogbot\njfrog/repo-1\njfrog/repo-2\n", stdout.String())
+
+	err = run(ctx, append(globalFlags, "create-pr", "--owner", "jfrog", "--repo", "repo-1", "--source", "feature", "--target", "main", "--title", "Add feature"), &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"head": "jfrog:feature", "base": "main", "title": "Add feature", "body": ""}, createdPullRequest)
+
+	// The token is read from the environment, if not set by a flag
+	t.Setenv(tokenEnvVar, "secret")
+	stdout.Reset()
+	err = run(ctx, []string{"--provider", "GitHub", "--api-endpoint", server.URL, "list-repos"}, &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "frogger/frogbot\njfrog/repo-1\njfrog/repo-2\n", stdout.String())
+}
+
+func TestRunInvalidArguments(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "no command", args: []string{"--provider", "github"}, expectedError: "no command was provided"},
+		{name: "unknown command", args: []string{"--provider", "github", "delete-repo"}, expectedError: `unknown command "delete-repo", expected one of: comment, create-pr, download, list-repos, set-status`},
+		{name: "unknown provider", args: []string{"--provider", "svn", "list-repos"}, expectedError: `unknown provider "svn", expected one of: azurerepos, bitbucketcloud, bitbucketserver, github, gitlab`},
+		{name: "missing flags", args: []string{"--provider", "github", "comment", "--owner", "jfrog"}, expectedError: "comment: missing required flags: --repo, --content"},
+		{name: "missing pull request", args: []string{"--provider", "github", "comment", "--owner", "jfrog", "--repo", "repo-1", "--content", "hello"}, expectedError: "comment: missing required flags: --pr"},
+		{name: "unknown state", args: []string{"--provider", "github", "set-status", "--owner", "jfrog", "--repo", "repo-1", "--ref", "abc", "--state", "ok", "--title", "scan"}, expectedError: `set-status: unknown state "ok", expected one of: pass, fail, error, in-progress`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			assert.EqualError(t, run(ctx, tt.args, &stdout, &stderr), tt.expectedError)
+		})
+	}
+}
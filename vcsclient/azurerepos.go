@@ -2,20 +2,26 @@ package vcsclient
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/jfrog/froggit-go/vcsutils"
-	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/gofrog/version"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -28,37 +34,286 @@ const (
 
 var errAzureGetCommitsWithOptionsNotSupported = fmt.Errorf("get commits with options is %s", notSupportedOnAzure)
 
+const (
+	// The API of the public session tokens, which hold the public SSH keys of the user
+	azureSessionTokensPath = "_apis/Token/SessionTokens?isPublic=true&api-version=5.0-preview.1"
+	azureSshKeyScope       = "app_token"
+)
+
 // Azure Devops API version 6
 type AzureReposClient struct {
 	vcsInfo           VcsInfo
 	connectionDetails *azuredevops.Connection
 	logger            vcsutils.Log
+	// The git client is built lazily and shared by all the requests, since building it requires resource area discovery requests
+	gitClient      git.Client
+	gitClientMutex sync.Mutex
+	// Resolves the branch names passed to GetCommitStatuses, which requires a commit hash
+	branchHeads branchHeadResolver
+	// Caches the maximal REST API version of the server
+	serverVersion serverVersionResolver
 }
 
 // NewAzureReposClient create a new AzureReposClient
 func NewAzureReposClient(vcsInfo VcsInfo, logger vcsutils.Log) (*AzureReposClient, error) {
 	client := &AzureReposClient{vcsInfo: vcsInfo, logger: logger}
 	baseUrl := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/")
-	client.connectionDetails = azuredevops.NewPatConnection(baseUrl, client.vcsInfo.Token)
+	if client.vcsInfo.TokenProvider != nil {
+		// The Authorization header is set by the HTTP client, with a token fetched from the provider before each request
+		client.connectionDetails = azuredevops.NewAnonymousConnection(baseUrl)
+	} else {
+		client.connectionDetails = azuredevops.NewPatConnection(baseUrl, client.vcsInfo.Token)
+	}
+	client.connectionDetails.TlsConfig = client.vcsInfo.TLSConfig
 	return client, nil
 }
 
+// Raw returns the Azure DevOps git client used by the AzureReposClient, to call the endpoints which aren't wrapped by the AzureReposClient.
+// The client is shared with the AzureReposClient, and reuses its authentication, including the token provider, and its TLS configuration,
+// HTTP tracing, metrics and trace propagation. The client is built on the first call, which discovers the URL of the git resource area.
+// The results aren't sorted.
+func (client *AzureReposClient) Raw(ctx context.Context) (git.Client, error) {
+	ctx = labelAPICalls(ctx, "Raw")
+	return client.buildAzureReposClient(ctx)
+}
+
 func (client *AzureReposClient) buildAzureReposClient(ctx context.Context) (git.Client, error) {
 	if client.connectionDetails == nil {
 		return nil, errors.New("connection details wasn't initialized")
 	}
-	return git.NewClient(ctx, client.connectionDetails)
+	client.gitClientMutex.Lock()
+	defer client.gitClientMutex.Unlock()
+	if client.gitClient != nil {
+		return client.gitClient, nil
+	}
+	gitClient, err := client.newAzureReposGitClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.gitClient = gitClient
+	return gitClient, nil
+}
+
+// newAzureReposGitClient discovers the URL of the git resource area and creates a git client for it.
+// An unauthorized response to any of the client's requests invalidates the cached client, so it is rebuilt on the next call.
+func (client *AzureReposClient) newAzureReposGitClient(ctx context.Context) (git.Client, error) {
+	baseUrl := client.connectionDetails.BaseUrl
+	resourceAreas, err := client.getResourceAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// On-premises servers return no resource areas, and are accessed using the base URL
+	if resourceAreas != nil && len(*resourceAreas) > 0 {
+		gitLocationUrl := ""
+		for _, resourceArea := range *resourceAreas {
+			if resourceArea.Id != nil && *resourceArea.Id == git.ResourceAreaId {
+				gitLocationUrl = vcsutils.DefaultIfNotNil(resourceArea.LocationUrl)
+			}
+		}
+		if gitLocationUrl == "" {
+			return nil, &azuredevops.ResourceAreaIdNotRegisteredError{ResourceAreaId: git.ResourceAreaId, Url: baseUrl}
+		}
+		baseUrl = gitLocationUrl
+	}
+	httpClient := client.newHTTPClient()
+	httpClient.Transport = &unauthorizedResponseHandlingTransport{
+		RoundTripper:   httpClient.Transport,
+		onUnauthorized: client.invalidateAzureReposClient,
+	}
+	azureDevopsClient := azuredevops.NewClientWithOptions(client.connectionDetails, strings.TrimSuffix(baseUrl, "/"), azuredevops.WithHTTPClient(httpClient))
+	return &git.ClientImpl{Client: *azureDevopsClient}, nil
+}
+
+// buildAzureWorkItemTrackingClient creates a work item tracking client, sharing the connection of the git client.
+// The work item tracking resource area is located at the organization URL, as the git resource area.
+func (client *AzureReposClient) buildAzureWorkItemTrackingClient(ctx context.Context) (workitemtracking.Client, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gitClient, ok := azureReposGitClient.(*git.ClientImpl)
+	if !ok {
+		return nil, errors.New("unexpected type of the Azure Repos git client")
+	}
+	return &workitemtracking.ClientImpl{Client: gitClient.Client}, nil
+}
+
+func (client *AzureReposClient) invalidateAzureReposClient() {
+	client.gitClientMutex.Lock()
+	defer client.gitClientMutex.Unlock()
+	client.gitClient = nil
+}
+
+// unauthorizedResponseHandlingTransport notifies about responses with an Unauthorized status
+type unauthorizedResponseHandlingTransport struct {
+	http.RoundTripper
+	onUnauthorized func()
+}
+
+func (transport *unauthorizedResponseHandlingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	response, err := roundTripper.RoundTrip(request)
+	if err == nil && response.StatusCode == http.StatusUnauthorized {
+		transport.onUnauthorized()
+	}
+	return response, err
+}
+
+// newHTTPClient creates an HTTP client, which authenticates the requests by a bearer token from the token provider, if set.
+// Otherwise, the requests are authenticated by the personal access token of the connection.
+func (client *AzureReposClient) newHTTPClient() *http.Client {
+	httpClient := newHTTPClient(vcsutils.AzureRepos, client.vcsInfo, client.logger)
+	if client.vcsInfo.TokenProvider != nil {
+		httpClient.Transport = &bearerTokenTransport{RoundTripper: httpClient.Transport, tokenProvider: client.vcsInfo.TokenProvider}
+	}
+	return httpClient
+}
+
+func (client *AzureReposClient) getResourceAreas(ctx context.Context) (*[]azuredevops.ResourceAreaInfo, error) {
+	baseUrl := client.connectionDetails.BaseUrl
+	return azuredevops.NewClientWithOptions(client.connectionDetails, baseUrl, azuredevops.WithHTTPClient(client.newHTTPClient())).GetResourceAreas(ctx)
+}
+
+// bearerTokenTransport sets a bearer token from the token provider in the Authorization header of each request
+type bearerTokenTransport struct {
+	http.RoundTripper
+	tokenProvider TokenProvider
+}
+
+func (transport *bearerTokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, err := transport.tokenProvider(request.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an access token: %w", err)
+	}
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	// A RoundTripper must not modify the original request
+	request = request.Clone(request.Context())
+	request.Header.Set("Authorization", "Bearer "+token)
+	return roundTripper.RoundTrip(request)
 }
 
 // TestConnection on Azure Repos
 func (client *AzureReposClient) TestConnection(ctx context.Context) error {
-	buildClient := azuredevops.NewClient(client.connectionDetails, client.connectionDetails.BaseUrl)
-	_, err := buildClient.GetResourceAreas(ctx)
+	ctx = labelAPICalls(ctx, "TestConnection")
+	_, err := client.getResourceAreas(ctx)
 	return err
 }
 
+// GetServerVersion on Azure Repos returns the maximal REST API version of the server.
+// For example, Azure DevOps Server 2020 supports version 6.0, and Azure DevOps Server 2022 supports version 7.0.
+func (client *AzureReposClient) GetServerVersion(ctx context.Context) (string, error) {
+	ctx = labelAPICalls(ctx, "GetServerVersion")
+	return client.serverVersion.resolve(ctx, func(ctx context.Context) (string, error) {
+		resourceLocations, err := client.getResourceLocations(ctx)
+		if err != nil {
+			return "", err
+		}
+		maxVersion := ""
+		for _, resourceLocation := range resourceLocations {
+			resourceVersion := vcsutils.DefaultIfNotNil(resourceLocation.MaxVersion)
+			if resourceVersion != "" && (maxVersion == "" || !version.NewVersion(maxVersion).AtLeast(resourceVersion)) {
+				maxVersion = resourceVersion
+			}
+		}
+		if maxVersion == "" {
+			return "", errors.New("no REST API version was found in the resource locations of the server")
+		}
+		return maxVersion, nil
+	})
+}
+
+// getResourceLocations returns the REST API resource locations of the server, including the API versions they support
+func (client *AzureReposClient) getResourceLocations(ctx context.Context) ([]azuredevops.ApiResourceLocation, error) {
+	var resourceLocations []azuredevops.ApiResourceLocation
+	err := client.sendServerRequest(ctx, http.MethodOptions, "_apis", func(azureDevopsClient *azuredevops.Client, response *http.Response) error {
+		return azureDevopsClient.UnmarshalCollectionBody(response, &resourceLocations)
+	})
+	return resourceLocations, err
+}
+
+// GetAuthenticatedUser on Azure Repos returns the identity authenticated by the client
+func (client *AzureReposClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	ctx = labelAPICalls(ctx, "GetAuthenticatedUser")
+	var connectionData struct {
+		AuthenticatedUser struct {
+			Id                  string `json:"id"`
+			ProviderDisplayName string `json:"providerDisplayName"`
+			Properties          struct {
+				Account struct {
+					Value string `json:"$value"`
+				} `json:"Account"`
+			} `json:"properties"`
+		} `json:"authenticatedUser"`
+	}
+	err := client.sendServerRequest(ctx, http.MethodGet, "_apis/connectionData", func(azureDevopsClient *azuredevops.Client, response *http.Response) error {
+		return azureDevopsClient.UnmarshalBody(response, &connectionData)
+	})
+	if err != nil {
+		return UserInfo{}, err
+	}
+	authenticatedUser := connectionData.AuthenticatedUser
+	if authenticatedUser.Id == "" {
+		return UserInfo{}, errors.New("the authenticated user is missing in the connection data")
+	}
+	userInfo := UserInfo{ID: authenticatedUser.Id, Login: authenticatedUser.Properties.Account.Value, DisplayName: authenticatedUser.ProviderDisplayName}
+	if strings.Contains(userInfo.Login, "@") {
+		userInfo.Email = userInfo.Login
+	}
+	return userInfo, nil
+}
+
+// sendServerRequest sends a request to an API of the server, which isn't covered by the Azure DevOps clients.
+// path - The API path, relative to the base URL
+// unmarshalResponse - Reads the body of a successful response
+func (client *AzureReposClient) sendServerRequest(ctx context.Context, method, path string, unmarshalResponse func(*azuredevops.Client, *http.Response) error) error {
+	return client.sendRequestWithBody(ctx, method, client.connectionDetails.BaseUrl, path, nil, unmarshalResponse)
+}
+
+// sendRequestWithBody sends a request with an optional JSON body to an API, which isn't covered by the Azure DevOps clients.
+// baseUrl - The URL of the organization or the collection on the host of the API
+// body    - The request body, which is marshalled to JSON, or nil
+func (client *AzureReposClient) sendRequestWithBody(ctx context.Context, method, baseUrl, path string, body any, unmarshalResponse func(*azuredevops.Client, *http.Response) error) error {
+	baseUrl = strings.TrimSuffix(baseUrl, "/")
+	azureDevopsClient := azuredevops.NewClientWithOptions(client.connectionDetails, baseUrl, azuredevops.WithHTTPClient(client.newHTTPClient()))
+	var requestBody io.Reader
+	mediaType := ""
+	if body != nil {
+		marshalledBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(marshalledBody)
+		mediaType = azuredevops.MediaTypeApplicationJson
+	}
+	request, err := azureDevopsClient.CreateRequestMessage(ctx, method, baseUrl+"/"+path, "", requestBody, mediaType, azuredevops.MediaTypeApplicationJson, nil)
+	if err != nil {
+		return err
+	}
+	response, err := azureDevopsClient.SendRequest(request)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return azureDevopsClient.UnwrapError(response)
+	}
+	return unmarshalResponse(azureDevopsClient, response)
+}
+
+// Capabilities on Azure Repos
+func (client *AzureReposClient) Capabilities(ctx context.Context) ([]Capability, error) {
+	ctx = labelAPICalls(ctx, "Capabilities")
+	return getServerCapabilities(ctx, vcsutils.AzureRepos, client.GetServerVersion)
+}
+
 // ListRepositories on Azure Repos
 func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	ctx = labelAPICalls(ctx, "ListRepositories")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return nil, err
@@ -71,28 +326,262 @@ func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[strin
 	for _, repo := range *resp {
 		repositories[client.vcsInfo.Project] = append(repositories[client.vcsInfo.Project], *repo.Name)
 	}
-	return repositories, nil
+	return orderRepositories(client.vcsInfo, repositories), nil
+}
+
+// ListRepositoryListings on Azure Repos returns the repositories of the project of the client
+func (client *AzureReposClient) ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryListings")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listings, err := listAzureReposProjectRepositories(ctx, azureReposGitClient, client.vcsInfo.Project, "")
+	return orderRepositoryListings(client.vcsInfo, listings), err
+}
+
+// SearchRepositories on Azure Repos returns the repositories of the project whose name contains the name filter.
+// The owner is the project, which defaults to the project of the client.
+func (client *AzureReposClient) SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "SearchRepositories")
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if options.Topic != "" {
+		return nil, getUnsupportedInAzureError("searching repositories by topic")
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project := options.Owner
+	if project == "" {
+		project = client.vcsInfo.Project
+	}
+	return listAzureReposProjectRepositories(ctx, azureReposGitClient, project, options.Name)
+}
+
+// SearchCode on Azure Repos
+func (client *AzureReposClient) SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error) {
+	return nil, getUnsupportedInAzureError("code search")
+}
+
+func listAzureReposProjectRepositories(ctx context.Context, azureReposGitClient git.Client, project, name string) ([]RepositoryListing, error) {
+	resp, err := azureReposGitClient.GetRepositories(ctx, git.GetRepositoriesArgs{Project: &project})
+	if err != nil {
+		return nil, err
+	}
+	listings := make([]RepositoryListing, 0, len(*resp))
+	for _, repo := range *resp {
+		// The repositories API has no name filter
+		if !strings.Contains(strings.ToLower(vcsutils.DefaultIfNotNil(repo.Name)), strings.ToLower(name)) {
+			continue
+		}
+		listings = append(listings, RepositoryListing{
+			Owner:         project,
+			OwnerType:     ProjectNamespace,
+			Name:          vcsutils.DefaultIfNotNil(repo.Name),
+			Visibility:    getAzureReposProjectVisibility(repo.Project),
+			DefaultBranch: strings.TrimPrefix(vcsutils.DefaultIfNotNil(repo.DefaultBranch), "refs/heads/"),
+		})
+	}
+	return listings, nil
+}
+
+// ListNamespaces on Azure Repos returns the projects of the organization
+func (client *AzureReposClient) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	ctx = labelAPICalls(ctx, "ListNamespaces")
+	const pageSize = 100
+	var namespaces []NamespaceInfo
+	for skip := 0; ; skip += pageSize {
+		var projects struct {
+			Value []struct {
+				Name string `json:"name"`
+			} `json:"value"`
+		}
+		path := fmt.Sprintf("_apis/projects?$top=%d&$skip=%d", pageSize, skip)
+		err := client.sendServerRequest(ctx, http.MethodGet, path, func(azureDevopsClient *azuredevops.Client, response *http.Response) error {
+			return azureDevopsClient.UnmarshalBody(response, &projects)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects.Value {
+			namespaces = append(namespaces, NamespaceInfo{Name: project.Name, DisplayName: project.Name, Kind: ProjectNamespace})
+		}
+		if len(projects.Value) < pageSize {
+			return orderNamespaces(client.vcsInfo, namespaces), nil
+		}
+	}
 }
 
 // ListBranches on Azure Repos
-func (client *AzureReposClient) ListBranches(ctx context.Context, _, repository string) ([]string, error) {
+func (client *AzureReposClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranches")
+	return client.ListBranchesWithOptions(ctx, owner, repository, ListBranchesOptions{})
+}
+
+// ListBranchesWithOptions on Azure Repos.
+// The protected branches are the branches in the scope of enabled blocking branch policies, as in GetBranchInfo.
+func (client *AzureReposClient) ListBranchesWithOptions(ctx context.Context, _, repository string, options ListBranchesOptions) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranchesWithOptions")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return nil, err
 	}
+	var policyScopes []azureReposPolicyScope
+	if options.ProtectedOnly {
+		if policyScopes, err = client.listBlockingPolicyScopes(ctx, azureReposGitClient, repository); err != nil {
+			return nil, err
+		}
+	}
 	var branches []string
 	gitBranchStats, err := azureReposGitClient.GetBranches(ctx, git.GetBranchesArgs{Project: &client.vcsInfo.Project, RepositoryId: &repository})
 	if err != nil {
 		return nil, err
 	}
 	for _, branch := range *gitBranchStats {
+		if options.ProtectedOnly && !isInAzureReposPolicyScopes(policyScopes, "refs/heads/"+*branch.Name) {
+			continue
+		}
 		branches = append(branches, *branch.Name)
 	}
-	return branches, nil
+	return orderNames(client.vcsInfo, branches), nil
+}
+
+// azureReposPolicyScope is the scope of the branches of a branch policy
+type azureReposPolicyScope struct {
+	// The ref of the branch, or empty for all the branches
+	RefName string `json:"refName"`
+	// Exact, or Prefix for the branches under the ref, such as refs/heads/release/
+	MatchKind string `json:"matchKind"`
+}
+
+// listBlockingPolicyScopes returns the scopes of the enabled blocking branch policies of a repository
+func (client *AzureReposClient) listBlockingPolicyScopes(ctx context.Context, azureReposGitClient git.Client, repository string) ([]azureReposPolicyScope, error) {
+	repo, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return nil, err
+	}
+	if repo.Id == nil {
+		return nil, fmt.Errorf("failed to retrieve the ID of the %s repository", repository)
+	}
+	var policies struct {
+		Value []struct {
+			IsEnabled  bool `json:"isEnabled"`
+			IsBlocking bool `json:"isBlocking"`
+			Settings   struct {
+				Scope []azureReposPolicyScope `json:"scope"`
+			} `json:"settings"`
+		} `json:"value"`
+	}
+	path := fmt.Sprintf("%s/_apis/git/policy/configurations?repositoryId=%s", url.PathEscape(client.vcsInfo.Project), repo.Id.String())
+	err = client.sendServerRequest(ctx, http.MethodGet, path, func(azureDevopsClient *azuredevops.Client, response *http.Response) error {
+		return azureDevopsClient.UnmarshalBody(response, &policies)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var scopes []azureReposPolicyScope
+	for _, policy := range policies.Value {
+		if policy.IsEnabled && policy.IsBlocking {
+			scopes = append(scopes, policy.Settings.Scope...)
+		}
+	}
+	return scopes, nil
+}
+
+func isInAzureReposPolicyScopes(scopes []azureReposPolicyScope, refName string) bool {
+	for _, scope := range scopes {
+		if scope.RefName == "" || scope.RefName == refName || (strings.EqualFold(scope.MatchKind, "prefix") && strings.HasPrefix(refName, scope.RefName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBranchInfo on Azure Repos.
+// The branch is protected if it has enabled blocking branch policies.
+func (client *AzureReposClient) GetBranchInfo(ctx context.Context, _, repository, branch string) (RepositoryBranchInfo, error) {
+	ctx = labelAPICalls(ctx, "GetBranchInfo")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	branchStats, err := azureReposGitClient.GetBranch(ctx, git.GetBranchArgs{RepositoryId: &repository, Name: &branch, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	repo, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	if repo.Id == nil {
+		return RepositoryBranchInfo{}, fmt.Errorf("failed to retrieve the ID of the %s repository", repository)
+	}
+	info := RepositoryBranchInfo{
+		Name:    vcsutils.DefaultIfNotNil(branchStats.Name),
+		Default: strings.TrimPrefix(vcsutils.DefaultIfNotNil(repo.DefaultBranch), "refs/heads/") == branch,
+	}
+	if branchStats.Commit != nil {
+		info.HeadCommitHash = vcsutils.DefaultIfNotNil(branchStats.Commit.CommitId)
+	}
+	var policies struct {
+		Value []struct {
+			IsEnabled  bool `json:"isEnabled"`
+			IsBlocking bool `json:"isBlocking"`
+		} `json:"value"`
+	}
+	path := fmt.Sprintf("%s/_apis/git/policy/configurations?repositoryId=%s&refName=%s", url.PathEscape(client.vcsInfo.Project), repo.Id.String(), url.QueryEscape("refs/heads/"+branch))
+	err = client.sendServerRequest(ctx, http.MethodGet, path, func(azureDevopsClient *azuredevops.Client, response *http.Response) error {
+		return azureDevopsClient.UnmarshalBody(response, &policies)
+	})
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	for _, policy := range policies.Value {
+		if policy.IsEnabled && policy.IsBlocking {
+			info.Protected = true
+			break
+		}
+	}
+	return info, nil
+}
+
+// GetDefaultBranch on Azure Repos
+func (client *AzureReposClient) GetDefaultBranch(ctx context.Context, _, repository string) (string, error) {
+	ctx = labelAPICalls(ctx, "GetDefaultBranch")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	repo, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(vcsutils.DefaultIfNotNil(repo.DefaultBranch), "refs/heads/"), nil
+}
+
+// SquashBranchIntoSingleCommit on Azure Repos
+func (client *AzureReposClient) SquashBranchIntoSingleCommit(_ context.Context, _, _, _, _, _ string) (string, error) {
+	return "", ErrBranchSquashNotSupported
 }
 
 // DownloadRepository on Azure Repos
-func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) (err error) {
+func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	ctx = labelAPICalls(ctx, "DownloadRepository")
+	return client.DownloadRepositoryWithOptions(ctx, owner, repository, branch, localPath, DownloadRepositoryOptions{})
+}
+
+// DownloadRepositoryWithOptions on Azure Repos
+func (client *AzureReposClient) DownloadRepositoryWithOptions(ctx context.Context, owner, repository, branch, localPath string, options DownloadRepositoryOptions) (err error) {
+	ctx = labelAPICalls(ctx, "DownloadRepositoryWithOptions")
 	wd, err := os.Getwd()
 	if err != nil {
 		return
@@ -117,11 +606,15 @@ func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, r
 	if err != nil {
 		return
 	}
-	err = vcsutils.Unzip(zipFileContent, localPath)
+	stats, err := vcsutils.UnzipWithStats(zipFileContent, localPath, options.Filter, options.ExtractionProgress)
 	if err != nil {
 		return err
 	}
+	reportRepositoryExtraction(client.logger, options, stats)
 	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	if client.vcsInfo.SkipDotGitCreation {
+		return nil
+	}
 	repoInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
@@ -142,12 +635,14 @@ func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, rep
 		branch)
 	client.logger.Debug("Download url:", downloadRepoUrl)
 	headers := map[string]string{
-		"Authorization":  client.connectionDetails.AuthorizationString,
 		"download":       "true",
 		"resolveLfs":     "true",
 		"includeContent": "true",
 	}
-	httpClient := &http.Client{}
+	if client.connectionDetails.AuthorizationString != "" {
+		headers["Authorization"] = client.connectionDetails.AuthorizationString
+	}
+	httpClient := client.newHTTPClient()
 	var req *http.Request
 	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, downloadRepoUrl, nil); err != nil {
 		return
@@ -174,7 +669,14 @@ func (client *AzureReposClient) GetPullRequestDetailsSizeLimit() int {
 }
 
 // CreatePullRequest on Azure Repos
-func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, repository, sourceBranch, targetBranch, title, description string) error {
+func (client *AzureReposClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+	ctx = labelAPICalls(ctx, "CreatePullRequest")
+	return client.CreatePullRequestWithWorkItems(ctx, owner, repository, sourceBranch, targetBranch, title, description)
+}
+
+// CreatePullRequestWithWorkItems creates a pull request linked to the work items, as required by the branch policies of some repositories
+func (client *AzureReposClient) CreatePullRequestWithWorkItems(ctx context.Context, _, repository, sourceBranch, targetBranch, title, description string, workItemIDs ...int) error {
+	ctx = labelAPICalls(ctx, "CreatePullRequestWithWorkItems")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
@@ -182,21 +684,73 @@ func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, reposi
 	sourceBranch = vcsutils.AddBranchPrefix(sourceBranch)
 	targetBranch = vcsutils.AddBranchPrefix(targetBranch)
 	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+	pullRequest := &git.GitPullRequest{
+		Description:   &description,
+		SourceRefName: &sourceBranch,
+		TargetRefName: &targetBranch,
+		Title:         &title,
+	}
+	if len(workItemIDs) > 0 {
+		workItemRefs := make([]webapi.ResourceRef, 0, len(workItemIDs))
+		for _, workItemID := range workItemIDs {
+			workItemRefs = append(workItemRefs, webapi.ResourceRef{Id: vcsutils.PointerOf(strconv.Itoa(workItemID))})
+		}
+		pullRequest.WorkItemRefs = &workItemRefs
+	}
 	_, err = azureReposGitClient.CreatePullRequest(ctx, git.CreatePullRequestArgs{
-		GitPullRequestToCreate: &git.GitPullRequest{
-			Description:   &description,
-			SourceRefName: &sourceBranch,
-			TargetRefName: &targetBranch,
-			Title:         &title,
-		},
-		RepositoryId: &repository,
-		Project:      &client.vcsInfo.Project,
+		GitPullRequestToCreate: pullRequest,
+		RepositoryId:           &repository,
+		Project:                &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// LinkWorkItem links a work item to an existing pull request
+func (client *AzureReposClient) LinkWorkItem(ctx context.Context, _, repository string, pullRequestID, workItemID int) error {
+	ctx = labelAPICalls(ctx, "LinkWorkItem")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The pull request is linked by an artifact URL made of the IDs of its project and repository
+	pullRequest, err := azureReposGitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	if pullRequest.Repository == nil || pullRequest.Repository.Id == nil || pullRequest.Repository.Project == nil || pullRequest.Repository.Project.Id == nil {
+		return fmt.Errorf("failed to get the repository of pull request %d", pullRequestID)
+	}
+	artifactURL := fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F%d", pullRequest.Repository.Project.Id.String(), pullRequest.Repository.Id.String(), pullRequestID)
+	workItemTrackingClient, err := client.buildAzureWorkItemTrackingClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = workItemTrackingClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Document: &[]webapi.JsonPatchOperation{{
+			Op:   &webapi.OperationValues.Add,
+			Path: vcsutils.PointerOf("/relations/-"),
+			Value: workitemtracking.WorkItemRelation{
+				Rel:        vcsutils.PointerOf("ArtifactLink"),
+				Url:        &artifactURL,
+				Attributes: &map[string]interface{}{"name": "Pull Request"},
+			},
+		}},
+		Id:      &workItemID,
+		Project: &client.vcsInfo.Project,
 	})
 	return err
 }
 
 // UpdatePullRequest on Azure Repos
 func (client *AzureReposClient) UpdatePullRequest(ctx context.Context, _, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequest")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
@@ -217,41 +771,270 @@ func (client *AzureReposClient) UpdatePullRequest(ctx context.Context, _, reposi
 	return err
 }
 
-// AddPullRequestComment on Azure Repos
-func (client *AzureReposClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
-	return client.addPullRequestComment(ctx, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
+// ClosePullRequest on Azure Repos
+func (client *AzureReposClient) ClosePullRequest(ctx context.Context, _, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ClosePullRequest")
+	return client.setPullRequestState(ctx, repository, pullRequestID, vcsutils.Closed)
 }
 
-// AddPullRequestReviewComments on Azure Repos
-func (client *AzureReposClient) AddPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int, comments ...PullRequestComment) error {
-	if len(comments) == 0 {
-		return errors.New(vcsutils.ErrNoCommentsProvided)
+// ReopenPullRequest on Azure Repos
+func (client *AzureReposClient) ReopenPullRequest(ctx context.Context, _, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ReopenPullRequest")
+	return client.setPullRequestState(ctx, repository, pullRequestID, vcsutils.Open)
+}
+
+// EnablePullRequestAutoMerge on Azure Repos sets the pull request to be completed automatically by the authenticated user, once its policies pass
+func (client *AzureReposClient) EnablePullRequestAutoMerge(ctx context.Context, _, repository string, pullRequestID int, mergeMethod MergeMethod) error {
+	ctx = labelAPICalls(ctx, "EnablePullRequestAutoMerge")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
 	}
-	for _, comment := range comments {
-		if err := client.addPullRequestComment(ctx, repository, pullRequestID, comment); err != nil {
-			return err
-		}
+	// Auto-complete is set on behalf of an identity, which is the authenticated user
+	user, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the authenticated user: %w", err)
 	}
-	return nil
+	client.logger.Debug(vcsutils.EnablingAutoMerge, pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			AutoCompleteSetBy: &webapi.IdentityRef{Id: &user.ID},
+			CompletionOptions: &git.GitPullRequestCompletionOptions{MergeStrategy: mapAzureReposMergeStrategy(mergeMethod)},
+		},
+		RepositoryId:  vcsutils.GetNilIfZeroVal(repository),
+		PullRequestId: vcsutils.GetNilIfZeroVal(pullRequestID),
+		Project:       vcsutils.GetNilIfZeroVal(client.vcsInfo.Project),
+	})
+	return err
 }
 
-func (client *AzureReposClient) addPullRequestComment(ctx context.Context, repository string, pullRequestID int, comment PullRequestComment) error {
+// MergePullRequest on Azure Repos completes the pull request
+func (client *AzureReposClient) MergePullRequest(ctx context.Context, _, repository string, pullRequestID int, options MergeOptions) error {
+	ctx = labelAPICalls(ctx, "MergePullRequest")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
 	}
-	threadArgs := getThreadArgs(repository, client.vcsInfo.Project, pullRequestID, comment)
-	_, err = azureReposGitClient.CreateThread(ctx, threadArgs)
+	// Completing a pull request requires its last merge source commit
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status:                &git.PullRequestStatusValues.Completed,
+			LastMergeSourceCommit: pullRequest.LastMergeSourceCommit,
+			CompletionOptions: &git.GitPullRequestCompletionOptions{
+				MergeStrategy:      mapAzureReposMergeStrategy(options.Method),
+				MergeCommitMessage: vcsutils.GetNilIfZeroVal(options.commitMessage()),
+				DeleteSourceBranch: &options.DeleteSourceBranch,
+				BypassPolicy:       &options.BypassPolicies,
+				BypassReason:       vcsutils.GetNilIfZeroVal(options.BypassReason),
+			},
+		},
+		RepositoryId:  vcsutils.GetNilIfZeroVal(repository),
+		PullRequestId: vcsutils.GetNilIfZeroVal(pullRequestID),
+		Project:       vcsutils.GetNilIfZeroVal(client.vcsInfo.Project),
+	})
 	return err
 }
 
-func getThreadArgs(repository, project string, prId int, comment PullRequestComment) git.CreateThreadArgs {
-	filePath := vcsutils.GetPullRequestFilePath(comment.NewFilePath)
-	return git.CreateThreadArgs{
-		CommentThread: &git.GitPullRequestCommentThread{
-			Comments: &[]git.Comment{{Content: &comment.Content}},
-			Status:   &git.CommentThreadStatusValues.Active,
-			ThreadContext: &git.CommentThreadContext{
+// mapAzureReposMergeStrategy returns the merge strategy of the merge method, or nil for the default merge strategy of the repository
+func mapAzureReposMergeStrategy(mergeMethod MergeMethod) *git.GitPullRequestMergeStrategy {
+	switch mergeMethod {
+	case MergeCommit:
+		return &git.GitPullRequestMergeStrategyValues.NoFastForward
+	case SquashMerge:
+		return &git.GitPullRequestMergeStrategyValues.Squash
+	case RebaseMerge:
+		return &git.GitPullRequestMergeStrategyValues.Rebase
+	}
+	return nil
+}
+
+// UpdatePullRequestBranch on Azure Repos, by creating a commit merging the target branch into the source branch,
+// and pushing it to the source branch if the source branch wasn't updated meanwhile
+func (client *AzureReposClient) UpdatePullRequestBranch(ctx context.Context, _, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestBranch")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	sourceBranch := strings.TrimPrefix(vcsutils.DefaultIfNotNil(pullRequest.SourceRefName), "refs/heads/")
+	targetBranch := strings.TrimPrefix(vcsutils.DefaultIfNotNil(pullRequest.TargetRefName), "refs/heads/")
+	sourceHead, err := client.GetLatestCommit(ctx, "", repository, sourceBranch)
+	if err != nil {
+		return err
+	}
+	targetHead, err := client.GetLatestCommit(ctx, "", repository, targetBranch)
+	if err != nil {
+		return err
+	}
+	// The source branch is up to date if the head of the target branch is already merged into it
+	mergeBases, err := azureReposGitClient.GetMergeBases(ctx, git.GetMergeBasesArgs{
+		RepositoryNameOrId: &repository,
+		CommitId:           &targetHead.Hash,
+		OtherCommitId:      &sourceHead.Hash,
+		Project:            &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	if mergeBases != nil && len(*mergeBases) > 0 && vcsutils.DefaultIfNotNil((*mergeBases)[0].CommitId) == targetHead.Hash {
+		return nil
+	}
+	mergeCommit, err := client.mergeCommits(ctx, azureReposGitClient, repository, pullRequestID,
+		fmt.Sprintf("Merge branch '%s' into %s", targetBranch, sourceBranch), sourceHead.Hash, targetHead.Hash)
+	if err != nil {
+		return err
+	}
+	refUpdateResults, err := azureReposGitClient.UpdateRefs(ctx, git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{{
+			Name:        pullRequest.SourceRefName,
+			OldObjectId: &sourceHead.Hash,
+			NewObjectId: &mergeCommit,
+		}},
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range *refUpdateResults {
+		if !vcsutils.DefaultIfNotNil(result.Success) {
+			return fmt.Errorf("failed to push the merge commit %s to branch '%s': %s", mergeCommit, sourceBranch, vcsutils.DefaultIfNotNil(result.CustomMessage))
+		}
+	}
+	return nil
+}
+
+// mergeCommits creates a commit merging the parent commits, without updating any branch, and waits for the asynchronous merge to complete.
+// Returns the hash of the merge commit, or a *PullRequestBranchConflictError if the merge failed.
+func (client *AzureReposClient) mergeCommits(ctx context.Context, azureReposGitClient git.Client, repository string, pullRequestID int, message string, parents ...string) (string, error) {
+	merge, err := azureReposGitClient.CreateMergeRequest(ctx, git.CreateMergeRequestArgs{
+		MergeParameters:    &git.GitMergeParameters{Comment: &message, Parents: &parents},
+		Project:            &client.vcsInfo.Project,
+		RepositoryNameOrId: &repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	done, err := isAzureReposMergeDone(merge, pullRequestID)
+	if !done && err == nil {
+		err = waitForPullRequestBranchUpdate(ctx, pullRequestID, func() (bool, error) {
+			var getErr error
+			if merge, getErr = azureReposGitClient.GetMergeRequest(ctx, git.GetMergeRequestArgs{
+				MergeOperationId:   merge.MergeOperationId,
+				Project:            &client.vcsInfo.Project,
+				RepositoryNameOrId: &repository,
+			}); getErr != nil {
+				return false, getErr
+			}
+			return isAzureReposMergeDone(merge, pullRequestID)
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+	if merge.DetailedStatus == nil || merge.DetailedStatus.MergeCommitId == nil {
+		return "", errors.New("the merge completed without returning the merge commit")
+	}
+	return *merge.DetailedStatus.MergeCommitId, nil
+}
+
+// isAzureReposMergeDone returns true if a merge operation completed, and a *PullRequestBranchConflictError if it failed
+func isAzureReposMergeDone(merge *git.GitMerge, pullRequestID int) (bool, error) {
+	switch vcsutils.DefaultIfNotNil(merge.Status) {
+	case git.GitAsyncOperationStatusValues.Completed:
+		return true, nil
+	case git.GitAsyncOperationStatusValues.Failed, git.GitAsyncOperationStatusValues.Abandoned:
+		var failureMessage string
+		if merge.DetailedStatus != nil {
+			failureMessage = vcsutils.DefaultIfNotNil(merge.DetailedStatus.FailureMessage)
+		}
+		return false, &PullRequestBranchConflictError{PullRequestID: pullRequestID, Message: failureMessage}
+	}
+	return false, nil
+}
+
+func (client *AzureReposClient) setPullRequestState(ctx context.Context, repository string, pullRequestID int, state vcsutils.PullRequestState) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	currentState := vcsutils.Open
+	if pullRequest.Status != nil && *pullRequest.Status == git.PullRequestStatusValues.Abandoned {
+		currentState = vcsutils.Closed
+	}
+	merged := pullRequest.Status != nil && *pullRequest.Status == git.PullRequestStatusValues.Completed
+	if err = validatePullRequestStateTransition(pullRequestID, merged, currentState, state); err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, pullRequestID)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{Status: azureMapPullRequestState(state)},
+		RepositoryId:           vcsutils.GetNilIfZeroVal(repository),
+		PullRequestId:          vcsutils.GetNilIfZeroVal(pullRequestID),
+		Project:                vcsutils.GetNilIfZeroVal(client.vcsInfo.Project),
+	})
+	return err
+}
+
+// AddPullRequestComment on Azure Repos
+func (client *AzureReposClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestComment")
+	return client.addPullRequestComment(ctx, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
+}
+
+// AddPullRequestReviewComments on Azure Repos
+func (client *AzureReposClient) AddPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestReviewComments")
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	for _, comment := range comments {
+		if err := client.addPullRequestComment(ctx, repository, pullRequestID, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *AzureReposClient) addPullRequestComment(ctx context.Context, repository string, pullRequestID int, comment PullRequestComment) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	threadArgs := getThreadArgs(repository, client.vcsInfo.Project, pullRequestID, comment)
+	_, err = azureReposGitClient.CreateThread(ctx, threadArgs)
+	return err
+}
+
+func getThreadArgs(repository, project string, prId int, comment PullRequestComment) git.CreateThreadArgs {
+	filePath := vcsutils.GetPullRequestFilePath(comment.NewFilePath)
+	return git.CreateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{{Content: &comment.Content}},
+			Status:   &git.CommentThreadStatusValues.Active,
+			ThreadContext: &git.CommentThreadContext{
 				FilePath:       &filePath,
 				LeftFileStart:  &git.CommentPosition{Line: &comment.OriginalStartLine, Offset: &comment.OriginalStartColumn},
 				LeftFileEnd:    &git.CommentPosition{Line: &comment.OriginalEndLine, Offset: &comment.OriginalEndColumn},
@@ -267,33 +1050,31 @@ func getThreadArgs(repository, project string, prId int, comment PullRequestComm
 
 // ListPullRequestReviewComments on Azure Repos
 func (client *AzureReposClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestReviewComments")
 	return client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
 }
 
 // ListPullRequestComments on Azure Repos
 func (client *AzureReposClient) ListPullRequestComments(ctx context.Context, _, repository string, pullRequestID int) ([]CommentInfo, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
-		RepositoryId:  &repository,
-		PullRequestId: &pullRequestID,
-		Project:       &client.vcsInfo.Project,
-	})
+	ctx = labelAPICalls(ctx, "ListPullRequestComments")
+	threads, err := client.getPullRequestThreads(ctx, repository, pullRequestID)
 	if err != nil {
 		return nil, err
 	}
 	var commentInfo []CommentInfo
-	for _, thread := range *threads {
+	for _, thread := range threads {
 		if thread.IsDeleted != nil && *thread.IsDeleted {
 			continue
 		}
 		var commentsAggregator strings.Builder
+		var author string
 		for _, comment := range *thread.Comments {
 			if comment.IsDeleted != nil && *comment.IsDeleted {
 				continue
 			}
+			if author == "" {
+				author = getAzureCommentAuthor(comment)
+			}
 			_, err = commentsAggregator.WriteString(
 				fmt.Sprintf("Author: %s, Id: %d, Content:%s\n",
 					*comment.Author.DisplayName,
@@ -303,17 +1084,173 @@ func (client *AzureReposClient) ListPullRequestComments(ctx context.Context, _,
 				return nil, err
 			}
 		}
+		filePath, line := getAzureThreadLocation(thread.ThreadContext)
 		commentInfo = append(commentInfo, CommentInfo{
-			ID:      int64(*thread.Id),
-			Created: thread.PublishedDate.Time,
-			Content: commentsAggregator.String(),
+			ID:       int64(*thread.Id),
+			ThreadID: strconv.Itoa(*thread.Id),
+			Created:  thread.PublishedDate.Time,
+			Content:  commentsAggregator.String(),
+			Resolved: isThreadResolved(thread.Status),
+			Author:   author,
+			FilePath: filePath,
+			Line:     line,
 		})
 	}
-	return commentInfo, nil
+	return orderComments(client.vcsInfo, commentInfo), nil
+}
+
+// getAzureThreadLocation returns the file path and the line in the new version of the file of a review thread, or empty values for a general thread
+func getAzureThreadLocation(threadContext *git.CommentThreadContext) (filePath string, line int) {
+	if threadContext == nil || threadContext.FilePath == nil {
+		return
+	}
+	filePath = strings.TrimPrefix(*threadContext.FilePath, "/")
+	if threadContext.RightFileStart != nil && threadContext.RightFileStart.Line != nil {
+		line = *threadContext.RightFileStart.Line
+	}
+	return
+}
+
+// listPullRequestSingleComments returns each of the comments of the threads of a pull request, instead of a comment aggregating each thread.
+// The ID of the returned comments is the ID of the comment in its thread.
+func (client *AzureReposClient) listPullRequestSingleComments(ctx context.Context, repository string, pullRequestID int) ([]CommentInfo, error) {
+	threads, err := client.getPullRequestThreads(ctx, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	var commentInfo []CommentInfo
+	for _, thread := range threads {
+		if (thread.IsDeleted != nil && *thread.IsDeleted) || thread.Comments == nil {
+			continue
+		}
+		for _, comment := range *thread.Comments {
+			if (comment.IsDeleted != nil && *comment.IsDeleted) || comment.Content == nil {
+				continue
+			}
+			info := CommentInfo{
+				ID:       int64(*comment.Id),
+				ThreadID: strconv.Itoa(*thread.Id),
+				Content:  *comment.Content,
+				Resolved: isThreadResolved(thread.Status),
+				Author:   getAzureCommentAuthor(comment),
+			}
+			if comment.PublishedDate != nil {
+				info.Created = comment.PublishedDate.Time
+			}
+			commentInfo = append(commentInfo, info)
+		}
+	}
+	return orderComments(client.vcsInfo, commentInfo), nil
+}
+
+func (client *AzureReposClient) getPullRequestThreads(ctx context.Context, repository string, pullRequestID int) ([]git.GitPullRequestCommentThread, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *threads, nil
+}
+
+func getAzureCommentAuthor(comment git.Comment) string {
+	if comment.Author == nil || comment.Author.UniqueName == nil {
+		return ""
+	}
+	return *comment.Author.UniqueName
+}
+
+// isThreadResolved returns true if the thread status is one of the resolved statuses: fixed, won't fix, closed or by design
+func isThreadResolved(status *git.CommentThreadStatus) bool {
+	if status == nil {
+		return false
+	}
+	switch *status {
+	case git.CommentThreadStatusValues.Fixed, git.CommentThreadStatusValues.WontFix, git.CommentThreadStatusValues.Closed, git.CommentThreadStatusValues.ByDesign:
+		return true
+	}
+	return false
+}
+
+// ThreadStatus is the status of a pull request comment thread on Azure Repos
+type ThreadStatus string
+
+const (
+	ThreadStatusActive   ThreadStatus = "active"
+	ThreadStatusPending  ThreadStatus = "pending"
+	ThreadStatusFixed    ThreadStatus = "fixed"
+	ThreadStatusWontFix  ThreadStatus = "wontFix"
+	ThreadStatusClosed   ThreadStatus = "closed"
+	ThreadStatusByDesign ThreadStatus = "byDesign"
+)
+
+// UpdateThreadStatus sets the status of a pull request comment thread, for example to resolve it as fixed or to reactivate it
+// The thread ID is the ID of the comment, as returned from ListPullRequestComments.
+func (client *AzureReposClient) UpdateThreadStatus(ctx context.Context, _, repository string, pullRequestID, threadID int, status ThreadStatus) error {
+	ctx = labelAPICalls(ctx, "UpdateThreadStatus")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "status": string(status)}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	threadStatus := git.CommentThreadStatus(status)
+	_, err = azureReposGitClient.UpdateThread(ctx, git.UpdateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{Status: &threadStatus},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// ResolvePullRequestReviewComments resolves the threads of the review comments as fixed, keeping their history in the pull request
+func (client *AzureReposClient) ResolvePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	ctx = labelAPICalls(ctx, "ResolvePullRequestReviewComments")
+	for _, comment := range comments {
+		if err := client.UpdateThreadStatus(ctx, owner, repository, pullRequestID, int(comment.ID), ThreadStatusFixed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPullRequestLastActivity on Azure Repos returns the time of the last update of the threads of a pull request,
+// since the pull requests don't include the time of their last update
+func (client *AzureReposClient) GetPullRequestLastActivity(ctx context.Context, _, repository string, pullRequestID int) (time.Time, error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestLastActivity")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	var lastActivity time.Time
+	for _, thread := range *threads {
+		if updated := extractTimeFromAzuredevopsTime(thread.LastUpdatedDate); updated.After(lastActivity) {
+			lastActivity = updated
+		}
+	}
+	return lastActivity, nil
 }
 
 // DeletePullRequestReviewComments on Azure Repos
 func (client *AzureReposClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestReviewComments")
 	for _, comment := range comments {
 		if err := client.DeletePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID)); err != nil {
 			return err
@@ -324,72 +1261,293 @@ func (client *AzureReposClient) DeletePullRequestReviewComments(ctx context.Cont
 
 // DeletePullRequestComment on Azure Repos
 func (client *AzureReposClient) DeletePullRequestComment(ctx context.Context, _, repository string, pullRequestID, commentID int) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestComment")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	firstCommentInThreadID := 1
+	return azureReposGitClient.DeleteComment(ctx, git.DeleteCommentArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &commentID,
+		Project:       &client.vcsInfo.Project,
+		CommentId:     &firstCommentInThreadID,
+	})
+}
+
+// UpdatePullRequestReviewComment on Azure Repos
+func (client *AzureReposClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestReviewComment")
+	return client.UpdatePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID), content)
+}
+
+// UpdatePullRequestComment on Azure Repos
+// The comment ID is the ID of the thread, as returned from ListPullRequestComments.
+func (client *AzureReposClient) UpdatePullRequestComment(ctx context.Context, _, repository string, pullRequestID, commentID int, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestComment")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	firstCommentInThreadID := 1
+	_, err = azureReposGitClient.UpdateComment(ctx, git.UpdateCommentArgs{
+		Comment:       &git.Comment{Content: &content},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &commentID,
+		CommentId:     &firstCommentInThreadID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// ListOpenPullRequestsWithBody on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithBody")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{WithBody: true})
+}
+
+// ListOpenPullRequests on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequests")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{})
+}
+
+// ListOpenPullRequestsWithQueryOptions on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithQueryOptions")
+	return client.getOpenPullRequests(ctx, owner, repository, options)
+}
+
+func (client *AzureReposClient) getOpenPullRequests(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	searchCriteria := &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active}
+	if options.SourceBranch != "" {
+		searchCriteria.SourceRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.SourceBranch))
+	}
+	if options.TargetBranch != "" {
+		searchCriteria.TargetRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.TargetBranch))
+	}
+	pullRequestsArgs := git.GetPullRequestsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: searchCriteria,
+	}
+	if options.PerPage > 0 {
+		pullRequestsArgs.Top = &options.PerPage
+		pullRequestsArgs.Skip = vcsutils.PointerOf((max(options.Page, 1) - 1) * options.PerPage)
+	}
+	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, pullRequestsArgs)
+	if err != nil {
+		return nil, err
+	}
+	var pullRequestsInfo []PullRequestInfo
+	for _, pullRequest := range *pullRequests {
+		pullRequestDetails := parsePullRequestDetails(client, pullRequest, owner, repository, options.WithBody)
+		pullRequestsInfo = append(pullRequestsInfo, pullRequestDetails)
+	}
+	return orderPullRequests(client.vcsInfo, pullRequestsInfo), nil
+}
+
+// ListPullRequests on Azure Repos. The API lists the newest pull requests first, and doesn't filter by the unique name of the author,
+// so the pull requests are filtered, sorted and paginated by the client when filtering by author or sorting differently.
+func (client *AzureReposClient) ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequests")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	searchCriteria := &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active}
+	switch options.State {
+	case PullRequestsClosed:
+		searchCriteria.Status = &git.PullRequestStatusValues.Abandoned
+	case PullRequestsMerged:
+		searchCriteria.Status = &git.PullRequestStatusValues.Completed
+	case PullRequestsAll:
+		searchCriteria.Status = &git.PullRequestStatusValues.All
+	}
+	if options.SourceBranch != "" {
+		searchCriteria.SourceRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.SourceBranch))
+	}
+	if options.TargetBranch != "" {
+		searchCriteria.TargetRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.TargetBranch))
+	}
+	pullRequestsArgs := git.GetPullRequestsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: searchCriteria,
+	}
+	filterByClient := options.Author != "" || options.Sort == SortByUpdated || options.Ascending
+	if options.PerPage > 0 && !filterByClient {
+		pullRequestsArgs.Top = &options.PerPage
+		pullRequestsArgs.Skip = vcsutils.PointerOf((max(options.Page, 1) - 1) * options.PerPage)
+	}
+	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, pullRequestsArgs)
+	if err != nil {
+		return nil, err
+	}
+	var pullRequestsInfo []PullRequestInfo
+	for _, pullRequest := range *pullRequests {
+		pullRequestDetails := parsePullRequestDetails(client, pullRequest, owner, repository, options.WithBody)
+		if options.Author == "" || pullRequestDetails.Author == options.Author {
+			pullRequestsInfo = append(pullRequestsInfo, pullRequestDetails)
+		}
+	}
+	if !filterByClient {
+		return pullRequestsInfo, nil
+	}
+	sortPullRequests(pullRequestsInfo, options)
+	return paginate(pullRequestsInfo, options.ListOptions), nil
+}
+
+// GetPullRequestById in Azure Repos
+func (client *AzureReposClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestByID")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return
+	}
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestId,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return
+	}
+	pullRequestInfo = parsePullRequestDetails(client, *pullRequest, owner, repository, false)
+	return
+}
+
+// GetPullRequestMergeCommit on Azure Repos returns the hash of the last merge commit of a pull request, which merges its source branch into its target branch.
+// Returns an empty string if the last merge didn't succeed, for example because of conflicts.
+func (client *AzureReposClient) GetPullRequestMergeCommit(ctx context.Context, _, _ string, pullRequestID int) (string, error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestMergeCommit")
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return "", err
+	}
+	if pullRequest.MergeStatus == nil || *pullRequest.MergeStatus != git.PullRequestAsyncStatusValues.Succeeded {
+		return "", nil
+	}
+	return getAzureReposCommitID(pullRequest.LastMergeCommit), nil
+}
+
+// CreateIssue on Azure Repos
+func (client *AzureReposClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	return IssueInfo{}, getUnsupportedInAzureError("create issue")
+}
+
+// ListIssues on Azure Repos
+func (client *AzureReposClient) ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error) {
+	return nil, getUnsupportedInAzureError("list issues")
+}
+
+// CommentOnIssue on Azure Repos
+func (client *AzureReposClient) CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error {
+	return getUnsupportedInAzureError("comment on issue")
+}
+
+// CloseIssue on Azure Repos
+func (client *AzureReposClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	return getUnsupportedInAzureError("close issue")
+}
+
+// ReplyToPullRequestReviewComment on Azure Repos
+func (client *AzureReposClient) ReplyToPullRequestReviewComment(ctx context.Context, _, repository string, pullRequestID int, threadID, content string) error {
+	ctx = labelAPICalls(ctx, "ReplyToPullRequestReviewComment")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "threadID": threadID, "content": content}); err != nil {
+		return err
+	}
+	threadIDNum, err := strconv.Atoi(threadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", threadID, err)
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
 	}
 	firstCommentInThreadID := 1
-	return azureReposGitClient.DeleteComment(ctx, git.DeleteCommentArgs{
+	_, err = azureReposGitClient.CreateComment(ctx, git.CreateCommentArgs{
+		Comment:       &git.Comment{Content: &content, ParentCommentId: &firstCommentInThreadID},
 		RepositoryId:  &repository,
 		PullRequestId: &pullRequestID,
-		ThreadId:      &commentID,
+		ThreadId:      &threadIDNum,
 		Project:       &client.vcsInfo.Project,
-		CommentId:     &firstCommentInThreadID,
 	})
+	return err
 }
 
-// ListOpenPullRequestsWithBody on Azure Repos
-func (client *AzureReposClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
-}
-
-// ListOpenPullRequests on Azure Repos
-func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
-}
-
-func (client *AzureReposClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
+// UploadPullRequestAttachment on Azure Repos
+func (client *AzureReposClient) UploadPullRequestAttachment(ctx context.Context, _, repository string, pullRequestID int, fileName string, content []byte) (PullRequestAttachmentInfo, error) {
+	ctx = labelAPICalls(ctx, "UploadPullRequestAttachment")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "fileName": fileName}); err != nil {
+		return PullRequestAttachmentInfo{}, err
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return nil, err
+		return PullRequestAttachmentInfo{}, err
 	}
-	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
-	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
-		RepositoryId:   &repository,
-		Project:        &client.vcsInfo.Project,
-		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
+	attachment, err := azureReposGitClient.CreateAttachment(ctx, git.CreateAttachmentArgs{
+		UploadStream:  bytes.NewReader(content),
+		FileName:      &fileName,
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
 	})
 	if err != nil {
-		return nil, err
-	}
-	var pullRequestsInfo []PullRequestInfo
-	for _, pullRequest := range *pullRequests {
-		pullRequestDetails := parsePullRequestDetails(client, pullRequest, owner, repository, withBody)
-		pullRequestsInfo = append(pullRequestsInfo, pullRequestDetails)
+		return PullRequestAttachmentInfo{}, err
 	}
-	return pullRequestsInfo, nil
+	return mapAzureReposAttachmentToAttachmentInfo(attachment), nil
 }
 
-// GetPullRequestById in Azure Repos
-func (client *AzureReposClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+// ListPullRequestAttachments on Azure Repos
+func (client *AzureReposClient) ListPullRequestAttachments(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestAttachmentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestAttachments")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return
+		return nil, err
 	}
-	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
-	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
-		PullRequestId: &pullRequestId,
+	attachments, err := azureReposGitClient.GetAttachments(ctx, git.GetAttachmentsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
 		Project:       &client.vcsInfo.Project,
 	})
-	if err != nil {
-		return
+	if err != nil || attachments == nil {
+		return nil, err
 	}
-	pullRequestInfo = parsePullRequestDetails(client, *pullRequest, owner, repository, false)
-	return
+	var attachmentsInfo []PullRequestAttachmentInfo
+	for i := range *attachments {
+		attachmentsInfo = append(attachmentsInfo, mapAzureReposAttachmentToAttachmentInfo(&(*attachments)[i]))
+	}
+	return attachmentsInfo, nil
+}
+
+func mapAzureReposAttachmentToAttachmentInfo(attachment *git.Attachment) PullRequestAttachmentInfo {
+	return newPullRequestAttachmentInfo(vcsutils.DefaultIfNotNil(attachment.DisplayName), vcsutils.DefaultIfNotNil(attachment.Url))
 }
 
 // GetLatestCommit on Azure Repos
 func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, repository, branch string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLatestCommit")
 	commitsInfo, err := client.GetCommits(ctx, "", repository, branch)
 	if err != nil {
 		return CommitInfo{}, err
@@ -404,6 +1562,7 @@ func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, reposito
 
 // GetCommits on Azure Repos
 func (client *AzureReposClient) GetCommits(ctx context.Context, _, repository, branch string) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommits")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return nil, err
@@ -422,17 +1581,27 @@ func (client *AzureReposClient) GetCommits(ctx context.Context, _, repository, b
 
 	var commitsInfo []CommitInfo
 	for _, commit := range *commits {
-		commitInfo := mapAzureReposCommitsToCommitInfo(commit)
+		commitInfo := client.mapAzureReposCommitsToCommitInfo(commit, repository)
 		commitsInfo = append(commitsInfo, commitInfo)
 	}
-	return commitsInfo, nil
+	return orderCommits(client.vcsInfo, commitsInfo), nil
 }
 
 func (client *AzureReposClient) GetCommitsWithQueryOptions(ctx context.Context, _, repository string, listOptions GitCommitsQueryOptions) ([]CommitInfo, error) {
 	return nil, errAzureGetCommitsWithOptionsNotSupported
 }
 
-func mapAzureReposCommitsToCommitInfo(commit git.GitCommitRef) CommitInfo {
+// ListCommitsBetween on Azure Repos
+func (client *AzureReposClient) ListCommitsBetween(_ context.Context, _, _, _, _ string) ([]string, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
+}
+
+// ListMergedPullRequestsOfCommit on Azure Repos
+func (client *AzureReposClient) ListMergedPullRequestsOfCommit(_ context.Context, _, _, _ string) ([]PullRequestInfo, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
+}
+
+func (client *AzureReposClient) mapAzureReposCommitsToCommitInfo(commit git.GitCommitRef, repository string) CommitInfo {
 	var authorName, authorEmail string
 	if commit.Author != nil {
 		authorName = vcsutils.DefaultIfNotNil(commit.Author.Name)
@@ -449,6 +1618,7 @@ func mapAzureReposCommitsToCommitInfo(commit git.GitCommitRef) CommitInfo {
 		AuthorName:    authorName,
 		CommitterName: committerName,
 		Url:           vcsutils.DefaultIfNotNil(commit.Url),
+		HTMLURL:       fmt.Sprintf("%s/commit/%s", client.getRepositoryWebURL(repository), vcsutils.DefaultIfNotNil(commit.CommitId)),
 		Timestamp:     timestamp,
 		Message:       vcsutils.DefaultIfNotNil(commit.Comment),
 		ParentHashes:  vcsutils.DefaultIfNotNil(commit.Parents),
@@ -465,8 +1635,64 @@ func (client *AzureReposClient) AddSshKeyToRepository(ctx context.Context, owner
 	return getUnsupportedInAzureError("add ssh key to repository")
 }
 
+// AddSshKeyToUserProfile adds a public SSH key to the profile of the authenticated user.
+// Azure DevOps doesn't support deploy keys, so unlike AddSshKeyToRepository on the other providers, the key isn't limited to a repository,
+// and has the permissions of the user on all the repositories of the organization. For read-only access, add the key to a dedicated user with a read permission.
+// keyName   - An identifier for the key
+// publicKey - The public SSH key
+func (client *AzureReposClient) AddSshKeyToUserProfile(ctx context.Context, keyName, publicKey string) error {
+	ctx = labelAPICalls(ctx, "AddSshKeyToUserProfile")
+	if err := validateParametersNotBlank(map[string]string{"key name": keyName, "public key": publicKey}); err != nil {
+		return err
+	}
+	sessionToken := azureSshKeySessionToken{
+		DisplayName: keyName,
+		PublicData:  strings.TrimSpace(publicKey),
+		Scope:       azureSshKeyScope,
+		IsPublic:    true,
+		ValidTo:     time.Now().UTC().AddDate(1, 0, 0),
+	}
+	err := client.sendRequestWithBody(ctx, http.MethodPost, getAzureProfileUrl(client.connectionDetails.BaseUrl), azureSessionTokensPath, sessionToken,
+		func(*azuredevops.Client, *http.Response) error { return nil })
+	if err != nil {
+		return fmt.Errorf("failed to add the SSH key %s: %w", keyName, err)
+	}
+	return nil
+}
+
+// CreateDeployToken on Azure Repos
+func (client *AzureReposClient) CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error) {
+	return DeployTokenInfo{}, getUnsupportedInAzureError("create deploy token")
+}
+
+// azureSshKeySessionToken is a public session token of Azure DevOps, holding a public SSH key of the user
+type azureSshKeySessionToken struct {
+	DisplayName string    `json:"displayName"`
+	PublicData  string    `json:"publicData"`
+	Scope       string    `json:"scope"`
+	IsPublic    bool      `json:"isPublic"`
+	ValidTo     time.Time `json:"validTo"`
+}
+
+// getAzureProfileUrl returns the URL of the organization on the host of the profile and token APIs.
+// Azure DevOps Services hosts these APIs on a separate domain, while Azure DevOps Server hosts them on the collection URL.
+func getAzureProfileUrl(baseUrl string) string {
+	parsedUrl, err := url.Parse(baseUrl)
+	if err != nil {
+		return baseUrl
+	}
+	switch {
+	case parsedUrl.Host == "dev.azure.com":
+		parsedUrl.Host = "vssps.dev.azure.com"
+	case strings.HasSuffix(parsedUrl.Host, ".visualstudio.com") && !strings.HasSuffix(parsedUrl.Host, ".vssps.visualstudio.com"):
+		parsedUrl.Host = strings.TrimSuffix(parsedUrl.Host, ".visualstudio.com") + ".vssps.visualstudio.com"
+	}
+	return parsedUrl.String()
+}
+
 // GetRepositoryInfo on Azure Repos
 func (client *AzureReposClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryInfo")
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return RepositoryInfo{}, err
@@ -485,22 +1711,177 @@ func (client *AzureReposClient) GetRepositoryInfo(ctx context.Context, owner, re
 		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty project info", owner, client.vcsInfo.Project, repository)
 	}
 
-	visibility := Private
-	visibilityFromResponse := *response.Project.Visibility
-	if visibilityFromResponse == core.ProjectVisibilityValues.Public {
-		visibility = Public
-	}
 	return RepositoryInfo{
 		CloneInfo:            CloneInfo{HTTP: *response.RemoteUrl, SSH: *response.SshUrl},
-		RepositoryVisibility: visibility,
+		RepositoryVisibility: getAzureReposProjectVisibility(response.Project),
 	}, nil
 }
 
+// The repositories of Azure Repos have the visibility of their project
+func getAzureReposProjectVisibility(project *core.TeamProjectReference) RepositoryVisibility {
+	if project != nil && project.Visibility != nil && *project.Visibility == core.ProjectVisibilityValues.Public {
+		return Public
+	}
+	return Private
+}
+
+// ArchiveRepository on Azure Repos
+func (client *AzureReposClient) ArchiveRepository(ctx context.Context, owner, repository string) error {
+	return getUnsupportedInAzureError("archive repository")
+}
+
+// UnarchiveRepository on Azure Repos
+func (client *AzureReposClient) UnarchiveRepository(ctx context.Context, owner, repository string) error {
+	return getUnsupportedInAzureError("unarchive repository")
+}
+
+// RenameRepository on Azure Repos
+func (client *AzureReposClient) RenameRepository(ctx context.Context, owner, repository, newName string) error {
+	ctx = labelAPICalls(ctx, "RenameRepository")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "newName": newName}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The repository is updated by its ID
+	gitRepository, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = azureReposGitClient.UpdateRepository(ctx, git.UpdateRepositoryArgs{
+		NewRepositoryInfo: &git.GitRepository{Name: &newName},
+		RepositoryId:      gitRepository.Id,
+		Project:           &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// GetRepositoryTopics on Azure Repos
+func (client *AzureReposClient) GetRepositoryTopics(_ context.Context, _, _ string) ([]string, error) {
+	return nil, ErrRepositoryTopicsNotSupported
+}
+
+// SetRepositoryTopics on Azure Repos
+func (client *AzureReposClient) SetRepositoryTopics(_ context.Context, _, _ string, _ []string) error {
+	return ErrRepositoryTopicsNotSupported
+}
+
+// GetUserPermissionOnRepo on Azure Repos
+func (client *AzureReposClient) GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	return NoPermission, getUnsupportedInAzureError("get user permission on repo")
+}
+
+// ListRepositoryCollaborators on Azure Repos
+func (client *AzureReposClient) ListRepositoryCollaborators(_ context.Context, _, _ string, _ CollaboratorAffiliation) ([]CollaboratorInfo, error) {
+	return nil, ErrCollaboratorsNotSupported
+}
+
+// GetRepositoryMirrors on Azure Repos
+func (client *AzureReposClient) GetRepositoryMirrors(_ context.Context, _, _ string) ([]RepositoryMirrorInfo, error) {
+	return nil, getUnsupportedInAzureError("get repository mirrors")
+}
+
+// SetRepositoryMirror on Azure Repos
+func (client *AzureReposClient) SetRepositoryMirror(_ context.Context, _, _ string, _ RepositoryMirrorInfo) error {
+	return getUnsupportedInAzureError("set repository mirror")
+}
+
 // GetCommitBySha on Azure Repos
 func (client *AzureReposClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
 	return CommitInfo{}, getUnsupportedInAzureError("get commit by sha")
 }
 
+// GetCommitDiff on Azure Repos.
+// Azure Repos returns the changed files of the commit without their diff, so the diff is built from the contents of the files before and after the commit.
+func (client *AzureReposClient) GetCommitDiff(ctx context.Context, _, repository, sha string) (CommitDiffInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitDiff")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sha": sha}); err != nil {
+		return CommitDiffInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+	commit, err := azureReposGitClient.GetCommit(ctx, git.GetCommitArgs{CommitId: &sha, RepositoryId: &repository, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+	// The changes of the commit are compared to its first parent
+	var parentSha string
+	if parents := vcsutils.DefaultIfNotNil(commit.Parents); len(parents) > 0 {
+		parentSha = parents[0]
+	}
+	var changedFiles []ModifiedFileInfo
+	changesToReturn := 100
+	for changesToSkip := 0; ; changesToSkip += changesToReturn {
+		commitChanges, err := azureReposGitClient.GetChanges(ctx, git.GetChangesArgs{
+			CommitId:     &sha,
+			RepositoryId: &repository,
+			Project:      &client.vcsInfo.Project,
+			Top:          &changesToReturn,
+			Skip:         &changesToSkip,
+		})
+		if err != nil {
+			return CommitDiffInfo{}, err
+		}
+		changes := vcsutils.DefaultIfNotNil(commitChanges.Changes)
+		changedFilesPage, err := mapAzureReposBlobChanges(changes)
+		if err != nil {
+			return CommitDiffInfo{}, err
+		}
+		changedFiles = append(changedFiles, changedFilesPage...)
+		if len(changes) < changesToReturn {
+			break
+		}
+	}
+	diffFiles := make([]unifiedDiffFile, 0, len(changedFiles))
+	for _, changedFile := range sortModifiedFiles(changedFiles) {
+		diffFile := unifiedDiffFile{previousPath: changedFile.Path, path: changedFile.Path}
+		if changedFile.PreviousPath != "" {
+			diffFile.previousPath = changedFile.PreviousPath
+		}
+		if changedFile.Status != FileAdded && parentSha != "" {
+			if diffFile.previousContent, err = client.getItemContentByCommit(ctx, azureReposGitClient, repository, diffFile.previousPath, parentSha); err != nil {
+				return CommitDiffInfo{}, err
+			}
+		}
+		if changedFile.Status != FileRemoved {
+			if diffFile.content, err = client.getItemContentByCommit(ctx, azureReposGitClient, repository, diffFile.path, sha); err != nil {
+				return CommitDiffInfo{}, err
+			}
+		}
+		diffFiles = append(diffFiles, diffFile)
+	}
+	diff, err := formatUnifiedDiff(diffFiles)
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+	return CommitDiffInfo{Diff: diff, Files: parseUnifiedDiffFiles(diff)}, nil
+}
+
+// getItemContentByCommit returns the content of a file in a commit
+func (client *AzureReposClient) getItemContentByCommit(ctx context.Context, azureReposGitClient git.Client, repository, path, sha string) ([]byte, error) {
+	output, err := azureReposGitClient.GetItemContent(ctx, git.GetItemContentArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &sha, VersionType: &git.GitVersionTypeValues.Commit},
+		IncludeContent:    vcsutils.PointerOf(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = output.Close()
+	}()
+	return io.ReadAll(output)
+}
+
 // CreateLabel on Azure Repos
 func (client *AzureReposClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
 	return getUnsupportedInAzureError("create label")
@@ -516,16 +1897,51 @@ func (client *AzureReposClient) ListPullRequestLabels(ctx context.Context, owner
 	return nil, getUnsupportedInAzureError("list pull request labels")
 }
 
+// LabelPullRequest on Azure Repos
+func (client *AzureReposClient) LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	return getUnsupportedInAzureError("label pull request")
+}
+
 // UnlabelPullRequest on Azure Repos
 func (client *AzureReposClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
 	return getUnsupportedInAzureError("unlabel pull request")
 }
 
+// ListMilestones on Azure Repos
+func (client *AzureReposClient) ListMilestones(_ context.Context, _, _ string, _ MilestoneState) ([]MilestoneInfo, error) {
+	return nil, ErrMilestonesNotSupported
+}
+
+// SetPullRequestMilestone on Azure Repos
+func (client *AzureReposClient) SetPullRequestMilestone(_ context.Context, _, _ string, _ int, _ int64) error {
+	return ErrMilestonesNotSupported
+}
+
 // UploadCodeScanning on Azure Repos
 func (client *AzureReposClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
 	return "", getUnsupportedInAzureError("upload code scanning")
 }
 
+// ListCodeScanningAlerts on Azure Repos
+func (client *AzureReposClient) ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error) {
+	return nil, getUnsupportedInAzureError("list code scanning alerts")
+}
+
+// GetCodeScanningAlert on Azure Repos
+func (client *AzureReposClient) GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error) {
+	return CodeScanningAlertInfo{}, getUnsupportedInAzureError("get code scanning alert")
+}
+
+// DismissCodeScanningAlert on Azure Repos
+func (client *AzureReposClient) DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, reason AlertDismissalReason, comment string) error {
+	return getUnsupportedInAzureError("dismiss code scanning alert")
+}
+
+// ListVulnerabilityAlerts on Azure Repos
+func (client *AzureReposClient) ListVulnerabilityAlerts(ctx context.Context, owner, repository string) ([]VulnerabilityAlertInfo, error) {
+	return nil, getUnsupportedInAzureError("list vulnerability alerts")
+}
+
 // CreateWebhook on Azure Repos
 func (client *AzureReposClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
 	return "", "", getUnsupportedInAzureError("create webhook")
@@ -541,8 +1957,39 @@ func (client *AzureReposClient) DeleteWebhook(ctx context.Context, owner, reposi
 	return getUnsupportedInAzureError("delete webhook")
 }
 
+// CreateGroupWebhook on Azure Repos
+func (client *AzureReposClient) CreateGroupWebhook(_ context.Context, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", ErrGroupWebhooksNotSupported
+}
+
+// UpdateGroupWebhook on Azure Repos
+func (client *AzureReposClient) UpdateGroupWebhook(_ context.Context, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return ErrGroupWebhooksNotSupported
+}
+
+// DeleteGroupWebhook on Azure Repos
+func (client *AzureReposClient) DeleteGroupWebhook(_ context.Context, _, _ string) error {
+	return ErrGroupWebhooksNotSupported
+}
+
 // SetCommitStatus on Azure Repos
 func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatus")
+	return client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, CommitStatusOptions{})
+}
+
+// SetCommitStatusWithOptions on Azure Repos, where the status is identified by the genre and the name of its context.
+// The genre is the title, and the name is the key of the options, which defaults to the owner.
+func (client *AzureReposClient) SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string, options CommitStatusOptions) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatusWithOptions")
+	contextName := owner
+	if options.Key != "" {
+		contextName = options.Key
+	}
+	description, err := encodeCommitStatusDescription(description, options.Metadata)
+	if err != nil {
+		return err
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
@@ -554,7 +2001,7 @@ func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatu
 			State:       &statusState,
 			TargetUrl:   &detailsURL,
 			Context: &git.GitStatusContext{
-				Name:  &owner,
+				Name:  &contextName,
 				Genre: &title,
 			},
 		},
@@ -568,6 +2015,11 @@ func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatu
 
 // GetCommitStatuses on Azure Repos
 func (client *AzureReposClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetCommitStatuses")
+	ref, err = client.branchHeads.resolve(ctx, owner, repository, ref, client.GetLatestCommit)
+	if err != nil {
+		return nil, err
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return nil, err
@@ -585,6 +2037,7 @@ func (client *AzureReposClient) GetCommitStatuses(ctx context.Context, owner, re
 	for _, singleStatus := range *resGitStatus {
 		results = append(results, CommitStatusInfo{
 			State:         commitStatusAsStringToStatus(string(*singleStatus.State)),
+			Context:       getAzureReposStatusContext(singleStatus.Context),
 			Description:   *singleStatus.Description,
 			DetailsUrl:    *singleStatus.TargetUrl,
 			Creator:       *singleStatus.CreatedBy.DisplayName,
@@ -595,8 +2048,27 @@ func (client *AzureReposClient) GetCommitStatuses(ctx context.Context, owner, re
 	return results, err
 }
 
+// The title passed to SetCommitStatus is the genre of the status context
+func getAzureReposStatusContext(statusContext *git.GitStatusContext) string {
+	if statusContext == nil {
+		return ""
+	}
+	return vcsutils.DefaultIfNotNil(statusContext.Genre)
+}
+
+// CreateCheckRun on Azure Repos
+func (client *AzureReposClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, getUnsupportedInAzureError("create check run")
+}
+
+// UpdateCheckRun on Azure Repos
+func (client *AzureReposClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return getUnsupportedInAzureError("update check run")
+}
+
 // DownloadFileFromRepo on Azure Repos
 func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepo")
 	if err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -631,12 +2103,97 @@ func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner,
 	return contents, http.StatusOK, nil
 }
 
+// FileExistsInRepo on Azure Repos
+func (client *AzureReposClient) FileExistsInRepo(ctx context.Context, _, repository, branch, path string) (bool, error) {
+	ctx = labelAPICalls(ctx, "FileExistsInRepo")
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "path": path}); err != nil {
+		return false, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	includeContent := false
+	item, err := azureReposGitClient.GetItem(ctx, git.GetItemArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch},
+		IncludeContent:    &includeContent,
+	})
+	if err != nil {
+		if isAzureNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return item.GitObjectType == nil || *item.GitObjectType == git.GitObjectTypeValues.Blob, nil
+}
+
+// CILint on Azure Repos
+func (client *AzureReposClient) CILint(_ context.Context, _, _, _ string) (CILintResult, error) {
+	return CILintResult{}, ErrCILintNotSupported
+}
+
+// isAzureNotFoundError returns true if the error is the response of a request of a missing resource
+func isAzureNotFoundError(err error) bool {
+	var wrappedError azuredevops.WrappedError
+	if errors.As(err, &wrappedError) {
+		return wrappedError.StatusCode != nil && *wrappedError.StatusCode == http.StatusNotFound
+	}
+	var wrappedErrorPointer *azuredevops.WrappedError
+	return errors.As(err, &wrappedErrorPointer) && wrappedErrorPointer.StatusCode != nil && *wrappedErrorPointer.StatusCode == http.StatusNotFound
+}
+
+// DownloadFileFromRepoStream on Azure Repos
+func (client *AzureReposClient) DownloadFileFromRepoStream(ctx context.Context, _, repository, branch, path string, writer io.Writer, maxBytes int64) (err error) {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepoStream")
+	if err = validateParametersNotBlank(map[string]string{"repository": repository, "path": path}); err != nil {
+		return
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return
+	}
+	output, err := azureReposGitClient.GetItemContent(ctx, git.GetItemContentArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch},
+		IncludeContent:    vcsutils.PointerOf(true),
+	})
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, output.Close())
+	}()
+	return copyWithLimit(writer, output, maxBytes)
+}
+
 // GetRepositoryEnvironmentInfo on GitLab
 func (client *AzureReposClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
 	return RepositoryEnvironmentInfo{}, getUnsupportedInAzureError("get repository environment info")
 }
 
-func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, repository, refBefore, refAfter string) ([]string, error) {
+// CreateOrUpdateEnvironment on Azure Repos
+func (client *AzureReposClient) CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error {
+	return getUnsupportedInAzureError("create or update environment")
+}
+
+func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFiles")
+	modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	return getModifiedFileNames(modifiedFiles), nil
+}
+
+// GetModifiedFilesWithDetails on Azure Repos.
+// Azure Repos doesn't return the added and deleted lines count.
+func (client *AzureReposClient) GetModifiedFilesWithDetails(ctx context.Context, _, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFilesWithDetails")
 	if err := validateParametersNotBlank(map[string]string{
 		"repository": repository,
 		"refBefore":  refBefore,
@@ -650,7 +2207,7 @@ func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, reposit
 		return nil, err
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	var modifiedFiles []ModifiedFileInfo
 	changesToReturn := vcsutils.PointerOf(100)
 	changesToSkip := vcsutils.PointerOf(0)
 
@@ -675,31 +2232,66 @@ func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, reposit
 			changesToSkip = vcsutils.PointerOf(*changesToSkip + *changesToReturn)
 		}
 
-		for _, anyChange := range changes {
-			change, err := vcsutils.RemapFields[git.GitChange](anyChange, "json")
-			if err != nil {
-				return nil, err
-			}
+		modifiedFilesPage, err := mapAzureReposBlobChanges(changes)
+		if err != nil {
+			return nil, err
+		}
+		modifiedFiles = append(modifiedFiles, modifiedFilesPage...)
+	}
+	return sortModifiedFiles(modifiedFiles), nil
+}
 
-			changedItem, err := vcsutils.RemapFields[git.GitItem](change.Item, "json")
-			if err != nil {
-				return nil, err
-			}
+// mapAzureReposBlobChanges maps the changes of the files, returned from the diffs and the changes APIs
+func mapAzureReposBlobChanges(changes []interface{}) ([]ModifiedFileInfo, error) {
+	var modifiedFiles []ModifiedFileInfo
+	for _, anyChange := range changes {
+		change, err := vcsutils.RemapFields[git.GitChange](anyChange, "json")
+		if err != nil {
+			return nil, err
+		}
 
-			if vcsutils.DefaultIfNotNil(changedItem.GitObjectType) != git.GitObjectTypeValues.Blob {
-				// We are not interested in the folders (trees) and other Git types.
-				continue
-			}
+		changedItem, err := vcsutils.RemapFields[git.GitItem](change.Item, "json")
+		if err != nil {
+			return nil, err
+		}
+
+		if vcsutils.DefaultIfNotNil(changedItem.GitObjectType) != git.GitObjectTypeValues.Blob {
+			// We are not interested in the folders (trees) and other Git types.
+			continue
+		}
+
+		modifiedFiles = append(modifiedFiles, mapAzureReposChange(change, changedItem))
+	}
+	return modifiedFiles, nil
+}
 
-			// Azure returns all paths with '/' prefix. Other providers doesn't, so let's
-			// remove the prefix here to produce output of the same format.
-			fileNamesSet.Add(strings.TrimPrefix(vcsutils.DefaultIfNotNil(changedItem.Path), "/"))
+func mapAzureReposChange(change git.GitChange, changedItem git.GitItem) ModifiedFileInfo {
+	// Azure returns all paths with '/' prefix. Other providers doesn't, so let's
+	// remove the prefix here to produce output of the same format.
+	modifiedFile := ModifiedFileInfo{Path: strings.TrimPrefix(vcsutils.DefaultIfNotNil(changedItem.Path), "/"), Status: FileModified}
+	// The change type is a comma separated list of change types, such as 'edit, rename'
+	changeType := string(vcsutils.DefaultIfNotNil(change.ChangeType))
+	switch {
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Delete)):
+		modifiedFile.Status = FileRemoved
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Add)):
+		modifiedFile.Status = FileAdded
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Rename)):
+		modifiedFile.Status = FileRenamed
+		previousPath := vcsutils.DefaultIfNotNil(change.OriginalPath)
+		if previousPath == "" {
+			previousPath = vcsutils.DefaultIfNotNil(change.SourceServerItem)
+		}
+		if previousPath = strings.TrimPrefix(previousPath, "/"); previousPath != modifiedFile.Path {
+			modifiedFile.PreviousPath = previousPath
 		}
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
+	return modifiedFile
+}
+
+// getRepositoryWebURL returns the web URL of a repository, under which the web URLs of its pull requests and commits are built
+func (client *AzureReposClient) getRepositoryWebURL(repository string) string {
+	return fmt.Sprintf("%s/%s/_git/%s", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/"), url.PathEscape(client.vcsInfo.Project), url.PathEscape(repository))
 }
 
 func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRequest, owner, repository string, withBody bool) PullRequestInfo {
@@ -722,9 +2314,10 @@ func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRe
 	}
 
 	return PullRequestInfo{
-		ID:   int64(*pullRequest.PullRequestId),
-		Body: prBody,
-		URL:  vcsutils.DefaultIfNotNil(pullRequest.Url),
+		ID:      int64(*pullRequest.PullRequestId),
+		Body:    prBody,
+		URL:     vcsutils.DefaultIfNotNil(pullRequest.Url),
+		HTMLURL: fmt.Sprintf("%s/pullrequest/%d", client.getRepositoryWebURL(repository), *pullRequest.PullRequestId),
 		Source: BranchInfo{
 			Name:       shortSourceName,
 			Repository: repository,
@@ -735,7 +2328,24 @@ func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRe
 			Repository: repository,
 			Owner:      owner,
 		},
+		HeadCommitHash: getAzureReposCommitID(pullRequest.LastMergeSourceCommit),
+		Author:         getAzureReposPullRequestAuthor(&pullRequest),
+		UpdatedAt:      extractTimeFromAzuredevopsTime(pullRequest.CreationDate),
+	}
+}
+
+func getAzureReposCommitID(commit *git.GitCommitRef) string {
+	if commit == nil {
+		return ""
+	}
+	return vcsutils.DefaultIfNotNil(commit.CommitId)
+}
+
+func getAzureReposPullRequestAuthor(pullRequest *git.GitPullRequest) string {
+	if pullRequest.CreatedBy == nil {
+		return ""
 	}
+	return vcsutils.DefaultIfNotNil(pullRequest.CreatedBy.UniqueName)
 }
 
 // Extract the repository owner of a forked source
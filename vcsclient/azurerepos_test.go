@@ -1,16 +1,21 @@
 package vcsclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,6 +31,190 @@ func TestAzureRepos_Connection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAzureReposClient_Raw(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+	azureClient := client.(*AzureReposClient)
+
+	gitClient, err := azureClient.Raw(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, gitClient)
+	// The raw client is shared with the client
+	sharedGitClient, err := azureClient.buildAzureReposClient(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, gitClient, sharedGitClient)
+}
+
+func TestAzureRepos_GetServerVersion(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+
+	serverVersion, err := client.GetServerVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "7.1", serverVersion)
+
+	capabilities, err := client.Capabilities(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, capabilities, PullRequestAttachmentsCapability)
+	assert.NotContains(t, capabilities, LabelsCapability)
+}
+
+func TestAzureRepos_TokenProviderAuthentication(t *testing.T) {
+	ctx := context.Background()
+	var authorizationHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizationHeaders = append(authorizationHeaders, r.Header.Get("Authorization"))
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			assert.Contains(t, r.RequestURI, "getRepository")
+			response = `{"value": [{"name": "repo-1"}],"count": 1}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	// Each call returns a new token, as if the token was refreshed
+	var tokenRequestsCount int
+	tokenProvider := func(ctx context.Context) (string, error) {
+		tokenRequestsCount++
+		return fmt.Sprintf("access-token-%d", tokenRequestsCount), nil
+	}
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Project("project").TokenProvider(tokenProvider).Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.TestConnection(ctx))
+	repositories, err := client.ListRepositories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"project": {repo1}}, repositories)
+	for i, authorizationHeader := range authorizationHeaders {
+		assert.Equal(t, fmt.Sprintf("Bearer access-token-%d", i+1), authorizationHeader)
+	}
+	assert.Equal(t, tokenRequestsCount, len(authorizationHeaders))
+
+	failingClient, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Project("project").TokenProvider(func(ctx context.Context) (string, error) {
+		return "", errors.New("the federated credential is expired")
+	}).Build()
+	assert.NoError(t, err)
+	err = failingClient.TestConnection(ctx)
+	assert.ErrorContains(t, err, "failed to get an access token: the federated credential is expired")
+}
+
+func TestAzureRepos_EnablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	var updatedPullRequest git.GitPullRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.RequestURI == "/_apis/connectionData":
+			response = `{"authenticatedUser": {"id": "5a5c7b4e-3b2a-4f3d-9d2c-1f3a2b4c5d6e", "providerDisplayName": "frogbot"}}`
+		default:
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updatedPullRequest))
+			response = `{"pullRequestId": 1}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	assert.NoError(t, client.EnablePullRequestAutoMerge(ctx, "", repo1, 1, SquashMerge))
+	assert.Equal(t, "5a5c7b4e-3b2a-4f3d-9d2c-1f3a2b4c5d6e", *updatedPullRequest.AutoCompleteSetBy.Id)
+	assert.Equal(t, git.GitPullRequestMergeStrategyValues.Squash, *updatedPullRequest.CompletionOptions.MergeStrategy)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	assert.Error(t, badClient.EnablePullRequestAutoMerge(ctx, "", repo1, 1, SquashMerge))
+}
+
+func TestAzureRepos_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			assert.Equal(t, "/_apis/connectionData", r.RequestURI)
+			response = `{"authenticatedUser": {"id": "5a5c7b4e-3b2a-4f3d-9d2c-1f3a2b4c5d6e", "providerDisplayName": "Frogbot", "properties": {"Account": {"$type": "System.String", "$value": "frogbot@jfrog.com"}}}}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{ID: "5a5c7b4e-3b2a-4f3d-9d2c-1f3a2b4c5d6e", Login: "frogbot@jfrog.com", DisplayName: "Frogbot", Email: "frogbot@jfrog.com"}, user)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	var updatedPullRequest git.GitPullRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.Method == http.MethodGet:
+			response = `{"pullRequestId": 1, "status": "active", "lastMergeSourceCommit": {"commitId": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}`
+		default:
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updatedPullRequest))
+			response = `{"pullRequestId": 1, "status": "completed"}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err := client.MergePullRequest(ctx, "", repo1, 1, MergeOptions{
+		Method:             RebaseMerge,
+		CommitTitle:        "Upgrade lodash",
+		DeleteSourceBranch: true,
+		BypassPolicies:     true,
+		BypassReason:       "Hotfix",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, git.PullRequestStatusValues.Completed, *updatedPullRequest.Status)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", *updatedPullRequest.LastMergeSourceCommit.CommitId)
+	completionOptions := updatedPullRequest.CompletionOptions
+	assert.Equal(t, git.GitPullRequestMergeStrategyValues.Rebase, *completionOptions.MergeStrategy)
+	assert.Equal(t, "Upgrade lodash", *completionOptions.MergeCommitMessage)
+	assert.True(t, *completionOptions.DeleteSourceBranch)
+	assert.True(t, *completionOptions.BypassPolicy)
+	assert.Equal(t, "Hotfix", *completionOptions.BypassReason)
+}
+
 func TestAzureRepos_ListRepositories(t *testing.T) {
 	type ListRepositoryResponse struct {
 		Value []git.GitRepository
@@ -53,6 +242,173 @@ func TestAzureRepos_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_ListRepositoryListings(t *testing.T) {
+	type ListRepositoryResponse struct {
+		Value []git.GitRepository
+		Count int
+	}
+	publicVisibility := core.ProjectVisibilityValues.Public
+	defaultBranch := "refs/heads/main"
+	res := ListRepositoryResponse{
+		Value: []git.GitRepository{
+			{Name: &repo1, DefaultBranch: &defaultBranch, Project: &core.TeamProjectReference{Visibility: &publicVisibility}},
+			{Name: &repo2},
+		},
+		Count: 2,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getRepository", createAzureReposHandler)
+	defer cleanUp()
+
+	listings, err := client.ListRepositoryListings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{
+		{OwnerType: ProjectNamespace, Name: repo1, Visibility: Public, DefaultBranch: "main"},
+		{OwnerType: ProjectNamespace, Name: repo2, Visibility: Private},
+	}, listings)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListRepositoryListings(ctx)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_SearchRepositories(t *testing.T) {
+	type ListRepositoryResponse struct {
+		Value []git.GitRepository
+		Count int
+	}
+	res := ListRepositoryResponse{Value: []git.GitRepository{{Name: &repo1}, {Name: &repo2}}, Count: 2}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getRepository", createAzureReposHandler)
+	defer cleanUp()
+
+	listings, err := client.SearchRepositories(ctx, RepositorySearchOptions{Owner: project, Name: "REPO-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{{Owner: project, OwnerType: ProjectNamespace, Name: repo2, Visibility: Private}}, listings)
+
+	_, err = client.SearchRepositories(ctx, RepositorySearchOptions{Topic: "frogbot-enabled"})
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.SearchCode(ctx, "froggit-go", CodeSearchOptions{})
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_GetBranchInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.Contains(r.RequestURI, "listBranches"):
+			assert.Equal(t, "main", r.URL.Query().Get("name"))
+			response = `{"name": "main", "commit": {"commitId": "86d6919952702f9ab03bc95b45687f145a663de0"}}`
+		case strings.Contains(r.RequestURI, "getRepository"):
+			response = `{"id": "5febef5a-833d-4e14-b9c0-14cb638f91e6", "name": "repo-1", "defaultBranch": "refs/heads/main"}`
+		case r.URL.Path == "/jfrog-project/_apis/git/policy/configurations":
+			assert.Equal(t, "5febef5a-833d-4e14-b9c0-14cb638f91e6", r.URL.Query().Get("repositoryId"))
+			assert.Equal(t, "refs/heads/main", r.URL.Query().Get("refName"))
+			response = `{"count": 2, "value": [{"isEnabled": true, "isBlocking": false}, {"isEnabled": true, "isBlocking": true}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	branchInfo, err := client.GetBranchInfo(ctx, "", repo1, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryBranchInfo{Name: "main", HeadCommitHash: "86d6919952702f9ab03bc95b45687f145a663de0", Protected: true, Default: true}, branchInfo)
+}
+
+func TestAzureRepos_ListProtectedBranches(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.Contains(r.RequestURI, "listBranches"):
+			response = `{"count": 4, "value": [{"name": "main"}, {"name": "feature"}, {"name": "release/1.0"}, {"name": "hotfix"}]}`
+		case strings.Contains(r.RequestURI, "getRepository"):
+			response = `{"id": "5febef5a-833d-4e14-b9c0-14cb638f91e6", "name": "repo-1"}`
+		case r.URL.Path == "/jfrog-project/_apis/git/policy/configurations":
+			assert.Equal(t, "5febef5a-833d-4e14-b9c0-14cb638f91e6", r.URL.Query().Get("repositoryId"))
+			response = `{"count": 3, "value": [
+				{"isEnabled": true, "isBlocking": true, "settings": {"scope": [{"refName": "refs/heads/main", "matchKind": "Exact"}]}},
+				{"isEnabled": true, "isBlocking": true, "settings": {"scope": [{"refName": "refs/heads/release/", "matchKind": "Prefix"}]}},
+				{"isEnabled": true, "isBlocking": false, "settings": {"scope": [{"refName": "refs/heads/hotfix", "matchKind": "Exact"}]}}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	branches, err := client.ListBranchesWithOptions(ctx, "", repo1, ListBranchesOptions{ProtectedOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main", "release/1.0"}, branches)
+}
+
+func TestAzureRepos_ListNamespaces(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			assert.Equal(t, "/_apis/projects", r.URL.Path)
+			assert.Equal(t, "0", r.URL.Query().Get("$skip"))
+			response = `{"count": 2, "value": [{"name": "froggit"}, {"name": "frogbot"}]}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	namespaces, err := client.ListNamespaces(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []NamespaceInfo{
+		{Name: "frogbot", DisplayName: "frogbot", Kind: ProjectNamespace},
+		{Name: "froggit", DisplayName: "froggit", Kind: ProjectNamespace},
+	}, namespaces)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListNamespaces(ctx)
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_TestListBranches(t *testing.T) {
 	type ListBranchesResponse struct {
 		Value []git.GitBranchStats
@@ -105,6 +461,65 @@ func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestDownloadRepositorySkipDotGitCreation(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	assert.NoError(t, err)
+
+	downloadURL := fmt.Sprintf("/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip", repo1, branch1)
+	// The handler fails the test on any request other than the archive download, such as getting the repository info
+	server := httptest.NewServer(createAzureReposHandler(t, downloadURL, repoFile, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).SkipDotGitCreation(true).Build()
+	assert.NoError(t, err)
+
+	err = client.DownloadRepository(ctx, "", repo1, branch1, dir)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.NoDirExists(t, filepath.Join(dir, ".git"))
+}
+
+func TestAzureReposClient_GitClientCaching(t *testing.T) {
+	ctx := context.Background()
+	resourceAreasRequests := 0
+	responseStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			_, err = w.Write(jsonVal)
+			assert.NoError(t, err)
+		case "/_apis/ResourceAreas":
+			resourceAreasRequests++
+			_, err := w.Write([]byte(`{"value": [],"count": 0}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(responseStatus)
+			_, err := w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	// The git client is built once and reused by the following requests
+	assert.NoError(t, client.UpdatePullRequestComment(ctx, "", repo1, 1, 1, "content"))
+	assert.NoError(t, client.UpdatePullRequestComment(ctx, "", repo1, 1, 1, "content"))
+	assert.Equal(t, 1, resourceAreasRequests)
+
+	// An unauthorized response invalidates the git client
+	responseStatus = http.StatusUnauthorized
+	assert.Error(t, client.UpdatePullRequestComment(ctx, "", repo1, 1, 1, "content"))
+	responseStatus = http.StatusOK
+	assert.NoError(t, client.UpdatePullRequestComment(ctx, "", repo1, 1, 1, "content"))
+	assert.Equal(t, 2, resourceAreasRequests)
+}
+
 func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
 	type CreatePullRequestResponse struct {
 		Value git.GitPullRequest
@@ -135,6 +550,91 @@ func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CreatePullRequestWithWorkItems(t *testing.T) {
+	ctx := context.Background()
+	var createdPullRequest git.GitPullRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&createdPullRequest))
+			response = `{"pullRequestId": 5}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server).(*AzureReposClient)
+
+	err := client.CreatePullRequestWithWorkItems(ctx, "", repo1, branch1, branch2, "Hello World", "Hello World", 7, 9)
+	assert.NoError(t, err)
+	assert.Equal(t, "refs/heads/"+branch1, *createdPullRequest.SourceRefName)
+	assert.Equal(t, []webapi.ResourceRef{{Id: vcsutils.PointerOf("7")}, {Id: vcsutils.PointerOf("9")}}, *createdPullRequest.WorkItemRefs)
+
+	// Without work items, no work item is linked
+	createdPullRequest = git.GitPullRequest{}
+	err = client.CreatePullRequest(ctx, "", repo1, branch1, branch2, "Hello World", "Hello World")
+	assert.NoError(t, err)
+	assert.Nil(t, createdPullRequest.WorkItemRefs)
+}
+
+func TestAzureReposClient_LinkWorkItem(t *testing.T) {
+	ctx := context.Background()
+	projectID := "0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d"
+	repositoryID := "94c1dba8-d9d9-4600-94b4-1a51acb43220"
+	pullRequestResponse := fmt.Sprintf(`{"pullRequestId": 5, "repository": {"id": "%s", "project": {"id": "%s"}}}`, repositoryID, projectID)
+	var workItemPatch []webapi.JsonPatchOperation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.URL.Path, "/_apis/ResourceAreas/workItems/"):
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/_apis/ResourceAreas/workItems/7", r.URL.Path)
+			assert.Contains(t, r.Header.Get("Content-Type"), "application/json-patch+json")
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&workItemPatch))
+			response = `{"id": 7}`
+		default:
+			assert.Equal(t, http.MethodGet, r.Method)
+			response = pullRequestResponse
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server).(*AzureReposClient)
+
+	err := client.LinkWorkItem(ctx, "", repo1, 5, 7)
+	assert.NoError(t, err)
+	assert.Len(t, workItemPatch, 1)
+	assert.Equal(t, webapi.OperationValues.Add, *workItemPatch[0].Op)
+	assert.Equal(t, "/relations/-", *workItemPatch[0].Path)
+	assert.Equal(t, map[string]interface{}{
+		"rel":        "ArtifactLink",
+		"url":        fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F5", projectID, repositoryID),
+		"attributes": map[string]interface{}{"name": "Pull Request"},
+	}, workItemPatch[0].Value)
+
+	// A pull request without a repository can't be linked
+	pullRequestResponse = `{"pullRequestId": 5}`
+	err = client.LinkWorkItem(ctx, "", repo1, 5, 7)
+	assert.EqualError(t, err, "failed to get the repository of pull request 5")
+
+	assert.Error(t, client.LinkWorkItem(ctx, "", "", 5, 7))
+}
+
 func TestAzureReposClient_TestUpdatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	pullRequestId := 1
@@ -155,6 +655,36 @@ func TestAzureReposClient_TestUpdatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_ClosePullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestId := 1
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"pullRequestId": 1, "status": "active"}`), "/_apis/ResourceAreas/getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+	err := client.ClosePullRequest(ctx, owner, repo1, pullRequestId)
+	assert.NoError(t, err)
+	err = client.ReopenPullRequest(ctx, owner, repo1, pullRequestId)
+	assert.EqualError(t, err, "pull request 1 is already open")
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	err = badClient.ClosePullRequest(ctx, owner, repo1, pullRequestId)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ReopenPullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestId := 1
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"pullRequestId": 1, "status": "abandoned"}`), "/_apis/ResourceAreas/getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+	err := client.ReopenPullRequest(ctx, owner, repo1, pullRequestId)
+	assert.NoError(t, err)
+
+	completedClient, completedCleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"pullRequestId": 1, "status": "completed"}`), "/_apis/ResourceAreas/getPullRequests", createAzureReposHandler)
+	defer completedCleanUp()
+	err = completedClient.ReopenPullRequest(ctx, owner, repo1, pullRequestId)
+	assert.EqualError(t, err, "pull request 1 is merged and its state can't be changed")
+}
+
 func TestAzureRepos_TestAddPullRequestComment(t *testing.T) {
 	type AddPullRequestCommentResponse struct {
 		Value git.GitPullRequestCommentThread
@@ -268,10 +798,11 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, []PullRequestInfo{
 		{
-			ID:     1,
-			Source: BranchInfo{Name: branch1, Repository: repo1},
-			Target: BranchInfo{Name: branch2, Repository: repo1},
-			URL:    url,
+			ID:      1,
+			Source:  BranchInfo{Name: branch1, Repository: repo1},
+			Target:  BranchInfo{Name: branch2, Repository: repo1},
+			URL:     url,
+			HTMLURL: client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/pullrequest/1",
 		},
 	})
 
@@ -306,11 +837,12 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, []PullRequestInfo{
 		{
-			ID:     1,
-			Body:   prBody,
-			Source: BranchInfo{Name: branch1, Repository: repo1},
-			Target: BranchInfo{Name: branch2, Repository: repo1},
-			URL:    url,
+			ID:      1,
+			Body:    prBody,
+			Source:  BranchInfo{Name: branch1, Repository: repo1},
+			Target:  BranchInfo{Name: branch2, Repository: repo1},
+			URL:     url,
+			HTMLURL: client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/pullrequest/1",
 		},
 	})
 
@@ -320,6 +852,108 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_ListOpenPullRequestsWithQueryOptions(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			query = r.URL.Query()
+			response = `{"value": [{"pullRequestId": 3, "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main", "repository": {"name": "repo-1"}}],"count": 1}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	pullRequestsInfo, err := client.ListOpenPullRequestsWithQueryOptions(ctx, "", repo1, PullRequestsQueryOptions{
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		ListOptions:  ListOptions{Page: 3, PerPage: 10},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestInfo{{
+		ID:      3,
+		Source:  BranchInfo{Name: "feature", Repository: repo1},
+		Target:  BranchInfo{Name: "main", Repository: repo1},
+		HTMLURL: client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/pullrequest/3",
+	}}, pullRequestsInfo)
+	assert.Equal(t, "active", query.Get("searchCriteria.status"))
+	assert.Equal(t, "refs/heads/feature", query.Get("searchCriteria.sourceRefName"))
+	assert.Equal(t, "refs/heads/main", query.Get("searchCriteria.targetRefName"))
+	assert.Equal(t, "10", query.Get("$top"))
+	assert.Equal(t, "20", query.Get("$skip"))
+
+	// Without options, all the active pull requests are fetched
+	_, err = client.ListOpenPullRequestsWithQueryOptions(ctx, "", repo1, PullRequestsQueryOptions{})
+	assert.NoError(t, err)
+	assert.False(t, query.Has("searchCriteria.targetRefName"))
+	assert.False(t, query.Has("$top"))
+	assert.False(t, query.Has("$skip"))
+}
+
+func TestAzureRepos_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			query = r.URL.Query()
+			response = `{"value": [
+				{"pullRequestId": 3, "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "frogger"}},
+				{"pullRequestId": 2, "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "tom"}},
+				{"pullRequestId": 1, "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "frogger"}}
+			],"count": 3}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	result, err := client.ListPullRequests(ctx, "", repo1, PullRequestListOptions{
+		State:        PullRequestsMerged,
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		ListOptions:  ListOptions{Page: 3, PerPage: 10},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, "completed", query.Get("searchCriteria.status"))
+	assert.Equal(t, "refs/heads/feature", query.Get("searchCriteria.sourceRefName"))
+	assert.Equal(t, "refs/heads/main", query.Get("searchCriteria.targetRefName"))
+	assert.Equal(t, "10", query.Get("$top"))
+	assert.Equal(t, "20", query.Get("$skip"))
+
+	// The author and the ascending order are applied by the client
+	result, err = client.ListPullRequests(ctx, "", repo1, PullRequestListOptions{
+		State:       PullRequestsAll,
+		Author:      username,
+		Ascending:   true,
+		ListOptions: ListOptions{Page: 1, PerPage: 1},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, "all", query.Get("searchCriteria.status"))
+	assert.False(t, query.Has("$top"))
+}
+
 func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	pullRequestId := 1
 	repoName := "repoName"
@@ -345,10 +979,11 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	pullRequestsInfo, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, PullRequestInfo{
-		ID:     1,
-		Source: BranchInfo{Name: sourceName, Repository: repoName, Owner: forkedOwner},
-		Target: BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
-		URL:    url,
+		ID:      1,
+		Source:  BranchInfo{Name: sourceName, Repository: repoName, Owner: forkedOwner},
+		Target:  BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
+		URL:     url,
+		HTMLURL: client.(*AzureReposClient).getRepositoryWebURL(repoName) + "/pullrequest/1",
 	})
 
 	// Fail source repository owner extraction, should be empty string and not fail the process.
@@ -367,10 +1002,11 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	pullRequestsInfo, err = client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, PullRequestInfo{
-		ID:     1,
-		Source: BranchInfo{Name: sourceName, Repository: repoName, Owner: ""},
-		Target: BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
-		URL:    url,
+		ID:      1,
+		Source:  BranchInfo{Name: sourceName, Repository: repoName, Owner: ""},
+		Target:  BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
+		URL:     url,
+		HTMLURL: client.(*AzureReposClient).getRepositoryWebURL(repoName) + "/pullrequest/1",
 	},
 	)
 
@@ -392,6 +1028,7 @@ func TestListPullRequestComments(t *testing.T) {
 	}
 	id1 := 1
 	id2 := 2
+	resolvedStatus := git.CommentThreadStatusValues.Closed
 	firstCommentContent := "first comment"
 	secondCommentContent := "second comment"
 	author := "test author"
@@ -411,8 +1048,13 @@ func TestListPullRequestComments(t *testing.T) {
 					Author:  &webapi.IdentityRef{DisplayName: &author},
 				},
 			},
+		}, {
+			Id:            &id2,
+			Status:        &resolvedStatus,
+			PublishedDate: &azuredevops.Time{Time: time.Now()},
+			Comments:      &[]git.Comment{},
 		}},
-		Count: 1,
+		Count: 2,
 	}
 	jsonRes, err := json.Marshal(res)
 	assert.NoError(t, err)
@@ -422,6 +1064,9 @@ func TestListPullRequestComments(t *testing.T) {
 	commentInfo, err := client.ListPullRequestComments(ctx, "", repo1, id1)
 	expected := "Author: test author, Id: 1, Content:first comment\nAuthor: test author, Id: 2, Content:second comment\n"
 	assert.Equal(t, expected, commentInfo[0].Content)
+	assert.Equal(t, "1", commentInfo[0].ThreadID)
+	assert.False(t, commentInfo[0].Resolved)
+	assert.True(t, commentInfo[1].Resolved)
 	assert.NoError(t, err)
 
 	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
@@ -444,6 +1089,7 @@ func TestAzureRepos_TestGetLatestCommit(t *testing.T) {
 		AuthorName:    "Test User",
 		CommitterName: "Test User",
 		Url:           "https://dev.azure.com/testuser/0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d/_apis/git/repositories/94c1dba8-d9d9-4600-94b4-1a51acb43220/commits/86d6919952702f9ab03bc95b45687f145a663de0",
+		HTMLURL:       client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/commit/86d6919952702f9ab03bc95b45687f145a663de0",
 		Timestamp:     1667812601,
 		Message:       "Updated package.json",
 		AuthorEmail:   "testuser@jfrog.com",
@@ -470,6 +1116,7 @@ func TestAzureRepos_TestGetCommits(t *testing.T) {
 		AuthorName:    "Test User",
 		CommitterName: "Test User",
 		Url:           "https://dev.azure.com/testuser/0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d/_apis/git/repositories/94c1dba8-d9d9-4600-94b4-1a51acb43220/commits/86d6919952702f9ab03bc95b45687f145a663de0",
+		HTMLURL:       client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/commit/86d6919952702f9ab03bc95b45687f145a663de0",
 		Timestamp:     1667812601,
 		Message:       "Updated package.json",
 		AuthorEmail:   "testuser@jfrog.com",
@@ -479,6 +1126,7 @@ func TestAzureRepos_TestGetCommits(t *testing.T) {
 		AuthorName:    "Test User",
 		CommitterName: "Test User",
 		Url:           "https://dev.azure.com/testuser/0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d/_apis/git/repositories/94c1dba8-d9d9-4600-94b4-1a51acb43220/commits/4aa8367809020c4e97af29e2b57f7528d5d27702",
+		HTMLURL:       client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/commit/4aa8367809020c4e97af29e2b57f7528d5d27702",
 		Timestamp:     1667201343,
 		Message:       "Set up CI with Azure Pipelines",
 		AuthorEmail:   "testuser@jfrog.com",
@@ -488,6 +1136,7 @@ func TestAzureRepos_TestGetCommits(t *testing.T) {
 		AuthorName:    "Test User",
 		CommitterName: "Test User",
 		Url:           "https://dev.azure.com/testuser/0b8072c4-ad86-4edb-a8f2-06dbc07e3e2d/_apis/git/repositories/94c1dba8-d9d9-4600-94b4-1a51acb43220/commits/3779104c35804e15b6fdf4fee303e717cd6c1352",
+		HTMLURL:       client.(*AzureReposClient).getRepositoryWebURL(repo1) + "/commit/3779104c35804e15b6fdf4fee303e717cd6c1352",
 		Timestamp:     1667201200,
 		Message:       "first commit",
 		AuthorEmail:   "testuser@jfrog.com",
@@ -507,6 +1156,35 @@ func TestAzureReposClient_AddSshKeyToRepository(t *testing.T) {
 	assert.Error(t, client.AddSshKeyToRepository(ctx, owner, repo1, "", "", 0777))
 }
 
+func TestAzureReposClient_AddSshKeyToUserProfile(t *testing.T) {
+	ctx := context.Background()
+	var sessionToken map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/_apis/Token/SessionTokens", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("isPublic"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&sessionToken))
+		_, err := w.Write([]byte(`{"authorizationId": "1", "displayName": "my key"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server).(*AzureReposClient)
+
+	assert.NoError(t, client.AddSshKeyToUserProfile(ctx, "my key", "ssh-rsa AAAA\n"))
+	assert.Equal(t, "my key", sessionToken["displayName"])
+	assert.Equal(t, "ssh-rsa AAAA", sessionToken["publicData"])
+	assert.Equal(t, "app_token", sessionToken["scope"])
+	assert.Equal(t, true, sessionToken["isPublic"])
+
+	assert.Error(t, client.AddSshKeyToUserProfile(ctx, "", ""))
+}
+
+func TestGetAzureProfileUrl(t *testing.T) {
+	assert.Equal(t, "https://vssps.dev.azure.com/jfrog", getAzureProfileUrl("https://dev.azure.com/jfrog"))
+	assert.Equal(t, "https://jfrog.vssps.visualstudio.com", getAzureProfileUrl("https://jfrog.visualstudio.com"))
+	assert.Equal(t, "https://azure.example.com/tfs/DefaultCollection", getAzureProfileUrl("https://azure.example.com/tfs/DefaultCollection"))
+}
+
 func TestAzureReposClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -520,6 +1198,7 @@ func TestAzureReposClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	defer cleanUp()
 	_, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, "")
 	assert.Error(t, err)
+	assert.Error(t, client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{Name: envName}))
 }
 
 func TestAzureReposClient_GetCommitBySha(t *testing.T) {
@@ -530,6 +1209,60 @@ func TestAzureReposClient_GetCommitBySha(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_GetCommitDiff(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.Contains(r.RequestURI, "getCommits"):
+			response = `{"commitId": "sha-2", "parents": ["sha-1"]}`
+		case strings.Contains(r.RequestURI, "getChanges"):
+			response = `{"changes": [
+				{"item": {"gitObjectType": "tree", "path": "/src"}, "changeType": "edit"},
+				{"item": {"gitObjectType": "blob", "path": "/src/main.go"}, "changeType": "edit"},
+				{"item": {"gitObjectType": "blob", "path": "/go.mod"}, "changeType": "add"},
+				{"item": {"gitObjectType": "blob", "path": "/README.md"}, "changeType": "delete"}
+			]}`
+		case strings.Contains(r.RequestURI, "DownloadFileFromRepo"):
+			files := map[string]string{
+				"sha-1 src/main.go": "package main\n\nfunc main() {\n}\n",
+				"sha-2 src/main.go": "package main\n\nfunc main() {\n\tprintln()\n}\n",
+				"sha-2 go.mod":      "module main\n",
+				"sha-1 README.md":   "# Readme\n",
+			}
+			content, exists := files[r.URL.Query().Get("versionDescriptor.version")+" "+r.URL.Query().Get("path")]
+			assert.True(t, exists, "unexpected file request "+r.RequestURI)
+			response = content
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	commitDiff, err := client.GetCommitDiff(ctx, owner, repo1, "sha-2")
+	assert.NoError(t, err)
+	assert.Equal(t, []FileDiffInfo{
+		{Path: "README.md", Deletions: 1},
+		{Path: "go.mod", Additions: 1},
+		{Path: "src/main.go", Additions: 1},
+	}, commitDiff.Files)
+	assert.Contains(t, commitDiff.Diff, "diff --git a/src/main.go b/src/main.go\n")
+	assert.Contains(t, commitDiff.Diff, "@@ -1,4 +1,5 @@\n package main\n \n func main() {\n+\tprintln()\n }\n")
+
+	_, err = client.GetCommitDiff(ctx, owner, repo1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'sha' is missing")
+}
+
 func TestAzureReposClient_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -546,6 +1279,14 @@ func TestAzureReposClient_UnlabelPullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	err := client.LabelPullRequest(ctx, owner, repo1, "", 1)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_UploadCodeScanning(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -554,6 +1295,26 @@ func TestAzureReposClient_UploadCodeScanning(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CodeScanningAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListCodeScanningAlerts(ctx, owner, repo1, CodeScanningAlertsQueryOptions{})
+	assert.Error(t, err)
+	_, err = client.GetCodeScanningAlert(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+	err = client.DismissCodeScanningAlert(ctx, owner, repo1, 1, DismissFalsePositive, "")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListVulnerabilityAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListVulnerabilityAlerts(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "badTest", createAzureReposHandler)
@@ -573,6 +1334,49 @@ func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, statusCode)
 }
 
+func TestAzureReposClient_DownloadFileFromRepoStream(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte("good"), "/_apis/ResourceAreas/DownloadFileFromRepo?includeContent=true&path=file.txt&versionDescriptor.version=&versionDescriptor.versionType=branch", createAzureReposHandler)
+	defer cleanUp()
+
+	var buffer bytes.Buffer
+	assert.NoError(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, "", "file.txt", &buffer, 0))
+	assert.Equal(t, "good", buffer.String())
+
+	buffer.Reset()
+	err := client.DownloadFileFromRepoStream(ctx, owner, repo1, "", "file.txt", &buffer, 3)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, "goo", buffer.String())
+
+	assert.Error(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, "", "", &buffer, 0))
+}
+
+func TestAzureReposClient_FileExistsInRepo(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		response       []byte
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{name: "file", response: []byte(`{"path": "/file.txt", "gitObjectType": "blob"}`), statusCode: http.StatusOK, expectedExists: true},
+		{name: "directory", response: []byte(`{"path": "/file.txt", "gitObjectType": "tree", "isFolder": true}`), statusCode: http.StatusOK, expectedExists: false},
+		{name: "not found", response: []byte(`{"message": "TF401174: The item '/file.txt' could not be found."}`), statusCode: http.StatusNotFound, expectedExists: false},
+		{name: "error", response: []byte(`{"message": "Internal error"}`), statusCode: http.StatusInternalServerError, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.AzureRepos, true, tt.response,
+				"/_apis/ResourceAreas/DownloadFileFromRepo?includeContent=false&path=file.txt&versionDescriptor.version=branch-1&versionDescriptor.versionType=branch", tt.statusCode, createAzureReposHandler)
+			defer cleanUp()
+			exists, err := client.FileExistsInRepo(ctx, owner, repo1, branch1, "file.txt")
+			assert.Equal(t, tt.expectedError, err != nil)
+			assert.Equal(t, tt.expectedExists, exists)
+		})
+	}
+}
+
 func TestAzureReposClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -612,6 +1416,41 @@ func TestAzureReposClient_SetCommitStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_SetCommitStatusWithOptions(t *testing.T) {
+	ctx := context.Background()
+	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
+	var status git.GitStatus
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		default:
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+			response = "{}"
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	err := client.SetCommitStatusWithOptions(ctx, Pass, owner, repo1, commitHash, "Frogbot scan", "", "", CommitStatusOptions{Key: "frogbot"})
+	assert.NoError(t, err)
+	assert.Equal(t, "frogbot", *status.Context.Name)
+	assert.Equal(t, "Frogbot scan", *status.Context.Genre)
+
+	// The name of the context defaults to the owner
+	err = client.SetCommitStatus(ctx, Pass, owner, repo1, commitHash, "Frogbot scan", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, owner, *status.Context.Name)
+}
+
 func TestAzureReposClient_GetRepositoryInfo(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "/_apis/ResourceAreas/getRepository", createGetRepositoryAzureReposHandler)
@@ -623,6 +1462,80 @@ func TestAzureReposClient_GetRepositoryInfo(t *testing.T) {
 	assert.Equal(t, repositoryInfo.RepositoryVisibility, Public)
 }
 
+func TestAzureReposClient_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "/_apis/ResourceAreas/getRepository", createGetRepositoryAzureReposHandler)
+	defer cleanUp()
+	defaultBranch, err := client.GetDefaultBranch(ctx, "jfrog", "froggit-go")
+	assert.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetDefaultBranch(ctx, "jfrog", "froggit-go")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ArchiveAndRenameRepository(t *testing.T) {
+	ctx := context.Background()
+	repositoryID := "5febef5a-833d-4e14-b9c0-14cb638f91e6"
+	var updatedRepository git.GitRepository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.Method == http.MethodGet:
+			response = fmt.Sprintf(`{"id": "%s", "name": "%s"}`, repositoryID, repo1)
+		default:
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updatedRepository))
+			response = fmt.Sprintf(`{"id": "%s", "name": "repo-2"}`, repositoryID)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	assert.NoError(t, client.RenameRepository(ctx, "", repo1, "repo-2"))
+	assert.Equal(t, "repo-2", *updatedRepository.Name)
+	assert.Error(t, client.ArchiveRepository(ctx, "", repo1))
+	assert.Error(t, client.UnarchiveRepository(ctx, "", repo1))
+}
+
+func TestAzureReposClient_GetUserPermissionOnRepo(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetUserPermissionOnRepo(context.Background(), "", repo1, username)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_RepositoryMirrors(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetRepositoryMirrors(context.Background(), "", repo1)
+	assert.Error(t, err)
+	err = client.SetRepositoryMirror(context.Background(), "", repo1, RepositoryMirrorInfo{URL: "https://github.com/jfrog/repo-1.git"})
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.CreateIssue(ctx, "", repo1, "title", "body")
+	assert.Error(t, err)
+	_, err = client.ListIssues(ctx, "", repo1, IssueOpen)
+	assert.Error(t, err)
+	assert.Error(t, client.CommentOnIssue(ctx, "", repo1, "content", 1))
+	assert.Error(t, client.CloseIssue(ctx, "", repo1, 1))
+}
+
 func TestAzureReposClient_GetLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
@@ -672,6 +1585,28 @@ func TestAzureReposClient_GetModifiedFiles(t *testing.T) {
 		}, actual)
 	})
 
+	t.Run("with details", func(t *testing.T) {
+		response := []byte(`{"changes": [
+			{"item": {"gitObjectType": "tree", "path": "/src"}, "changeType": "edit"},
+			{"item": {"gitObjectType": "blob", "path": "/src/main.go"}, "changeType": "edit"},
+			{"item": {"gitObjectType": "blob", "path": "/go.mod"}, "changeType": "add"},
+			{"item": {"gitObjectType": "blob", "path": "/package.json"}, "changeType": "delete"},
+			{"item": {"gitObjectType": "blob", "path": "/src/util.go"}, "changeType": "edit, rename", "sourceServerItem": "/src/utils.go"}
+		]}`)
+		const expectedURI = "/_apis/ResourceAreas?%24skip=0&%24top=100&baseVersion=sha-1&diffCommonCommit=true&targetVersion=sha-2"
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, expectedURI, createAzureReposHandler)
+		defer cleanUp()
+
+		modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, "", repo1, "sha-1", "sha-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []ModifiedFileInfo{
+			{Path: "go.mod", Status: FileAdded},
+			{Path: "package.json", Status: FileRemoved},
+			{Path: "src/main.go", Status: FileModified},
+			{Path: "src/util.go", Status: FileRenamed, PreviousPath: "src/utils.go"},
+		}, modifiedFiles)
+	})
+
 	t.Run("validation fails", func(t *testing.T) {
 		client := AzureReposClient{}
 		_, err := client.GetModifiedFiles(ctx, owner, "", "sha-1", "sha-2")
@@ -722,6 +1657,86 @@ func TestAzureReposClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_UpdatePullRequestComment(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte("{}"), "deletePullRequestComments", createAzureReposHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(context.Background(), "", repo1, 1, 1, "Updated content")
+	assert.NoError(t, err)
+	err = client.UpdatePullRequestReviewComment(context.Background(), "", repo1, 1, CommentInfo{ID: 1}, "Updated content")
+	assert.NoError(t, err)
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.UpdatePullRequestComment(context.Background(), "", repo1, 1, 1, "Updated content")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_UpdateThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"id": 1, "status": "fixed"}`), "pullRequestComments", createAzureReposHandler)
+	defer cleanUp()
+	azureClient, ok := client.(*AzureReposClient)
+	assert.True(t, ok)
+	assert.NoError(t, azureClient.UpdateThreadStatus(ctx, "", repo1, 1, 1, ThreadStatusFixed))
+	assert.NoError(t, azureClient.ResolvePullRequestReviewComments(ctx, "", repo1, 1, CommentInfo{ID: 1}, CommentInfo{ID: 2}))
+	assert.Error(t, azureClient.UpdateThreadStatus(ctx, "", repo1, 1, 1, ""))
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	badAzureClient, ok := badClient.(*AzureReposClient)
+	assert.True(t, ok)
+	assert.Error(t, badAzureClient.UpdateThreadStatus(ctx, "", repo1, 1, 1, ThreadStatusClosed))
+}
+
+func TestAzureReposClient_ReplyToPullRequestReviewComment(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte("{}"), "deletePullRequestComments", createAzureReposHandler)
+	defer cleanUp()
+	err := client.ReplyToPullRequestReviewComment(context.Background(), "", repo1, 1, "3", "Reply content")
+	assert.NoError(t, err)
+	err = client.ReplyToPullRequestReviewComment(context.Background(), "", repo1, 1, "not-a-number", "Reply content")
+	assert.Error(t, err)
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.ReplyToPullRequestReviewComment(context.Background(), "", repo1, 1, "3", "Reply content")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_UploadPullRequestAttachment(t *testing.T) {
+	response := []byte(`{"id":1,"displayName":"report.json","url":"https://dev.azure.com/org/project/_apis/git/repositories/repo-1/pullRequests/1/attachments/report.json"}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestAttachments", createAzureReposHandler)
+	defer cleanUp()
+	attachment, err := client.UploadPullRequestAttachment(context.Background(), "", repo1, 1, "report.json", []byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, PullRequestAttachmentInfo{
+		Name:     "report.json",
+		URL:      "https://dev.azure.com/org/project/_apis/git/repositories/repo-1/pullRequests/1/attachments/report.json",
+		Markdown: "[report.json](https://dev.azure.com/org/project/_apis/git/repositories/repo-1/pullRequests/1/attachments/report.json)",
+	}, attachment)
+
+	_, err = client.UploadPullRequestAttachment(context.Background(), "", repo1, 1, "", []byte("{}"))
+	assert.Error(t, err)
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.UploadPullRequestAttachment(context.Background(), "", repo1, 1, "report.json", []byte("{}"))
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListPullRequestAttachments(t *testing.T) {
+	response := []byte(`{"value":[{"id":1,"displayName":"report.json","url":"https://attachments/report.json"},{"id":2,"displayName":"image.png","url":"https://attachments/image.png"}],"count":2}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestAttachments", createAzureReposHandler)
+	defer cleanUp()
+	attachments, err := client.ListPullRequestAttachments(context.Background(), "", repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestAttachmentInfo{
+		{Name: "report.json", URL: "https://attachments/report.json", Markdown: "[report.json](https://attachments/report.json)"},
+		{Name: "image.png", URL: "https://attachments/image.png", Markdown: "[image.png](https://attachments/image.png)"},
+	}, attachments)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestAttachments(context.Background(), "", repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_GetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
@@ -845,3 +1860,14 @@ func createBadAzureReposClient(t *testing.T, response []byte) (VcsClient, func()
 		createAzureReposHandler)
 	return client, cleanUp
 }
+
+func TestAzureReposClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.Error(t, err)
+	err = client.UpdateCheckRun(ctx, owner, repo1, 1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.Error(t, err)
+}
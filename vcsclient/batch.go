@@ -0,0 +1,92 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The default maximal number of items processed concurrently by the operations on multiple items
+const defaultBatchConcurrency = 5
+
+// BatchError is returned by the operations on multiple items, such as DownloadFilesFromRepo and GetCommitsBySha, if any of the items failed
+type BatchError struct {
+	// The operation, such as "download files from the repository"
+	Operation string
+	// The errors returned while processing the items, by the items, such as the paths of the files
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	items := make([]string, 0, len(e.Errors))
+	for item := range e.Errors {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	itemErrors := make([]string, len(items))
+	for i, item := range items {
+		itemErrors[i] = fmt.Sprintf("%s: %s", item, e.Errors[item])
+	}
+	return fmt.Sprintf("failed to %s: %s", e.Operation, strings.Join(itemErrors, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	itemErrors := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		itemErrors = append(itemErrors, err)
+	}
+	return itemErrors
+}
+
+// newBatchError returns a *BatchError with the errors of the items, or nil if none of the items failed
+func newBatchError(operation string, itemErrors map[string]error) error {
+	if len(itemErrors) == 0 {
+		return nil
+	}
+	return &BatchError{Operation: operation, Errors: itemErrors}
+}
+
+// uniqueItems returns the items without their duplicates, in the order of their first occurrence
+func uniqueItems(items []string) []string {
+	unique := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// runConcurrently runs the action on each of the items, at most concurrency items at a time. A non-positive concurrency defaults to 5.
+// Once the context is done, the action isn't run on the items left, whose error is the context error.
+// Returns the results and the errors of the actions, in the order of the items.
+func runConcurrently[T, R any](ctx context.Context, items []T, concurrency int, action func(item T) (R, error)) ([]R, []error) {
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < min(concurrency, len(items)); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if errs[i] = ctx.Err(); errs[i] == nil {
+					results[i], errs[i] = action(items[i])
+				}
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results, errs
+}
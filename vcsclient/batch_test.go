@@ -0,0 +1,51 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	var concurrentActions, maxConcurrentActions int32
+	results, errs := runConcurrently(context.Background(), []int{1, 2, 3, 4, 5, 6}, 2, func(item int) (string, error) {
+		current := atomic.AddInt32(&concurrentActions, 1)
+		defer atomic.AddInt32(&concurrentActions, -1)
+		for {
+			maxSoFar := atomic.LoadInt32(&maxConcurrentActions)
+			if current <= maxSoFar || atomic.CompareAndSwapInt32(&maxConcurrentActions, maxSoFar, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if item == 3 {
+			return "", errors.New("failed")
+		}
+		return strconv.Itoa(item), nil
+	})
+	// The results and the errors are in the order of the items
+	assert.Equal(t, []string{"1", "2", "", "4", "5", "6"}, results)
+	assert.Equal(t, []error{nil, nil, errors.New("failed"), nil, nil, nil}, errs)
+	assert.LessOrEqual(t, maxConcurrentActions, int32(2))
+
+	// The action isn't run once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = runConcurrently(ctx, []string{"a", "b"}, 0, func(string) (string, error) {
+		assert.Fail(t, "the action was run after the context was done")
+		return "", nil
+	})
+	assert.Equal(t, []error{context.Canceled, context.Canceled}, errs)
+}
+
+func TestBatchError(t *testing.T) {
+	assert.NoError(t, newBatchError("get the commits", map[string]error{}))
+	err := newBatchError("get the commits", map[string]error{"b": context.Canceled, "a": errors.New("not found")})
+	assert.EqualError(t, err, "failed to get the commits: a: not found; b: context canceled")
+	assert.ErrorIs(t, err, context.Canceled)
+}
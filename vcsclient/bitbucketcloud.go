@@ -8,30 +8,45 @@ import (
 	"fmt"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/ktrysmt/go-bitbucket"
+	"io"
 	"net/http"
 	"net/url"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
+// The Bitbucket Cloud OAuth2 access token endpoint
+var bitbucketCloudOAuthTokenURL = "https://bitbucket.org/site/oauth2/access_token"
+
 // BitbucketCloudClient API version 2.0
 type BitbucketCloudClient struct {
 	vcsInfo VcsInfo
 	url     *url.URL
 	logger  vcsutils.Log
+	// Provides the access token of clients which aren't authenticated by a username and an app password
+	tokenSource oauth2.TokenSource
+	// Resolves the branch names passed to GetCommitStatuses, which requires a commit hash
+	branchHeads branchHeadResolver
 }
 
-// NewBitbucketCloudClient create a new BitbucketCloudClient
+// NewBitbucketCloudClient create a new BitbucketCloudClient.
+// The client is authenticated by one of the following:
+// 1. OAuth2 consumer and refresh token - if VcsInfo.OAuthClientID and VcsInfo.OAuthRefreshToken are set.
+// 2. OAuth2 client credentials - if VcsInfo.OAuthClientID is set.
+// 3. Workspace, project or repository access token - if VcsInfo.Token is set without VcsInfo.Username.
+// 4. Username and app password - if VcsInfo.Username and VcsInfo.Token are set.
 func NewBitbucketCloudClient(vcsInfo VcsInfo, logger vcsutils.Log) (*BitbucketCloudClient, error) {
 	bitbucketClient := &BitbucketCloudClient{
-		vcsInfo: vcsInfo,
-		logger:  logger,
+		vcsInfo:     vcsInfo,
+		logger:      logger,
+		tokenSource: newBitbucketCloudTokenSource(vcsInfo, logger),
 	}
 	if vcsInfo.APIEndpoint != "" {
 		url, err := url.Parse(vcsInfo.APIEndpoint)
@@ -43,23 +58,118 @@ func NewBitbucketCloudClient(vcsInfo VcsInfo, logger vcsutils.Log) (*BitbucketCl
 	return bitbucketClient, nil
 }
 
+// The token source is created once, so that the access token is reused until it expires
+func newBitbucketCloudTokenSource(vcsInfo VcsInfo, logger vcsutils.Log) oauth2.TokenSource {
+	// The HTTP client used to fetch the access tokens
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, newHTTPClient(vcsutils.BitbucketCloud, vcsInfo, logger))
+	switch {
+	case vcsInfo.OAuthClientID != "" && vcsInfo.OAuthRefreshToken != "":
+		config := oauth2.Config{
+			ClientID:     vcsInfo.OAuthClientID,
+			ClientSecret: vcsInfo.OAuthClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: bitbucketCloudOAuthTokenURL},
+		}
+		return config.TokenSource(ctx, &oauth2.Token{RefreshToken: vcsInfo.OAuthRefreshToken})
+	case vcsInfo.OAuthClientID != "":
+		config := clientcredentials.Config{
+			ClientID:     vcsInfo.OAuthClientID,
+			ClientSecret: vcsInfo.OAuthClientSecret,
+			TokenURL:     bitbucketCloudOAuthTokenURL,
+		}
+		return config.TokenSource(ctx)
+	case vcsInfo.Username == "" && vcsInfo.Token != "":
+		// Workspace, project and repository access tokens are sent as bearer tokens
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token})
+	}
+	return nil
+}
+
+// Raw returns a go-bitbucket client, to call the endpoints which aren't wrapped by the BitbucketCloudClient.
+// A new client is created for each call. The client reuses the authentication, including the OAuth2 access token of the BitbucketCloudClient,
+// and its TLS configuration, HTTP tracing and metrics. The requests of go-bitbucket don't carry a context, so the trace context isn't propagated.
+// The results aren't sorted.
+func (client *BitbucketCloudClient) Raw() *bitbucket.Client {
+	return client.buildBitbucketCloudClient(context.Background())
+}
+
 func (client *BitbucketCloudClient) buildBitbucketCloudClient(_ context.Context) *bitbucket.Client {
-	bitbucketClient := bitbucket.NewBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+	var bitbucketClient *bitbucket.Client
+	if client.tokenSource != nil {
+		// The requests are authenticated by the OAuth2 transport, which also refreshes the access token when it expires
+		bitbucketClient = bitbucket.NewBasicAuth("", "")
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, newHTTPClient(vcsutils.BitbucketCloud, client.vcsInfo, client.logger))
+		bitbucketClient.HttpClient = oauth2.NewClient(ctx, client.tokenSource)
+	} else {
+		bitbucketClient = bitbucket.NewBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+		bitbucketClient.HttpClient = newHTTPClient(vcsutils.BitbucketCloud, client.vcsInfo, client.logger)
+	}
 	if client.url != nil {
 		bitbucketClient.SetApiBaseURL(*client.url)
 	}
 	return bitbucketClient
 }
 
+// Sets the username and the app password on a request sent directly by the HTTP client of go-bitbucket.
+// The requests of clients authenticated by an access token are authenticated by the HTTP client itself.
+func (client *BitbucketCloudClient) setBasicAuth(req *http.Request) {
+	if client.tokenSource == nil && (client.vcsInfo.Username != "" || client.vcsInfo.Token != "") {
+		req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+	}
+}
+
 // TestConnection on Bitbucket cloud
 func (client *BitbucketCloudClient) TestConnection(ctx context.Context) error {
+	ctx = labelAPICalls(ctx, "TestConnection")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	_, err := bitbucketClient.User.Profile()
 	return err
 }
 
+// GetAuthenticatedUser on Bitbucket cloud
+func (client *BitbucketCloudClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	ctx = labelAPICalls(ctx, "GetAuthenticatedUser")
+	var user struct {
+		UUID        string `json:"uuid"`
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+	}
+	if err := client.sendRequest(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return UserInfo{}, err
+	}
+	userInfo := UserInfo{ID: user.UUID, Login: user.Username, DisplayName: user.DisplayName}
+	// Reading the emails requires the email scope, so the email is left empty if it can't be read
+	var emails struct {
+		Values []struct {
+			Email     string `json:"email"`
+			IsPrimary bool   `json:"is_primary"`
+		} `json:"values"`
+	}
+	if err := client.sendRequest(ctx, http.MethodGet, "/user/emails", nil, &emails); err != nil {
+		client.logger.Debug("couldn't read the emails of the authenticated user:", err.Error())
+		return userInfo, nil
+	}
+	for _, email := range emails.Values {
+		if email.IsPrimary {
+			userInfo.Email = email.Email
+		}
+	}
+	return userInfo, nil
+}
+
+// GetServerVersion on Bitbucket cloud, which has no version
+func (client *BitbucketCloudClient) GetServerVersion(_ context.Context) (string, error) {
+	return "", nil
+}
+
+// Capabilities on Bitbucket cloud
+func (client *BitbucketCloudClient) Capabilities(ctx context.Context) ([]Capability, error) {
+	ctx = labelAPICalls(ctx, "Capabilities")
+	return getServerCapabilities(ctx, vcsutils.BitbucketCloud, client.GetServerVersion)
+}
+
 // ListRepositories on Bitbucket cloud
 func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	ctx = labelAPICalls(ctx, "ListRepositories")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	results := make(map[string][]string)
 	workspaces, err := bitbucketClient.Workspaces.List()
@@ -75,11 +185,210 @@ func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[s
 			results[workspace.Slug] = append(results[workspace.Slug], repo.Slug)
 		}
 	}
-	return results, nil
+	return orderRepositories(client.vcsInfo, results), nil
+}
+
+// ListRepositoryListings on Bitbucket cloud, where the owners of the repositories are workspaces
+func (client *BitbucketCloudClient) ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryListings")
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	workspaces, err := bitbucketClient.Workspaces.List()
+	if err != nil {
+		return nil, err
+	}
+	var listings []RepositoryListing
+	for _, workspace := range workspaces.Workspaces {
+		repositoriesRes, err := bitbucketClient.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: workspace.Slug})
+		if err != nil {
+			return nil, err
+		}
+		for i := range repositoriesRes.Items {
+			listings = append(listings, mapBitbucketCloudRepositoryToListing(workspace.Slug, &repositoriesRes.Items[i]))
+		}
+	}
+	return orderRepositoryListings(client.vcsInfo, listings), nil
+}
+
+func mapBitbucketCloudRepositoryToListing(workspace string, repo *bitbucket.Repository) RepositoryListing {
+	return RepositoryListing{
+		Owner:         workspace,
+		OwnerType:     WorkspaceNamespace,
+		Name:          repo.Slug,
+		Visibility:    getBitbucketCloudRepositoryVisibility(repo),
+		DefaultBranch: repo.Mainbranch.Name,
+	}
+}
+
+// SearchRepositories on Bitbucket cloud, by the query of the repositories API on the full names of the repositories.
+// Without an owner, the repositories of all the workspaces of the user are searched.
+func (client *BitbucketCloudClient) SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "SearchRepositories")
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if options.Topic != "" {
+		return nil, errBitbucketSearchRepositoriesByTopicNotSupported
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	workspaces := []string{options.Owner}
+	if options.Owner == "" {
+		workspacesRes, err := bitbucketClient.Workspaces.List()
+		if err != nil {
+			return nil, err
+		}
+		workspaces = workspaces[:0]
+		for _, workspace := range workspacesRes.Workspaces {
+			workspaces = append(workspaces, workspace.Slug)
+		}
+	}
+	var listings []RepositoryListing
+	for _, workspace := range workspaces {
+		repositories, err := client.searchWorkspaceRepositories(ctx, workspace, options.Name)
+		if err != nil {
+			return nil, err
+		}
+		for i := range repositories {
+			listings = append(listings, mapBitbucketCloudRepositoryToListing(workspace, &repositories[i]))
+		}
+	}
+	return listings, nil
+}
+
+// searchWorkspaceRepositories returns the repositories of a workspace whose full name contains the name.
+// The query isn't escaped by the Bitbucket library, so the request is sent directly.
+func (client *BitbucketCloudClient) searchWorkspaceRepositories(ctx context.Context, workspace, name string) ([]bitbucket.Repository, error) {
+	query := url.Values{}
+	if name != "" {
+		query.Set("q", fmt.Sprintf("full_name ~ %q", name))
+	}
+	var repositories []bitbucket.Repository
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var repositoriesPage struct {
+			Values []bitbucket.Repository `json:"values"`
+			Next   string                 `json:"next"`
+		}
+		if err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/repositories/%s?%s", url.PathEscape(workspace), query.Encode()), nil, &repositoriesPage); err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repositoriesPage.Values...)
+		if repositoriesPage.Next == "" {
+			return repositories, nil
+		}
+	}
+}
+
+// SearchCode on Bitbucket cloud, by the code search API of the workspace of the owner, which must be enabled.
+func (client *BitbucketCloudClient) SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error) {
+	ctx = labelAPICalls(ctx, "SearchCode")
+	if err := options.validate(query); err != nil {
+		return nil, err
+	}
+	if options.Owner == "" {
+		return nil, errBitbucketCloudSearchCodeWithoutOwnerNotSupported
+	}
+	if options.Repository != "" {
+		query += " repo:" + options.Repository
+	}
+	searchQuery := url.Values{"search_query": {query}, "pagelen": {"100"}}
+	var results []CodeSearchResult
+	for page := 1; ; page++ {
+		searchQuery.Set("page", strconv.Itoa(page))
+		var searchPage bitbucketCloudCodeSearchPage
+		if err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%s/search/code?%s", url.PathEscape(options.Owner), searchQuery.Encode()), nil, &searchPage); err != nil {
+			return nil, err
+		}
+		for _, searchResult := range searchPage.Values {
+			results = append(results, searchResult.toCodeSearchResults(options.Owner)...)
+		}
+		if page*searchPage.PageLen >= searchPage.Size {
+			return results, nil
+		}
+	}
+}
+
+type bitbucketCloudCodeSearchPage struct {
+	Size    int                              `json:"size"`
+	PageLen int                              `json:"pagelen"`
+	Values  []bitbucketCloudCodeSearchResult `json:"values"`
+}
+
+type bitbucketCloudCodeSearchResult struct {
+	ContentMatches []struct {
+		Lines []struct {
+			Line     int `json:"line"`
+			Segments []struct {
+				Text string `json:"text"`
+			} `json:"segments"`
+		} `json:"lines"`
+	} `json:"content_matches"`
+	File struct {
+		Path   string `json:"path"`
+		Commit struct {
+			Repository struct {
+				Name string `json:"name"`
+			} `json:"repository"`
+		} `json:"commit"`
+	} `json:"file"`
+}
+
+// toCodeSearchResults returns a result per content match in the file, or a single result if only the path of the file matches
+func (searchResult bitbucketCloudCodeSearchResult) toCodeSearchResults(workspace string) []CodeSearchResult {
+	result := CodeSearchResult{Owner: workspace, Repository: searchResult.File.Commit.Repository.Name, Path: searchResult.File.Path}
+	if len(searchResult.ContentMatches) == 0 {
+		return []CodeSearchResult{result}
+	}
+	results := make([]CodeSearchResult, 0, len(searchResult.ContentMatches))
+	for _, contentMatch := range searchResult.ContentMatches {
+		var lines []string
+		for i, line := range contentMatch.Lines {
+			if i == 0 {
+				result.Line = line.Line
+			}
+			var text strings.Builder
+			for _, segment := range line.Segments {
+				text.WriteString(segment.Text)
+			}
+			lines = append(lines, text.String())
+		}
+		result.Fragment = strings.Join(lines, "\n")
+		results = append(results, result)
+	}
+	return results
+}
+
+// ListNamespaces on Bitbucket cloud returns the workspaces of the user
+func (client *BitbucketCloudClient) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	ctx = labelAPICalls(ctx, "ListNamespaces")
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	workspaces, err := bitbucketClient.Workspaces.List()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]NamespaceInfo, 0, len(workspaces.Workspaces))
+	for _, workspace := range workspaces.Workspaces {
+		namespaces = append(namespaces, NamespaceInfo{Name: workspace.Slug, DisplayName: workspace.Name, Kind: WorkspaceNamespace})
+	}
+	return orderNamespaces(client.vcsInfo, namespaces), nil
 }
 
 // ListBranches on Bitbucket cloud
 func (client *BitbucketCloudClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranches")
+	return client.ListBranchesWithOptions(ctx, owner, repository, ListBranchesOptions{})
+}
+
+// ListBranchesWithOptions on Bitbucket cloud.
+// The protected branches are the branches with branch restrictions matching their names, as in GetBranchInfo.
+func (client *BitbucketCloudClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListBranchesOptions) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranchesWithOptions")
+	var restrictedBranches *datastructures.Set[string]
+	if options.ProtectedOnly {
+		var err error
+		if restrictedBranches, err = client.listRestrictedBranches(ctx, owner, repository); err != nil {
+			return nil, err
+		}
+	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	branches, err := bitbucketClient.Repositories.Repository.ListBranches(&bitbucket.RepositoryBranchOptions{Owner: owner, RepoSlug: repository})
 	if err != nil {
@@ -88,13 +397,101 @@ func (client *BitbucketCloudClient) ListBranches(ctx context.Context, owner, rep
 
 	results := make([]string, 0, len(branches.Branches))
 	for _, branch := range branches.Branches {
+		if options.ProtectedOnly && !restrictedBranches.Exists(branch.Name) {
+			continue
+		}
 		results = append(results, branch.Name)
 	}
-	return results, nil
+	return orderNames(client.vcsInfo, results), nil
+}
+
+// listRestrictedBranches returns the patterns of the branch restrictions of a repository, which match the branches by their names
+func (client *BitbucketCloudClient) listRestrictedBranches(ctx context.Context, owner, repository string) (*datastructures.Set[string], error) {
+	restrictedBranches := datastructures.MakeSet[string]()
+	query := url.Values{"pagelen": {"100"}}
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var restrictions struct {
+			Values []struct {
+				Pattern string `json:"pattern"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		path := fmt.Sprintf("/repositories/%s/%s/branch-restrictions?%s", url.PathEscape(owner), url.PathEscape(repository), query.Encode())
+		if err := client.sendRequest(ctx, http.MethodGet, path, nil, &restrictions); err != nil {
+			return nil, err
+		}
+		for _, restriction := range restrictions.Values {
+			if restriction.Pattern != "" {
+				restrictedBranches.Add(restriction.Pattern)
+			}
+		}
+		if restrictions.Next == "" {
+			return restrictedBranches, nil
+		}
+	}
+}
+
+// GetBranchInfo on Bitbucket cloud.
+// The branch is protected if it has branch restrictions, such as restricted pushes or required approvals.
+func (client *BitbucketCloudClient) GetBranchInfo(ctx context.Context, owner, repository, branch string) (RepositoryBranchInfo, error) {
+	ctx = labelAPICalls(ctx, "GetBranchInfo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	var branchDetails struct {
+		Name   string `json:"name"`
+		Target struct {
+			Hash string `json:"hash"`
+		} `json:"target"`
+	}
+	repositoryPath := fmt.Sprintf("/repositories/%s/%s", url.PathEscape(owner), url.PathEscape(repository))
+	if err := client.sendRequest(ctx, http.MethodGet, repositoryPath+"/refs/branches/"+url.PathEscape(branch), nil, &branchDetails); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repository)
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	var restrictions struct {
+		Size int `json:"size"`
+	}
+	if err := client.sendRequest(ctx, http.MethodGet, repositoryPath+"/branch-restrictions?pattern="+url.QueryEscape(branch), nil, &restrictions); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	return RepositoryBranchInfo{
+		Name:           branchDetails.Name,
+		HeadCommitHash: branchDetails.Target.Hash,
+		Protected:      restrictions.Size > 0,
+		Default:        defaultBranch == branchDetails.Name,
+	}, nil
+}
+
+// GetDefaultBranch on Bitbucket cloud, where the default branch is the main branch of the repository
+func (client *BitbucketCloudClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	ctx = labelAPICalls(ctx, "GetDefaultBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	var repositoryDetails struct {
+		Mainbranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/repositories/%s/%s", url.PathEscape(owner), url.PathEscape(repository)), nil, &repositoryDetails); err != nil {
+		return "", err
+	}
+	return repositoryDetails.Mainbranch.Name, nil
+}
+
+// SquashBranchIntoSingleCommit on Bitbucket cloud
+func (client *BitbucketCloudClient) SquashBranchIntoSingleCommit(_ context.Context, _, _, _, _, _ string) (string, error) {
+	return "", ErrBranchSquashNotSupported
 }
 
 // AddSshKeyToRepository on Bitbucket cloud, the deploy-key is always read-only.
 func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, _ Permission) (err error) {
+	ctx = labelAPICalls(ctx, "AddSshKeyToRepository")
 	err = validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -124,7 +521,7 @@ func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, o
 		return
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
+	client.setBasicAuth(req)
 
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	response, err := bitbucketClient.HttpClient.Do(req)
@@ -141,6 +538,11 @@ func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, o
 	return
 }
 
+// CreateDeployToken on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error) {
+	return DeployTokenInfo{}, errBitbucketCloudDeployTokensNotSupported
+}
+
 type bitbucketCloudAddSSHKeyRequest struct {
 	Key   string `json:"key"`
 	Label string `json:"label"`
@@ -149,6 +551,7 @@ type bitbucketCloudAddSSHKeyRequest struct {
 // CreateWebhook on Bitbucket cloud
 func (client *BitbucketCloudClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
 	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateWebhook")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	token := vcsutils.CreateToken()
 	options := &bitbucket.WebhooksOptions{
@@ -172,6 +575,7 @@ func (client *BitbucketCloudClient) CreateWebhook(ctx context.Context, owner, re
 // UpdateWebhook on Bitbucket cloud
 func (client *BitbucketCloudClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateWebhook")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	options := &bitbucket.WebhooksOptions{
 		Active:   true,
@@ -187,6 +591,7 @@ func (client *BitbucketCloudClient) UpdateWebhook(ctx context.Context, owner, re
 
 // DeleteWebhook on Bitbucket cloud
 func (client *BitbucketCloudClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteWebhook")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	options := &bitbucket.WebhooksOptions{
 		Uuid:     webhookID,
@@ -197,27 +602,61 @@ func (client *BitbucketCloudClient) DeleteWebhook(ctx context.Context, owner, re
 	return err
 }
 
+// CreateGroupWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateGroupWebhook(_ context.Context, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", ErrGroupWebhooksNotSupported
+}
+
+// UpdateGroupWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateGroupWebhook(_ context.Context, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return ErrGroupWebhooksNotSupported
+}
+
+// DeleteGroupWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteGroupWebhook(_ context.Context, _, _ string) error {
+	return ErrGroupWebhooksNotSupported
+}
+
 // SetCommitStatus on Bitbucket cloud
 func (client *BitbucketCloudClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository,
 	ref, title, description, detailsURL string) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatus")
+	return client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, CommitStatusOptions{})
+}
+
+// SetCommitStatusWithOptions on Bitbucket cloud, where the build key identifies the status
+func (client *BitbucketCloudClient) SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, owner, repository,
+	ref, title, description, detailsURL string, options CommitStatusOptions) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatusWithOptions")
+	description, err := encodeCommitStatusDescription(description, options.Metadata)
+	if err != nil {
+		return err
+	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	commitOptions := &bitbucket.CommitsOptions{
 		Owner:    owner,
 		RepoSlug: repository,
 		Revision: ref,
 	}
+	key, name := getBitbucketBuildKeyAndName(title, options)
 	commitStatusOptions := &bitbucket.CommitStatusOptions{
 		State:       getBitbucketCommitState(commitStatus),
-		Key:         title,
+		Key:         key,
+		Name:        name,
 		Description: description,
 		Url:         detailsURL,
 	}
-	_, err := bitbucketClient.Repositories.Commits.CreateCommitStatus(commitOptions, commitStatusOptions)
+	_, err = bitbucketClient.Repositories.Commits.CreateCommitStatus(commitOptions, commitStatusOptions)
 	return err
 }
 
 // GetCommitStatuses on Bitbucket cloud
 func (client *BitbucketCloudClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetCommitStatuses")
+	ref, err = client.branchHeads.resolve(ctx, owner, repository, ref, client.GetLatestCommit)
+	if err != nil {
+		return nil, err
+	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	commitOptions := &bitbucket.CommitsOptions{
 		Owner:    owner,
@@ -235,9 +674,29 @@ func (client *BitbucketCloudClient) GetCommitStatuses(ctx context.Context, owner
 	return results, err
 }
 
+// CreateCheckRun on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errBitbucketCheckRunsNotSupported
+}
+
+// UpdateCheckRun on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errBitbucketCheckRunsNotSupported
+}
+
 // DownloadRepository on Bitbucket cloud
-func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owner, repository, branch,
+// The ref may be a branch, a tag or a commit hash. An empty ref downloads the main branch of the repository.
+func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owner, repository, ref,
 	localPath string) error {
+	ctx = labelAPICalls(ctx, "DownloadRepository")
+	return client.DownloadRepositoryWithOptions(ctx, owner, repository, ref, localPath, DownloadRepositoryOptions{})
+}
+
+// DownloadRepositoryWithOptions on Bitbucket cloud
+// The ref may be a branch, a tag or a commit hash. An empty ref downloads the main branch of the repository.
+func (client *BitbucketCloudClient) DownloadRepositoryWithOptions(ctx context.Context, owner, repository, ref,
+	localPath string, options DownloadRepositoryOptions) error {
+	ctx = labelAPICalls(ctx, "DownloadRepositoryWithOptions")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	client.logger.Debug("getting Bitbucket Cloud archive link to download")
 	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
@@ -248,7 +707,7 @@ func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owne
 		return err
 	}
 
-	downloadLink, err := getDownloadLink(repo, branch)
+	downloadLink, err := getDownloadLink(repo, ref)
 	if err != nil {
 		return err
 	}
@@ -257,9 +716,7 @@ func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owne
 	if err != nil {
 		return err
 	}
-	if len(client.vcsInfo.Username) > 0 || len(client.vcsInfo.Token) > 0 {
-		getRequest.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
-	}
+	client.setBasicAuth(getRequest)
 
 	response, err := bitbucketClient.HttpClient.Do(getRequest)
 	if err != nil {
@@ -269,11 +726,15 @@ func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owne
 		return err
 	}
 	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, response.Body, true)
+	stats, err := vcsutils.UntarWithStats(localPath, response.Body, true, options.Filter, options.ExtractionProgress)
 	if err != nil {
 		return err
 	}
+	reportRepositoryExtraction(client.logger, options, stats)
 	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	if client.vcsInfo.SkipDotGitCreation {
+		return nil
+	}
 	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
@@ -293,6 +754,7 @@ func (client *BitbucketCloudClient) GetPullRequestDetailsSizeLimit() int {
 // CreatePullRequest on Bitbucket cloud
 func (client *BitbucketCloudClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch,
 	targetBranch, title, description string) error {
+	ctx = labelAPICalls(ctx, "CreatePullRequest")
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	client.logger.Debug(vcsutils.CreatingPullRequest, title)
 	options := &bitbucket.PullRequestsOptions{
@@ -309,7 +771,17 @@ func (client *BitbucketCloudClient) CreatePullRequest(ctx context.Context, owner
 }
 
 // UpdatePullRequest on Bitbucket cloud
+// The labels, which are kept in the pull request description, are kept in the new body.
 func (client *BitbucketCloudClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequest")
+	pullRequestDetails, err := client.getPullRequestDetails(ctx, owner, repository, prId)
+	if err != nil {
+		return err
+	}
+	description, err := keepBitbucketPullRequestLabels(pullRequestDetails.Body, body)
+	if err != nil {
+		return err
+	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	client.logger.Debug(vcsutils.CreatingPullRequest, title)
 	options := &bitbucket.PullRequestsOptions{
@@ -317,26 +789,104 @@ func (client *BitbucketCloudClient) UpdatePullRequest(ctx context.Context, owner
 		SourceRepository:  owner + "/" + repository,
 		RepoSlug:          repository,
 		Title:             title,
-		Description:       body,
+		Description:       description,
 		DestinationBranch: targetBranchName,
 		ID:                strconv.Itoa(prId),
 		States:            []string{*vcsutils.MapPullRequestState(&state)},
 	}
-	_, err := bitbucketClient.Repositories.PullRequests.Update(options)
+	_, err = bitbucketClient.Repositories.PullRequests.Update(options)
 	return err
 }
 
+// ClosePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ClosePullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	pullRequestRaw, err := bitbucketClient.Repositories.PullRequests.Get(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       strconv.Itoa(pullRequestID),
+	})
+	if err != nil {
+		return err
+	}
+	pullRequest, err := vcsutils.RemapFields[pullRequestsDetails](pullRequestRaw, "json")
+	if err != nil {
+		return err
+	}
+	currentState := vcsutils.Open
+	if pullRequest.State == "DECLINED" || pullRequest.State == "SUPERSEDED" {
+		currentState = vcsutils.Closed
+	}
+	if err = validatePullRequestStateTransition(pullRequestID, pullRequest.State == "MERGED", currentState, vcsutils.Closed); err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, pullRequestID)
+	return client.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/decline", owner, repository, pullRequestID), nil, nil)
+}
+
+// ReopenPullRequest on Bitbucket cloud.
+// Bitbucket cloud doesn't allow reopening declined pull requests.
+func (client *BitbucketCloudClient) ReopenPullRequest(_ context.Context, _, _ string, _ int) error {
+	return errBitbucketCloudReopenPullRequestNotSupported
+}
+
+// MergePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error {
+	ctx = labelAPICalls(ctx, "MergePullRequest")
+	if options.BypassPolicies {
+		return errBitbucketBypassPoliciesNotSupported
+	}
+	mergeRequest := mergeParameters{
+		Type:              "pullrequest_merge_parameters",
+		Message:           options.commitMessage(),
+		CloseSourceBranch: options.DeleteSourceBranch,
+		MergeStrategy:     bitbucketCloudMergeStrategies[options.Method],
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, pullRequestID)
+	return client.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", owner, repository, pullRequestID), mergeRequest, nil)
+}
+
+// UpdatePullRequestBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdatePullRequestBranch(_ context.Context, _, _ string, _ int) error {
+	return ErrPullRequestBranchUpdateNotSupported
+}
+
+// The Bitbucket cloud merge strategies, by merge method
+var bitbucketCloudMergeStrategies = map[MergeMethod]string{
+	MergeCommit: "merge_commit",
+	SquashMerge: "squash",
+	RebaseMerge: "rebase_fast_forward",
+}
+
+// EnablePullRequestAutoMerge on Bitbucket cloud
+func (client *BitbucketCloudClient) EnablePullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ MergeMethod) error {
+	return errBitbucketCloudAutoMergeNotSupported
+}
+
 // ListOpenPullRequestsWithBody on Bitbucket cloud
 func (client *BitbucketCloudClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) (res []PullRequestInfo, err error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithBody")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{WithBody: true})
 }
 
 // ListOpenPullRequests on Bitbucket cloud
 func (client *BitbucketCloudClient) ListOpenPullRequests(ctx context.Context, owner, repository string) (res []PullRequestInfo, err error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequests")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{})
+}
+
+// ListOpenPullRequestsWithQueryOptions on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithQueryOptions")
+	return client.getOpenPullRequests(ctx, owner, repository, options)
 }
 
-func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) (res []PullRequestInfo, err error) {
+func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, owner, repository string, queryOptions PullRequestsQueryOptions) (res []PullRequestInfo, err error) {
 	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return nil, err
@@ -347,6 +897,7 @@ func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, own
 		Owner:    owner,
 		RepoSlug: repository,
 		States:   []string{"OPEN"},
+		Query:    getBitbucketCloudPullRequestsQuery(queryOptions.SourceBranch, queryOptions.TargetBranch, ""),
 	}
 	pullRequests, err := bitbucketClient.Repositories.PullRequests.Gets(options)
 	if err != nil {
@@ -356,10 +907,73 @@ func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, own
 	if err != nil {
 		return
 	}
-	return mapBitbucketCloudPullRequestToPullRequestInfo(&parsedPullRequests, withBody), nil
+	pullRequestsInfo := orderPullRequests(client.vcsInfo, mapBitbucketCloudPullRequestToPullRequestInfo(&parsedPullRequests, queryOptions.WithBody))
+	return paginate(pullRequestsInfo, queryOptions.ListOptions), nil
+}
+
+// ListPullRequests on Bitbucket cloud. The pull requests API is requested directly, since go-bitbucket can't filter by several states.
+func (client *BitbucketCloudClient) ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequests")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	query := url.Values{"pagelen": {"50"}}
+	switch options.State {
+	case PullRequestsClosed:
+		query["state"] = []string{"DECLINED"}
+	case PullRequestsMerged:
+		query["state"] = []string{"MERGED"}
+	case PullRequestsAll:
+		query["state"] = []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+	default:
+		query["state"] = []string{"OPEN"}
+	}
+	if filters := getBitbucketCloudPullRequestsQuery(options.SourceBranch, options.TargetBranch, options.Author); filters != "" {
+		query.Set("q", filters)
+	}
+	sortField := "created_on"
+	if options.Sort == SortByUpdated {
+		sortField = "updated_on"
+	}
+	if !options.Ascending {
+		sortField = "-" + sortField
+	}
+	query.Set("sort", sortField)
+	var parsedPullRequests pullRequestsResponse
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var pullRequestsPage struct {
+			pullRequestsResponse
+			Next string `json:"next"`
+		}
+		if err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/repositories/%s/%s/pullrequests?%s", url.PathEscape(owner), url.PathEscape(repository), query.Encode()), nil, &pullRequestsPage); err != nil {
+			return nil, err
+		}
+		parsedPullRequests.Values = append(parsedPullRequests.Values, pullRequestsPage.Values...)
+		if pullRequestsPage.Next == "" {
+			break
+		}
+	}
+	return paginate(mapBitbucketCloudPullRequestToPullRequestInfo(&parsedPullRequests, options.WithBody), options.ListOptions), nil
+}
+
+// getBitbucketCloudPullRequestsQuery returns the query language filter of the pull requests branches and author
+func getBitbucketCloudPullRequestsQuery(sourceBranch, targetBranch, author string) string {
+	var filters []string
+	if sourceBranch != "" {
+		filters = append(filters, fmt.Sprintf("source.branch.name = %q", sourceBranch))
+	}
+	if targetBranch != "" {
+		filters = append(filters, fmt.Sprintf("destination.branch.name = %q", targetBranch))
+	}
+	if author != "" {
+		filters = append(filters, fmt.Sprintf("author.nickname = %q", author))
+	}
+	return strings.Join(filters, " AND ")
 }
 
 func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestByID")
 	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return
@@ -385,7 +999,8 @@ func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owne
 	targetOwner, targetRepository := splitBitbucketCloudRepoName(pullRequestDetails.Target.Repository.Name)
 
 	pullRequestInfo = PullRequestInfo{
-		ID: pullRequestDetails.ID,
+		ID:      pullRequestDetails.ID,
+		HTMLURL: pullRequestDetails.Links.HTML.Href,
 		Source: BranchInfo{
 			Name:       pullRequestDetails.Source.Name.Str,
 			Repository: sourceRepository,
@@ -396,12 +1011,147 @@ func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owne
 			Repository: targetRepository,
 			Owner:      targetOwner,
 		},
+		HeadCommitHash: pullRequestDetails.Source.Commit.Hash,
+		Author:         pullRequestDetails.Author.Nickname,
+		UpdatedAt:      pullRequestDetails.UpdatedOn.UTC(),
+		Participants:   mapBitbucketCloudPullRequestParticipants(pullRequestDetails),
 	}
 	return
 }
 
+// mapBitbucketCloudPullRequestParticipants returns the participants of a pull request.
+// Reviewers missing from the participants are added without an approval.
+func mapBitbucketCloudPullRequestParticipants(pullRequestDetails pullRequestsDetails) []PullRequestParticipant {
+	var participants []PullRequestParticipant
+	participantNames := datastructures.MakeSet[string]()
+	for _, participant := range pullRequestDetails.Participants {
+		role := ParticipantRole
+		if participant.Role == "REVIEWER" {
+			role = ReviewerRole
+		}
+		participants = append(participants, PullRequestParticipant{
+			Username:         participant.User.Nickname,
+			Role:             role,
+			Approved:         participant.Approved,
+			ChangesRequested: participant.State == "changes_requested",
+		})
+		participantNames.Add(participant.User.Nickname)
+	}
+	for _, reviewer := range pullRequestDetails.Reviewers {
+		if !participantNames.Exists(reviewer.Nickname) {
+			participants = append(participants, PullRequestParticipant{Username: reviewer.Nickname, Role: ReviewerRole})
+		}
+	}
+	return participants
+}
+
+// CreateIssue on Bitbucket cloud, in the issue tracker of the repository
+func (client *BitbucketCloudClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "CreateIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title}); err != nil {
+		return IssueInfo{}, err
+	}
+	issueRequest := map[string]interface{}{"title": title, "content": map[string]string{"raw": body}}
+	var issue bitbucketCloudIssue
+	if err := client.sendRequest(ctx, http.MethodPost, getBitbucketCloudIssuesPath(owner, repository), issueRequest, &issue); err != nil {
+		return IssueInfo{}, err
+	}
+	return issue.toIssueInfo(), nil
+}
+
+// The states of the open issues on Bitbucket cloud. The other states, such as resolved and wontfix, are closed states.
+var bitbucketCloudOpenIssueStates = []string{"new", "open", "on hold"}
+
+// ListIssues on Bitbucket cloud
+func (client *BitbucketCloudClient) ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "ListIssues")
+	operator, conjunction := "=", " OR "
+	if state == IssueClosed {
+		operator, conjunction = "!=", " AND "
+	}
+	conditions := make([]string, 0, len(bitbucketCloudOpenIssueStates))
+	for _, openState := range bitbucketCloudOpenIssueStates {
+		conditions = append(conditions, fmt.Sprintf("state %s %q", operator, openState))
+	}
+	query := url.Values{"q": {strings.Join(conditions, conjunction)}, "pagelen": {"100"}}
+	var issues []IssueInfo
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var issuesPage struct {
+			Values []bitbucketCloudIssue `json:"values"`
+			Next   string                `json:"next"`
+		}
+		if err := client.sendRequest(ctx, http.MethodGet, getBitbucketCloudIssuesPath(owner, repository)+"?"+query.Encode(), nil, &issuesPage); err != nil {
+			return nil, err
+		}
+		for _, issue := range issuesPage.Values {
+			issues = append(issues, issue.toIssueInfo())
+		}
+		if issuesPage.Next == "" {
+			return orderIssues(client.vcsInfo, issues), nil
+		}
+	}
+}
+
+// CommentOnIssue on Bitbucket cloud
+func (client *BitbucketCloudClient) CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CommentOnIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	comment := map[string]interface{}{"content": map[string]string{"raw": content}}
+	return client.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/comments", getBitbucketCloudIssuesPath(owner, repository), issueID), comment, nil)
+}
+
+// CloseIssue on Bitbucket cloud, by setting the issue state to closed
+func (client *BitbucketCloudClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CloseIssue")
+	return client.sendRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", getBitbucketCloudIssuesPath(owner, repository), issueID), map[string]string{"state": "closed"}, nil)
+}
+
+func getBitbucketCloudIssuesPath(owner, repository string) string {
+	return fmt.Sprintf("/repositories/%s/%s/issues", url.PathEscape(owner), url.PathEscape(repository))
+}
+
+type bitbucketCloudIssue struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Reporter struct {
+		Nickname string `json:"nickname"`
+	} `json:"reporter"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+func (issue bitbucketCloudIssue) toIssueInfo() IssueInfo {
+	state := IssueClosed
+	for _, openState := range bitbucketCloudOpenIssueStates {
+		if issue.State == openState {
+			state = IssueOpen
+		}
+	}
+	return IssueInfo{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Body:      issue.Content.Raw,
+		State:     state,
+		URL:       issue.Links.HTML.Href,
+		Author:    issue.Reporter.Nickname,
+		CreatedAt: issue.CreatedOn.UTC(),
+	}
+}
+
 // AddPullRequestComment on Bitbucket cloud
 func (client *BitbucketCloudClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestComment")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
 	if err != nil {
 		return err
@@ -418,8 +1168,41 @@ func (client *BitbucketCloudClient) AddPullRequestComment(ctx context.Context, o
 }
 
 // AddPullRequestReviewComments on Bitbucket cloud
-func (client *BitbucketCloudClient) AddPullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...PullRequestComment) error {
-	return errBitbucketAddPullRequestReviewCommentsNotSupported
+func (client *BitbucketCloudClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestReviewComments")
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	for _, comment := range comments {
+		if err := client.addPullRequestReviewComment(ctx, owner, repository, pullRequestID, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *BitbucketCloudClient) addPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment PullRequestComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": comment.Content})
+	if err != nil {
+		return err
+	}
+	reviewComment := reviewCommentDetails{Content: commentContent{Raw: comment.Content}}
+	// Bitbucket Cloud expects a path relative to the repository root
+	if filePath := strings.TrimPrefix(comment.NewFilePath, "/"); filePath != "" {
+		reviewComment.Inline = &inlineCommentDetails{Path: filePath, To: comment.NewStartLine}
+	}
+	var createdComment commentDetails
+	commentsPath := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", owner, repository, pullRequestID)
+	if err = client.sendRequest(ctx, http.MethodPost, commentsPath, reviewComment, &createdComment); err != nil {
+		return err
+	}
+	if !comment.Blocking {
+		return nil
+	}
+	// A task attached to the comment has to be resolved before merging, if the repository requires resolving all the tasks
+	task := taskDetails{Content: commentContent{Raw: comment.Content}, Comment: &taskCommentDetails{ID: createdComment.ID}}
+	tasksPath := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/tasks", owner, repository, pullRequestID)
+	return client.sendRequest(ctx, http.MethodPost, tasksPath, task, nil)
 }
 
 // ListPullRequestReviewComments on Bitbucket cloud
@@ -429,6 +1212,7 @@ func (client *BitbucketCloudClient) ListPullRequestReviewComments(_ context.Cont
 
 // ListPullRequestComments on Bitbucket cloud
 func (client *BitbucketCloudClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) (res []CommentInfo, err error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestComments")
 	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return nil, err
@@ -447,7 +1231,7 @@ func (client *BitbucketCloudClient) ListPullRequestComments(ctx context.Context,
 	if err != nil {
 		return
 	}
-	return mapBitbucketCloudCommentToCommentInfo(&parsedComments), nil
+	return orderComments(client.vcsInfo, mapBitbucketCloudCommentToCommentInfo(&parsedComments)), nil
 }
 
 // DeletePullRequestReviewComments on Bitbucket cloud
@@ -460,8 +1244,69 @@ func (client *BitbucketCloudClient) DeletePullRequestComment(_ context.Context,
 	return errBitbucketDeletePullRequestComment
 }
 
+// UpdatePullRequestComment on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdatePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.PullRequestCommentOptions{
+		Owner:         owner,
+		RepoSlug:      repository,
+		PullRequestID: fmt.Sprint(pullRequestID),
+		CommentId:     fmt.Sprint(commentID),
+		Content:       content,
+	}
+	_, err = bitbucketClient.Repositories.PullRequests.UpdateComment(options)
+	return err
+}
+
+// UpdatePullRequestReviewComment on Bitbucket cloud
+// The inline comments are pull request comments, which are updated by their ID.
+func (client *BitbucketCloudClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestReviewComment")
+	return client.UpdatePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID), content)
+}
+
+// ReplyToPullRequestReviewComment on Bitbucket cloud
+// The thread ID is the ID of the parent comment, as returned from ListPullRequestComments.
+func (client *BitbucketCloudClient) ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error {
+	ctx = labelAPICalls(ctx, "ReplyToPullRequestReviewComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "threadID": threadID, "content": content})
+	if err != nil {
+		return err
+	}
+	parentID, err := strconv.Atoi(threadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", threadID, err)
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.PullRequestCommentOptions{
+		Owner:         owner,
+		RepoSlug:      repository,
+		PullRequestID: fmt.Sprint(pullRequestID),
+		Content:       content,
+		Parent:        &parentID,
+	}
+	_, err = bitbucketClient.Repositories.PullRequests.AddComment(options)
+	return err
+}
+
+// UploadPullRequestAttachment on Bitbucket cloud
+func (client *BitbucketCloudClient) UploadPullRequestAttachment(_ context.Context, _, _ string, _ int, _ string, _ []byte) (PullRequestAttachmentInfo, error) {
+	return PullRequestAttachmentInfo{}, errBitbucketPullRequestAttachmentsNotSupported
+}
+
+// ListPullRequestAttachments on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestAttachments(_ context.Context, _, _ string, _ int) ([]PullRequestAttachmentInfo, error) {
+	return nil, errBitbucketPullRequestAttachmentsNotSupported
+}
+
 // GetLatestCommit on Bitbucket cloud
 func (client *BitbucketCloudClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLatestCommit")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -497,12 +1342,56 @@ func (client *BitbucketCloudClient) GetCommits(_ context.Context, _, _, _ string
 	return nil, errBitbucketGetCommitsNotSupported
 }
 
+// GetCommitsWithQueryOptions on Bitbucket cloud, from the newest commit of the repository.
+// The commits are filtered by the date query of the API. A page is fetched if the options specify it, and all the pages otherwise.
 func (client *BitbucketCloudClient) GetCommitsWithQueryOptions(ctx context.Context, owner, repository string, listOptions GitCommitsQueryOptions) ([]CommitInfo, error) {
-	return nil, errBitbucketGetCommitsWithOptionsNotSupported
+	ctx = labelAPICalls(ctx, "GetCommitsWithQueryOptions")
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if !listOptions.Since.IsZero() {
+		query.Set("q", "date >= "+listOptions.Since.UTC().Format(time.RFC3339))
+	}
+	if listOptions.PerPage > 0 {
+		query.Set("pagelen", strconv.Itoa(listOptions.PerPage))
+	}
+	var commits []CommitInfo
+	for page := max(listOptions.Page, 1); ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var commitsPage struct {
+			commitResponse
+			Next string `json:"next"`
+		}
+		if err = client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/repositories/%s/%s/commits?%s", url.PathEscape(owner), url.PathEscape(repository), query.Encode()), nil, &commitsPage); err != nil {
+			return nil, err
+		}
+		for _, commit := range commitsPage.Values {
+			commits = append(commits, mapBitbucketCloudCommitToCommitInfo(commit))
+		}
+		if listOptions.Page > 0 || commitsPage.Next == "" {
+			return orderCommits(client.vcsInfo, commits), nil
+		}
+	}
+}
+
+// ListCommitsBetween on Bitbucket cloud
+func (client *BitbucketCloudClient) ListCommitsBetween(_ context.Context, _, _, _, _ string) ([]string, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
+}
+
+// ListMergedPullRequestsOfCommit on Bitbucket cloud
+func (client *BitbucketCloudClient) ListMergedPullRequestsOfCommit(_ context.Context, _, _, _ string) ([]PullRequestInfo, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
 }
 
 // GetRepositoryInfo on Bitbucket cloud
 func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryInfo")
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
 		return RepositoryInfo{}, err
 	}
@@ -538,8 +1427,98 @@ func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner
 	return RepositoryInfo{RepositoryVisibility: getBitbucketCloudRepositoryVisibility(repo), CloneInfo: info}, nil
 }
 
+// ArchiveRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) ArchiveRepository(ctx context.Context, owner, repository string) error {
+	return errBitbucketCloudArchiveRepositoryNotSupported
+}
+
+// UnarchiveRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) UnarchiveRepository(ctx context.Context, owner, repository string) error {
+	return errBitbucketCloudArchiveRepositoryNotSupported
+}
+
+// RenameRepository on Bitbucket cloud. The slug of the repository is derived from the new name.
+func (client *BitbucketCloudClient) RenameRepository(ctx context.Context, owner, repository, newName string) error {
+	ctx = labelAPICalls(ctx, "RenameRepository")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "newName": newName})
+	if err != nil {
+		return err
+	}
+	repositoryChanges := struct {
+		Name string `json:"name"`
+	}{Name: newName}
+	return client.sendRequest(ctx, http.MethodPut, fmt.Sprintf("/repositories/%s/%s", owner, repository), repositoryChanges, nil)
+}
+
+// GetRepositoryTopics on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryTopics(_ context.Context, _, _ string) ([]string, error) {
+	return nil, ErrRepositoryTopicsNotSupported
+}
+
+// SetRepositoryTopics on Bitbucket cloud
+func (client *BitbucketCloudClient) SetRepositoryTopics(_ context.Context, _, _ string, _ []string) error {
+	return ErrRepositoryTopicsNotSupported
+}
+
+// GetUserPermissionOnRepo on Bitbucket cloud. The username may also be the UUID of the user.
+// Reading the permissions requires the admin permission on the workspace.
+func (client *BitbucketCloudClient) GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	ctx = labelAPICalls(ctx, "GetUserPermissionOnRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
+	}
+	userField := "nickname"
+	if strings.HasPrefix(username, "{") {
+		userField = "uuid"
+	}
+	query := url.QueryEscape(fmt.Sprintf("user.%s=%q", userField, username))
+	var permissions struct {
+		Values []struct {
+			Permission string `json:"permission"`
+		} `json:"values"`
+	}
+	path := fmt.Sprintf("/workspaces/%s/permissions/repositories/%s?q=%s", owner, repository, query)
+	if err := client.sendRequest(ctx, http.MethodGet, path, nil, &permissions); err != nil {
+		return NoPermission, err
+	}
+	permission := NoPermission
+	for _, userPermission := range permissions.Values {
+		permission = max(permission, mapBitbucketCloudPermission(userPermission.Permission))
+	}
+	return permission, nil
+}
+
+// ListRepositoryCollaborators on Bitbucket cloud
+func (client *BitbucketCloudClient) ListRepositoryCollaborators(_ context.Context, _, _ string, _ CollaboratorAffiliation) ([]CollaboratorInfo, error) {
+	return nil, ErrCollaboratorsNotSupported
+}
+
+// GetRepositoryMirrors on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryMirrors(_ context.Context, _, _ string) ([]RepositoryMirrorInfo, error) {
+	return nil, errBitbucketRepositoryMirrorsNotSupported
+}
+
+// SetRepositoryMirror on Bitbucket cloud
+func (client *BitbucketCloudClient) SetRepositoryMirror(_ context.Context, _, _ string, _ RepositoryMirrorInfo) error {
+	return errBitbucketRepositoryMirrorsNotSupported
+}
+
+func mapBitbucketCloudPermission(permission string) RepositoryPermission {
+	switch permission {
+	case "admin":
+		return AdminPermission
+	case "write":
+		return WritePermission
+	case "read":
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
 // GetCommitBySha on Bitbucket cloud
 func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitBySha")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -566,24 +1545,177 @@ func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, r
 	return mapBitbucketCloudCommitToCommitInfo(parsedCommit), nil
 }
 
+// GetCommitDiff on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommitDiff(ctx context.Context, owner, repository, sha string) (CommitDiffInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitDiff")
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+
+	// A single commit spec is compared to the commit's first parent
+	var diff []byte
+	if err = client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("/repositories/%s/%s/diff/%s", owner, repository, sha), nil, &diff); err != nil {
+		return CommitDiffInfo{}, err
+	}
+	return CommitDiffInfo{Diff: string(diff), Files: parseUnifiedDiffFiles(string(diff))}, nil
+}
+
 // CreateLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return errLabelsNotSupported
+// Bitbucket cloud has no repository labels. The labels are kept in the pull request description only, so there is nothing to create.
+func (client *BitbucketCloudClient) CreateLabel(_ context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "label name": labelInfo.Name})
 }
 
 // GetLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, errLabelsNotSupported
+// Bitbucket cloud has no repository labels. The labels are kept in the pull request description only, so every label is considered to exist.
+func (client *BitbucketCloudClient) GetLabel(_ context.Context, owner, repository, name string) (*LabelInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return nil, err
+	}
+	return &LabelInfo{Name: name}, nil
 }
 
 // ListPullRequestLabels on Bitbucket cloud
+// Bitbucket cloud has no pull request labels, therefore the labels are read from the pull request description.
 func (client *BitbucketCloudClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, errLabelsNotSupported
+	ctx = labelAPICalls(ctx, "ListPullRequestLabels")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	pullRequestDetails, err := client.getPullRequestDetails(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := getBitbucketPullRequestLabels(pullRequestDetails.Body)
+	return orderNames(client.vcsInfo, labels), err
+}
+
+// LabelPullRequest on Bitbucket cloud
+// Bitbucket cloud has no pull request labels, therefore the label is added to the pull request description.
+func (client *BitbucketCloudClient) LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "LabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	pullRequestDetails, err := client.getPullRequestDetails(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	description, added, err := addBitbucketPullRequestLabel(pullRequestDetails.Body, name)
+	if err != nil || !added {
+		return err
+	}
+	return client.updatePullRequestDescription(ctx, owner, repository, pullRequestID, pullRequestDetails.Title, description)
 }
 
 // UnlabelPullRequest on Bitbucket cloud
+// Bitbucket cloud has no pull request labels, therefore the label is removed from the pull request description.
 func (client *BitbucketCloudClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return errLabelsNotSupported
+	ctx = labelAPICalls(ctx, "UnlabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	pullRequestDetails, err := client.getPullRequestDetails(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	description, removed, err := removeBitbucketPullRequestLabel(pullRequestDetails.Body, name)
+	if err != nil || !removed {
+		return err
+	}
+	return client.updatePullRequestDescription(ctx, owner, repository, pullRequestID, pullRequestDetails.Title, description)
+}
+
+// ListMilestones on Bitbucket cloud
+func (client *BitbucketCloudClient) ListMilestones(_ context.Context, _, _ string, _ MilestoneState) ([]MilestoneInfo, error) {
+	return nil, ErrMilestonesNotSupported
+}
+
+// SetPullRequestMilestone on Bitbucket cloud
+func (client *BitbucketCloudClient) SetPullRequestMilestone(_ context.Context, _, _ string, _ int, _ int64) error {
+	return ErrMilestonesNotSupported
+}
+
+func (client *BitbucketCloudClient) updatePullRequestDescription(ctx context.Context, owner, repository string, pullRequestID int, title, description string) error {
+	// The pull request is updated directly, since go-bitbucket's update overrides the reviewers and the branches
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", owner, repository, pullRequestID)
+	return client.sendRequest(ctx, http.MethodPut, path, map[string]string{"title": title, "description": description}, nil)
+}
+
+func (client *BitbucketCloudClient) getPullRequestDetails(ctx context.Context, owner, repository string, pullRequestID int) (pullRequestsDetails, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	pullRequestRaw, err := bitbucketClient.Repositories.PullRequests.Get(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       strconv.Itoa(pullRequestID),
+	})
+	if err != nil {
+		return pullRequestsDetails{}, err
+	}
+	return vcsutils.RemapFields[pullRequestsDetails](pullRequestRaw, "json")
+}
+
+// sendRequest sends a request to a Bitbucket cloud REST API which isn't covered by go-bitbucket.
+// path - The API path, relative to the API endpoint
+// requestBody - Optional object to send as a JSON body
+// responseBody - Optional pointer to decode the JSON response into, a *[]byte to read the raw response into, or an io.Writer to stream the raw response into
+func (client *BitbucketCloudClient) sendRequest(ctx context.Context, method, path string, requestBody, responseBody interface{}) error {
+	_, err := client.sendRequestWithStatus(ctx, method, path, requestBody, responseBody)
+	return err
+}
+
+// sendRequestWithStatus sends a request like sendRequest, and returns the status code of the response, or 0 if no response was received
+func (client *BitbucketCloudClient) sendRequestWithStatus(ctx context.Context, method, path string, requestBody, responseBody interface{}) (statusCode int, err error) {
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	body := new(bytes.Buffer)
+	if requestBody != nil {
+		if err = json.NewEncoder(body).Encode(requestBody); err != nil {
+			return
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+path, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client.setBasicAuth(req)
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.HttpClient.Do(req)
+	if err != nil {
+		return
+	}
+	statusCode = response.StatusCode
+	defer func() {
+		err = errors.Join(err, vcsutils.DiscardResponseBody(response), response.Body.Close())
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return
+	}
+	if responseBody == nil {
+		return
+	}
+	if rawResponseBody, ok := responseBody.(*[]byte); ok {
+		*rawResponseBody, err = io.ReadAll(response.Body)
+		return
+	}
+	if writer, ok := responseBody.(io.Writer); ok {
+		_, err = io.Copy(writer, response.Body)
+		return
+	}
+	err = json.NewDecoder(response.Body).Decode(responseBody)
+	return
 }
 
 // UploadCodeScanning on Bitbucket cloud
@@ -591,9 +1723,80 @@ func (client *BitbucketCloudClient) UploadCodeScanning(ctx context.Context, owne
 	return "", errBitbucketCodeScanningNotSupported
 }
 
+// ListCodeScanningAlerts on Bitbucket cloud
+func (client *BitbucketCloudClient) ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error) {
+	return nil, errBitbucketCodeScanningNotSupported
+}
+
+// GetCodeScanningAlert on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error) {
+	return CodeScanningAlertInfo{}, errBitbucketCodeScanningNotSupported
+}
+
+// DismissCodeScanningAlert on Bitbucket cloud
+func (client *BitbucketCloudClient) DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, reason AlertDismissalReason, comment string) error {
+	return errBitbucketCodeScanningNotSupported
+}
+
+// ListVulnerabilityAlerts on Bitbucket cloud
+func (client *BitbucketCloudClient) ListVulnerabilityAlerts(ctx context.Context, owner, repository string) ([]VulnerabilityAlertInfo, error) {
+	return nil, errBitbucketVulnerabilityAlertsNotSupported
+}
+
 // DownloadFileFromRepo on Bitbucket cloud
 func (client *BitbucketCloudClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
-	return nil, 0, errBitbucketDownloadFileFromRepoNotSupported
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepo")
+	var content bytes.Buffer
+	statusCode, err := client.downloadFileFromRepoStream(ctx, owner, repository, branch, path, &content, 0)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return content.Bytes(), statusCode, nil
+}
+
+// DownloadFileFromRepoStream on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) error {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepoStream")
+	_, err := client.downloadFileFromRepoStream(ctx, owner, repository, branch, path, writer, maxBytes)
+	return err
+}
+
+// downloadFileFromRepoStream writes the raw content of a file, returned by the src endpoint, and returns the status code of the response
+func (client *BitbucketCloudClient) downloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) (int, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "path": path}); err != nil {
+		return 0, err
+	}
+	srcPath := fmt.Sprintf("/repositories/%s/%s/src/%s/%s", url.PathEscape(owner), url.PathEscape(repository), url.PathEscape(branch), escapeFilePath(path))
+	return client.sendRequestWithStatus(ctx, http.MethodGet, srcPath, nil, newLimitedWriter(writer, maxBytes))
+}
+
+// FileExistsInRepo on Bitbucket cloud
+func (client *BitbucketCloudClient) FileExistsInRepo(ctx context.Context, owner, repository, branch, path string) (bool, error) {
+	ctx = labelAPICalls(ctx, "FileExistsInRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "path": path}); err != nil {
+		return false, err
+	}
+	endpoint := client.vcsInfo.APIEndpoint
+	if endpoint == "" {
+		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	// With the meta format, the metadata of the path is returned instead of its content
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/repositories/%s/%s/src/%s/%s?format=meta",
+		endpoint, url.PathEscape(owner), url.PathEscape(repository), url.PathEscape(branch), escapeFilePath(path)), nil)
+	if err != nil {
+		return false, err
+	}
+	client.setBasicAuth(request)
+	var pathMetadata struct {
+		Type string `json:"type"`
+	}
+	found, err := getBitbucketFileMetadata(client.buildBitbucketCloudClient(ctx).HttpClient, request, &pathMetadata)
+	return found && pathMetadata.Type == "commit_file", err
+}
+
+// CILint on Bitbucket cloud
+func (client *BitbucketCloudClient) CILint(_ context.Context, _, _, _ string) (CILintResult, error) {
+	return CILintResult{}, ErrCILintNotSupported
 }
 
 // GetRepositoryEnvironmentInfo on Bitbucket cloud
@@ -601,7 +1804,23 @@ func (client *BitbucketCloudClient) GetRepositoryEnvironmentInfo(ctx context.Con
 	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
+// CreateOrUpdateEnvironment on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error {
+	return errBitbucketCreateOrUpdateEnvironmentNotSupported
+}
+
 func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFiles")
+	modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	return getModifiedFileNames(modifiedFiles), nil
+}
+
+// GetModifiedFilesWithDetails on Bitbucket Cloud
+func (client *BitbucketCloudClient) GetModifiedFilesWithDetails(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFilesWithDetails")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -624,7 +1843,7 @@ func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner,
 		Merge:   true,
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	var modifiedFiles []ModifiedFileInfo
 	nextPage := 1
 
 	for nextPage > 0 {
@@ -641,29 +1860,65 @@ func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner,
 		}
 
 		for _, diffStat := range diffStatRes.DiffStats {
-			if path, ok := diffStat.New["path"].(string); ok {
-				fileNamesSet.Add(path)
-			}
-			if path, ok := diffStat.Old["path"].(string); ok {
-				fileNamesSet.Add(path)
-			}
+			modifiedFiles = append(modifiedFiles, mapBitbucketCloudDiffStat(diffStat))
 		}
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
+	return sortModifiedFiles(modifiedFiles), nil
+}
+
+func mapBitbucketCloudDiffStat(diffStat *bitbucket.DiffStat) ModifiedFileInfo {
+	newPath, _ := diffStat.New["path"].(string)
+	oldPath, _ := diffStat.Old["path"].(string)
+	modifiedFile := ModifiedFileInfo{Path: newPath, Status: FileModified, Additions: diffStat.LinedAdded, Deletions: diffStat.LinesRemoved}
+	switch {
+	case diffStat.Status == "added" || oldPath == "":
+		modifiedFile.Status = FileAdded
+	case diffStat.Status == "removed" || newPath == "":
+		modifiedFile.Status = FileRemoved
+		modifiedFile.Path = oldPath
+	case diffStat.Status == "renamed" || oldPath != newPath:
+		modifiedFile.Status = FileRenamed
+		modifiedFile.PreviousPath = oldPath
+	}
+	return modifiedFile
 }
 
 type pullRequestsResponse struct {
 	Values []pullRequestsDetails `json:"values"`
 }
 
+type mergeParameters struct {
+	Type              string `json:"type"`
+	Message           string `json:"message,omitempty"`
+	CloseSourceBranch bool   `json:"close_source_branch"`
+	MergeStrategy     string `json:"merge_strategy,omitempty"`
+}
+
 type pullRequestsDetails struct {
 	ID     int64             `json:"id"`
+	Title  string            `json:"title"`
 	Body   string            `json:"description"`
+	State  string            `json:"state"`
 	Source pullRequestBranch `json:"source"`
 	Target pullRequestBranch `json:"destination"`
+	Author struct {
+		Nickname string `json:"nickname"`
+	} `json:"author"`
+	UpdatedOn time.Time `json:"updated_on"`
+	Links     struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+	Reviewers []struct {
+		Nickname string `json:"nickname"`
+	} `json:"reviewers"`
+	Participants []struct {
+		User struct {
+			Nickname string `json:"nickname"`
+		} `json:"user"`
+		Role     string `json:"role"`
+		Approved bool   `json:"approved"`
+		State    string `json:"state"`
+	} `json:"participants"`
 }
 
 type pullRequestBranch struct {
@@ -671,6 +1926,9 @@ type pullRequestBranch struct {
 		Str string `json:"name"`
 	} `json:"branch"`
 	Repository pullRequestRepository `json:"repository"`
+	Commit     struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
 }
 
 type pullRequestRepository struct {
@@ -682,7 +1940,11 @@ type commentsResponse struct {
 }
 
 type commentDetails struct {
-	ID        int64          `json:"id"`
+	ID int64 `json:"id"`
+	// The comment replied to, for a reply
+	Parent *struct {
+		ID int64 `json:"id"`
+	} `json:"parent,omitempty"`
 	User      user           `json:"user"`
 	IsDeleted bool           `json:"deleted"`
 	Content   commentContent `json:"content"`
@@ -693,6 +1955,26 @@ type commentContent struct {
 	Raw string `json:"raw"`
 }
 
+type reviewCommentDetails struct {
+	Content commentContent        `json:"content"`
+	Inline  *inlineCommentDetails `json:"inline,omitempty"`
+}
+
+type inlineCommentDetails struct {
+	Path string `json:"path"`
+	// The line in the new version of the file. If zero, the comment is on the whole file.
+	To int `json:"to,omitempty"`
+}
+
+type taskDetails struct {
+	Content commentContent      `json:"content"`
+	Comment *taskCommentDetails `json:"comment,omitempty"`
+}
+
+type taskCommentDetails struct {
+	ID int64 `json:"id"`
+}
+
 type commitResponse struct {
 	Values []commitDetails `json:"values"`
 }
@@ -706,6 +1988,7 @@ type commitDetails struct {
 	} `json:"author"`
 	Links struct {
 		Self link `json:"self"`
+		HTML link `json:"html"`
 	} `json:"links"`
 	Parents []struct {
 		Hash string `json:"hash"`
@@ -714,6 +1997,7 @@ type commitDetails struct {
 
 type user struct {
 	DisplayName string `json:"display_name"`
+	UUID        string `json:"uuid"`
 }
 type link struct {
 	Href string `json:"href"`
@@ -754,7 +2038,7 @@ func getBitbucketCloudWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []st
 }
 
 // The get repository request returns HTTP link to the repository - extract the link from the response.
-func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error) {
+func getDownloadLink(repo *bitbucket.Repository, ref string) (string, error) {
 	repositoryHTMLLinks := &link{}
 	b, err := json.Marshal(repo.Links["html"])
 	if err != nil {
@@ -768,7 +2052,25 @@ func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error)
 	if htmlLink == "" {
 		return "", fmt.Errorf("couldn't find repository HTML link: %s", repo.Links["html"])
 	}
-	return htmlLink + "/get/" + branch + ".tar.gz", err
+	if ref = strings.TrimSpace(ref); ref == "" {
+		ref = repo.Mainbranch.Name
+	}
+	if ref == "" {
+		return "", fmt.Errorf("couldn't find the main branch of repository %s", repo.Full_name)
+	}
+	return htmlLink + "/get/" + getBitbucketCloudArchiveRef(ref) + ".tar.gz", err
+}
+
+// getBitbucketCloudArchiveRef returns the ref in the URL of a repository archive, which is a branch name, a tag name or a commit hash.
+// The prefixes of fully qualified refs, such as 'refs/tags/', are trimmed, and the ref is escaped keeping the slashes of branch names.
+func getBitbucketCloudArchiveRef(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/heads/")
+	ref = strings.TrimPrefix(ref, "refs/tags/")
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
 
 func mapBitbucketCloudCommitToCommitInfo(parsedCommit commitDetails) CommitInfo {
@@ -781,19 +2083,33 @@ func mapBitbucketCloudCommitToCommitInfo(parsedCommit commitDetails) CommitInfo
 		AuthorName:    parsedCommit.Author.User.DisplayName,
 		CommitterName: "", // not provided
 		Url:           parsedCommit.Links.Self.Href,
+		HTMLURL:       parsedCommit.Links.HTML.Href,
 		Timestamp:     parsedCommit.Date.UTC().Unix(),
 		Message:       parsedCommit.Message,
 		ParentHashes:  parents,
 	}
 }
 
+// mapBitbucketCloudCommentToCommentInfo maps the comments, whose thread is the comment at the root of the replies to each other
 func mapBitbucketCloudCommentToCommentInfo(parsedComments *commentsResponse) []CommentInfo {
+	parentIDs := make(map[int64]int64, len(parsedComments.Values))
+	for _, comment := range parsedComments.Values {
+		if comment.Parent != nil {
+			parentIDs[comment.ID] = comment.Parent.ID
+		}
+	}
 	comments := make([]CommentInfo, len(parsedComments.Values))
 	for i, comment := range parsedComments.Values {
+		rootID := comment.ID
+		for parentID, isReply := parentIDs[rootID]; isReply && parentID != comment.ID; parentID, isReply = parentIDs[rootID] {
+			rootID = parentID
+		}
 		comments[i] = CommentInfo{
-			ID:      comment.ID,
-			Content: comment.Content.Raw,
-			Created: comment.Created,
+			ID:       comment.ID,
+			ThreadID: strconv.FormatInt(rootID, 10),
+			Content:  comment.Content.Raw,
+			Created:  comment.Created,
+			Author:   comment.User.UUID,
 		}
 	}
 	return comments
@@ -804,11 +2120,12 @@ func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullReque
 	for i, pullRequest := range parsedPullRequests.Values {
 		var body string
 		if withBody {
-			body = pullRequest.Body
+			body = stripBitbucketPullRequestLabels(pullRequest.Body)
 		}
 		pullRequests[i] = PullRequestInfo{
-			ID:   pullRequest.ID,
-			Body: body,
+			ID:      pullRequest.ID,
+			Body:    body,
+			HTMLURL: pullRequest.Links.HTML.Href,
 			Source: BranchInfo{
 				Name:       pullRequest.Source.Name.Str,
 				Repository: pullRequest.Source.Repository.Name,
@@ -817,6 +2134,9 @@ func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullReque
 				Name:       pullRequest.Target.Name.Str,
 				Repository: pullRequest.Target.Repository.Name,
 			},
+			HeadCommitHash: pullRequest.Source.Commit.Hash,
+			Author:         pullRequest.Author.Nickname,
+			UpdatedAt:      pullRequest.UpdatedOn.UTC(),
 		}
 	}
 	return pullRequests
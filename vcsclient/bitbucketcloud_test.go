@@ -1,13 +1,18 @@
 package vcsclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +32,135 @@ func TestBitbucketCloud_Connection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_Raw(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user", r.RequestURI)
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		_, err := w.Write([]byte(`{"username": "frogger"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	// The raw client is authenticated by the username and the app password of the client
+	_, err := client.(*BitbucketCloudClient).Raw().User.Profile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+token))}, authorizations)
+}
+
+func TestBitbucketCloud_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	emailsStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/user":
+			response = `{"uuid": "{3f4c5d6e-1a2b-4c3d-8e9f-0a1b2c3d4e5f}", "username": "frogbot", "display_name": "Frogbot"}`
+		case "/user/emails":
+			w.WriteHeader(emailsStatus)
+			response = `{"values": [{"email": "frog@jfrog.com", "is_primary": false}, {"email": "frogbot@jfrog.com", "is_primary": true}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{ID: "{3f4c5d6e-1a2b-4c3d-8e9f-0a1b2c3d4e5f}", Login: "frogbot", DisplayName: "Frogbot", Email: "frogbot@jfrog.com"}, user)
+
+	// The email is left empty when the token has no permission to read it
+	emailsStatus = http.StatusForbidden
+	user, err = client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "frogbot", user.Login)
+	assert.Empty(t, user.Email)
+}
+
+func TestBitbucketCloud_AccessTokenAuthentication(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	// A workspace, project or repository access token has no username
+	client := buildClient(t, vcsutils.BitbucketCloud, false, server)
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.AddSshKeyToRepository(ctx, owner, repo1, "key", "ssh-rsa AAAA", Read))
+}
+
+func TestBitbucketCloud_OAuthClientCredentialsAuthentication(t *testing.T) {
+	ctx := context.Background()
+	var tokenRequestsCount int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequestsCount++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		clientID, clientSecret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "consumer-key", clientID)
+		assert.Equal(t, "consumer-secret", clientSecret)
+		w.Header().Set("Content-Type", "application/json")
+		// The token expires immediately, so it's fetched again before each request
+		_, err := fmt.Fprintf(w, `{"access_token": "access-token-%d", "token_type": "bearer", "expires_in": 1}`, tokenRequestsCount)
+		assert.NoError(t, err)
+	}))
+	defer tokenServer.Close()
+	defer setBitbucketCloudOAuthTokenURL(tokenServer.URL)()
+
+	var authorizationHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizationHeaders = append(authorizationHeaders, r.Header.Get("Authorization"))
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).ApiEndpoint(server.URL).OAuthClientCredentials("consumer-key", "consumer-secret").Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.Equal(t, []string{"Bearer access-token-1", "Bearer access-token-2"}, authorizationHeaders)
+}
+
+func TestBitbucketCloud_OAuthRefreshTokenAuthentication(t *testing.T) {
+	ctx := context.Background()
+	var tokenRequestsCount int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequestsCount++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "refresh-token", r.PostForm.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"access_token": "access-token", "refresh_token": "refresh-token", "token_type": "bearer", "expires_in": 7200}`))
+		assert.NoError(t, err)
+	}))
+	defer tokenServer.Close()
+	defer setBitbucketCloudOAuthTokenURL(tokenServer.URL)()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).ApiEndpoint(server.URL).
+		OAuthClientCredentials("consumer-key", "consumer-secret").OAuthRefreshToken("refresh-token").Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnection(ctx))
+	// The access token is reused until it expires
+	assert.Equal(t, 1, tokenRequestsCount)
+}
+
 func TestBitbucketCloud_ConnectionWhenContextCancelled(t *testing.T) {
 	t.Skip("Bitbucket cloud does not use the context")
 	ctx := context.Background()
@@ -64,6 +198,94 @@ func TestBitbucketCloud_ListRepositories(t *testing.T) {
 	assert.Equal(t, map[string][]string{username: {repo1, repo2}}, actualRepositories)
 }
 
+func TestBitbucketCloud_ListRepositoryListings(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucket.Repository{
+		"values": {{Slug: repo1, Is_private: true, Mainbranch: bitbucket.RepositoryBranch{Name: "master"}}, {Slug: repo2}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/"+username, createBitbucketCloudHandler)
+	defer cleanUp()
+
+	listings, err := client.ListRepositoryListings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{
+		{Owner: username, OwnerType: WorkspaceNamespace, Name: repo1, Visibility: Private, DefaultBranch: "master"},
+		{Owner: username, OwnerType: WorkspaceNamespace, Name: repo2, Visibility: Public},
+	}, listings)
+}
+
+func TestBitbucketCloud_SearchRepositories(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucket.Repository{
+		"values": {{Slug: repo1, Is_private: true, Mainbranch: bitbucket.RepositoryBranch{Name: "master"}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/"+username+"?page=1&q=full_name+~+%22repo%22", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	listings, err := client.SearchRepositories(ctx, RepositorySearchOptions{Name: "repo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{{Owner: username, OwnerType: WorkspaceNamespace, Name: repo1, Visibility: Private, DefaultBranch: "master"}}, listings)
+
+	_, err = client.SearchRepositories(ctx, RepositorySearchOptions{Owner: username, Topic: "frogbot-enabled"})
+	assert.ErrorIs(t, err, errBitbucketSearchRepositoriesByTopicNotSupported)
+}
+
+func TestBitbucketCloud_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := `{"size": 1, "page": 1, "pagelen": 100, "values": [{
+		"content_matches": [{"lines": [
+			{"line": 3, "segments": [{"text": "require github.com/jfrog/"}, {"text": "froggit-go", "match": true}, {"text": " v1.0.0"}]},
+			{"line": 4, "segments": []}
+		]}],
+		"file": {"path": "go.mod", "commit": {"repository": {"name": "repo-1", "full_name": "frogger/repo-1"}}}
+	}]}`
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(mockResponse),
+		"/workspaces/"+username+"/search/code?page=1&pagelen=100&search_query=froggit-go+repo%3Arepo-1", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	results, err := client.SearchCode(ctx, "froggit-go", CodeSearchOptions{Owner: username, Repository: repo1})
+	assert.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{{Owner: username, Repository: repo1, Path: "go.mod", Line: 3, Fragment: "require github.com/jfrog/froggit-go v1.0.0\n"}}, results)
+
+	_, err = client.SearchCode(ctx, "froggit-go", CodeSearchOptions{})
+	assert.ErrorIs(t, err, errBitbucketCloudSearchCodeWithoutOwnerNotSupported)
+}
+
+func TestBitbucketCloud_GetBranchInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/refs/branches/feature":
+			response = `{"name": "feature", "target": {"hash": "ce5ebb0c85f8d6f4b3b9b2e5e8b0b3d7b2a0ddc5"}}`
+		case "/repositories/jfrog/repo-1":
+			response = `{"slug": "repo-1", "mainbranch": {"name": "main"}}`
+		case "/repositories/jfrog/repo-1/branch-restrictions?pattern=feature":
+			response = `{"size": 0, "values": []}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	branchInfo, err := client.GetBranchInfo(ctx, owner, repo1, "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryBranchInfo{Name: "feature", HeadCommitHash: "ce5ebb0c85f8d6f4b3b9b2e5e8b0b3d7b2a0ddc5"}, branchInfo)
+}
+
+func TestBitbucketCloud_ListNamespaces(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/workspaces", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	namespaces, err := client.ListNamespaces(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []NamespaceInfo{{Name: username, Kind: WorkspaceNamespace}}, namespaces)
+}
+
 func TestBitbucketCloud_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucket.BranchModel{
@@ -77,6 +299,30 @@ func TestBitbucketCloud_ListBranches(t *testing.T) {
 	assert.ElementsMatch(t, actualRepositories, []string{branch1, branch2})
 }
 
+func TestBitbucketCloud_ListProtectedBranches(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/repositories/jfrog/repo-1/refs/branches":
+			response = `{"values": [{"name": "main"}, {"name": "feature"}, {"name": "release/1.0"}]}`
+		case "/repositories/jfrog/repo-1/branch-restrictions":
+			// A restriction by the branching model has no pattern
+			response = `{"values": [{"kind": "push", "pattern": "main"}, {"kind": "push", "branch_match_kind": "branching_model"}, {"kind": "require_approvals_to_merge", "pattern": "release/*"}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	branches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListBranchesOptions{ProtectedOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main"}, branches)
+}
+
 func TestBitbucketCloud_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id, err := uuid.NewUUID()
@@ -142,6 +388,39 @@ func TestBitbucketCloud_DownloadRepository(t *testing.T) {
 	assert.DirExists(t, filepath.Join(dir, ".git"))
 }
 
+func TestBitbucketCloud_DownloadRepositoryAtRef(t *testing.T) {
+	ctx := context.Background()
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+	var archiveRequests []string
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/repositories/jfrog/repo-1" {
+			response := fmt.Sprintf(`{"full_name": "jfrog/repo-1", "mainbranch": {"name": "main"}, "links": {"html": {"href": "%s/jfrog/repo-1"}}}`, serverURL)
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+			return
+		}
+		archiveRequests = append(archiveRequests, r.RequestURI)
+		_, err := w.Write(repoFile)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).ApiEndpoint(server.URL).Username(username).Token(token).SkipDotGitCreation(true).Build()
+	assert.NoError(t, err)
+
+	for _, ref := range []string{"", "refs/tags/v1.0.0", "feature/new login", "6d1b0f0c3ba5d0b4bd0b43f3c7d4a0a6a3a5b4e7"} {
+		assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, ref, t.TempDir()))
+	}
+	assert.Equal(t, []string{
+		"/jfrog/repo-1/get/main.tar.gz",
+		"/jfrog/repo-1/get/v1.0.0.tar.gz",
+		"/jfrog/repo-1/get/feature/new%20login.tar.gz",
+		"/jfrog/repo-1/get/6d1b0f0c3ba5d0b4bd0b43f3c7d4a0a6a3a5b4e7.tar.gz",
+	}, archiveRequests)
+}
+
 func TestBitbucketCloud_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/pullrequests/", createBitbucketCloudHandler)
@@ -161,6 +440,77 @@ func TestBitbucketCloudClient_UpdatePullRequest(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloudClient_UpdatePullRequestKeepsLabels(t *testing.T) {
+	ctx := context.Background()
+	var updatedDescription string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var updateRequest struct {
+				Description string `json:"description"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updateRequest))
+			updatedDescription = updateRequest.Description
+		}
+		_, err := w.Write([]byte(`{"id": 3, "description": "PR body\n\n[comment]: <> (froggit-labels: [\"label-1\"])"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.UpdatePullRequest(ctx, owner, repo1, "PR title", "New PR body", "master", 3, vcsutils.Open)
+	assert.NoError(t, err)
+	assert.Equal(t, "New PR body\n\n[comment]: <> (froggit-labels: [\"label-1\"])", updatedDescription)
+}
+
+func TestBitbucketCloudClient_ClosePullRequest(t *testing.T) {
+	ctx := context.Background()
+	prId := 3
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		_, err := w.Write([]byte(`{"id": 3, "state": "OPEN"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.ClosePullRequest(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /repositories/jfrog/repo-1/pullrequests/3",
+		"POST /repositories/jfrog/repo-1/pullrequests/3/decline",
+	}, requests)
+}
+
+func TestBitbucketCloudClient_ReopenPullRequest(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	err = client.ReopenPullRequest(context.Background(), owner, repo1, 3)
+	assert.ErrorIs(t, err, errBitbucketCloudReopenPullRequestNotSupported)
+}
+
+func TestBitbucketCloudClient_EnablePullRequestAutoMerge(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	err = client.EnablePullRequestAutoMerge(context.Background(), owner, repo1, 3, SquashMerge)
+	assert.ErrorIs(t, err, errBitbucketCloudAutoMergeNotSupported)
+}
+
+func TestBitbucketCloudClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"type":"pullrequest_merge_parameters","message":"Upgrade lodash\n\nFixes CVE-2021-23337","close_source_branch":true,"merge_strategy":"squash"}` + "\n")
+	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("{}"),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/merge", owner, repo1), http.StatusOK,
+		expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer closeServer()
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{Method: SquashMerge, CommitTitle: "Upgrade lodash", CommitBody: "Fixes CVE-2021-23337", DeleteSourceBranch: true})
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{BypassPolicies: true})
+	assert.ErrorIs(t, err, errBitbucketBypassPoliciesNotSupported)
+}
+
 func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
@@ -174,9 +524,13 @@ func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 3)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     3,
-		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
-		Target: BranchInfo{Name: "master", Repository: "user17/test"},
+		ID:             3,
+		Source:         BranchInfo{Name: "test-2", Repository: "user17/test"},
+		Target:         BranchInfo{Name: "master", Repository: "user17/test"},
+		HTMLURL:        "https://bitbucket.org/user17/test/pull-requests/3",
+		HeadCommitHash: "b1fbbe453dbb",
+		Author:         "user",
+		UpdatedAt:      time.Date(2022, time.May, 16, 11, 5, 33, 889646000, time.UTC),
 	}, result[0])
 
 	// With Body
@@ -185,10 +539,14 @@ func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 3)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     3,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
-		Target: BranchInfo{Name: "master", Repository: "user17/test"},
+		ID:             3,
+		Body:           "hello world",
+		Source:         BranchInfo{Name: "test-2", Repository: "user17/test"},
+		Target:         BranchInfo{Name: "master", Repository: "user17/test"},
+		HTMLURL:        "https://bitbucket.org/user17/test/pull-requests/3",
+		HeadCommitHash: "b1fbbe453dbb",
+		Author:         "user",
+		UpdatedAt:      time.Date(2022, time.May, 16, 11, 5, 33, 889646000, time.UTC),
 	}, result[0])
 }
 
@@ -206,9 +564,18 @@ func TestBitbucketCloudClient_GetPullRequest(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "pr", Repository: "froggit", Owner: "forkedWorkspace"},
-		Target: BranchInfo{Name: "main", Repository: "froggit", Owner: "workspace"},
+		ID:             int64(pullRequestId),
+		Source:         BranchInfo{Name: "pr", Repository: "froggit", Owner: "forkedWorkspace"},
+		Target:         BranchInfo{Name: "main", Repository: "froggit", Owner: "workspace"},
+		HTMLURL:        "https://bitbucket.org/workspace/froggit/pull-requests/1",
+		HeadCommitHash: "18f5e1ecb37e",
+		Author:         "fname lname",
+		UpdatedAt:      time.Date(2023, time.June, 20, 9, 0, 47, 725250000, time.UTC),
+		Participants: []PullRequestParticipant{
+			{Username: "frogger", Role: ReviewerRole, Approved: true},
+			{Username: "toad", Role: ParticipantRole, ChangesRequested: true},
+			{Username: "tadpole", Role: ReviewerRole},
+		},
 	}, result)
 
 	// Bad Response
@@ -237,6 +604,96 @@ func TestBitbucketCloud_AddPullRequestComment(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/jfrog/repo-1/pullrequests", r.URL.Path)
+		query = r.URL.Query()
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	result, err := client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{
+		State:        PullRequestsAll,
+		SourceBranch: "test-2",
+		TargetBranch: "master",
+		Author:       "user",
+		Sort:         SortByUpdated,
+		Ascending:    true,
+		ListOptions:  ListOptions{Page: 1, PerPage: 2},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(3), result[0].ID)
+	assert.Equal(t, []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}, query["state"])
+	assert.Equal(t, `source.branch.name = "test-2" AND destination.branch.name = "master" AND author.nickname = "user"`, query.Get("q"))
+	assert.Equal(t, "updated_on", query.Get("sort"))
+
+	// By default, the open pull requests are listed from the newest
+	_, err = client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OPEN"}, query["state"])
+	assert.False(t, query.Has("q"))
+	assert.Equal(t, "-created_on", query.Get("sort"))
+}
+
+func TestBitbucketCloud_Issues(t *testing.T) {
+	ctx := context.Background()
+	issueJSON := `{"id": 3, "title": "Vulnerable dependencies", "state": "%s", "content": {"raw": "Upgrade lodash"},
+		"reporter": {"nickname": "frogger"}, "links": {"html": {"href": "https://bitbucket.org/jfrog/repo-1/issues/3"}}, "created_on": "2024-01-02T10:00:00+00:00"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == "/repositories/jfrog/repo-1/issues":
+			var issueRequest map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&issueRequest))
+			assert.Equal(t, "Vulnerable dependencies", issueRequest["title"])
+			response = fmt.Sprintf(issueJSON, "new")
+		case r.Method == http.MethodGet && r.URL.Path == "/repositories/jfrog/repo-1/issues":
+			assert.Equal(t, `state != "new" AND state != "open" AND state != "on hold"`, r.URL.Query().Get("q"))
+			response = `{"values": [` + fmt.Sprintf(issueJSON, "resolved") + `]}`
+		case r.Method == http.MethodPost && r.RequestURI == "/repositories/jfrog/repo-1/issues/3/comments":
+			response = "{}"
+		case r.Method == http.MethodPut && r.RequestURI == "/repositories/jfrog/repo-1/issues/3":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"state": "closed"}`, string(body))
+			response = fmt.Sprintf(issueJSON, "closed")
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependencies", "Upgrade lodash")
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{
+		ID:        3,
+		Title:     "Vulnerable dependencies",
+		Body:      "Upgrade lodash",
+		State:     IssueOpen,
+		URL:       "https://bitbucket.org/jfrog/repo-1/issues/3",
+		Author:    "frogger",
+		CreatedAt: time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC),
+	}, issue)
+
+	issues, err := client.ListIssues(ctx, owner, repo1, IssueClosed)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, IssueClosed, issues[0].State)
+
+	assert.NoError(t, client.CommentOnIssue(ctx, owner, repo1, "Still vulnerable", 3))
+	assert.NoError(t, client.CloseIssue(ctx, owner, repo1, 3))
+}
+
 func TestBitbucketCloud_ListPullRequestComments(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_request_comments_list_response.json"))
@@ -252,10 +709,15 @@ func TestBitbucketCloud_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, CommentInfo{
-		ID:      301545835,
-		Content: "I’m a comment ",
-		Created: expectedCreated,
+		ID:       301545835,
+		ThreadID: "301545835",
+		Content:  "I’m a comment ",
+		Created:  expectedCreated,
+		Author:   "{337d7a24-7ebf-4174-8bd9-59bbb900d5e5}",
 	}, result[0])
+	// The thread of a reply is the comment it replies to
+	assert.Equal(t, int64(301546211), result[1].ID)
+	assert.Equal(t, "301545835", result[1].ThreadID)
 }
 
 func TestBitbucketCloud_GetLatestCommit(t *testing.T) {
@@ -275,6 +737,7 @@ func TestBitbucketCloud_GetLatestCommit(t *testing.T) {
 		AuthorName:    "user",
 		CommitterName: "",
 		Url:           "https://api.bitbucket.org/2.0/repositories/user2/setup-jfrog-cli/commit/ec05bacb91d757b4b6b2a11a0676471020e89fb5",
+		HTMLURL:       "https://bitbucket.org/user2/setup-jfrog-cli/commits/ec05bacb91d757b4b6b2a11a0676471020e89fb5",
 		Timestamp:     1591040823,
 		Message:       "Fix README.md: yaml\n",
 		ParentHashes:  []string{"774aa0fb252bccbc2a7e01060ef4d4be0b0eeaa9", "def26c6128ebe11fac555fe58b59227e9655dc4d"},
@@ -296,6 +759,46 @@ func TestBitbucketCloud_GetCommits(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestBitbucketCloud_GetCommitsWithQueryOptions(t *testing.T) {
+	ctx := context.Background()
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/jfrog/repo-1/commits", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		response := `{"values": [{"hash": "ec05bacb91d757b4b6b2a11a0676471020e89fb5", "date": "2023-06-02T10:00:00+00:00", "message": "Second"}], "next": "next-page"}`
+		if r.URL.Query().Get("page") == "2" {
+			response = `{"values": [{"hash": "774aa0fb252bccbc2a7e01060ef4d4be0b0eeaa9", "date": "2023-06-01T10:00:00+00:00", "message": "First"}]}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	// All the pages are fetched if no page is specified
+	since := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.FixedZone("IDT", 3*60*60))
+	commits, err := client.GetCommitsWithQueryOptions(ctx, owner, repo1, GitCommitsQueryOptions{Since: since})
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, "ec05bacb91d757b4b6b2a11a0676471020e89fb5", commits[0].Hash)
+		assert.Equal(t, "First", commits[1].Message)
+	}
+	assert.Equal(t, []url.Values{
+		{"q": {"date >= 2023-05-31T21:00:00Z"}, "page": {"1"}},
+		{"q": {"date >= 2023-05-31T21:00:00Z"}, "page": {"2"}},
+	}, queries)
+
+	// A single page is fetched if specified
+	queries = nil
+	commits, err = client.GetCommitsWithQueryOptions(ctx, owner, repo1, GitCommitsQueryOptions{ListOptions: ListOptions{Page: 2, PerPage: 50}})
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, []url.Values{{"pagelen": {"50"}, "page": {"2"}}}, queries)
+
+	_, err = client.GetCommitsWithQueryOptions(ctx, owner, "", GitCommitsQueryOptions{})
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloud_GetLatestCommitNotFound(t *testing.T) {
 	ctx := context.Background()
 	response := []byte(`<!DOCTYPE html><html lang="en"></html>`)
@@ -374,6 +877,13 @@ func TestBitbucketCloud_AddSshKeyToRepositoryNotFound(t *testing.T) {
 	assert.EqualError(t, err, "404 Not Found")
 }
 
+func TestBitbucketCloud_CreateDeployToken(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.CreateDeployToken(context.Background(), owner, repo1, "frogbot", []DeployTokenScope{ReadRepositoryScope}, time.Time{})
+	assert.ErrorIs(t, err, errBitbucketCloudDeployTokensNotSupported)
+}
+
 func TestBitbucketCloud_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	sha := "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"
@@ -392,12 +902,28 @@ func TestBitbucketCloud_GetCommitBySha(t *testing.T) {
 		AuthorName:    "user",
 		CommitterName: "",
 		Url:           "https://api.bitbucket.org/2.0/repositories/user2/setup-jfrog-cli/commit/f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c",
+		HTMLURL:       "https://bitbucket.org/user2/setup-jfrog-cli/commits/f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c",
 		Timestamp:     1591030449,
 		Message:       "Update image name\n",
 		ParentHashes:  []string{"f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"},
 	}, result)
 }
 
+func TestBitbucketCloud_GetCommitDiff(t *testing.T) {
+	ctx := context.Background()
+	sha := "ec05bacb91d757b4b6b2a11a0676471020e89fb5"
+	diff, err := os.ReadFile(filepath.Join("testdata", "commit_diff.patch"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, diff,
+		fmt.Sprintf("/repositories/%s/%s/diff/%s", owner, repo1, sha), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	commitDiff, err := client.GetCommitDiff(ctx, owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, string(diff), commitDiff.Diff)
+	assert.Equal(t, FileDiffInfo{Path: "deleted.txt", Deletions: 2}, commitDiff.Files[2])
+}
+
 func TestBitbucketCloud_GetCommitByShaNotFound(t *testing.T) {
 	ctx := context.Background()
 	sha := "062ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"
@@ -455,22 +981,110 @@ func TestBitbucketCloud_GetRepositoryInfo(t *testing.T) {
 	)
 }
 
+func TestBitbucketCloud_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "repository_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusOK,
+		createBitbucketCloudHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+}
+
+func TestBitbucketCloud_ArchiveAndRenameRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"slug": "repo-2"}`),
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusOK, []byte(`{"name":"repo-2"}`+"\n"), http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.RenameRepository(ctx, owner, repo1, "repo-2"))
+	assert.ErrorIs(t, client.ArchiveRepository(ctx, owner, repo1), errBitbucketCloudArchiveRepositoryNotSupported)
+	assert.ErrorIs(t, client.UnarchiveRepository(ctx, owner, repo1), errBitbucketCloudArchiveRepositoryNotSupported)
+}
+
+func TestBitbucketCloud_GetUserPermissionOnRepo(t *testing.T) {
+	ctx := context.Background()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces/jfrog/permissions/repositories/repo-1", r.URL.Path)
+		queries = append(queries, r.URL.Query().Get("q"))
+		_, err := w.Write([]byte(`{"values": [{"permission": "read"}, {"permission": "write"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	permission, err := client.GetUserPermissionOnRepo(ctx, owner, repo1, username)
+	assert.NoError(t, err)
+	assert.Equal(t, WritePermission, permission)
+	_, err = client.GetUserPermissionOnRepo(ctx, owner, repo1, "{3f4c5d6e-1a2b-4c3d-8e9f-0a1b2c3d4e5f}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`user.nickname="frogger"`, `user.uuid="{3f4c5d6e-1a2b-4c3d-8e9f-0a1b2c3d4e5f}"`}, queries)
+}
+
+func TestBitbucketCloud_RepositoryMirrors(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.GetRepositoryMirrors(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketRepositoryMirrorsNotSupported)
+	err = client.SetRepositoryMirror(ctx, owner, repo1, RepositoryMirrorInfo{URL: "https://github.com/jfrog/repo-1.git"})
+	assert.ErrorIs(t, err, errBitbucketRepositoryMirrorsNotSupported)
+}
+
 func TestBitbucketCloud_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
 	assert.NoError(t, err)
 
+	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{Name: labelName})
+	assert.NoError(t, err)
+
 	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	assert.Error(t, err)
 }
 
 func TestBitbucketCloud_AddPullRequestReviewComments(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"id": 3}`),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments", owner, repo1), http.StatusCreated,
+		[]byte(`{"content":{"raw":"Comment content"},"inline":{"path":"index.js","to":7}}`+"\n"), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{CommentInfo: CommentInfo{Content: "Comment content"}, PullRequestDiff: PullRequestDiff{NewFilePath: "index.js", NewStartLine: 7}})
 	assert.NoError(t, err)
 
 	err = client.AddPullRequestReviewComments(ctx, owner, repo1, 1)
-	assert.ErrorIs(t, err, errBitbucketAddPullRequestReviewCommentsNotSupported)
+	assert.EqualError(t, err, vcsutils.ErrNoCommentsProvided)
+}
+
+func TestBitbucketCloud_AddBlockingPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requestBodies = append(requestBodies, r.RequestURI+" "+strings.TrimSpace(string(body)))
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write([]byte(`{"id": 3}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{CommentInfo: CommentInfo{Content: "Critical finding"}, Blocking: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`/repositories/jfrog/repo-1/pullrequests/1/comments {"content":{"raw":"Critical finding"}}`,
+		`/repositories/jfrog/repo-1/pullrequests/1/tasks {"content":{"raw":"Critical finding"},"comment":{"id":3}}`,
+	}, requestBodies)
 }
 
 func TestBitbucketCloudClient_ListPullRequestReviewComments(t *testing.T) {
@@ -491,6 +1105,40 @@ func TestBitbucketCloudClient_DeletePullRequestComment(t *testing.T) {
 	assert.ErrorIs(t, err, errBitbucketDeletePullRequestComment)
 }
 
+func TestBitbucketCloudClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2", owner, repo1), http.StatusOK,
+		[]byte(`{"content":{"raw":"Updated content"}}`), http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, 1, 2, "Updated content")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloudClient_UpdatePullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2", owner, repo1), http.StatusOK,
+		[]byte(`{"content":{"raw":"Updated content"}}`), http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestReviewComment(ctx, owner, repo1, 1, CommentInfo{ID: 2}, "Updated content")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloudClient_ReplyToPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments", owner, repo1), http.StatusCreated,
+		[]byte(`{"content":{"raw":"Reply content"},"parent":{"id":2}}`), http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+	err := client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 1, "2", "Reply content")
+	assert.NoError(t, err)
+	err = client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 1, "", "Reply content")
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloudClient_DeletePullRequestReviewComment(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -502,11 +1150,66 @@ func TestBitbucketCloudClient_DeletePullRequestReviewComment(t *testing.T) {
 
 func TestBitbucketCloudClient_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("hello world"), "/repositories/jfrog/repo-1/src/branch-1/dir/hello-world", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "dir/hello-world")
 	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "hello world", string(content))
+
+	// A missing file is reported with the status code of the response
+	server := httptest.NewServer(createBitbucketCloudHandler(t, "/repositories/jfrog/repo-1/src/branch-1/dir/missing", []byte("{}"), http.StatusNotFound))
+	defer server.Close()
+	_, statusCode, err = buildClient(t, vcsutils.BitbucketCloud, true, server).DownloadFileFromRepo(ctx, owner, repo1, branch1, "dir/missing")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
 
 	_, _, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "")
-	assert.ErrorIs(t, err, errBitbucketDownloadFileFromRepoNotSupported)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_DownloadFileFromRepoStream(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("hello world"), "/repositories/jfrog/repo-1/src/branch-1/dir/hello-world", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	var buffer bytes.Buffer
+	assert.NoError(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "dir/hello-world", &buffer, 0))
+	assert.Equal(t, "hello world", buffer.String())
+
+	buffer.Reset()
+	err := client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "dir/hello-world", &buffer, 5)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, "hello", buffer.String())
+
+	assert.Error(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "", &buffer, 0))
+}
+
+func TestBitbucketCloudClient_FileExistsInRepo(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		response       []byte
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{name: "file", response: []byte(`{"type": "commit_file", "path": "dir/package.json"}`), statusCode: http.StatusOK, expectedExists: true},
+		{name: "directory", response: []byte(`{"type": "commit_directory", "path": "dir/package.json"}`), statusCode: http.StatusOK, expectedExists: false},
+		{name: "not found", response: []byte(`{"type": "error"}`), statusCode: http.StatusNotFound, expectedExists: false},
+		{name: "error", response: []byte(`{"type": "error"}`), statusCode: http.StatusUnauthorized, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, tt.response,
+				"/repositories/jfrog/repo-1/src/branch-1/dir/package.json?format=meta", tt.statusCode, createBitbucketCloudHandler)
+			defer cleanUp()
+			exists, err := client.FileExistsInRepo(ctx, owner, repo1, branch1, "dir/package.json")
+			assert.Equal(t, tt.expectedError, err != nil)
+			assert.Equal(t, tt.expectedExists, exists)
+		})
+	}
 }
 
 func TestBitbucketCloud_GetLabel(t *testing.T) {
@@ -514,26 +1217,82 @@ func TestBitbucketCloud_GetLabel(t *testing.T) {
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
 	assert.NoError(t, err)
 
-	_, err = client.GetLabel(ctx, owner, repo1, labelName)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	label, err := client.GetLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+	assert.Equal(t, &LabelInfo{Name: labelName}, label)
 }
 
 func TestBitbucketCloud_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
-	assert.NoError(t, err)
+	response := pullRequestsDetails{ID: 1, Body: "Pull request body\n\n[comment]: <> (froggit-labels: [\"" + labelName + "\"])"}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
 
-	_, err = client.ListPullRequestLabels(ctx, owner, repo1, 1)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{labelName}, labels)
 }
 
 func TestBitbucketCloud_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	response := pullRequestsDetails{ID: 1, Title: "Pull request title",
+		Body: "Pull request body\n\n[comment]: <> (froggit-labels: [\"" + labelName + "\"])"}
+	uri := fmt.Sprintf("/repositories/%s/%s/pullrequests/1", owner, repo1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		if r.Method == http.MethodPut {
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"description":"Pull request body","title":"Pull request title"}`+"\n", string(b))
+		}
+		responseBytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
 	assert.NoError(t, err)
 
-	err = client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	// Label is not assigned to the pull request
+	err = client.UnlabelPullRequest(ctx, owner, repo1, "not-assigned-label", 1)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	response := pullRequestsDetails{ID: 1, Title: "Pull request title", Body: "Pull request body"}
+	uri := fmt.Sprintf("/repositories/%s/%s/pullrequests/1", owner, repo1)
+	var putRequestsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		if r.Method == http.MethodPut {
+			putRequestsCount++
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"description":"Pull request body\n\n[comment]: \u003c\u003e (froggit-labels: [\"`+labelName+`\"])","title":"Pull request title"}`+"\n", string(b))
+			response.Body = "Pull request body\n\n[comment]: <> (froggit-labels: [\"" + labelName + "\"])"
+		}
+		responseBytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	err := client.LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+
+	// Label is already assigned to the pull request
+	err = client.LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, putRequestsCount)
 }
 
 func TestBitbucketCloud_GetRepositoryEnvironmentInfo(t *testing.T) {
@@ -543,6 +1302,9 @@ func TestBitbucketCloud_GetRepositoryEnvironmentInfo(t *testing.T) {
 
 	_, err = client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
+
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{Name: envName})
+	assert.ErrorIs(t, err, errBitbucketCreateOrUpdateEnvironmentNotSupported)
 }
 
 func TestBitbucketCloud_getRepositoryVisibility(t *testing.T) {
@@ -567,6 +1329,24 @@ func TestBitbucketCloudClient_GetModifiedFiles(t *testing.T) {
 		assert.Equal(t, []string{"setup.py", "some/full.py"}, res)
 	})
 
+	t.Run("with details", func(t *testing.T) {
+		response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "compare_commits.json"))
+		assert.NoError(t, err)
+
+		client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, response,
+			fmt.Sprintf("/repositories/%s/%s/diffstat/sha-2..sha-1?page=1", owner, repo1), http.StatusOK,
+			createBitbucketCloudHandler)
+		defer cleanUp()
+
+		modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repo1, "sha-1", "sha-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []ModifiedFileInfo{
+			{Path: "setup.py", Status: FileModified, Additions: 2, Deletions: 1},
+			{Path: "some/full.py", Status: FileModified, Additions: 2, Deletions: 1},
+			{Path: "some/full.py", Status: FileModified, Additions: 2, Deletions: 1},
+		}, modifiedFiles)
+	})
+
 	t.Run("validation fails", func(t *testing.T) {
 		client := BitbucketCloudClient{}
 		_, err := client.GetModifiedFiles(ctx, "", repo1, "sha-1", "sha-2")
@@ -598,18 +1378,18 @@ func TestBitbucketCloudClient_GetModifiedFiles(t *testing.T) {
 func TestBitbucketCloudClient_GetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	t.Run("empty response", func(t *testing.T) {
-		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/owner/repo/commit/ref/statuses", createBitbucketCloudHandler)
+		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/owner/repo/commit/5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69/statuses", createBitbucketCloudHandler)
 		defer cleanUp()
-		_, err := client.GetCommitStatuses(ctx, "owner", "repo", "ref")
+		_, err := client.GetCommitStatuses(ctx, "owner", "repo", "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69")
 		assert.NoError(t, err)
 	})
 
 	t.Run("non empty response", func(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commits_statuses.json"))
 		assert.NoError(t, err)
-		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/owner/repo/commit/ref/statuses", createBitbucketCloudHandler)
+		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/owner/repo/commit/5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69/statuses", createBitbucketCloudHandler)
 		defer cleanUp()
-		commitStatuses, err := client.GetCommitStatuses(ctx, "owner", "repo", "ref")
+		commitStatuses, err := client.GetCommitStatuses(ctx, "owner", "repo", "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69")
 		assert.NoError(t, err)
 		assert.Len(t, commitStatuses, 3)
 		assert.Equal(t, InProgress, commitStatuses[0].State)
@@ -648,3 +1428,56 @@ func createBitbucketCloudHandler(t *testing.T, expectedURI string, response []by
 		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
 	}
 }
+
+func TestBitbucketCloudClient_PullRequestAttachments(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.UploadPullRequestAttachment(ctx, owner, repo1, 1, "report.json", []byte("{}"))
+	assert.ErrorIs(t, err, errBitbucketPullRequestAttachmentsNotSupported)
+	_, err = client.ListPullRequestAttachments(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketPullRequestAttachmentsNotSupported)
+}
+
+func TestBitbucketCloudClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.ErrorIs(t, err, errBitbucketCheckRunsNotSupported)
+	err = client.UpdateCheckRun(ctx, owner, repo1, 1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.ErrorIs(t, err, errBitbucketCheckRunsNotSupported)
+}
+
+func TestBitbucketCloudClient_CodeScanningAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListCodeScanningAlerts(ctx, owner, repo1, CodeScanningAlertsQueryOptions{})
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+	_, err = client.GetCodeScanningAlert(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+	err = client.DismissCodeScanningAlert(ctx, owner, repo1, 1, DismissFalsePositive, "")
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+}
+
+func TestBitbucketCloudClient_ListVulnerabilityAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListVulnerabilityAlerts(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketVulnerabilityAlertsNotSupported)
+}
+
+// Sets the OAuth2 token endpoint of Bitbucket Cloud, and returns a function restoring the original endpoint
+func setBitbucketCloudOAuthTokenURL(tokenURL string) func() {
+	originalTokenURL := bitbucketCloudOAuthTokenURL
+	bitbucketCloudOAuthTokenURL = tokenURL
+	return func() {
+		bitbucketCloudOAuthTokenURL = originalTokenURL
+	}
+}
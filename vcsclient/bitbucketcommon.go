@@ -1,29 +1,129 @@
 package vcsclient
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/mitchellh/mapstructure"
+	"net/http"
+	"regexp"
+	"strings"
 	"time"
 )
 
 const (
 	notSupportedOnBitbucket     = "currently not supported on Bitbucket"
 	bitbucketPrContentSizeLimit = 32768
+	// Bitbucket has no native pull request labels, so they are kept in a hidden markdown comment in the pull request description
+	bitbucketLabelsMarkerFormat = "[comment]: <> (froggit-labels: %s)"
 )
 
 var (
-	errLabelsNotSupported                                 = fmt.Errorf("labels are %s", notSupportedOnBitbucket)
 	errBitbucketCodeScanningNotSupported                  = fmt.Errorf("code scanning is %s", notSupportedOnBitbucket)
-	errBitbucketDownloadFileFromRepoNotSupported          = fmt.Errorf("download file from repo is %s", notSupportedOnBitbucket)
 	errBitbucketGetCommitsNotSupported                    = fmt.Errorf("get commits is %s", notSupportedOnBitbucket)
-	errBitbucketGetCommitsWithOptionsNotSupported         = fmt.Errorf("get commits with options is %s", notSupportedOnBitbucket)
 	errBitbucketGetRepoEnvironmentInfoNotSupported        = fmt.Errorf("get repository environment info is %s", notSupportedOnBitbucket)
+	errBitbucketCreateOrUpdateEnvironmentNotSupported     = fmt.Errorf("create or update environment is %s", notSupportedOnBitbucket)
 	errBitbucketListPullRequestReviewCommentsNotSupported = fmt.Errorf("list pull request review comments is %s", notSupportedOnBitbucket)
-	errBitbucketAddPullRequestReviewCommentsNotSupported  = fmt.Errorf("add pull request review comment is %s", notSupportedOnBitbucket)
 	errBitbucketDeletePullRequestComment                  = fmt.Errorf("delete pull request comment is %s", notSupportedOnBitbucket)
+	errBitbucketPullRequestAttachmentsNotSupported        = fmt.Errorf("pull request attachments are %s", notSupportedOnBitbucket)
+	errBitbucketCheckRunsNotSupported                     = fmt.Errorf("check runs are %s", notSupportedOnBitbucket)
+	errBitbucketVulnerabilityAlertsNotSupported           = fmt.Errorf("vulnerability alerts are %s", notSupportedOnBitbucket)
+	errBitbucketCloudReopenPullRequestNotSupported        = fmt.Errorf("reopen pull request is %s cloud", notSupportedOnBitbucket)
+	errBitbucketCloudAutoMergeNotSupported                = fmt.Errorf("auto merge is %s cloud", notSupportedOnBitbucket)
+	errBitbucketCloudArchiveRepositoryNotSupported        = fmt.Errorf("archiving repositories is %s cloud", notSupportedOnBitbucket)
+	errBitbucketBypassPoliciesNotSupported                = fmt.Errorf("bypassing the merge checks is %s", notSupportedOnBitbucket)
+	errBitbucketRepositoryMirrorsNotSupported             = fmt.Errorf("repository mirrors are %s", notSupportedOnBitbucket)
+	errBitbucketSearchRepositoriesByTopicNotSupported     = fmt.Errorf("searching repositories by topic is %s", notSupportedOnBitbucket)
+	errBitbucketCloudSearchCodeWithoutOwnerNotSupported   = fmt.Errorf("searching code without an owner is %s cloud", notSupportedOnBitbucket)
+	errBitbucketCloudDeployTokensNotSupported             = fmt.Errorf("creating repository access tokens is %s cloud, where the tokens are created in the repository settings", notSupportedOnBitbucket)
+	errBitbucketServerIssuesNotSupported                  = fmt.Errorf("issues are %s server", notSupportedOnBitbucket)
+	errBitbucketOutsideCollaboratorsNotSupported          = fmt.Errorf("listing the outside collaborators is %s, where the collaborators are the users granted a permission", notSupportedOnBitbucket)
 )
 
+var bitbucketLabelsMarkerRegexp = regexp.MustCompile(`(?m)^\[comment\]: <> \(froggit-labels: (.*)\)$\n?`)
+
+// getBitbucketPullRequestLabels extracts the labels stored in the pull request description
+func getBitbucketPullRequestLabels(description string) ([]string, error) {
+	labels := []string{}
+	match := bitbucketLabelsMarkerRegexp.FindStringSubmatch(description)
+	if match == nil {
+		return labels, nil
+	}
+	if err := json.Unmarshal([]byte(match[1]), &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse the pull request labels: %w", err)
+	}
+	return labels, nil
+}
+
+// setBitbucketPullRequestLabels replaces the labels stored in the pull request description.
+// The labels marker is removed from the description if no labels are provided.
+func setBitbucketPullRequestLabels(description string, labels []string) (string, error) {
+	description = stripBitbucketPullRequestLabels(description)
+	if len(labels) == 0 {
+		return description, nil
+	}
+	labelsBytes, err := json.Marshal(labels)
+	if err != nil {
+		return "", err
+	}
+	marker := fmt.Sprintf(bitbucketLabelsMarkerFormat, labelsBytes)
+	if description == "" {
+		return marker, nil
+	}
+	return description + "\n\n" + marker, nil
+}
+
+// stripBitbucketPullRequestLabels returns the pull request description without the labels marker
+func stripBitbucketPullRequestLabels(description string) string {
+	return strings.TrimRight(bitbucketLabelsMarkerRegexp.ReplaceAllString(description, ""), "\n")
+}
+
+// keepBitbucketPullRequestLabels returns the new pull request description, with the labels stored in the current description
+func keepBitbucketPullRequestLabels(currentDescription, newDescription string) (string, error) {
+	labels, err := getBitbucketPullRequestLabels(currentDescription)
+	if err != nil {
+		return "", err
+	}
+	return setBitbucketPullRequestLabels(newDescription, labels)
+}
+
+// addBitbucketPullRequestLabel adds a label to the pull request description.
+// Returns false if the label already exists.
+func addBitbucketPullRequestLabel(description, name string) (string, bool, error) {
+	labels, err := getBitbucketPullRequestLabels(description)
+	if err != nil {
+		return "", false, err
+	}
+	for _, label := range labels {
+		if label == name {
+			return description, false, nil
+		}
+	}
+	description, err = setBitbucketPullRequestLabels(description, append(labels, name))
+	return description, err == nil, err
+}
+
+// removeBitbucketPullRequestLabel removes a label from the pull request description.
+// Returns false if the label was not found.
+func removeBitbucketPullRequestLabel(description, name string) (string, bool, error) {
+	labels, err := getBitbucketPullRequestLabels(description)
+	if err != nil {
+		return "", false, err
+	}
+	remainingLabels := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label != name {
+			remainingLabels = append(remainingLabels, label)
+		}
+	}
+	if len(remainingLabels) == len(labels) {
+		return description, false, nil
+	}
+	description, err = setBitbucketPullRequestLabels(description, remainingLabels)
+	return description, err == nil, err
+}
+
 type BitbucketCommitInfo struct {
 	Title       string  `mapstructure:"key"`
 	Url         string  `mapstructure:"url"`
@@ -35,6 +135,15 @@ type BitbucketCommitInfo struct {
 	DateAdded   float64 `mapstructure:"DateAdded"`
 }
 
+// getBitbucketBuildKeyAndName returns the key and the name of a build status.
+// The key defaults to the title. When a key is provided, the title is the displayed name of the status.
+func getBitbucketBuildKeyAndName(title string, options CommitStatusOptions) (key, name string) {
+	if options.Key == "" {
+		return title, ""
+	}
+	return options.Key, title
+}
+
 func getBitbucketCommitState(commitState CommitStatus) string {
 	switch commitState {
 	case Pass:
@@ -84,6 +193,7 @@ func getBitbucketServerCommitStatusInfo(commitStatus *BitbucketCommitInfo) Commi
 	timeInNanoSec := (int64(commitStatus.DateAdded) - (timeInSec * int64(time.Microsecond))) * int64(time.Millisecond)
 	return CommitStatusInfo{
 		State:       commitStatusAsStringToStatus(commitStatus.State),
+		Context:     commitStatus.Title,
 		Description: commitStatus.Description,
 		DetailsUrl:  commitStatus.Url,
 		Creator:     commitStatus.Title,
@@ -110,6 +220,7 @@ func getBitbucketCloudCommitStatusInfo(commitStatus *BitbucketCommitInfo) (Commi
 
 	return CommitStatusInfo{
 		State:         commitStatusAsStringToStatus(commitStatus.State),
+		Context:       commitStatus.Title,
 		Description:   commitStatus.Description,
 		DetailsUrl:    commitStatus.Url,
 		Creator:       commitStatus.Creator,
@@ -117,3 +228,22 @@ func getBitbucketCloudCommitStatusInfo(commitStatus *BitbucketCommitInfo) (Commi
 		LastUpdatedAt: updatedOn,
 	}, nil
 }
+
+// getBitbucketFileMetadata sends the request for the metadata of a file, and decodes the metadata from the response.
+// Returns false if the file wasn't found.
+func getBitbucketFileMetadata(httpClient *http.Client, request *http.Request, metadata interface{}) (found bool, err error) {
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, vcsutils.DiscardResponseBody(response), response.Body.Close())
+	}()
+	if response.StatusCode == http.StatusNotFound {
+		return
+	}
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
+		return
+	}
+	return true, json.NewDecoder(response.Body).Decode(metadata)
+}
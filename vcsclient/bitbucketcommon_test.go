@@ -40,6 +40,7 @@ func TestBitbucketParseCommitStatuses(t *testing.T) {
 	expectedStatuses := []CommitStatusInfo{
 		{
 			State:       Pass,
+			Context:     "jenkins",
 			Description: "Build successful",
 			DetailsUrl:  "https://example.com/build/1234",
 			Creator:     "jenkins",
@@ -47,6 +48,7 @@ func TestBitbucketParseCommitStatuses(t *testing.T) {
 		},
 		{
 			State:       Fail,
+			Context:     "jenkins",
 			Description: "Build failed",
 			DetailsUrl:  "https://example.com/build/5678",
 			Creator:     "jenkins",
@@ -70,6 +72,7 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketServer(t *testing.T) {
 
 	expectedStatus := CommitStatusInfo{
 		State:       Pass,
+		Context:     "jenkins",
 		Description: "Build successful",
 		DetailsUrl:  "https://example.com/build/1234",
 		Creator:     "jenkins",
@@ -104,3 +107,55 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketCloud(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResult, result)
 }
+
+func TestBitbucketPullRequestLabels(t *testing.T) {
+	labels, err := getBitbucketPullRequestLabels("Pull request body")
+	assert.NoError(t, err)
+	assert.Empty(t, labels)
+
+	description, err := setBitbucketPullRequestLabels("Pull request body", []string{"label-1", "label (2)"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Pull request body\n\n[comment]: <> (froggit-labels: [\"label-1\",\"label (2)\"])", description)
+	labels, err = getBitbucketPullRequestLabels(description)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"label-1", "label (2)"}, labels)
+
+	description, removed, err := removeBitbucketPullRequestLabel(description, "label-1")
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, "Pull request body\n\n[comment]: <> (froggit-labels: [\"label (2)\"])", description)
+
+	_, removed, err = removeBitbucketPullRequestLabel(description, "label-1")
+	assert.NoError(t, err)
+	assert.False(t, removed)
+
+	description, removed, err = removeBitbucketPullRequestLabel(description, "label (2)")
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, "Pull request body", description)
+
+	description, added, err := addBitbucketPullRequestLabel(description, "label-3")
+	assert.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "Pull request body\n\n[comment]: <> (froggit-labels: [\"label-3\"])", description)
+
+	_, added, err = addBitbucketPullRequestLabel(description, "label-3")
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	_, err = getBitbucketPullRequestLabels("[comment]: <> (froggit-labels: not-a-json)")
+	assert.Error(t, err)
+}
+
+func TestKeepBitbucketPullRequestLabels(t *testing.T) {
+	currentDescription := "Old body\n\n[comment]: <> (froggit-labels: [\"label-1\"])"
+	assert.Equal(t, "Old body", stripBitbucketPullRequestLabels(currentDescription))
+
+	description, err := keepBitbucketPullRequestLabels(currentDescription, "New body")
+	assert.NoError(t, err)
+	assert.Equal(t, "New body\n\n[comment]: <> (froggit-labels: [\"label-1\"])", description)
+
+	description, err = keepBitbucketPullRequestLabels("Old body", "New body")
+	assert.NoError(t, err)
+	assert.Equal(t, "New body", description)
+}
@@ -6,16 +6,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/jfrog/gofrog/datastructures"
+	"html"
 	"io"
+	"math"
 	"net/http"
-	"sort"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	bitbucketv1 "github.com/gfleury/go-bitbucket-v1"
 	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/datastructures"
 	"github.com/mitchellh/mapstructure"
 	"golang.org/x/oauth2"
 )
@@ -24,10 +27,18 @@ import (
 type BitbucketServerClient struct {
 	vcsInfo VcsInfo
 	logger  vcsutils.Log
+	// Resolves the branch names passed to GetCommitStatuses, which requires a commit hash
+	branchHeads branchHeadResolver
+	// Caches the Bitbucket server version, which determines the endpoints used by some of the operations
+	serverVersion serverVersionResolver
 }
 
 // NewBitbucketServerClient create a new BitbucketServerClient
 func NewBitbucketServerClient(vcsInfo VcsInfo, logger vcsutils.Log) (*BitbucketServerClient, error) {
+	// Bitbucket API Endpoint ends with '/rest'
+	if !strings.HasSuffix(vcsInfo.APIEndpoint, "/rest") {
+		vcsInfo.APIEndpoint += "/rest"
+	}
 	bitbucketServerClient := &BitbucketServerClient{
 		vcsInfo: vcsInfo,
 		logger:  logger,
@@ -35,22 +46,26 @@ func NewBitbucketServerClient(vcsInfo VcsInfo, logger vcsutils.Log) (*BitbucketS
 	return bitbucketServerClient, nil
 }
 
-func (client *BitbucketServerClient) buildBitbucketClient(ctx context.Context) *bitbucketv1.DefaultApiService {
-	// Bitbucket API Endpoint ends with '/rest'
-	if !strings.HasSuffix(client.vcsInfo.APIEndpoint, "/rest") {
-		client.vcsInfo.APIEndpoint += "/rest"
-	}
-
-	bbClient := bitbucketv1.NewAPIClient(ctx, &bitbucketv1.Configuration{
+// Raw returns a go-bitbucket-v1 client, to call the endpoints which aren't wrapped by the BitbucketServerClient.
+// A new client is created for each call, since the client is bound to the context of its requests.
+// The client reuses the authentication, TLS configuration, HTTP tracing, metrics and trace propagation of the BitbucketServerClient.
+// The results aren't sorted.
+func (client *BitbucketServerClient) Raw(ctx context.Context) *bitbucketv1.APIClient {
+	ctx = labelAPICalls(ctx, "Raw")
+	return bitbucketv1.NewAPIClient(ctx, &bitbucketv1.Configuration{
 		HTTPClient: client.buildHTTPClient(ctx),
 		BasePath:   client.vcsInfo.APIEndpoint,
 	})
-	return bbClient.DefaultApi
+}
+
+func (client *BitbucketServerClient) buildBitbucketClient(ctx context.Context) *bitbucketv1.DefaultApiService {
+	return client.Raw(ctx).DefaultApi
 }
 
 func (client *BitbucketServerClient) buildHTTPClient(ctx context.Context) *http.Client {
-	httpClient := &http.Client{}
+	httpClient := newHTTPClient(vcsutils.BitbucketServer, client.vcsInfo, client.logger)
 	if client.vcsInfo.Token != "" {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: client.vcsInfo.Token}))
 	}
 	return httpClient
@@ -58,6 +73,7 @@ func (client *BitbucketServerClient) buildHTTPClient(ctx context.Context) *http.
 
 // TestConnection on Bitbucket server
 func (client *BitbucketServerClient) TestConnection(ctx context.Context) error {
+	ctx = labelAPICalls(ctx, "TestConnection")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 
 	options := map[string]interface{}{"limit": 1}
@@ -65,8 +81,51 @@ func (client *BitbucketServerClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// GetAuthenticatedUser on Bitbucket server
+func (client *BitbucketServerClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	ctx = labelAPICalls(ctx, "GetAuthenticatedUser")
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	// The username of the authenticated user is returned in a header of every response
+	apiResponse, err := bitbucketClient.GetUsers(map[string]interface{}{"limit": 1})
+	if err != nil {
+		return UserInfo{}, err
+	}
+	username := apiResponse.Header.Get("X-Ausername")
+	if username == "" {
+		return UserInfo{}, errors.New("X-Ausername header is missing")
+	}
+	apiResponse, err = bitbucketClient.GetUser(username)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	var user bitbucketv1.User
+	if err = mapstructure.Decode(apiResponse.Values, &user); err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{ID: strconv.Itoa(user.ID), Login: user.Name, DisplayName: user.DisplayName, Email: user.EmailAddress}, nil
+}
+
+// GetServerVersion on Bitbucket server
+func (client *BitbucketServerClient) GetServerVersion(ctx context.Context) (string, error) {
+	ctx = labelAPICalls(ctx, "GetServerVersion")
+	return client.serverVersion.resolve(ctx, func(ctx context.Context) (string, error) {
+		var applicationProperties struct {
+			Version string `json:"version"`
+		}
+		err := client.sendRequest(ctx, http.MethodGet, "/api/1.0/application-properties", nil, &applicationProperties)
+		return applicationProperties.Version, err
+	})
+}
+
+// Capabilities on Bitbucket server
+func (client *BitbucketServerClient) Capabilities(ctx context.Context) ([]Capability, error) {
+	ctx = labelAPICalls(ctx, "Capabilities")
+	return getServerCapabilities(ctx, vcsutils.BitbucketServer, client.GetServerVersion)
+}
+
 // ListRepositories on Bitbucket server
 func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	ctx = labelAPICalls(ctx, "ListRepositories")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	projects, err := client.listProjects(bitbucketClient)
 	if err != nil {
@@ -75,28 +134,248 @@ func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[
 
 	results := make(map[string][]string)
 	for _, project := range projects {
-		var apiResponse *bitbucketv1.APIResponse
-		for isLastReposPage, nextReposPageStart := true, 0; isLastReposPage; isLastReposPage, nextReposPageStart = bitbucketv1.HasNextPage(apiResponse) {
-			// Get all repositories for which the authenticated user has the REPO_READ permission
-			apiResponse, err = bitbucketClient.GetRepositoriesWithOptions(project, createPaginationOptions(nextReposPageStart))
-			if err != nil {
-				return nil, err
-			}
+		repos, err := listProjectRepositories(bitbucketClient, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			results[project] = append(results[project], repo.Slug)
+		}
+	}
+	return orderRepositories(client.vcsInfo, results), nil
+}
 
-			repos, err := bitbucketv1.GetRepositoriesResponse(apiResponse)
+// ListRepositoryListings on Bitbucket server.
+// The default branches are read by a request per repository.
+func (client *BitbucketServerClient) ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryListings")
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	namespaces, err := client.listNamespaces(bitbucketClient)
+	if err != nil {
+		return nil, err
+	}
+	var listings []RepositoryListing
+	for _, namespace := range namespaces {
+		repos, err := listProjectRepositories(bitbucketClient, namespace.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			defaultBranch, err := getBitbucketServerDefaultBranch(bitbucketClient, namespace.Name, repo.Slug)
 			if err != nil {
 				return nil, err
 			}
-			for _, repo := range repos {
-				results[project] = append(results[project], repo.Slug)
+			listings = append(listings, RepositoryListing{
+				Owner:         namespace.Name,
+				OwnerType:     namespace.Kind,
+				Name:          repo.Slug,
+				Visibility:    getBitbucketServerRepositoryVisibility(repo.Public),
+				DefaultBranch: defaultBranch,
+			})
+		}
+	}
+	return orderRepositoryListings(client.vcsInfo, listings), nil
+}
+
+// SearchRepositories on Bitbucket server, by the name filter of the repositories API.
+// The default branches are read by a request per repository.
+func (client *BitbucketServerClient) SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "SearchRepositories")
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if options.Topic != "" {
+		return nil, errBitbucketSearchRepositoriesByTopicNotSupported
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	var repos []bitbucketv1.Repository
+	var err error
+	if options.Owner != "" {
+		// The repositories API filters by the project name, rather than by the project key
+		repos, err = listProjectRepositories(bitbucketClient, options.Owner)
+	} else {
+		repos, err = searchBitbucketServerRepositories(bitbucketClient, options.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var listings []RepositoryListing
+	for _, repo := range repos {
+		name := strings.ToLower(options.Name)
+		if !strings.Contains(strings.ToLower(repo.Name), name) && !strings.Contains(strings.ToLower(repo.Slug), name) {
+			continue
+		}
+		defaultBranch, err := getBitbucketServerDefaultBranch(bitbucketClient, repo.Project.Key, repo.Slug)
+		if err != nil {
+			return nil, err
+		}
+		ownerType := ProjectNamespace
+		if strings.HasPrefix(repo.Project.Key, "~") {
+			ownerType = UserNamespace
+		}
+		listings = append(listings, RepositoryListing{
+			Owner:         vcsutils.NormalizeBitbucketServerOwner(repo.Project.Key),
+			OwnerType:     ownerType,
+			Name:          repo.Slug,
+			Visibility:    getBitbucketServerRepositoryVisibility(repo.Public),
+			DefaultBranch: defaultBranch,
+		})
+	}
+	return listings, nil
+}
+
+// SearchCode on Bitbucket server, by the search API of the code search, which requires the search server of Bitbucket.
+// The owner is the key of a project.
+func (client *BitbucketServerClient) SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error) {
+	ctx = labelAPICalls(ctx, "SearchCode")
+	if err := options.validate(query); err != nil {
+		return nil, err
+	}
+	if options.Owner != "" {
+		query += " project:" + options.Owner
+	}
+	if options.Repository != "" {
+		query += " repo:" + options.Repository
+	}
+	var results []CodeSearchResult
+	for start, isLastPage := 0, false; !isLastPage; {
+		requestBody := map[string]interface{}{
+			"query":    query,
+			"entities": map[string]interface{}{"code": map[string]int{"start": start, "limit": 100}},
+		}
+		var searchResponse struct {
+			Code struct {
+				IsLastPage bool                              `json:"isLastPage"`
+				NextStart  int                               `json:"nextStart"`
+				Values     []bitbucketServerCodeSearchResult `json:"values"`
+			} `json:"code"`
+		}
+		if err := client.sendRequest(ctx, http.MethodPost, "/search/latest/search", requestBody, &searchResponse); err != nil {
+			return nil, err
+		}
+		for _, searchResult := range searchResponse.Code.Values {
+			results = append(results, searchResult.toCodeSearchResults()...)
+		}
+		start, isLastPage = searchResponse.Code.NextStart, searchResponse.Code.IsLastPage || len(searchResponse.Code.Values) == 0
+	}
+	return results, nil
+}
+
+type bitbucketServerCodeSearchResult struct {
+	Repository  bitbucketv1.Repository `json:"repository"`
+	File        string                 `json:"file"`
+	HitContexts [][]struct {
+		Line int    `json:"line"`
+		Text string `json:"text"`
+	} `json:"hitContexts"`
+}
+
+// The search server highlights the matches in the lines of the hit contexts, which are HTML escaped
+var bitbucketServerSearchHighlightReplacer = strings.NewReplacer("<em>", "", "</em>", "")
+
+// toCodeSearchResults returns a result per hit context in the file, or a single result if only the path of the file matches
+func (searchResult bitbucketServerCodeSearchResult) toCodeSearchResults() []CodeSearchResult {
+	result := CodeSearchResult{Repository: searchResult.Repository.Slug, Path: searchResult.File}
+	if searchResult.Repository.Project != nil {
+		result.Owner = vcsutils.NormalizeBitbucketServerOwner(searchResult.Repository.Project.Key)
+	}
+	if len(searchResult.HitContexts) == 0 {
+		return []CodeSearchResult{result}
+	}
+	results := make([]CodeSearchResult, 0, len(searchResult.HitContexts))
+	for _, hitContext := range searchResult.HitContexts {
+		lines := make([]string, 0, len(hitContext))
+		for i, line := range hitContext {
+			if i == 0 {
+				result.Line = line.Line
 			}
+			lines = append(lines, html.UnescapeString(bitbucketServerSearchHighlightReplacer.Replace(line.Text)))
 		}
+		result.Fragment = strings.Join(lines, "\n")
+		results = append(results, result)
+	}
+	return results
+}
+
+func searchBitbucketServerRepositories(bitbucketClient *bitbucketv1.DefaultApiService, name string) ([]bitbucketv1.Repository, error) {
+	var results []bitbucketv1.Repository
+	var apiResponse *bitbucketv1.APIResponse
+	var err error
+	for isLastReposPage, nextReposPageStart := true, 0; isLastReposPage; isLastReposPage, nextReposPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		options := createPaginationOptions(nextReposPageStart)
+		options["name"] = name
+		apiResponse, err = bitbucketClient.GetRepositories_19(options)
+		if err != nil {
+			return nil, err
+		}
+		repos, err := bitbucketv1.GetRepositoriesResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, repos...)
+	}
+	return results, nil
+}
+
+// Get all repositories of the project for which the authenticated user has the REPO_READ permission
+func listProjectRepositories(bitbucketClient *bitbucketv1.DefaultApiService, project string) ([]bitbucketv1.Repository, error) {
+	var results []bitbucketv1.Repository
+	var apiResponse *bitbucketv1.APIResponse
+	var err error
+	for isLastReposPage, nextReposPageStart := true, 0; isLastReposPage; isLastReposPage, nextReposPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		apiResponse, err = bitbucketClient.GetRepositoriesWithOptions(project, createPaginationOptions(nextReposPageStart))
+		if err != nil {
+			return nil, err
+		}
+		repos, err := bitbucketv1.GetRepositoriesResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, repos...)
 	}
 	return results, nil
 }
 
+// getBitbucketServerDefaultBranch returns the display ID of the default branch of a repository, or an empty string if the repository is empty
+func getBitbucketServerDefaultBranch(bitbucketClient *bitbucketv1.DefaultApiService, project, repository string) (string, error) {
+	apiResponse, err := bitbucketClient.GetDefaultBranch(project, repository)
+	if err != nil {
+		if apiResponse != nil && apiResponse.Response != nil && apiResponse.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	defaultBranch := &bitbucketv1.Branch{}
+	if err = unmarshalAPIResponseValues(apiResponse, defaultBranch); err != nil {
+		return "", err
+	}
+	return defaultBranch.DisplayID, nil
+}
+
+// ListNamespaces on Bitbucket server returns the projects of the user, including the personal project of the user
+func (client *BitbucketServerClient) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	ctx = labelAPICalls(ctx, "ListNamespaces")
+	namespaces, err := client.listNamespaces(client.buildBitbucketClient(ctx))
+	return orderNamespaces(client.vcsInfo, namespaces), err
+}
+
 // ListBranches on Bitbucket server
 func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranches")
+	return client.ListBranchesWithOptions(ctx, owner, repository, ListBranchesOptions{})
+}
+
+// ListBranchesWithOptions on Bitbucket server.
+// The protected branches are the branches with branch permissions restricting the changes to them, as in GetBranchInfo.
+func (client *BitbucketServerClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListBranchesOptions) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranchesWithOptions")
+	var restrictedBranches *datastructures.Set[string]
+	if options.ProtectedOnly {
+		var err error
+		if restrictedBranches, err = client.listRestrictedBranches(ctx, owner, repository); err != nil {
+			return nil, err
+		}
+	}
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	var results []string
 	var apiResponse *bitbucketv1.APIResponse
@@ -112,15 +391,106 @@ func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, re
 		}
 
 		for _, branch := range branches {
+			if options.ProtectedOnly && !restrictedBranches.Exists(branch.ID) {
+				continue
+			}
 			results = append(results, branch.ID)
 		}
 	}
 
-	return results, nil
+	return orderNames(client.vcsInfo, results), nil
+}
+
+// listRestrictedBranches returns the IDs of the branches matched by name by the branch permissions of a repository, such as refs/heads/main
+func (client *BitbucketServerClient) listRestrictedBranches(ctx context.Context, owner, repository string) (*datastructures.Set[string], error) {
+	restrictedBranches := datastructures.MakeSet[string]()
+	for start := 0; ; {
+		var restrictions struct {
+			Values []struct {
+				Matcher struct {
+					ID string `json:"id"`
+				} `json:"matcher"`
+			} `json:"values"`
+			IsLastPage    bool `json:"isLastPage"`
+			NextPageStart int  `json:"nextPageStart"`
+		}
+		path := fmt.Sprintf("/branch-permissions/2.0/projects/%s/repos/%s/restrictions?matcherType=BRANCH&start=%d", owner, repository, start)
+		if err := client.sendRequest(ctx, http.MethodGet, path, nil, &restrictions); err != nil {
+			return nil, err
+		}
+		for _, restriction := range restrictions.Values {
+			restrictedBranches.Add(restriction.Matcher.ID)
+		}
+		if restrictions.IsLastPage {
+			return restrictedBranches, nil
+		}
+		start = restrictions.NextPageStart
+	}
+}
+
+// GetBranchInfo on Bitbucket server.
+// The branch is protected if it has branch permissions restricting the changes to it.
+func (client *BitbucketServerClient) GetBranchInfo(ctx context.Context, owner, repository, branch string) (RepositoryBranchInfo, error) {
+	ctx = labelAPICalls(ctx, "GetBranchInfo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	var apiResponse *bitbucketv1.APIResponse
+	var branchDetails *bitbucketv1.Branch
+	for isLastPage, nextPageStart := true, 0; isLastPage && branchDetails == nil; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		var err error
+		options := createPaginationOptions(nextPageStart)
+		options["filterText"] = branch
+		if apiResponse, err = bitbucketClient.GetBranches(owner, repository, options); err != nil {
+			return RepositoryBranchInfo{}, err
+		}
+		branches, err := bitbucketv1.GetBranchesResponse(apiResponse)
+		if err != nil {
+			return RepositoryBranchInfo{}, err
+		}
+		for i := range branches {
+			if branches[i].DisplayID == branch {
+				branchDetails = &branches[i]
+				break
+			}
+		}
+	}
+	if branchDetails == nil {
+		return RepositoryBranchInfo{}, fmt.Errorf("branch %s wasn't found in %s/%s", branch, owner, repository)
+	}
+	var restrictions struct {
+		Size int `json:"size"`
+	}
+	path := fmt.Sprintf("/branch-permissions/2.0/projects/%s/repos/%s/restrictions?matcherType=BRANCH&matcherId=%s", owner, repository, url.QueryEscape(branchDetails.ID))
+	if err := client.sendRequest(ctx, http.MethodGet, path, nil, &restrictions); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	return RepositoryBranchInfo{
+		Name:           branchDetails.DisplayID,
+		HeadCommitHash: branchDetails.LatestCommit,
+		Protected:      restrictions.Size > 0,
+		Default:        branchDetails.IsDefault,
+	}, nil
+}
+
+// GetDefaultBranch on Bitbucket server
+func (client *BitbucketServerClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	ctx = labelAPICalls(ctx, "GetDefaultBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	return getBitbucketServerDefaultBranch(client.buildBitbucketClient(ctx), owner, repository)
+}
+
+// SquashBranchIntoSingleCommit on Bitbucket server
+func (client *BitbucketServerClient) SquashBranchIntoSingleCommit(_ context.Context, _, _, _, _, _ string) (string, error) {
+	return "", ErrBranchSquashNotSupported
 }
 
 // AddSshKeyToRepository on Bitbucket server
 func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) (err error) {
+	ctx = labelAPICalls(ctx, "AddSshKeyToRepository")
 	// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ssh-rest.html
 	err = validateParametersNotBlank(map[string]string{
 		"owner":      owner,
@@ -184,9 +554,82 @@ type bitbucketServerSSHKey struct {
 	Label string `json:"label"`
 }
 
+// CreateDeployToken on Bitbucket server creates a repository HTTP access token, which is owned by a bot user of the repository.
+// The write scope includes the read scope. Creating the token requires the admin permission on the repository.
+func (client *BitbucketServerClient) CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error) {
+	ctx = labelAPICalls(ctx, "CreateDeployToken")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return DeployTokenInfo{}, err
+	}
+	if !supportsServerVersion(ctx, client.GetServerVersion, bitbucketServerRepositoryAccessTokensMinimalVersion, client.logger) {
+		return DeployTokenInfo{}, fmt.Errorf("repository access tokens require Bitbucket server %s or above", bitbucketServerRepositoryAccessTokensMinimalVersion)
+	}
+	permission, err := getBitbucketServerAccessTokenPermission(scopes)
+	if err != nil {
+		return DeployTokenInfo{}, err
+	}
+	tokenRequest := bitbucketServerAccessTokenRequest{Name: name, Permissions: []string{permission}}
+	if !expiresAt.IsZero() {
+		// The expiration is set in days, so it's rounded up to the next day
+		tokenRequest.ExpiryDays = int(math.Ceil(time.Until(expiresAt).Hours() / 24))
+		if tokenRequest.ExpiryDays < 1 {
+			return DeployTokenInfo{}, errors.New("the expiration time of the token must be in the future")
+		}
+	}
+	var accessToken bitbucketServerAccessToken
+	path := fmt.Sprintf("/access-tokens/latest/projects/%s/repos/%s", owner, repository)
+	if err = client.sendRequest(ctx, http.MethodPut, path, tokenRequest, &accessToken); err != nil {
+		return DeployTokenInfo{}, err
+	}
+	tokenInfo := DeployTokenInfo{ID: accessToken.ID, Name: accessToken.Name, Username: accessToken.User.Name, Token: accessToken.Token}
+	if accessToken.ExpiryDays > 0 {
+		tokenInfo.ExpiresAt = time.UnixMilli(accessToken.CreatedDate).AddDate(0, 0, accessToken.ExpiryDays).UTC()
+	}
+	return tokenInfo, nil
+}
+
+// getBitbucketServerAccessTokenPermission returns the repository permission of an access token with the scopes
+func getBitbucketServerAccessTokenPermission(scopes []DeployTokenScope) (string, error) {
+	permission := ""
+	for _, scope := range scopes {
+		switch scope {
+		case ReadRepositoryScope:
+			if permission == "" {
+				permission = "REPO_READ"
+			}
+		case WriteRepositoryScope:
+			permission = "REPO_WRITE"
+		default:
+			return "", fmt.Errorf("the %s scope of access tokens is %s server", scope, notSupportedOnBitbucket)
+		}
+	}
+	if permission == "" {
+		return "", errors.New("at least one scope of the token is required")
+	}
+	return permission, nil
+}
+
+type bitbucketServerAccessTokenRequest struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	ExpiryDays  int      `json:"expiryDays,omitempty"`
+}
+
+type bitbucketServerAccessToken struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Token       string `json:"token"`
+	CreatedDate int64  `json:"createdDate"`
+	ExpiryDays  int    `json:"expiryDays"`
+	User        struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
 // CreateWebhook on Bitbucket server
 func (client *BitbucketServerClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
 	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateWebhook")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	token := vcsutils.CreateToken()
 	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
@@ -201,14 +644,20 @@ func (client *BitbucketServerClient) CreateWebhook(ctx context.Context, owner, r
 	return webhoodID, token, err
 }
 
-// UpdateWebhook on Bitbucket server
+// UpdateWebhook on Bitbucket server. The webhook configuration is replaced by the update, so without a token the configured secret is kept.
 func (client *BitbucketServerClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateWebhook")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
 	if err != nil {
 		return err
 	}
+	if token == "" {
+		if token, err = getBitbucketServerWebhookSecret(bitbucketClient, owner, repository, int32(webhookIDInt32)); err != nil {
+			return err
+		}
+	}
 	hook := createBitbucketServerHook(token, payloadURL, webhookEvents...)
 	_, err = bitbucketClient.UpdateWebhook(owner, repository, int32(webhookIDInt32), hook, []string{})
 	return err
@@ -216,6 +665,7 @@ func (client *BitbucketServerClient) UpdateWebhook(ctx context.Context, owner, r
 
 // DeleteWebhook on Bitbucket server
 func (client *BitbucketServerClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteWebhook")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
 	if err != nil {
@@ -225,13 +675,42 @@ func (client *BitbucketServerClient) DeleteWebhook(ctx context.Context, owner, r
 	return err
 }
 
+// CreateGroupWebhook on Bitbucket server
+func (client *BitbucketServerClient) CreateGroupWebhook(_ context.Context, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", ErrGroupWebhooksNotSupported
+}
+
+// UpdateGroupWebhook on Bitbucket server
+func (client *BitbucketServerClient) UpdateGroupWebhook(_ context.Context, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return ErrGroupWebhooksNotSupported
+}
+
+// DeleteGroupWebhook on Bitbucket server
+func (client *BitbucketServerClient) DeleteGroupWebhook(_ context.Context, _, _ string) error {
+	return ErrGroupWebhooksNotSupported
+}
+
 // SetCommitStatus on Bitbucket server
-func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, _, _, ref, title,
+func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title,
 	description, detailsURL string) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatus")
+	return client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, CommitStatusOptions{})
+}
+
+// SetCommitStatusWithOptions on Bitbucket server, where the build key identifies the status
+func (client *BitbucketServerClient) SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, _, _, ref, title,
+	description, detailsURL string, options CommitStatusOptions) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatusWithOptions")
+	description, err := encodeCommitStatusDescription(description, options.Metadata)
+	if err != nil {
+		return err
+	}
 	bitbucketClient := client.buildBitbucketClient(ctx)
-	_, err := bitbucketClient.SetCommitStatus(ref, bitbucketv1.BuildStatus{
+	key, name := getBitbucketBuildKeyAndName(title, options)
+	_, err = bitbucketClient.SetCommitStatus(ref, bitbucketv1.BuildStatus{
 		State:       getBitbucketCommitState(commitStatus),
-		Key:         title,
+		Key:         key,
+		Name:        name,
 		Description: description,
 		Url:         detailsURL,
 	})
@@ -240,6 +719,11 @@ func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commit
 
 // GetCommitStatuses on Bitbucket server
 func (client *BitbucketServerClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetCommitStatuses")
+	ref, err = client.branchHeads.resolve(ctx, owner, repository, ref, client.GetLatestCommit)
+	if err != nil {
+		return nil, err
+	}
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	response, err := bitbucketClient.GetCommitStatus(ref)
 	if err != nil {
@@ -248,24 +732,49 @@ func (client *BitbucketServerClient) GetCommitStatuses(ctx context.Context, owne
 	return bitbucketParseCommitStatuses(response.Values, vcsutils.BitbucketServer)
 }
 
+// CreateCheckRun on Bitbucket server
+func (client *BitbucketServerClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errBitbucketCheckRunsNotSupported
+}
+
+// UpdateCheckRun on Bitbucket server
+func (client *BitbucketServerClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errBitbucketCheckRunsNotSupported
+}
+
 // DownloadRepository on Bitbucket server
-func (client *BitbucketServerClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+// The ref may be a branch, a tag or a commit hash. An empty ref downloads the default branch of the repository.
+func (client *BitbucketServerClient) DownloadRepository(ctx context.Context, owner, repository, ref, localPath string) error {
+	ctx = labelAPICalls(ctx, "DownloadRepository")
+	return client.DownloadRepositoryWithOptions(ctx, owner, repository, ref, localPath, DownloadRepositoryOptions{})
+}
+
+// DownloadRepositoryWithOptions on Bitbucket server
+// The ref may be a branch, a tag or a commit hash. An empty ref downloads the default branch of the repository.
+func (client *BitbucketServerClient) DownloadRepositoryWithOptions(ctx context.Context, owner, repository, ref, localPath string, options DownloadRepositoryOptions) error {
+	ctx = labelAPICalls(ctx, "DownloadRepositoryWithOptions")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	params := map[string]interface{}{"format": "tgz"}
-	branch = strings.TrimSpace(branch)
-	if branch != "" {
-		params["at"] = branch
+	if ref = strings.TrimSpace(ref); ref != "" {
+		params["at"] = ref
+	}
+	if includedDir := options.Filter.GetIncludedDir(); includedDir != "" {
+		params["path"] = includedDir
 	}
 	response, err := bitbucketClient.GetArchive(owner, repository, params)
 	if err != nil {
 		return err
 	}
 	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, bytes.NewReader(response.Payload), false)
+	stats, err := vcsutils.UntarWithStats(localPath, bytes.NewReader(response.Payload), false, options.Filter, options.ExtractionProgress)
 	if err != nil {
 		return err
 	}
+	reportRepositoryExtraction(client.logger, options, stats)
 	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	if client.vcsInfo.SkipDotGitCreation {
+		return nil
+	}
 	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
@@ -288,11 +797,12 @@ func (client *BitbucketServerClient) GetPullRequestDetailsSizeLimit() int {
 // CreatePullRequest on Bitbucket server
 func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
 	title, description string) error {
+	ctx = labelAPICalls(ctx, "CreatePullRequest")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	bitbucketRepo := &bitbucketv1.Repository{
 		Slug: repository,
 		Project: &bitbucketv1.Project{
-			Key: owner,
+			Key: vcsutils.NormalizeBitbucketServerOwner(owner),
 		},
 	}
 	options := bitbucketv1.PullRequest{
@@ -313,41 +823,165 @@ func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owne
 
 // UpdatePullRequest on bitbucket server
 // Changing targetBranchRef currently not supported.
+// The labels, which are kept in the pull request description, are kept in the new body.
 func (client *BitbucketServerClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchRef string, prId int, state vcsutils.PullRequestState) (err error) {
+	ctx = labelAPICalls(ctx, "UpdatePullRequest")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, prId)
 	if err != nil {
 		return
 	}
-	version := apiResponse.Values["version"]
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return
+	}
+	description, err := keepBitbucketPullRequestLabels(pullRequest.Description, body)
+	if err != nil {
+		return
+	}
 	editOptions := bitbucketv1.EditPullRequestOptions{
-		Version:     fmt.Sprintf("%v", version),
+		Version:     fmt.Sprintf("%v", pullRequest.Version),
 		ID:          int64(prId),
 		State:       *vcsutils.MapPullRequestState(&state),
 		Title:       title,
-		Description: body,
+		Description: description,
 	}
 	_, err = bitbucketClient.UpdatePullRequest(owner, repository, &editOptions)
 	return err
 }
 
+// ClosePullRequest on Bitbucket server
+func (client *BitbucketServerClient) ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ClosePullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Closed)
+}
+
+// ReopenPullRequest on Bitbucket server
+func (client *BitbucketServerClient) ReopenPullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ReopenPullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Open)
+}
+
+// EnablePullRequestAutoMerge on Bitbucket server sets the pull request to be merged when its merge checks pass
+func (client *BitbucketServerClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod MergeMethod) error {
+	ctx = labelAPICalls(ctx, "EnablePullRequestAutoMerge")
+	if !supportsServerVersion(ctx, client.GetServerVersion, bitbucketServerAutoMergeMinimalVersion, client.logger) {
+		return fmt.Errorf("auto merge requires Bitbucket server %s or above", bitbucketServerAutoMergeMinimalVersion)
+	}
+	autoMergeRequest := struct {
+		StrategyID string `json:"strategyId,omitempty"`
+	}{StrategyID: bitbucketServerMergeStrategies[mergeMethod]}
+	client.logger.Debug(vcsutils.EnablingAutoMerge, pullRequestID)
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", owner, repository, pullRequestID)
+	return client.sendRequest(ctx, http.MethodPost, path, autoMergeRequest, nil)
+}
+
+// MergePullRequest on Bitbucket server
+func (client *BitbucketServerClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error {
+	ctx = labelAPICalls(ctx, "MergePullRequest")
+	if options.BypassPolicies {
+		return errBitbucketBypassPoliciesNotSupported
+	}
+	// Merging requires the current pull request version
+	apiResponse, err := client.buildBitbucketClient(ctx).GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return err
+	}
+	mergeRequest := struct {
+		Message    string `json:"message,omitempty"`
+		StrategyID string `json:"strategyId,omitempty"`
+	}{Message: options.commitMessage(), StrategyID: bitbucketServerMergeStrategies[options.Method]}
+	client.logger.Debug(vcsutils.MergingPullRequest, pullRequestID)
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge?version=%d", owner, repository, pullRequestID, pullRequest.Version)
+	if err = client.sendRequest(ctx, http.MethodPost, path, mergeRequest, nil); err != nil || !options.DeleteSourceBranch {
+		return err
+	}
+
+	// The source branch may be in a forked repository
+	sourceOwner, sourceRepository := owner, repository
+	if fromRepository := pullRequest.FromRef.Repository; fromRepository.Project != nil && fromRepository.Slug != "" {
+		sourceOwner, sourceRepository = fromRepository.Project.Key, fromRepository.Slug
+	}
+	deleteBranchRequest := map[string]interface{}{"name": pullRequest.FromRef.ID, "dryRun": false}
+	path = fmt.Sprintf("/branch-utils/1.0/projects/%s/repos/%s/branches", sourceOwner, sourceRepository)
+	if err = client.sendRequest(ctx, http.MethodDelete, path, deleteBranchRequest, nil); err != nil {
+		return fmt.Errorf("pull request %d was merged, but its source branch wasn't deleted: %w", pullRequestID, err)
+	}
+	return nil
+}
+
+// UpdatePullRequestBranch on Bitbucket server
+func (client *BitbucketServerClient) UpdatePullRequestBranch(_ context.Context, _, _ string, _ int) error {
+	return ErrPullRequestBranchUpdateNotSupported
+}
+
+// Bitbucket server declines and reopens pull requests through dedicated endpoints, which require the current pull request version
+func (client *BitbucketServerClient) setPullRequestState(ctx context.Context, owner, repository string, pullRequestID int, state vcsutils.PullRequestState) error {
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return err
+	}
+	currentState := vcsutils.Open
+	if pullRequest.State == "DECLINED" {
+		currentState = vcsutils.Closed
+	}
+	if err = validatePullRequestStateTransition(pullRequestID, pullRequest.State == "MERGED", currentState, state); err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, pullRequestID)
+	versionParam := map[string]interface{}{"version": pullRequest.Version}
+	if state == vcsutils.Closed {
+		_, err = bitbucketClient.Decline(owner, repository, int64(pullRequestID), versionParam)
+	} else {
+		_, err = bitbucketClient.Reopen(owner, repository, int64(pullRequestID), versionParam)
+	}
+	return err
+}
+
 // ListOpenPullRequestsWithBody on Bitbucket server
 func (client *BitbucketServerClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithBody")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{WithBody: true})
 }
 
 // ListOpenPullRequests on Bitbucket server
 func (client *BitbucketServerClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequests")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{})
+}
+
+// ListOpenPullRequestsWithQueryOptions on Bitbucket server
+func (client *BitbucketServerClient) ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithQueryOptions")
+	return client.getOpenPullRequests(ctx, owner, repository, options)
 }
 
-func (client *BitbucketServerClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
+func (client *BitbucketServerClient) getOpenPullRequests(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	var results []PullRequestInfo
 	var apiResponse *bitbucketv1.APIResponse
-	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+	for hasNextPage, nextPageStart := true, 0; hasNextPage; hasNextPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
 		var err error
-		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, createPaginationOptions(nextPageStart))
+		paginationOptions := createPaginationOptions(nextPageStart)
+		if options.PerPage > 0 {
+			// All the pages are fetched to order the pull requests, by requests of the size of the requested page
+			paginationOptions["limit"] = options.PerPage
+		}
+		if options.TargetBranch != "" {
+			// The API filters by a single branch, so the source branch is filtered below
+			paginationOptions["at"] = vcsutils.AddBranchPrefix(options.TargetBranch)
+			paginationOptions["direction"] = "INCOMING"
+		}
+		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, paginationOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -357,20 +991,71 @@ func (client *BitbucketServerClient) getOpenPullRequests(ctx context.Context, ow
 			return nil, err
 		}
 		for _, pullRequest := range pullRequests {
-			if pullRequest.Open {
+			if pullRequest.Open && (options.SourceBranch == "" || pullRequest.FromRef.DisplayID == options.SourceBranch) {
 				var pullRequestInfo PullRequestInfo
-				if pullRequestInfo, err = mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, withBody, owner); err != nil {
+				if pullRequestInfo, err = client.mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, options.WithBody, owner); err != nil {
 					return nil, err
 				}
 				results = append(results, pullRequestInfo)
 			}
 		}
 	}
-	return results, nil
+	return paginate(orderPullRequests(client.vcsInfo, results), options.ListOptions), nil
+}
+
+// ListPullRequests on Bitbucket server. The pull requests are filtered by the source branch and the author, and sorted, by the client.
+func (client *BitbucketServerClient) ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequests")
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	state := "OPEN"
+	switch options.State {
+	case PullRequestsClosed:
+		state = "DECLINED"
+	case PullRequestsMerged:
+		state = "MERGED"
+	case PullRequestsAll:
+		state = "ALL"
+	}
+	var results []PullRequestInfo
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		var err error
+		paginationOptions := createPaginationOptions(nextPageStart)
+		paginationOptions["state"] = state
+		if options.TargetBranch != "" {
+			// The API filters by a single branch, so the source branch is filtered below
+			paginationOptions["at"] = vcsutils.AddBranchPrefix(options.TargetBranch)
+			paginationOptions["direction"] = "INCOMING"
+		}
+		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, paginationOptions)
+		if err != nil {
+			return nil, err
+		}
+		var pullRequests []bitbucketv1.PullRequest
+		pullRequests, err = bitbucketv1.GetPullRequestsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequests {
+			if options.SourceBranch != "" && pullRequest.FromRef.DisplayID != options.SourceBranch {
+				continue
+			}
+			var pullRequestInfo PullRequestInfo
+			if pullRequestInfo, err = client.mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, options.WithBody, owner); err != nil {
+				return nil, err
+			}
+			if options.Author == "" || pullRequestInfo.Author == options.Author {
+				results = append(results, pullRequestInfo)
+			}
+		}
+	}
+	sortPullRequests(results, options)
+	return paginate(results, options.ListOptions), nil
 }
 
 // GetPullRequestInfoById on bitbucket server
 func (client *BitbucketServerClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestByID")
 	client.logger.Debug("fetching pull request by ID in ", repository)
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestId)
@@ -386,35 +1071,74 @@ func (client *BitbucketServerClient) GetPullRequestByID(ctx context.Context, own
 	if err != nil {
 		return
 	}
-	pullRequestInfo, err = mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, false, owner)
+	pullRequestInfo, err = client.mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, false, owner)
 	return
 }
 
-func mapBitbucketServerPullRequestToPullRequestInfo(pullRequest bitbucketv1.PullRequest, withBody bool, owner string) (PullRequestInfo, error) {
+func (client *BitbucketServerClient) mapBitbucketServerPullRequestToPullRequestInfo(pullRequest bitbucketv1.PullRequest, withBody bool, owner string) (PullRequestInfo, error) {
 	sourceOwner, err := getSourceRepositoryOwner(pullRequest)
 	if err != nil {
 		return PullRequestInfo{}, err
 	}
 	var body string
 	if withBody {
-		body = pullRequest.Description
+		body = stripBitbucketPullRequestLabels(pullRequest.Description)
+	}
+	var author string
+	if pullRequest.Author != nil {
+		author = pullRequest.Author.User.Name
+	}
+	var updatedAt time.Time
+	if pullRequest.UpdatedDate > 0 {
+		updatedAt = time.UnixMilli(pullRequest.UpdatedDate).UTC()
+	}
+	// The self link is the web URL of the pull request
+	htmlURL := fmt.Sprintf("%s/pull-requests/%d", client.getRepositoryWebURL(owner, pullRequest.ToRef.Repository.Slug), pullRequest.ID)
+	if len(pullRequest.Links.Self) > 0 {
+		htmlURL = pullRequest.Links.Self[0].Href
 	}
 	return PullRequestInfo{
-		ID:     int64(pullRequest.ID),
-		Source: BranchInfo{Name: pullRequest.FromRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: sourceOwner},
-		Target: BranchInfo{Name: pullRequest.ToRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: owner},
-		Body:   body,
-		URL:    pullRequest.Links.Self[0].Href,
+		ID:             int64(pullRequest.ID),
+		Source:         BranchInfo{Name: pullRequest.FromRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: vcsutils.NormalizeBitbucketServerOwner(sourceOwner)},
+		Target:         BranchInfo{Name: pullRequest.ToRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: vcsutils.NormalizeBitbucketServerOwner(owner)},
+		Body:           body,
+		URL:            htmlURL,
+		HTMLURL:        htmlURL,
+		HeadCommitHash: pullRequest.FromRef.LatestCommit,
+		Author:         author,
+		UpdatedAt:      updatedAt,
 	}, nil
 }
 
+// CreateIssue on Bitbucket server
+func (client *BitbucketServerClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	return IssueInfo{}, errBitbucketServerIssuesNotSupported
+}
+
+// ListIssues on Bitbucket server
+func (client *BitbucketServerClient) ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error) {
+	return nil, errBitbucketServerIssuesNotSupported
+}
+
+// CommentOnIssue on Bitbucket server
+func (client *BitbucketServerClient) CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error {
+	return errBitbucketServerIssuesNotSupported
+}
+
+// CloseIssue on Bitbucket server
+func (client *BitbucketServerClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	return errBitbucketServerIssuesNotSupported
+}
+
 // AddPullRequestComment on Bitbucket server
 func (client *BitbucketServerClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestComment")
 	return client.addPullRequestComment(ctx, owner, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
 }
 
 // AddPullRequestReviewComments on Bitbucket server
 func (client *BitbucketServerClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestReviewComments")
 	if len(comments) == 0 {
 		return errors.New(vcsutils.ErrNoCommentsProvided)
 	}
@@ -431,7 +1155,6 @@ func (client *BitbucketServerClient) addPullRequestComment(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	bitbucketClient := client.buildBitbucketClient(ctx)
 	// Determine the file path and anchor
 	var anchor *bitbucketv1.Anchor
 	if filePath := vcsutils.GetPullRequestFilePath(comment.NewFilePath); filePath != "" {
@@ -444,24 +1167,79 @@ func (client *BitbucketServerClient) addPullRequestComment(ctx context.Context,
 		}
 	}
 
-	// Create the pull request comment
-	commentData := bitbucketv1.Comment{
-		Text:   comment.Content,
-		Anchor: anchor,
+	// Create the pull request comment. A blocker comment is a task, which has to be resolved before merging.
+	commentData := bitbucketServerReviewComment{Comment: bitbucketv1.Comment{Text: comment.Content, Anchor: anchor}}
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments", owner, repository, pullRequestID)
+	if !comment.Blocking {
+		return client.sendRequest(ctx, http.MethodPost, path, commentData, nil)
+	}
+	if supportsServerVersion(ctx, client.GetServerVersion, bitbucketServerBlockerCommentsMinimalVersion, client.logger) {
+		commentData.Severity = bitbucketServerBlockerCommentSeverity
+		return client.sendRequest(ctx, http.MethodPost, path, commentData, nil)
+	}
+
+	// Before blocker comments, a task is created on the comment
+	var createdComment struct {
+		ID int `json:"id"`
 	}
-	_, err = bitbucketClient.CreatePullRequestComment(owner, repository, pullRequestID, commentData, []string{"application/json"})
-	return err
+	if err = client.sendRequest(ctx, http.MethodPost, path, commentData, &createdComment); err != nil {
+		return err
+	}
+	task := bitbucketServerTask{Anchor: bitbucketServerTaskAnchor{ID: createdComment.ID, Type: "COMMENT"}, Text: comment.Content}
+	return client.sendRequest(ctx, http.MethodPost, "/api/1.0/tasks", task, nil)
+}
+
+const (
+	// The severity of a comment, which is a task that has to be resolved before merging
+	bitbucketServerBlockerCommentSeverity = "BLOCKER"
+	// The minimal Bitbucket server version with blocker comments, which replace the tasks
+	bitbucketServerBlockerCommentsMinimalVersion = "7.0"
+	// The minimal Bitbucket server version with auto merge of pull requests
+	bitbucketServerAutoMergeMinimalVersion = "8.15"
+	// The minimal Bitbucket server version with archived repositories
+	bitbucketServerArchiveRepositoryMinimalVersion = "8.0"
+	// The minimal Bitbucket server version with repository HTTP access tokens
+	bitbucketServerRepositoryAccessTokensMinimalVersion = "7.15"
+)
+
+// The IDs of the Bitbucket server merge strategies, by merge method
+var bitbucketServerMergeStrategies = map[MergeMethod]string{
+	MergeCommit: "no-ff",
+	SquashMerge: "squash",
+	RebaseMerge: "rebase-ff-only",
+}
+
+// bitbucketServerReviewComment is a pull request comment with a severity, which the Bitbucket client doesn't support
+type bitbucketServerReviewComment struct {
+	bitbucketv1.Comment
+	// NORMAL or BLOCKER
+	Severity string `json:"severity,omitempty"`
+}
+
+// bitbucketServerTask is a task of a pull request comment, used before Bitbucket server 7.0
+type bitbucketServerTask struct {
+	Anchor bitbucketServerTaskAnchor `json:"anchor"`
+	Text   string                    `json:"text"`
+}
+
+type bitbucketServerTaskAnchor struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
 }
 
 // ListPullRequestReviewComments on Bitbucket server
 func (client *BitbucketServerClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestReviewComments")
 	return client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
 }
 
 // ListPullRequestComments on Bitbucket server
 func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestComments")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	var results []CommentInfo
+	// The IDs of the root comments of the replies, which are nested in the comments they reply to
+	rootIDs := map[int]int{}
 	var apiResponse *bitbucketv1.APIResponse
 	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
 		var err error
@@ -474,22 +1252,44 @@ func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context
 			return nil, err
 		}
 		for _, activity := range activities.Values {
+			addBitbucketServerReplyThreads(rootIDs, activity.Comment.ID, activity.Comment.Comments)
 			// Add activity only if from type new comment.
 			if activity.Action == "COMMENTED" && activity.CommentAction == "ADDED" {
 				results = append(results, CommentInfo{
-					ID:      int64(activity.Comment.ID),
-					Created: time.Unix(activity.Comment.CreatedDate, 0),
-					Content: activity.Comment.Text,
-					Version: activity.Comment.Version,
+					ID:       int64(activity.Comment.ID),
+					Created:  time.Unix(activity.Comment.CreatedDate, 0),
+					Content:  activity.Comment.Text,
+					Version:  activity.Comment.Version,
+					Author:   activity.Comment.Author.Name,
+					FilePath: strings.TrimPrefix(activity.CommentAnchor.Path, "/"),
+					Line:     activity.CommentAnchor.Line,
 				})
 			}
 		}
 	}
-	return results, nil
+	for i := range results {
+		threadID, isReply := rootIDs[int(results[i].ID)]
+		if !isReply {
+			threadID = int(results[i].ID)
+		}
+		results[i].ThreadID = strconv.Itoa(threadID)
+	}
+	return orderComments(client.vcsInfo, results), nil
+}
+
+// addBitbucketServerReplyThreads maps the IDs of the replies nested in a root comment to the ID of the root comment
+func addBitbucketServerReplyThreads(rootIDs map[int]int, rootID int, replies []bitbucketv1.ActivityComment) {
+	for _, reply := range replies {
+		if reply.ID != rootID {
+			rootIDs[reply.ID] = rootID
+		}
+		addBitbucketServerReplyThreads(rootIDs, rootID, reply.Comments)
+	}
 }
 
 // DeletePullRequestReviewComments on Bitbucket server
 func (client *BitbucketServerClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestReviewComments")
 	for _, comment := range comments {
 		if err := client.DeletePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID)); err != nil {
 			return err
@@ -500,6 +1300,7 @@ func (client *BitbucketServerClient) DeletePullRequestReviewComments(ctx context
 
 // DeletePullRequestComment on Bitbucket Server
 func (client *BitbucketServerClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestComment")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
 	if err != nil {
@@ -519,14 +1320,84 @@ func (client *BitbucketServerClient) DeletePullRequestComment(ctx context.Contex
 	return nil
 }
 
+// UpdatePullRequestComment on Bitbucket Server
+func (client *BitbucketServerClient) UpdatePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	// The latest comment version is required for updating the comment
+	apiResponse, err := bitbucketClient.GetComment_6(owner, repository, int64(pullRequestID), int64(commentID))
+	if err != nil {
+		return err
+	}
+	comment := bitbucketServerComment{}
+	if err = unmarshalAPIResponseValues(apiResponse, &comment); err != nil {
+		return err
+	}
+	comment.Text = content
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", owner, repository, pullRequestID, commentID)
+	if err = client.sendRequest(ctx, http.MethodPut, path, comment, nil); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// UpdatePullRequestReviewComment on Bitbucket Server
+func (client *BitbucketServerClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestReviewComment")
+	return client.UpdatePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID), content)
+}
+
+// ReplyToPullRequestReviewComment on Bitbucket Server
+// The thread ID is the ID of the parent comment.
+func (client *BitbucketServerClient) ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error {
+	ctx = labelAPICalls(ctx, "ReplyToPullRequestReviewComment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "threadID": threadID, "content": content}); err != nil {
+		return err
+	}
+	parentID, err := strconv.Atoi(threadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", threadID, err)
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	commentData := bitbucketv1.Comment{
+		Text:   content,
+		Parent: &bitbucketv1.Parent{ID: parentID},
+	}
+	if _, err = bitbucketClient.CreatePullRequestComment(owner, repository, pullRequestID, commentData, []string{"application/json"}); err != nil {
+		return fmt.Errorf("an error occurred while replying to pull request review comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// UploadPullRequestAttachment on Bitbucket Server
+func (client *BitbucketServerClient) UploadPullRequestAttachment(_ context.Context, _, _ string, _ int, _ string, _ []byte) (PullRequestAttachmentInfo, error) {
+	return PullRequestAttachmentInfo{}, errBitbucketPullRequestAttachmentsNotSupported
+}
+
+// ListPullRequestAttachments on Bitbucket Server
+func (client *BitbucketServerClient) ListPullRequestAttachments(_ context.Context, _, _ string, _ int) ([]PullRequestAttachmentInfo, error) {
+	return nil, errBitbucketPullRequestAttachmentsNotSupported
+}
+
+type bitbucketServerComment struct {
+	Text    string `json:"text"`
+	Version int    `json:"version"`
+}
+
 type projectsResponse struct {
 	Values []struct {
-		Key string `json:"key,omitempty"`
+		Key  string `json:"key,omitempty"`
+		Name string `json:"name,omitempty"`
 	} `json:"values,omitempty"`
 }
 
 // GetLatestCommit on Bitbucket server
 func (client *BitbucketServerClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLatestCommit")
 	commits, err := client.GetCommits(ctx, owner, repository, branch)
 	if err != nil {
 		return CommitInfo{}, err
@@ -541,6 +1412,7 @@ func (client *BitbucketServerClient) GetLatestCommit(ctx context.Context, owner,
 
 // GetCommits on Bitbucket server
 func (client *BitbucketServerClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommits")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -558,6 +1430,7 @@ func (client *BitbucketServerClient) GetCommits(ctx context.Context, owner, repo
 }
 
 func (client *BitbucketServerClient) GetCommitsWithQueryOptions(ctx context.Context, owner, repository string, listOptions GitCommitsQueryOptions) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitsWithQueryOptions")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -572,6 +1445,16 @@ func (client *BitbucketServerClient) GetCommitsWithQueryOptions(ctx context.Cont
 	return getCommitsInDateRate(commits, listOptions), nil
 }
 
+// ListCommitsBetween on Bitbucket server
+func (client *BitbucketServerClient) ListCommitsBetween(_ context.Context, _, _, _, _ string) ([]string, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
+}
+
+// ListMergedPullRequestsOfCommit on Bitbucket server
+func (client *BitbucketServerClient) ListMergedPullRequestsOfCommit(_ context.Context, _, _, _ string) ([]PullRequestInfo, error) {
+	return nil, ErrCommitRangePullRequestsNotSupported
+}
+
 // Bitbucket doesn't support filtering by date, so we need to filter the commits by date ourselves.
 func getCommitsInDateRate(commits []CommitInfo, options GitCommitsQueryOptions) []CommitInfo {
 	commitsNumber := len(commits)
@@ -615,7 +1498,7 @@ func (client *BitbucketServerClient) getCommitsWithQueryOptions(ctx context.Cont
 		commitInfo := client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository)
 		commitsInfo = append(commitsInfo, commitInfo)
 	}
-	return commitsInfo, nil
+	return orderCommits(client.vcsInfo, commitsInfo), nil
 }
 
 func convertToBitbucketOptionsMap(listOptions GitCommitsQueryOptions) map[string]interface{} {
@@ -627,6 +1510,7 @@ func convertToBitbucketOptionsMap(listOptions GitCommitsQueryOptions) map[string
 
 // GetRepositoryInfo on Bitbucket server
 func (client *BitbucketServerClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryInfo")
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
 		return RepositoryInfo{}, err
 	}
@@ -665,8 +1549,220 @@ func (client *BitbucketServerClient) GetRepositoryInfo(ctx context.Context, owne
 	return RepositoryInfo{RepositoryVisibility: getBitbucketServerRepositoryVisibility(holder.Public), CloneInfo: info}, nil
 }
 
+// GetRepositoryTopics on Bitbucket server, where the topics are the labels of the repository
+func (client *BitbucketServerClient) GetRepositoryTopics(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryTopics")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var topics []string
+	for start := 0; ; {
+		var labels struct {
+			Values []struct {
+				Name string `json:"name"`
+			} `json:"values"`
+			IsLastPage    bool `json:"isLastPage"`
+			NextPageStart int  `json:"nextPageStart"`
+		}
+		path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/labels?start=%d", url.PathEscape(owner), url.PathEscape(repository), start)
+		if err := client.sendRequest(ctx, http.MethodGet, path, nil, &labels); err != nil {
+			return nil, err
+		}
+		for _, label := range labels.Values {
+			topics = append(topics, label.Name)
+		}
+		if labels.IsLastPage {
+			slices.Sort(topics)
+			return topics, nil
+		}
+		start = labels.NextPageStart
+	}
+}
+
+// SetRepositoryTopics on Bitbucket server. The missing labels are added to the repository, and the other labels are removed from it.
+func (client *BitbucketServerClient) SetRepositoryTopics(ctx context.Context, owner, repository string, topics []string) error {
+	ctx = labelAPICalls(ctx, "SetRepositoryTopics")
+	currentTopics, err := client.GetRepositoryTopics(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+	topics = normalizeRepositoryTopics(topics)
+	labelsPath := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/labels", url.PathEscape(owner), url.PathEscape(repository))
+	for _, topic := range topics {
+		if slices.Contains(currentTopics, topic) {
+			continue
+		}
+		if err = client.sendRequest(ctx, http.MethodPost, labelsPath, map[string]string{"name": topic}, nil); err != nil {
+			return err
+		}
+	}
+	for _, topic := range currentTopics {
+		if slices.Contains(topics, topic) {
+			continue
+		}
+		if err = client.sendRequest(ctx, http.MethodDelete, labelsPath+"/"+url.PathEscape(topic), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveRepository on Bitbucket server
+func (client *BitbucketServerClient) ArchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "ArchiveRepository")
+	return client.setRepositoryArchived(ctx, owner, repository, true)
+}
+
+// UnarchiveRepository on Bitbucket server
+func (client *BitbucketServerClient) UnarchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "UnarchiveRepository")
+	return client.setRepositoryArchived(ctx, owner, repository, false)
+}
+
+func (client *BitbucketServerClient) setRepositoryArchived(ctx context.Context, owner, repository string, archived bool) error {
+	if !supportsServerVersion(ctx, client.GetServerVersion, bitbucketServerArchiveRepositoryMinimalVersion, client.logger) {
+		return fmt.Errorf("archiving repositories requires Bitbucket server %s or above", bitbucketServerArchiveRepositoryMinimalVersion)
+	}
+	return client.updateRepository(ctx, owner, repository, map[string]interface{}{"archived": archived})
+}
+
+// RenameRepository on Bitbucket server. The slug of the repository is derived from the new name.
+func (client *BitbucketServerClient) RenameRepository(ctx context.Context, owner, repository, newName string) error {
+	ctx = labelAPICalls(ctx, "RenameRepository")
+	if err := validateParametersNotBlank(map[string]string{"newName": newName}); err != nil {
+		return err
+	}
+	return client.updateRepository(ctx, owner, repository, map[string]interface{}{"name": newName})
+}
+
+// GetUserPermissionOnRepo on Bitbucket server, considering the permissions granted to the user on the repository and its project.
+// The permissions granted through groups aren't considered. Reading the permissions requires the admin permission on the repository.
+func (client *BitbucketServerClient) GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	ctx = labelAPICalls(ctx, "GetUserPermissionOnRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
+	}
+	// The user owns the repositories of the personal project
+	if strings.EqualFold(owner, "~"+username) {
+		return AdminPermission, nil
+	}
+	permission, err := client.getUserPermission(ctx, fmt.Sprintf("/api/1.0/projects/%s/repos/%s/permissions/users", owner, repository), username)
+	if err != nil || permission == AdminPermission || strings.HasPrefix(owner, "~") {
+		return permission, err
+	}
+	projectPermission, err := client.getUserPermission(ctx, fmt.Sprintf("/api/1.0/projects/%s/permissions/users", owner), username)
+	return max(permission, projectPermission), err
+}
+
+// ListRepositoryCollaborators on Bitbucket server returns the users granted a permission on the repository.
+// All the collaborators include the users granted a permission on the project, with the higher of their permissions.
+func (client *BitbucketServerClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string, affiliation CollaboratorAffiliation) ([]CollaboratorInfo, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryCollaborators")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	affiliation, err := getCollaboratorAffiliation(affiliation)
+	if err != nil {
+		return nil, err
+	}
+	if affiliation == OutsideCollaborators {
+		return nil, errBitbucketOutsideCollaboratorsNotSupported
+	}
+	permissions := map[string]RepositoryPermission{}
+	paths := []string{fmt.Sprintf("/api/1.0/projects/%s/repos/%s/permissions/users", owner, repository)}
+	if affiliation == AllCollaborators && !strings.HasPrefix(owner, "~") {
+		paths = append(paths, fmt.Sprintf("/api/1.0/projects/%s/permissions/users", owner))
+	}
+	for _, path := range paths {
+		for start := 0; ; {
+			var userPermissions struct {
+				Values []struct {
+					User struct {
+						Name string `json:"name"`
+					} `json:"user"`
+					Permission string `json:"permission"`
+				} `json:"values"`
+				IsLastPage    bool `json:"isLastPage"`
+				NextPageStart int  `json:"nextPageStart"`
+			}
+			if err := client.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s?start=%d", path, start), nil, &userPermissions); err != nil {
+				return nil, err
+			}
+			for _, userPermission := range userPermissions.Values {
+				username := userPermission.User.Name
+				permissions[username] = max(permissions[username], mapBitbucketServerPermission(userPermission.Permission))
+			}
+			if userPermissions.IsLastPage {
+				break
+			}
+			start = userPermissions.NextPageStart
+		}
+	}
+	collaborators := make([]CollaboratorInfo, 0, len(permissions))
+	for username, permission := range permissions {
+		collaborators = append(collaborators, CollaboratorInfo{Username: username, Permission: permission})
+	}
+	sortCollaborators(collaborators)
+	return collaborators, nil
+}
+
+// getUserPermission returns the permission of the user in a list of user permissions, which is filtered by the username
+func (client *BitbucketServerClient) getUserPermission(ctx context.Context, path, username string) (RepositoryPermission, error) {
+	var userPermissions struct {
+		Values []struct {
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+			Permission string `json:"permission"`
+		} `json:"values"`
+	}
+	if err := client.sendRequest(ctx, http.MethodGet, path+"?filter="+url.QueryEscape(username), nil, &userPermissions); err != nil {
+		return NoPermission, err
+	}
+	// The filter matches usernames containing the username
+	for _, userPermission := range userPermissions.Values {
+		if strings.EqualFold(userPermission.User.Name, username) {
+			return mapBitbucketServerPermission(userPermission.Permission), nil
+		}
+	}
+	return NoPermission, nil
+}
+
+// mapBitbucketServerPermission maps a repository permission, such as REPO_WRITE, or a project permission, such as PROJECT_WRITE
+func mapBitbucketServerPermission(permission string) RepositoryPermission {
+	switch {
+	case strings.HasSuffix(permission, "_ADMIN"):
+		return AdminPermission
+	case strings.HasSuffix(permission, "_WRITE"):
+		return WritePermission
+	case strings.HasSuffix(permission, "_READ"):
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetRepositoryMirrors on Bitbucket server
+func (client *BitbucketServerClient) GetRepositoryMirrors(_ context.Context, _, _ string) ([]RepositoryMirrorInfo, error) {
+	return nil, errBitbucketRepositoryMirrorsNotSupported
+}
+
+// SetRepositoryMirror on Bitbucket server
+func (client *BitbucketServerClient) SetRepositoryMirror(_ context.Context, _, _ string, _ RepositoryMirrorInfo) error {
+	return errBitbucketRepositoryMirrorsNotSupported
+}
+
+func (client *BitbucketServerClient) updateRepository(ctx context.Context, owner, repository string, repositoryChanges map[string]interface{}) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	return client.sendRequest(ctx, http.MethodPut, fmt.Sprintf("/api/1.0/projects/%s/repos/%s", owner, repository), repositoryChanges, nil)
+}
+
 // GetCommitBySha on Bitbucket server
 func (client *BitbucketServerClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitBySha")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -690,24 +1786,202 @@ func (client *BitbucketServerClient) GetCommitBySha(ctx context.Context, owner,
 	return client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository), nil
 }
 
+// GetCommitDiff on Bitbucket server
+func (client *BitbucketServerClient) GetCommitDiff(ctx context.Context, owner, repository, sha string) (CommitDiffInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitDiff")
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+
+	// Without the 'since' parameter, the patch is compared to the commit's first parent
+	var diff []byte
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/patch?until=%s", owner, repository, url.QueryEscape(sha))
+	if err = client.sendRequest(ctx, http.MethodGet, path, nil, &diff); err != nil {
+		return CommitDiffInfo{}, err
+	}
+	return CommitDiffInfo{Diff: string(diff), Files: parseUnifiedDiffFiles(string(diff))}, nil
+}
+
 // CreateLabel on Bitbucket server
-func (client *BitbucketServerClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return errLabelsNotSupported
+// Bitbucket server has no repository labels. The labels are kept in the pull request description only, so there is nothing to create.
+func (client *BitbucketServerClient) CreateLabel(_ context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "label name": labelInfo.Name})
 }
 
 // GetLabel on Bitbucket server
-func (client *BitbucketServerClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, errLabelsNotSupported
+// Bitbucket server has no repository labels. The labels are kept in the pull request description only, so every label is considered to exist.
+func (client *BitbucketServerClient) GetLabel(_ context.Context, owner, repository, name string) (*LabelInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return nil, err
+	}
+	return &LabelInfo{Name: name}, nil
 }
 
 // ListPullRequestLabels on Bitbucket server
+// Bitbucket server has no pull request labels, therefore the labels are read from the pull request description.
 func (client *BitbucketServerClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, errLabelsNotSupported
+	ctx = labelAPICalls(ctx, "ListPullRequestLabels")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	pullRequest, err := client.getPullRequest(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := getBitbucketPullRequestLabels(pullRequest.Description)
+	return orderNames(client.vcsInfo, labels), err
+}
+
+// LabelPullRequest on Bitbucket server
+// Bitbucket server has no pull request labels, therefore the label is added to the pull request description.
+func (client *BitbucketServerClient) LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "LabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	pullRequest, err := client.getPullRequest(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	description, added, err := addBitbucketPullRequestLabel(pullRequest.Description, name)
+	if err != nil || !added {
+		return err
+	}
+	return client.updatePullRequestDescription(ctx, owner, repository, pullRequest, description)
 }
 
 // UnlabelPullRequest on Bitbucket server
+// Bitbucket server has no pull request labels, therefore the label is removed from the pull request description.
 func (client *BitbucketServerClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return errLabelsNotSupported
+	ctx = labelAPICalls(ctx, "UnlabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	pullRequest, err := client.getPullRequest(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	description, removed, err := removeBitbucketPullRequestLabel(pullRequest.Description, name)
+	if err != nil || !removed {
+		return err
+	}
+	return client.updatePullRequestDescription(ctx, owner, repository, pullRequest, description)
+}
+
+// ListMilestones on Bitbucket server
+func (client *BitbucketServerClient) ListMilestones(_ context.Context, _, _ string, _ MilestoneState) ([]MilestoneInfo, error) {
+	return nil, ErrMilestonesNotSupported
+}
+
+// SetPullRequestMilestone on Bitbucket server
+func (client *BitbucketServerClient) SetPullRequestMilestone(_ context.Context, _, _ string, _ int, _ int64) error {
+	return ErrMilestonesNotSupported
+}
+
+func (client *BitbucketServerClient) updatePullRequestDescription(ctx context.Context, owner, repository string, pullRequest bitbucketv1.PullRequest, description string) error {
+	// The reviewers are sent as well, since Bitbucket removes the reviewers which are missing from the request
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/pull-requests/%d", owner, repository, pullRequest.ID)
+	return client.sendRequest(ctx, http.MethodPut, path, bitbucketServerEditPullRequest{
+		Version:     pullRequest.Version,
+		Title:       pullRequest.Title,
+		Description: description,
+		Reviewers:   pullRequest.Reviewers,
+	}, nil)
+}
+
+type bitbucketServerEditPullRequest struct {
+	Version     int32                          `json:"version"`
+	Title       string                         `json:"title"`
+	Description string                         `json:"description"`
+	Reviewers   []bitbucketv1.UserWithMetadata `json:"reviewers"`
+}
+
+func (client *BitbucketServerClient) getPullRequest(ctx context.Context, owner, repository string, pullRequestID int) (bitbucketv1.PullRequest, error) {
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return bitbucketv1.PullRequest{}, err
+	}
+	return bitbucketv1.GetPullRequestResponse(apiResponse)
+}
+
+// sendRequest sends a request to a Bitbucket server REST API which isn't covered by go-bitbucket-v1.
+// path - The API path, relative to the '/rest' endpoint
+// requestBody - Optional object to send as a JSON body
+// responseBody - Optional pointer to decode the JSON response into, a *[]byte to read the raw response into, or an io.Writer to stream the raw response into
+func (client *BitbucketServerClient) sendRequest(ctx context.Context, method, path string, requestBody, responseBody interface{}) (err error) {
+	var body io.Reader
+	if requestBody != nil {
+		var requestBytes []byte
+		if requestBytes, err = json.Marshal(requestBody); err != nil {
+			return
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, client.vcsInfo.APIEndpoint+path, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := client.buildHTTPClient(ctx).Do(req)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, vcsutils.DiscardResponseBody(response), response.Body.Close())
+	}()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return
+	}
+	if responseBody == nil {
+		return
+	}
+	if rawResponseBody, ok := responseBody.(*[]byte); ok {
+		*rawResponseBody, err = io.ReadAll(response.Body)
+		return
+	}
+	if writer, ok := responseBody.(io.Writer); ok {
+		_, err = io.Copy(writer, response.Body)
+		return
+	}
+	return json.NewDecoder(response.Body).Decode(responseBody)
+}
+
+// FileExistsInRepo on Bitbucket server
+func (client *BitbucketServerClient) FileExistsInRepo(ctx context.Context, owner, repository, branch, path string) (bool, error) {
+	ctx = labelAPICalls(ctx, "FileExistsInRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path}); err != nil {
+		return false, err
+	}
+	// With the type parameter, only the type of the path is returned, instead of its lines or children
+	browseURL := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/browse/%s?type=true", client.vcsInfo.APIEndpoint,
+		url.PathEscape(owner), url.PathEscape(repository), escapeFilePath(path))
+	if branch != "" {
+		browseURL += "&at=" + url.QueryEscape(branch)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, browseURL, nil)
+	if err != nil {
+		return false, err
+	}
+	var pathType struct {
+		Type string `json:"type"`
+	}
+	found, err := getBitbucketFileMetadata(client.buildHTTPClient(ctx), request, &pathType)
+	return found && pathType.Type == "FILE", err
+}
+
+// CILint on Bitbucket server
+func (client *BitbucketServerClient) CILint(_ context.Context, _, _, _ string) (CILintResult, error) {
+	return CILintResult{}, ErrCILintNotSupported
 }
 
 // GetRepositoryEnvironmentInfo on Bitbucket server
@@ -715,11 +1989,28 @@ func (client *BitbucketServerClient) GetRepositoryEnvironmentInfo(ctx context.Co
 	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
+// CreateOrUpdateEnvironment on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error {
+	return errBitbucketCreateOrUpdateEnvironmentNotSupported
+}
+
 // Get all projects for which the authenticated user has the PROJECT_VIEW permission
 func (client *BitbucketServerClient) listProjects(bitbucketClient *bitbucketv1.DefaultApiService) ([]string, error) {
+	namespaces, err := client.listNamespaces(bitbucketClient)
+	if err != nil {
+		return []string{}, err
+	}
+	projects := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		projects = append(projects, namespace.Name)
+	}
+	return projects, nil
+}
+
+func (client *BitbucketServerClient) listNamespaces(bitbucketClient *bitbucketv1.DefaultApiService) ([]NamespaceInfo, error) {
 	var apiResponse *bitbucketv1.APIResponse
 	var err error
-	var projects []string
+	var projects []NamespaceInfo
 	for isLastProjectsPage, nextProjectsPageStart := true, 0; isLastProjectsPage; isLastProjectsPage, nextProjectsPageStart = bitbucketv1.HasNextPage(apiResponse) {
 		apiResponse, err = bitbucketClient.GetProjects(createPaginationOptions(nextProjectsPageStart))
 		if err != nil {
@@ -731,21 +2022,21 @@ func (client *BitbucketServerClient) listProjects(bitbucketClient *bitbucketv1.D
 			return nil, err
 		}
 		for _, project := range projectsResponse.Values {
-			projects = append(projects, project.Key)
+			projects = append(projects, NamespaceInfo{Name: project.Key, DisplayName: project.Name, Kind: ProjectNamespace})
 		}
 	}
 	// Add user's private project
 	username := apiResponse.Header.Get("X-Ausername")
 	if username == "" {
-		return []string{}, errors.New("X-Ausername header is missing")
+		return []NamespaceInfo{}, errors.New("X-Ausername header is missing")
 	}
-	// project keys are upper case
-	projects = append(projects, "~"+strings.ToUpper(username))
+	projects = append(projects, NamespaceInfo{Name: vcsutils.NormalizeBitbucketServerOwner("~" + username), DisplayName: username, Kind: UserNamespace})
 	return projects, nil
 }
 
 // DownloadFileFromRepo on Bitbucket server
 func (client *BitbucketServerClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepo")
 	bitbucketClient := client.buildBitbucketClient(ctx)
 
 	var statusCode int
@@ -759,6 +2050,16 @@ func (client *BitbucketServerClient) DownloadFileFromRepo(ctx context.Context, o
 	return bbResp.Payload, statusCode, err
 }
 
+// DownloadFileFromRepoStream on Bitbucket Server
+func (client *BitbucketServerClient) DownloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) error {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepoStream")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path}); err != nil {
+		return err
+	}
+	rawPath := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/raw/%s?at=%s", url.PathEscape(owner), url.PathEscape(repository), escapeFilePath(path), url.QueryEscape(branch))
+	return client.sendRequest(ctx, http.MethodGet, rawPath, nil, newLimitedWriter(writer, maxBytes))
+}
+
 func createPaginationOptions(nextPageStart int) map[string]interface{} {
 	return map[string]interface{}{"start": nextPageStart}
 }
@@ -780,6 +2081,18 @@ func getBitbucketServerWebhookID(r *bitbucketv1.APIResponse) (string, error) {
 	return strconv.Itoa(webhook.ID), nil
 }
 
+func getBitbucketServerWebhookSecret(bitbucketClient *bitbucketv1.DefaultApiService, owner, repository string, webhookID int32) (string, error) {
+	response, err := bitbucketClient.GetWebhook(owner, repository, webhookID, nil)
+	if err != nil {
+		return "", err
+	}
+	webhook := bitbucketv1.Webhook{}
+	if err = unmarshalAPIResponseValues(response, &webhook); err != nil {
+		return "", err
+	}
+	return webhook.Configuration.Secret, nil
+}
+
 func createBitbucketServerHook(token, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) *map[string]interface{} {
 	return &map[string]interface{}{
 		"url":           payloadURL,
@@ -808,19 +2121,29 @@ func getBitbucketServerWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []s
 	return events
 }
 
+// getRepositoryWebURL returns the web URL of a repository, under which the web URLs of its pull requests and commits are built.
+// The repositories of the personal projects are under the pages of their users, whose slugs are the lower-case usernames.
+func (client *BitbucketServerClient) getRepositoryWebURL(owner, repository string) string {
+	webEndpoint := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest")
+	if username, isPersonal := strings.CutPrefix(owner, "~"); isPersonal {
+		return fmt.Sprintf("%s/users/%s/repos/%s", webEndpoint, strings.ToLower(username), repository)
+	}
+	return fmt.Sprintf("%s/projects/%s/repos/%s", webEndpoint, owner, repository)
+}
+
 func (client *BitbucketServerClient) mapBitbucketServerCommitToCommitInfo(commit bitbucketv1.Commit,
 	owner, repo string) CommitInfo {
 	parents := make([]string, len(commit.Parents))
 	for i, p := range commit.Parents {
 		parents[i] = p.ID
 	}
-	url := fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s",
-		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repo, commit.ID)
+	url := fmt.Sprintf("%s/commits/%s", client.getRepositoryWebURL(owner, repo), commit.ID)
 	return CommitInfo{
 		Hash:          commit.ID,
 		AuthorName:    commit.Author.Name,
 		CommitterName: commit.Committer.Name,
 		Url:           url,
+		HTMLURL:       url,
 		// Convert from bitbucket millisecond timestamp to CommitInfo seconds timestamp.
 		Timestamp:    commit.CommitterTimestamp / 1000,
 		Message:      commit.Message,
@@ -833,6 +2156,26 @@ func (client *BitbucketServerClient) UploadCodeScanning(ctx context.Context, own
 	return "", errBitbucketCodeScanningNotSupported
 }
 
+// ListCodeScanningAlerts on Bitbucket server
+func (client *BitbucketServerClient) ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error) {
+	return nil, errBitbucketCodeScanningNotSupported
+}
+
+// GetCodeScanningAlert on Bitbucket server
+func (client *BitbucketServerClient) GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error) {
+	return CodeScanningAlertInfo{}, errBitbucketCodeScanningNotSupported
+}
+
+// DismissCodeScanningAlert on Bitbucket server
+func (client *BitbucketServerClient) DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, reason AlertDismissalReason, comment string) error {
+	return errBitbucketCodeScanningNotSupported
+}
+
+// ListVulnerabilityAlerts on Bitbucket server
+func (client *BitbucketServerClient) ListVulnerabilityAlerts(ctx context.Context, owner, repository string) ([]VulnerabilityAlertInfo, error) {
+	return nil, errBitbucketVulnerabilityAlertsNotSupported
+}
+
 type diffPayload struct {
 	Diffs []struct {
 		Source struct {
@@ -841,10 +2184,28 @@ type diffPayload struct {
 		Destination struct {
 			ToString string `mapstructure:"toString"`
 		} `mapstructure:"destination"`
+		Hunks []struct {
+			Segments []struct {
+				// ADDED, REMOVED or CONTEXT
+				Type  string        `mapstructure:"type"`
+				Lines []interface{} `mapstructure:"lines"`
+			} `mapstructure:"segments"`
+		} `mapstructure:"hunks"`
 	} `mapstructure:"diffs"`
 }
 
 func (client *BitbucketServerClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFiles")
+	modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	return getModifiedFileNames(modifiedFiles), nil
+}
+
+// GetModifiedFilesWithDetails on Bitbucket Server
+func (client *BitbucketServerClient) GetModifiedFilesWithDetails(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFilesWithDetails")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -868,15 +2229,32 @@ func (client *BitbucketServerClient) GetModifiedFiles(ctx context.Context, owner
 		return nil, err
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	modifiedFiles := make([]ModifiedFileInfo, 0, len(dst.Diffs))
 	for _, diff := range dst.Diffs {
-		fileNamesSet.Add(diff.Source.ToString)
-		fileNamesSet.Add(diff.Destination.ToString)
+		modifiedFile := ModifiedFileInfo{Path: diff.Destination.ToString, Status: FileModified}
+		switch {
+		case diff.Source.ToString == "":
+			modifiedFile.Status = FileAdded
+		case diff.Destination.ToString == "":
+			modifiedFile.Status = FileRemoved
+			modifiedFile.Path = diff.Source.ToString
+		case diff.Source.ToString != diff.Destination.ToString:
+			modifiedFile.Status = FileRenamed
+			modifiedFile.PreviousPath = diff.Source.ToString
+		}
+		for _, hunk := range diff.Hunks {
+			for _, segment := range hunk.Segments {
+				switch segment.Type {
+				case "ADDED":
+					modifiedFile.Additions += len(segment.Lines)
+				case "REMOVED":
+					modifiedFile.Deletions += len(segment.Lines)
+				}
+			}
+		}
+		modifiedFiles = append(modifiedFiles, modifiedFile)
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
+	return sortModifiedFiles(modifiedFiles), nil
 }
 
 func getBitbucketServerRepositoryVisibility(public bool) RepositoryVisibility {
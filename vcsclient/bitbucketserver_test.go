@@ -1,6 +1,7 @@
 package vcsclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -34,6 +36,53 @@ func TestBitbucketServer_Connection(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_Raw(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, []byte(`{"version": "8.19.1"}`), "/rest/api/1.0/application-properties", createBitbucketServerHandler)
+	defer cleanUp()
+
+	// The raw client is authenticated by the token of the client
+	response, err := client.(*BitbucketServerClient).Raw(context.Background()).DefaultApi.GetApplicationProperties()
+	assert.NoError(t, err)
+	assert.Equal(t, "8.19.1", response.Values["version"])
+}
+
+func TestNewBitbucketServerClientEndpoint(t *testing.T) {
+	// The '/rest' suffix is added once, when the client is created
+	for _, apiEndpoint := range []string{"https://bitbucket.example.com", "https://bitbucket.example.com/rest"} {
+		client, err := NewBitbucketServerClient(VcsInfo{APIEndpoint: apiEndpoint}, nil)
+		assert.NoError(t, err)
+		client.Raw(context.Background())
+		assert.Equal(t, "https://bitbucket.example.com/rest", client.vcsInfo.APIEndpoint)
+	}
+}
+
+func TestBitbucketServer_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/admin/users?limit=1":
+			w.Header().Set("X-Ausername", "frogbot")
+			response = `{"values": [], "isLastPage": true}`
+		case "/rest/api/1.0/users/frogbot":
+			response = `{"id": 101, "name": "frogbot", "slug": "frogbot", "displayName": "Frogbot", "emailAddress": "frogbot@jfrog.com"}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{ID: "101", Login: "frogbot", DisplayName: "Frogbot", Email: "frogbot@jfrog.com"}, user)
+
+	_, err = createBadBitbucketServerClient(t).GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ConnectionWhenContextCancelled(t *testing.T) {
 	ctx := context.Background()
 	ctxWithCancel, cancel := context.WithCancel(ctx)
@@ -69,6 +118,174 @@ func TestBitbucketServer_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListRepositoryListings(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBitbucketServerListRepositoriesHandler)
+	defer cleanUp()
+
+	listings, err := client.ListRepositoryListings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{
+		{Owner: username, OwnerType: ProjectNamespace, Name: repo2, Visibility: Private},
+		{Owner: "~" + strings.ToUpper(username), OwnerType: UserNamespace, Name: repo1, Visibility: Public, DefaultBranch: "master"},
+	}, listings)
+
+	_, err = createBadBitbucketServerClient(t).ListRepositoryListings(ctx)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SearchRepositories(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var responseObj interface{}
+		switch r.RequestURI {
+		case "/rest/api/1.0/repos?name=repo&start=0":
+			responseObj = map[string][]bitbucketv1.Repository{"values": {
+				{Slug: repo1, Name: repo1, Project: &bitbucketv1.Project{Key: "~FROGGER"}, Public: true},
+				{Slug: repo2, Name: repo2, Project: &bitbucketv1.Project{Key: "JFROG"}},
+			}}
+		case "/rest/api/1.0/projects/JFROG/repos?start=0":
+			responseObj = map[string][]bitbucketv1.Repository{"values": {
+				{Slug: repo2, Name: repo2, Project: &bitbucketv1.Project{Key: "JFROG"}},
+				{Slug: "frogbot", Name: "Frogbot", Project: &bitbucketv1.Project{Key: "JFROG"}},
+			}}
+		case "/rest/api/1.0/projects/~FROGGER/repos/repo-1/branches/default":
+			responseObj = bitbucketv1.Branch{ID: "refs/heads/master", DisplayID: "master"}
+		case "/rest/api/1.0/projects/JFROG/repos/repo-2/branches/default":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		response, err := json.Marshal(responseObj)
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	listings, err := client.SearchRepositories(ctx, RepositorySearchOptions{Name: "repo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{
+		{Owner: "~FROGGER", OwnerType: UserNamespace, Name: repo1, Visibility: Public, DefaultBranch: "master"},
+		{Owner: "JFROG", OwnerType: ProjectNamespace, Name: repo2, Visibility: Private},
+	}, listings)
+
+	// The repositories of an owner are filtered by their name
+	listings, err = client.SearchRepositories(ctx, RepositorySearchOptions{Owner: "JFROG", Name: "REPO"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{{Owner: "JFROG", OwnerType: ProjectNamespace, Name: repo2, Visibility: Private}}, listings)
+
+	_, err = client.SearchRepositories(ctx, RepositorySearchOptions{Topic: "frogbot-enabled"})
+	assert.ErrorIs(t, err, errBitbucketSearchRepositoriesByTopicNotSupported)
+}
+
+func TestBitbucketServer_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rest/search/latest/search", r.RequestURI)
+		var requestBody struct {
+			Query    string `json:"query"`
+			Entities struct {
+				Code struct {
+					Start int `json:"start"`
+				} `json:"code"`
+			} `json:"entities"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestBody))
+		assert.Equal(t, "froggit-go project:JFROG repo:repo-1", requestBody.Query)
+		response := `{"code": {"isLastPage": true, "values": [{"repository": {"slug": "repo-1", "project": {"key": "JFROG"}}, "file": "go.mod",
+			"hitContexts": [[{"line": 3, "text": "require github.com/jfrog/&lt;<em>froggit-go</em>&gt;"}, {"line": 4, "text": ")"}]]}]}}`
+		if requestBody.Entities.Code.Start > 0 {
+			assert.Fail(t, "unexpected page request")
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	results, err := client.SearchCode(ctx, "froggit-go", CodeSearchOptions{Owner: "JFROG", Repository: repo1})
+	assert.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{{Owner: "JFROG", Repository: repo1, Path: "go.mod", Line: 3, Fragment: "require github.com/jfrog/<froggit-go>\n)"}}, results)
+
+	_, err = createBadBitbucketServerClient(t).SearchCode(ctx, "froggit-go", CodeSearchOptions{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetBranchInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches":
+			assert.Equal(t, "master", r.URL.Query().Get("filterText"))
+			response = `{"isLastPage": true, "values": [
+				{"id": "refs/heads/master-backup", "displayId": "master-backup", "latestCommit": "1b8d1d2fb7a3dc1eff8b4e0cd5e5cf0c1bd8c3c2"},
+				{"id": "refs/heads/master", "displayId": "master", "latestCommit": "8d51122def5632836d1cb1026e879069e10a1e13", "isDefault": true}]}`
+		case "/rest/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions":
+			assert.Equal(t, "BRANCH", r.URL.Query().Get("matcherType"))
+			assert.Equal(t, "refs/heads/master", r.URL.Query().Get("matcherId"))
+			response = `{"size": 1, "values": [{"id": 1, "type": "read-only"}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	branchInfo, err := client.GetBranchInfo(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryBranchInfo{Name: "master", HeadCommitHash: "8d51122def5632836d1cb1026e879069e10a1e13", Protected: true, Default: true}, branchInfo)
+
+	_, err = client.GetBranchInfo(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	response, err := json.Marshal(bitbucketv1.Branch{ID: "refs/heads/master", DisplayID: "master"})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default", createBitbucketServerHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+
+	// An empty repository has no default branch
+	client, cleanUp = createServerAndClientReturningStatus(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default", http.StatusNotFound, createBitbucketServerHandler)
+	defer cleanUp()
+	defaultBranch, err = client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Empty(t, defaultBranch)
+
+	_, err = createBadBitbucketServerClient(t).GetDefaultBranch(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListNamespaces(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBitbucketServerListRepositoriesHandler)
+	defer cleanUp()
+
+	namespaces, err := client.ListNamespaces(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []NamespaceInfo{
+		{Name: "~" + strings.ToUpper(username), DisplayName: username, Kind: UserNamespace},
+		{Name: username, Kind: ProjectNamespace},
+	}, namespaces)
+
+	_, err = createBadBitbucketServerClient(t).ListNamespaces(ctx)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucketv1.Branch{
@@ -85,6 +302,34 @@ func TestBitbucketServer_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListProtectedBranches(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches":
+			response = `{"isLastPage": true, "values": [{"id": "refs/heads/master"}, {"id": "refs/heads/feature"}, {"id": "refs/heads/release"}]}`
+		case "/rest/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions":
+			assert.Equal(t, "BRANCH", r.URL.Query().Get("matcherType"))
+			if r.URL.Query().Get("start") == "0" {
+				response = `{"isLastPage": false, "nextPageStart": 1, "values": [{"matcher": {"id": "refs/heads/master"}}]}`
+			} else {
+				response = `{"isLastPage": true, "values": [{"matcher": {"id": "refs/heads/release"}}]}`
+			}
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	branches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListBranchesOptions{ProtectedOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"refs/heads/master", "refs/heads/release"}, branches)
+}
+
 func TestBitbucketServer_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31() // #nosec G404
@@ -118,6 +363,34 @@ func TestBitbucketServer_UpdateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_UpdateWebhookKeepsSecret(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks/12", r.RequestURI)
+		switch r.Method {
+		case http.MethodGet:
+			response, err := json.Marshal(bitbucketv1.Webhook{ID: 12, Configuration: bitbucketv1.WebhookConfiguration{Secret: "existing-secret"}})
+			assert.NoError(t, err)
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		case http.MethodPut:
+			var hook bitbucketv1.Webhook
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+			assert.Equal(t, "existing-secret", hook.Configuration.Secret)
+			assert.Equal(t, []string{"pr:opened"}, hook.Events)
+			_, err := w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.UpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", "", "12", vcsutils.PrOpened)
+	assert.NoError(t, err)
+}
+
 func TestBitbucketServer_DeleteWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31() // #nosec G404
@@ -148,6 +421,34 @@ func TestBitbucketServer_SetCommitStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_SetCommitStatusWithOptions(t *testing.T) {
+	ctx := context.Background()
+	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
+	var buildStatuses []bitbucketv1.BuildStatus
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/build-status/1.0/commits/"+ref, r.RequestURI)
+		var buildStatus bitbucketv1.BuildStatus
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&buildStatus))
+		buildStatuses = append(buildStatuses, buildStatus)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.SetCommitStatusWithOptions(ctx, Pass, owner, repo1, ref, "Frogbot scan", "Commit status description",
+		"https://httpbin.org/anything", CommitStatusOptions{Key: "frogbot"})
+	assert.NoError(t, err)
+	// The title is the key of a status set without a key
+	err = client.SetCommitStatus(ctx, Pass, owner, repo1, ref, "Frogbot scan", "Commit status description", "https://httpbin.org/anything")
+	assert.NoError(t, err)
+	if assert.Len(t, buildStatuses, 2) {
+		assert.Equal(t, "frogbot", buildStatuses[0].Key)
+		assert.Equal(t, "Frogbot scan", buildStatuses[0].Name)
+		assert.Equal(t, "Frogbot scan", buildStatuses[1].Key)
+		assert.Empty(t, buildStatuses[1].Name)
+	}
+}
+
 func TestBitbucketServer_DownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -172,6 +473,42 @@ func TestBitbucketServer_DownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_DownloadRepositoryWithOptions(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, repoFile,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?format=tgz&path=docs", owner, repo1), createBitbucketServerDownloadRepositoryHandler)
+	defer cleanUp()
+	err = client.DownloadRepositoryWithOptions(ctx, owner, repo1, "", dir, DownloadRepositoryOptions{Filter: vcsutils.PathFilter{Include: []string{"/docs"}, Exclude: []string{"*.md"}}})
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "README.md"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+}
+
+func TestBitbucketServer_DownloadRepositoryAtTagAndCommit(t *testing.T) {
+	ctx := context.Background()
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+
+	for ref, expectedQuery := range map[string]string{
+		"refs/tags/v1.0.0":                         "at=refs%2Ftags%2Fv1.0.0&format=tgz",
+		"6d1b0f0c3ba5d0b4bd0b43f3c7d4a0a6a3a5b4e7": "at=6d1b0f0c3ba5d0b4bd0b43f3c7d4a0a6a3a5b4e7&format=tgz",
+	} {
+		dir := t.TempDir()
+		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, repoFile,
+			fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?%s", owner, repo1, expectedQuery), createBitbucketServerDownloadRepositoryHandler)
+		assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, ref, dir))
+		assert.FileExists(t, filepath.Join(dir, "README.md"))
+		cleanUp()
+	}
+}
+
 func TestBitbucketServer_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests", createBitbucketServerHandler)
@@ -184,6 +521,38 @@ func TestBitbucketServer_CreatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_PersonalProject(t *testing.T) {
+	ctx := context.Background()
+	sha := "abcdef0123abcdef4567abcdef8987abcdef6543"
+	commitResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_single_response.json"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/~frogger/repos/repo-1/pull-requests":
+			var pullRequest bitbucketv1.PullRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&pullRequest))
+			assert.Equal(t, "~FROGGER", pullRequest.FromRef.Repository.Project.Key)
+			assert.Equal(t, "~FROGGER", pullRequest.ToRef.Repository.Project.Key)
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte("{}"))
+		case "/rest/api/1.0/projects/~FROGGER/repos/repo-1/commits/" + sha:
+			_, err = w.Write(commitResponse)
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	assert.NoError(t, client.CreatePullRequest(ctx, "~frogger", repo1, branch1, branch2, "PR title", "PR body"))
+
+	// The web URLs of the personal projects are under the pages of their users
+	commit, err := client.GetCommitBySha(ctx, "~FROGGER", repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/users/frogger/repos/repo-1/commits/"+sha, commit.HTMLURL)
+}
+
 func TestBitbucketServer_UpdatePullRequest(t *testing.T) {
 	prId := 4
 	ctx := context.Background()
@@ -197,6 +566,132 @@ func TestBitbucketServer_UpdatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_UpdatePullRequestKeepsLabels(t *testing.T) {
+	ctx := context.Background()
+	var updatedDescription string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var updateRequest bitbucketv1.EditPullRequestOptions
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updateRequest))
+			updatedDescription = updateRequest.Description
+		}
+		_, err := w.Write([]byte(`{"id": 4, "version": 1, "description": "PR body\n\n[comment]: <> (froggit-labels: [\"label-1\"])"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.UpdatePullRequest(ctx, owner, repo1, "PR title", "New PR body", "", 4, vcsutils.Open)
+	assert.NoError(t, err)
+	assert.Equal(t, "New PR body\n\n[comment]: <> (froggit-labels: [\"label-1\"])", updatedDescription)
+}
+
+func TestBitbucketServer_ClosePullRequest(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	response := bitbucketv1.PullRequest{ID: prId, Version: 2, State: "OPEN"}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/decline?version=2", prId), createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.ClosePullRequest(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	err = client.ReopenPullRequest(ctx, owner, repo1, prId)
+	assert.EqualError(t, err, "pull request 4 is already open")
+
+	err = createBadBitbucketServerClient(t).ClosePullRequest(ctx, owner, repo1, prId)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ReopenPullRequest(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	response := bitbucketv1.PullRequest{ID: prId, Version: 3, State: "DECLINED"}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/reopen?version=3", prId), createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.ReopenPullRequest(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+
+	mergedResponse := bitbucketv1.PullRequest{ID: prId, Version: 3, State: "MERGED"}
+	mergedClient, mergedCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, mergedResponse, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v", prId), createBitbucketServerHandler)
+	defer mergedCleanUp()
+	err = mergedClient.ReopenPullRequest(ctx, owner, repo1, prId)
+	assert.EqualError(t, err, "pull request 4 is merged and its state can't be changed")
+}
+
+func TestBitbucketServer_EnablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		serverVersion string
+		expectedError string
+	}{
+		{serverVersion: "8.19.1"},
+		{serverVersion: "8.14.0", expectedError: "auto merge requires Bitbucket server 8.15 or above"},
+	}
+	for _, test := range tests {
+		t.Run(test.serverVersion, func(t *testing.T) {
+			var autoMergeRequests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+				if r.RequestURI == "/rest/api/1.0/application-properties" {
+					_, err := fmt.Fprintf(w, `{"version":"%s"}`, test.serverVersion)
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/auto-merge", r.RequestURI)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				autoMergeRequests = append(autoMergeRequests, string(body))
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+			client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+			err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Empty(t, autoMergeRequests)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, ""))
+			assert.Equal(t, []string{`{"strategyId":"squash"}`, `{}`}, autoMergeRequests)
+		})
+	}
+}
+
+func TestBitbucketServer_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.RequestURI+" "+string(body))
+		if r.Method == http.MethodGet {
+			// The source branch is in a forked repository
+			_, err = w.Write([]byte(`{"id":1,"version":3,"fromRef":{"id":"refs/heads/feature","repository":{"slug":"repo-1-fork","project":{"key":"~FORKER"}}}}`))
+			assert.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{Method: RebaseMerge, CommitTitle: "Upgrade lodash", DeleteSourceBranch: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1 ",
+		`POST /rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/merge?version=3 {"message":"Upgrade lodash","strategyId":"rebase-ff-only"}`,
+		`DELETE /rest/branch-utils/1.0/projects/~FORKER/repos/repo-1-fork/branches {"dryRun":false,"name":"refs/heads/feature"}`,
+	}, requests)
+
+	err = client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{BypassPolicies: true})
+	assert.ErrorIs(t, err, errBitbucketBypassPoliciesNotSupported)
+}
+
 func TestBitbucketServer_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments", createBitbucketServerHandler)
@@ -221,6 +716,81 @@ func TestBitbucketServer_AddPullRequestReviewComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_AddBlockingPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	comment := PullRequestComment{
+		CommentInfo:     CommentInfo{Content: "Critical finding"},
+		PullRequestDiff: PullRequestDiff{NewFilePath: "index.js", NewStartLine: 7},
+		Blocking:        true,
+	}
+	commentBody := `{"text":"Critical finding","anchor":{"line":7,"lineType":"CONTEXT","fileType":"FROM","path":"/index.js","srcPath":"/index.js"}`
+	tests := []struct {
+		serverVersion    string
+		expectedRequests map[string]string
+	}{
+		{
+			serverVersion: "8.9.0",
+			expectedRequests: map[string]string{
+				"/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments": commentBody + `,"severity":"BLOCKER"}`,
+			},
+		},
+		{
+			// Blocker comments aren't supported before Bitbucket server 7.0, so a task is created on the comment
+			serverVersion: "6.10.0",
+			expectedRequests: map[string]string{
+				"/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments": commentBody + "}",
+				"/rest/api/1.0/tasks": `{"anchor":{"id":12,"type":"COMMENT"},"text":"Critical finding"}`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.serverVersion, func(t *testing.T) {
+			receivedRequests := make(map[string]string)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+				if r.RequestURI == "/rest/api/1.0/application-properties" {
+					_, err := fmt.Fprintf(w, `{"version":"%s","displayName":"Bitbucket"}`, test.serverVersion)
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				receivedRequests[r.RequestURI] = string(body)
+				w.WriteHeader(http.StatusCreated)
+				_, err = w.Write([]byte(`{"id":12,"text":"Critical finding"}`))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+			client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+			assert.NoError(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 1, comment))
+			assert.Equal(t, test.expectedRequests, receivedRequests)
+		})
+	}
+}
+
+func TestBitbucketServer_GetServerVersion(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true,
+		[]byte(`{"version":"5.3.1","buildNumber":"5003001","displayName":"Bitbucket"}`),
+		"/rest/api/1.0/application-properties", createBitbucketServerHandler)
+	defer cleanUp()
+
+	serverVersion, err := client.GetServerVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.3.1", serverVersion)
+
+	// The REST API of webhooks was added in Bitbucket server 5.4
+	capabilities, err := client.Capabilities(ctx)
+	assert.NoError(t, err)
+	assert.NotContains(t, capabilities, WebhooksCapability)
+	assert.Contains(t, capabilities, SshKeysCapability)
+
+	_, err = createBadBitbucketServerClient(t).GetServerVersion(ctx)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_requests_list_response.json"))
@@ -234,10 +804,13 @@ func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     101,
-		Source: BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://link/to/pullrequest",
+		ID:        101,
+		Source:    BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://link/to/pullrequest",
+		HTMLURL:   "https://link/to/pullrequest",
+		Author:    "tom",
+		UpdatedAt: time.Date(1970, time.January, 16, 17, 31, 25, 920000000, time.UTC),
 	}, result[0])
 
 	// With body:
@@ -246,14 +819,88 @@ func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     101,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://link/to/pullrequest",
+		ID:        101,
+		Body:      "hello world",
+		Source:    BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://link/to/pullrequest",
+		HTMLURL:   "https://link/to/pullrequest",
+		Author:    "tom",
+		UpdatedAt: time.Date(1970, time.January, 16, 17, 31, 25, 920000000, time.UTC),
 	}, result[0])
 }
 
+func TestBitbucketServer_ListOpenPullRequestsWithQueryOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?at=refs%%2Fheads%%2Fmaster&direction=INCOMING&start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithQueryOptions(ctx, owner, repo1, PullRequestsQueryOptions{SourceBranch: "feature-ABC-123", TargetBranch: "master"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(101), result[0].ID)
+
+	// The source branch is filtered by the client
+	result, err = client.ListOpenPullRequestsWithQueryOptions(ctx, owner, repo1, PullRequestsQueryOptions{SourceBranch: "feature-XYZ", TargetBranch: "master"})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestBitbucketServer_ListOpenPullRequestsPages(t *testing.T) {
+	ctx := context.Background()
+	pullRequest := `{"id": %d, "open": true, "author": {"user": {"name": "tom"}},
+		"fromRef": {"displayId": "feature-%d", "repository": {"slug": "repo-1", "project": {"key": "jfrog"}}},
+		"toRef": {"displayId": "master", "repository": {"slug": "repo-1", "project": {"key": "jfrog"}}}}`
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.RequestURI)
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests?limit=2&start=0":
+			response = fmt.Sprintf(`{"values": [`+pullRequest+`, `+pullRequest+`], "isLastPage": false, "nextPageStart": 2}`, 3, 3, 2, 2)
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests?limit=2&start=2":
+			response = fmt.Sprintf(`{"values": [`+pullRequest+`], "isLastPage": true}`, 1, 1)
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	// All the pages are fetched by requests of the page size, and the pages are sliced by the client after ordering the pull requests
+	result, err := client.ListOpenPullRequestsWithQueryOptions(ctx, owner, repo1, PullRequestsQueryOptions{ListOptions: ListOptions{Page: 2, PerPage: 2}})
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, int64(1), result[0].ID)
+		assert.Equal(t, "feature-1", result[0].Source.Name)
+	}
+	assert.Len(t, requests, 2)
+}
+
+func TestBitbucketServer_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?at=refs%%2Fheads%%2Fmaster&direction=INCOMING&start=0&state=MERGED", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{State: PullRequestsMerged, SourceBranch: "feature-ABC-123", TargetBranch: "master", Author: "tom"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(101), result[0].ID)
+
+	// The author is filtered by the client
+	result, err = client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{State: PullRequestsMerged, TargetBranch: "master", Author: username})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
 func TestBitbucketServerClient_GetPullRequest(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "get_pull_request_response.json"))
@@ -267,12 +914,25 @@ func TestBitbucketServerClient_GetPullRequest(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repo1, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "new_vul_2", Repository: "repoName", Owner: "~fromOwner"},
-		Target: BranchInfo{Name: "master", Repository: "repoName", Owner: owner},
-		URL:    "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6",
+		ID:             int64(pullRequestId),
+		Source:         BranchInfo{Name: "new_vul_2", Repository: "repoName", Owner: "~FROMOWNER"},
+		Target:         BranchInfo{Name: "master", Repository: "repoName", Owner: owner},
+		URL:            "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6",
+		HTMLURL:        "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6",
+		HeadCommitHash: "7121b72f7c2a4bdd953bcddd80c037cb598db690",
+		Author:         "owner",
+		UpdatedAt:      time.Date(2023, time.June, 13, 10, 11, 20, 688000000, time.UTC),
 	}, result)
 
+	// The personal project owners are normalized to the project key
+	personalOwner := "~fromOwner"
+	personalClient, personalCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", personalOwner, repo1, pullRequestId), createBitbucketServerHandler)
+	defer personalCleanUp()
+	result, err = personalClient.GetPullRequestByID(ctx, personalOwner, repo1, pullRequestId)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Source.Owner, result.Target.Owner)
+
 	// Failed owner extraction
 	response, err = os.ReadFile(filepath.Join("testdata", "bitbucketserver", "get_pull_request_response_nil.json"))
 	assert.NoError(t, err)
@@ -311,13 +971,38 @@ func TestBitbucketServer_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, CommentInfo{
-		ID:      1,
-		Content: "A measured reply.",
-		Created: time.Unix(1548720847370, 0),
-		Version: 1,
+		ID:       1,
+		ThreadID: "1",
+		Content:  "A measured reply.",
+		Created:  time.Unix(1548720847370, 0),
+		Version:  1,
+		Author:   "jcitizen",
+		FilePath: "path/to/file",
+		Line:     1,
 	}, result[0])
 }
 
+func TestBitbucketServer_ListPullRequestCommentsReplies(t *testing.T) {
+	ctx := context.Background()
+	// The replies are nested in the comments they reply to, and are added by their own activities
+	response := `{"isLastPage": true, "values": [
+		{"action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 12, "text": "Thanks"}},
+		{"action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 11, "text": "Done"}},
+		{"action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 10, "text": "Please fix", "comments": [
+			{"id": 11, "text": "Done", "comments": [{"id": 12, "text": "Thanks"}]}]}}]}`
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(response),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/activities?start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	threadIDs := map[int64]string{}
+	for _, comment := range result {
+		threadIDs[comment.ID] = comment.ThreadID
+	}
+	assert.Equal(t, map[int64]string{10: "10", 11: "10", 12: "10"}, threadIDs)
+}
+
 func TestBitbucketServer_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
@@ -340,6 +1025,7 @@ func TestBitbucketServer_GetLatestCommit(t *testing.T) {
 		AuthorName:    "charlie",
 		CommitterName: "mark",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1548720847,
 		Message:       "More work on feature 1",
 		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
@@ -371,6 +1057,7 @@ func TestBitbucketServer_GetCommits(t *testing.T) {
 		AuthorName:    "charlie",
 		CommitterName: "mark",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1548720847,
 		Message:       "More work on feature 1",
 		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
@@ -381,6 +1068,7 @@ func TestBitbucketServer_GetCommits(t *testing.T) {
 		AuthorName:    "marly",
 		CommitterName: "marly",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1548720847,
 		Message:       "More work on feature 2",
 		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
@@ -419,6 +1107,7 @@ func TestBitbucketServer_GetCommitsWithQueryOptions(t *testing.T) {
 		AuthorName:    "charlie",
 		CommitterName: "mark",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1548720847,
 		Message:       "More work on feature 1",
 		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
@@ -429,6 +1118,7 @@ func TestBitbucketServer_GetCommitsWithQueryOptions(t *testing.T) {
 		AuthorName:    "marly",
 		CommitterName: "marly",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1548720847,
 		Message:       "More work on feature 2",
 		ParentHashes:  []string{"abcdef0123abcdef4567abcdef8987abcdef6543", "qwerty0123abcdef4567abcdef8987abcdef6543"},
@@ -493,7 +1183,7 @@ func TestBitbucketServer_AddSshKeyToRepository(t *testing.T) {
 	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
 
 	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
+		response, fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
 		expectedBody, http.MethodPost,
 		createBitbucketServerWithBodyHandler)
 	defer closeServer()
@@ -513,7 +1203,7 @@ func TestBitbucketServer_AddSshKeyToRepositoryReadWrite(t *testing.T) {
 	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_WRITE"}` + "\n")
 
 	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
+		response, fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh", owner, repo1), http.StatusOK,
 		expectedBody, http.MethodPost,
 		createBitbucketServerWithBodyHandler)
 	defer closeServer()
@@ -538,7 +1228,7 @@ func TestBitbucketServer_AddSshKeyToRepositoryNotFound(t *testing.T) {
 	expectedBody := []byte(`{"key":{"text":"ssh-rsa AAAA...","label":"My deploy key"},"permission":"REPO_READ"}` + "\n")
 
 	client, closeServer := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false,
-		response, fmt.Sprintf("/keys/1.0/projects/%s/repos/%s/ssh", "unknown", repo1), http.StatusNotFound,
+		response, fmt.Sprintf("/rest/keys/1.0/projects/%s/repos/%s/ssh", "unknown", repo1), http.StatusNotFound,
 		expectedBody, http.MethodPost,
 		createBitbucketServerWithBodyHandler)
 	defer closeServer()
@@ -549,6 +1239,44 @@ func TestBitbucketServer_AddSshKeyToRepositoryNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "status: 404 Not Found")
 }
 
+func TestBitbucketServer_CreateDeployToken(t *testing.T) {
+	ctx := context.Background()
+	var tokenRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/rest/api/1.0/application-properties" {
+			_, err := w.Write([]byte(`{"version":"8.19.1"}`))
+			assert.NoError(t, err)
+			return
+		}
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/rest/access-tokens/latest/projects/jfrog/repos/repo-1", r.RequestURI)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		tokenRequests = append(tokenRequests, string(body))
+		_, err = w.Write([]byte(`{"id":"1234","name":"frogbot","token":"s3cr3t","createdDate":1704067200000,"expiryDays":30,"user":{"name":"bot-1234"}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	tokenInfo, err := client.CreateDeployToken(ctx, owner, repo1, "frogbot", []DeployTokenScope{ReadRepositoryScope, WriteRepositoryScope}, time.Now().Add(30*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, DeployTokenInfo{ID: "1234", Name: "frogbot", Username: "bot-1234", Token: "s3cr3t", ExpiresAt: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}, tokenInfo)
+	assert.Equal(t, []string{`{"name":"frogbot","permissions":["REPO_WRITE"],"expiryDays":30}`}, tokenRequests)
+
+	_, err = client.CreateDeployToken(ctx, owner, repo1, "frogbot", []DeployTokenScope{ReadRepositoryScope}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"frogbot","permissions":["REPO_READ"]}`, tokenRequests[1])
+
+	_, err = client.CreateDeployToken(ctx, owner, repo1, "frogbot", []DeployTokenScope{ReadRegistryScope}, time.Time{})
+	assert.EqualError(t, err, "the read_registry scope of access tokens is currently not supported on Bitbucket server")
+	_, err = client.CreateDeployToken(ctx, owner, repo1, "frogbot", nil, time.Time{})
+	assert.EqualError(t, err, "at least one scope of the token is required")
+	_, err = client.CreateDeployToken(ctx, owner, repo1, "frogbot", []DeployTokenScope{ReadRepositoryScope}, time.Now().Add(-time.Hour))
+	assert.EqualError(t, err, "the expiration time of the token must be in the future")
+	assert.Len(t, tokenRequests, 2)
+}
+
 func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
 	ctx := context.Background()
 
@@ -585,40 +1313,184 @@ func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestBitbucketServer_CreateLabel(t *testing.T) {
+func TestBitbucketServer_ArchiveAndRenameRepository(t *testing.T) {
+	ctx := context.Background()
+	serverVersion := "8.19.1"
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		if r.RequestURI == "/rest/api/1.0/application-properties" {
+			_, err := fmt.Fprintf(w, `{"version":"%s"}`, serverVersion)
+			assert.NoError(t, err)
+			return
+		}
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1", r.RequestURI)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, string(body))
+		_, err = w.Write([]byte(`{"slug": "repo-1"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	assert.NoError(t, client.ArchiveRepository(ctx, owner, repo1))
+	assert.NoError(t, client.UnarchiveRepository(ctx, owner, repo1))
+	assert.NoError(t, client.RenameRepository(ctx, owner, repo1, "repo-2"))
+	assert.Equal(t, []string{`{"archived":true}`, `{"archived":false}`, `{"name":"repo-2"}`}, requests)
+
+	// Archiving repositories requires Bitbucket server 8.0
+	serverVersion = "7.21.0"
+	client = buildClient(t, vcsutils.BitbucketServer, true, server)
+	assert.EqualError(t, client.ArchiveRepository(ctx, owner, repo1), "archiving repositories requires Bitbucket server 8.0 or above")
+	assert.Len(t, requests, 3)
+}
+
+func TestBitbucketServer_GetUserPermissionOnRepo(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.RequestURI)
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/permissions/users?filter=frogger":
+			response = `{"values": [{"user": {"name": "frogger2"}, "permission": "REPO_ADMIN"}, {"user": {"name": "frogger"}, "permission": "REPO_READ"}]}`
+		case "/rest/api/1.0/projects/jfrog/permissions/users?filter=frogger":
+			response = `{"values": [{"user": {"name": "frogger"}, "permission": "PROJECT_WRITE"}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	// The highest of the repository and the project permissions
+	permission, err := client.GetUserPermissionOnRepo(ctx, owner, repo1, username)
+	assert.NoError(t, err)
+	assert.Equal(t, WritePermission, permission)
+	assert.Len(t, requests, 2)
+
+	// The user owns the repositories of the personal project
+	permission, err = client.GetUserPermissionOnRepo(ctx, "~FROGGER", repo1, username)
+	assert.NoError(t, err)
+	assert.Equal(t, AdminPermission, permission)
+	assert.Len(t, requests, 2)
+}
+
+func TestBitbucketServer_RepositoryMirrors(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
 	assert.NoError(t, err)
 
-	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	_, err = client.GetRepositoryMirrors(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketRepositoryMirrorsNotSupported)
+	err = client.SetRepositoryMirror(ctx, owner, repo1, RepositoryMirrorInfo{URL: "https://github.com/jfrog/repo-1.git"})
+	assert.ErrorIs(t, err, errBitbucketRepositoryMirrorsNotSupported)
 }
 
-func TestBitbucketServer_GetLabel(t *testing.T) {
+func TestBitbucketServer_Issues(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
 	assert.NoError(t, err)
 
-	_, err = client.GetLabel(ctx, owner, repo1, labelName)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	_, err = client.CreateIssue(ctx, owner, repo1, "title", "body")
+	assert.ErrorIs(t, err, errBitbucketServerIssuesNotSupported)
+	_, err = client.ListIssues(ctx, owner, repo1, IssueOpen)
+	assert.ErrorIs(t, err, errBitbucketServerIssuesNotSupported)
+	assert.ErrorIs(t, client.CommentOnIssue(ctx, owner, repo1, "content", 1), errBitbucketServerIssuesNotSupported)
+	assert.ErrorIs(t, client.CloseIssue(ctx, owner, repo1, 1), errBitbucketServerIssuesNotSupported)
+}
+
+func TestBitbucketServer_CreateLabel(t *testing.T) {
+	ctx, client := createClientAndContext(t, vcsutils.BitbucketServer)
+	err := client.CreateLabel(ctx, owner, repo1, LabelInfo{Name: labelName})
+	assert.NoError(t, err)
+
+	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetLabel(t *testing.T) {
+	ctx, client := createClientAndContext(t, vcsutils.BitbucketServer)
+	label, err := client.GetLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+	assert.Equal(t, &LabelInfo{Name: labelName}, label)
 }
 
 func TestBitbucketServer_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	response := bitbucketv1.PullRequest{ID: 1, Description: "Pull request body\n\n[comment]: <> (froggit-labels: [\"" + labelName + "\"])"}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
 	assert.NoError(t, err)
+	assert.Equal(t, []string{labelName}, labels)
 
-	_, err = client.ListPullRequestLabels(ctx, owner, repo1, 1)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	_, err = createBadBitbucketServerClient(t).ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.Error(t, err)
 }
 
 func TestBitbucketServer_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	response := bitbucketv1.PullRequest{ID: 1, Version: 3, Title: "Pull request title",
+		Description: "Pull request body\n\n[comment]: <> (froggit-labels: [\"" + labelName + "\",\"other-label\"])"}
+	expectedRequest := bitbucketServerEditPullRequest{Version: 3, Title: "Pull request title",
+		Description: "Pull request body\n\n[comment]: <> (froggit-labels: [\"other-label\"])"}
+	uri := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1", owner, repo1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		if r.Method == http.MethodPut {
+			var editRequest bitbucketServerEditPullRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&editRequest))
+			assert.Equal(t, expectedRequest, editRequest)
+		}
+		responseBytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
 	assert.NoError(t, err)
 
-	err = client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
-	assert.ErrorIs(t, err, errLabelsNotSupported)
+	err = createBadBitbucketServerClient(t).UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	response := bitbucketv1.PullRequest{ID: 1, Version: 3, Title: "Pull request title",
+		Description: "Pull request body\n\n[comment]: <> (froggit-labels: [\"other-label\"])"}
+	expectedRequest := bitbucketServerEditPullRequest{Version: 3, Title: "Pull request title",
+		Description: "Pull request body\n\n[comment]: <> (froggit-labels: [\"other-label\",\"" + labelName + "\"])"}
+	uri := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1", owner, repo1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		if r.Method == http.MethodPut {
+			var editRequest bitbucketServerEditPullRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&editRequest))
+			assert.Equal(t, expectedRequest, editRequest)
+		}
+		responseBytes, err := json.Marshal(response)
+		assert.NoError(t, err)
+		_, err = w.Write(responseBytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.Error(t, err)
 }
 
 func TestBitbucketServer_GetRepositoryEnvironmentInfo(t *testing.T) {
@@ -628,6 +1500,9 @@ func TestBitbucketServer_GetRepositoryEnvironmentInfo(t *testing.T) {
 
 	_, err = client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
+
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{Name: envName})
+	assert.ErrorIs(t, err, errBitbucketCreateOrUpdateEnvironmentNotSupported)
 }
 
 func TestBitbucketServer_GetCommitBySha(t *testing.T) {
@@ -651,6 +1526,7 @@ func TestBitbucketServer_GetCommitBySha(t *testing.T) {
 		AuthorName:    "charlie",
 		CommitterName: "mark",
 		Url:           expectedUrl,
+		HTMLURL:       expectedUrl,
 		Timestamp:     1636089306,
 		Message:       "WIP on feature 1",
 		ParentHashes:  []string{"bbcdef0123abcdef4567abcdef8987abcdef6543"},
@@ -661,6 +1537,24 @@ func TestBitbucketServer_GetCommitBySha(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_GetCommitDiff(t *testing.T) {
+	ctx := context.Background()
+	sha := "def0123abcdef4567abcdef8987abcdef6543abc"
+	diff, err := os.ReadFile(filepath.Join("testdata", "commit_diff.patch"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, diff,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/patch?until=%s", owner, repo1, sha), createBitbucketServerHandler)
+	defer cleanUp()
+
+	commitDiff, err := client.GetCommitDiff(ctx, owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, string(diff), commitDiff.Diff)
+	assert.Equal(t, FileDiffInfo{Path: "src/new.go", PreviousPath: "src/old.go", Additions: 1, Deletions: 1}, commitDiff.Files[1])
+
+	_, err = createBadBitbucketServerClient(t).GetCommitDiff(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_GetCommitByShaNotFound(t *testing.T) {
 	ctx := context.Background()
 	sha := "bbcdef0123abcdef4567abcdef8987abcdef6543"
@@ -693,6 +1587,26 @@ func TestBitbucketServer_UploadCodeScanning(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_CodeScanningAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, "", "unsupportedTest", createBitbucketServerHandler)
+	defer cleanUp()
+	_, err := client.ListCodeScanningAlerts(ctx, owner, repo1, CodeScanningAlertsQueryOptions{})
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+	_, err = client.GetCodeScanningAlert(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+	err = client.DismissCodeScanningAlert(ctx, owner, repo1, 1, DismissFalsePositive, "")
+	assert.ErrorIs(t, err, errBitbucketCodeScanningNotSupported)
+}
+
+func TestBitbucketServer_ListVulnerabilityAlerts(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, "", "unsupportedTest", createBitbucketServerHandler)
+	defer cleanUp()
+	_, err := client.ListVulnerabilityAlerts(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketVulnerabilityAlertsNotSupported)
+}
+
 func TestBitbucketServer_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
 	expectedPayload := []byte("hello world")
@@ -715,6 +1629,54 @@ func TestBitbucketServer_DownloadFileFromRepo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_DownloadFileFromRepoStream(t *testing.T) {
+	ctx := context.Background()
+	expectedPayload := []byte("hello world")
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, expectedPayload, "", createBitbucketServerDownloadFileFromRepositoryHandler)
+	defer cleanUp()
+
+	var buffer bytes.Buffer
+	assert.NoError(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "hello-world", &buffer, 0))
+	assert.Equal(t, expectedPayload, buffer.Bytes())
+
+	buffer.Reset()
+	err := client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "hello-world", &buffer, 5)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, "hello", buffer.String())
+
+	assert.Error(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "bad-test", &buffer, 0))
+	assert.Error(t, createBadBitbucketServerClient(t).DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "hello-world", &buffer, 0))
+}
+
+func TestBitbucketServer_FileExistsInRepo(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		response       []byte
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{name: "file", response: []byte(`{"type": "FILE"}`), statusCode: http.StatusOK, expectedExists: true},
+		{name: "directory", response: []byte(`{"type": "DIRECTORY"}`), statusCode: http.StatusOK, expectedExists: false},
+		{name: "not found", response: []byte(`{"errors": []}`), statusCode: http.StatusNotFound, expectedExists: false},
+		{name: "error", response: []byte(`{"errors": []}`), statusCode: http.StatusUnauthorized, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketServer, false, tt.response,
+				"/rest/api/1.0/projects/jfrog/repos/repo-1/browse/dir/package.json?type=true&at=branch-1", tt.statusCode, createBitbucketServerHandler)
+			defer cleanUp()
+			exists, err := client.FileExistsInRepo(ctx, owner, repo1, branch1, "dir/package.json")
+			assert.Equal(t, tt.expectedError, err != nil)
+			assert.Equal(t, tt.expectedExists, exists)
+		})
+	}
+
+	_, err := createBadBitbucketServerClient(t).FileExistsInRepo(ctx, owner, repo1, branch1, "package.json")
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_getRepositoryVisibility(t *testing.T) {
 	assert.Equal(t, Public, getBitbucketServerRepositoryVisibility(true))
 	assert.Equal(t, Private, getBitbucketServerRepositoryVisibility(false))
@@ -744,6 +1706,32 @@ func TestBitbucketServerClient_GetModifiedFiles(t *testing.T) {
 		assert.Equal(t, []string{"path/to/file.txt", "path/to/other_file.txt", "path/to/other_file2.txt"}, actual)
 	})
 
+	t.Run("with details", func(t *testing.T) {
+		response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "compare_commits.json"))
+		assert.NoError(t, err)
+
+		client, closeServer := createBodyHandlingServerAndClient(
+			t,
+			vcsutils.BitbucketServer,
+			false,
+			response,
+			"/rest/api/1.0/projects/jfrog/repos/repo-1/compare/diff?contextLines=0&from=sha-2&to=sha-1",
+			http.StatusOK,
+			nil,
+			http.MethodGet,
+			createBitbucketServerWithBodyHandler,
+		)
+		defer closeServer()
+
+		modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repo1, "sha-1", "sha-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []ModifiedFileInfo{
+			{Path: "path/to/file.txt", Status: FileModified, Additions: 2, Deletions: 1},
+			{Path: "path/to/other_file.txt", Status: FileModified, Additions: 2, Deletions: 1},
+			{Path: "path/to/other_file2.txt", Status: FileRenamed, PreviousPath: "path/to/other_file.txt", Additions: 2, Deletions: 1},
+		}, modifiedFiles)
+	})
+
 	t.Run("validation fails", func(t *testing.T) {
 		client := BitbucketServerClient{}
 		_, err := client.GetModifiedFiles(ctx, "", repo1, "sha-1", "sha-2")
@@ -806,9 +1794,15 @@ func createBitbucketServerListRepositoriesHandler(t *testing.T, _ string, _ []by
 			responseObj = map[string][]bitbucketv1.Project{"values": {{Key: username}}}
 			w.Header().Add("X-Ausername", username)
 		case "/rest/api/1.0/projects/~FROGGER/repos?start=0":
-			responseObj = map[string][]bitbucketv1.Repository{"values": {{Slug: repo1}}}
+			responseObj = map[string][]bitbucketv1.Repository{"values": {{Slug: repo1, Public: true}}}
 		case "/rest/api/1.0/projects/frogger/repos?start=0":
 			responseObj = map[string][]bitbucketv1.Repository{"values": {{Slug: repo2}}}
+		case "/rest/api/1.0/projects/~FROGGER/repos/repo-1/branches/default":
+			responseObj = bitbucketv1.Branch{ID: "refs/heads/master", DisplayID: "master"}
+		case "/rest/api/1.0/projects/frogger/repos/repo-2/branches/default":
+			// An empty repository has no default branch
+			w.WriteHeader(http.StatusNotFound)
+			return
 		default:
 			assert.Fail(t, "Unexpected request Uri "+r.RequestURI)
 		}
@@ -893,6 +1887,38 @@ func TestBitbucketServer_TestGetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestBitbucketServer_GetCommitStatusesOfBranch(t *testing.T) {
+	ctx := context.Background()
+	commitsResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+	assert.NoError(t, err)
+	statusesResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commits_statuses.json"))
+	assert.NoError(t, err)
+	var requestedURIs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURIs = append(requestedURIs, r.RequestURI)
+		response := statusesResponse
+		if strings.Contains(r.RequestURI, "/commits?") {
+			response = commitsResponse
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	commitStatuses, err := client.GetCommitStatuses(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Len(t, commitStatuses, 3)
+	_, err = client.GetCommitStatuses(ctx, owner, repo1, "refs/heads/master")
+	assert.NoError(t, err)
+	// The branch head commit is fetched once
+	assert.Equal(t, []string{
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=50&limit=50&until=master", owner, repo1),
+		"/rest/build-status/1.0/commits/def0123abcdef4567abcdef8987abcdef6543abc",
+		"/rest/build-status/1.0/commits/def0123abcdef4567abcdef8987abcdef6543abc",
+	}, requestedURIs)
+}
+
 func TestBitbucketServerClient_DeletePullRequestReviewComments(t *testing.T) {
 	ctx := context.Background()
 	prId := 4
@@ -925,6 +1951,48 @@ func TestBitbucketServerClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServerClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	prId := 4
+	commentId := 10
+	uri := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", owner, repo1, prId, commentId)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uri, r.RequestURI)
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		if r.Method == http.MethodPut {
+			b, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"text":"Updated content","version":3}`, string(b))
+		}
+		_, err := w.Write([]byte(`{"id":10,"version":3,"text":"Old content"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, prId, commentId, "Updated content")
+	assert.NoError(t, err)
+	err = client.UpdatePullRequestReviewComment(ctx, owner, repo1, prId, CommentInfo{ID: int64(commentId)}, "Updated content")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).UpdatePullRequestComment(ctx, owner, repo1, prId, commentId, "Updated content")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServerClient_ReplyToPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/comments", owner, repo1), http.StatusCreated,
+		[]byte(`{"text":"Reply content","parent":{"id":10}}`), http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+	err := client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 1, "10", "Reply content")
+	assert.NoError(t, err)
+	err = client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 1, "not-a-number", "Reply content")
+	assert.Error(t, err)
+	err = createBadBitbucketServerClient(t).ReplyToPullRequestReviewComment(ctx, owner, repo1, 1, "10", "Reply content")
+	assert.Error(t, err)
+}
+
 func createBadBitbucketServerClient(t *testing.T) VcsClient {
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint("https://bad^endpoint").Build()
 	assert.NoError(t, err)
@@ -1005,3 +2073,25 @@ func TestGetCommitsInDateRate(t *testing.T) {
 		})
 	}
 }
+
+func TestBitbucketServerClient_PullRequestAttachments(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.UploadPullRequestAttachment(ctx, owner, repo1, 1, "report.json", []byte("{}"))
+	assert.ErrorIs(t, err, errBitbucketPullRequestAttachmentsNotSupported)
+	_, err = client.ListPullRequestAttachments(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errBitbucketPullRequestAttachmentsNotSupported)
+}
+
+func TestBitbucketServerClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.ErrorIs(t, err, errBitbucketCheckRunsNotSupported)
+	err = client.UpdateCheckRun(ctx, owner, repo1, 1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.ErrorIs(t, err, errBitbucketCheckRunsNotSupported)
+}
@@ -0,0 +1,77 @@
+package vcsclient
+
+import (
+	"context"
+	"time"
+)
+
+// BulkLabelOptions configures LabelPullRequests and UnlabelPullRequests
+type BulkLabelOptions struct {
+	// The maximal number of pull requests updated concurrently. Defaults to 5.
+	Concurrency int
+	// The minimal interval between the updates of two pull requests, shared by all the concurrent updates.
+	// Use it to stay below the rate limit of the VCS provider. Zero disables the rate limiting.
+	Interval time.Duration
+}
+
+// PullRequestLabelResult is the result of a bulk label operation on a single pull request
+type PullRequestLabelResult struct {
+	PullRequestID int
+	// The error returned while updating the pull request, or nil if the update succeeded
+	Err error
+}
+
+// LabelPullRequests adds a label to each of the pull requests.
+// The pull requests are updated concurrently, and a failure to update one of them doesn't stop the others.
+// Returns a result for each of the pull requests, in the order of pullRequestIDs.
+func LabelPullRequests(ctx context.Context, client VcsClient, owner, repository, label string, pullRequestIDs []int, options BulkLabelOptions) []PullRequestLabelResult {
+	return runOnPullRequests(ctx, pullRequestIDs, options, func(pullRequestID int) error {
+		return client.LabelPullRequest(ctx, owner, repository, label, pullRequestID)
+	})
+}
+
+// UnlabelPullRequests removes a label from each of the pull requests.
+// The pull requests are updated concurrently, and a failure to update one of them doesn't stop the others.
+// Returns a result for each of the pull requests, in the order of pullRequestIDs.
+func UnlabelPullRequests(ctx context.Context, client VcsClient, owner, repository, label string, pullRequestIDs []int, options BulkLabelOptions) []PullRequestLabelResult {
+	return runOnPullRequests(ctx, pullRequestIDs, options, func(pullRequestID int) error {
+		return client.UnlabelPullRequest(ctx, owner, repository, label, pullRequestID)
+	})
+}
+
+func runOnPullRequests(ctx context.Context, pullRequestIDs []int, options BulkLabelOptions, action func(pullRequestID int) error) []PullRequestLabelResult {
+	var rateLimiter <-chan time.Time
+	if options.Interval > 0 {
+		ticker := time.NewTicker(options.Interval)
+		defer ticker.Stop()
+		rateLimiter = ticker.C
+	}
+	_, errs := runConcurrently(ctx, pullRequestIDs, options.Concurrency, func(pullRequestID int) (struct{}, error) {
+		if err := waitForRateLimiter(ctx, rateLimiter); err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, action(pullRequestID)
+	})
+	results := make([]PullRequestLabelResult, len(pullRequestIDs))
+	for i, pullRequestID := range pullRequestIDs {
+		results[i] = PullRequestLabelResult{PullRequestID: pullRequestID, Err: errs[i]}
+	}
+	return results
+}
+
+// Waits for the next tick of the rate limiter, shared by all the workers.
+// Returns the context error if the context is done before.
+func waitForRateLimiter(ctx context.Context, rateLimiter <-chan time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if rateLimiter == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-rateLimiter:
+		return nil
+	}
+}
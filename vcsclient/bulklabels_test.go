@@ -0,0 +1,89 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelPullRequests(t *testing.T) {
+	var concurrentRequests, maxConcurrentRequests int32
+	var requestedURIsMutex sync.Mutex
+	var requestedURIs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&concurrentRequests, 1)
+		defer atomic.AddInt32(&concurrentRequests, -1)
+		for {
+			maxSoFar := atomic.LoadInt32(&maxConcurrentRequests)
+			if current <= maxSoFar || atomic.CompareAndSwapInt32(&maxConcurrentRequests, maxSoFar, current) {
+				break
+			}
+		}
+		requestedURIsMutex.Lock()
+		requestedURIs = append(requestedURIs, r.Method+" "+r.RequestURI)
+		requestedURIsMutex.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		if r.RequestURI == "/repos/jfrog/repo-1/issues/3/labels" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte("[]"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	results := LabelPullRequests(context.Background(), client, owner, repo1, labelName, []int{1, 2, 3, 4, 5}, BulkLabelOptions{Concurrency: 2})
+	if assert.Len(t, results, 5) {
+		for i, result := range results {
+			assert.Equal(t, i+1, result.PullRequestID)
+			if result.PullRequestID == 3 {
+				assert.Error(t, result.Err)
+			} else {
+				assert.NoError(t, result.Err)
+			}
+		}
+	}
+	assert.LessOrEqual(t, maxConcurrentRequests, int32(2))
+	assert.Len(t, requestedURIs, 5)
+	for i := 1; i <= 5; i++ {
+		assert.Contains(t, requestedURIs, fmt.Sprintf("POST /repos/jfrog/repo-1/issues/%d/labels", i))
+	}
+}
+
+func TestUnlabelPullRequests(t *testing.T) {
+	var requestsCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestsCount, 1)
+		assert.Equal(t, http.MethodDelete, r.Method)
+		_, err := w.Write([]byte("[]"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	start := time.Now()
+	results := UnlabelPullRequests(context.Background(), client, owner, repo1, labelName, []int{1, 2, 3}, BulkLabelOptions{Interval: 20 * time.Millisecond})
+	// The rate limiter is shared by the workers, so the updates are spread over the intervals
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+	assert.Equal(t, []PullRequestLabelResult{{PullRequestID: 1}, {PullRequestID: 2}, {PullRequestID: 3}}, results)
+	assert.Equal(t, int32(3), requestsCount)
+}
+
+func TestLabelPullRequestsContextCanceled(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := LabelPullRequests(ctx, client, owner, repo1, labelName, []int{1, 2}, BulkLabelOptions{})
+	assert.Equal(t, []PullRequestLabelResult{{PullRequestID: 1, Err: context.Canceled}, {PullRequestID: 2, Err: context.Canceled}}, results)
+}
@@ -0,0 +1,268 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// Capability is a group of VcsClient operations, which isn't supported by all the VCS providers
+type Capability string
+
+const (
+	// AddSshKeyToRepository
+	SshKeysCapability Capability = "ssh-keys"
+	// CreateDeployToken
+	DeployTokensCapability Capability = "deploy-tokens"
+	// CreateWebhook, UpdateWebhook and DeleteWebhook
+	WebhooksCapability Capability = "webhooks"
+	// CreateCheckRun and UpdateCheckRun
+	CheckRunsCapability Capability = "check-runs"
+	// ReopenPullRequest
+	ReopenPullRequestCapability Capability = "reopen-pull-request"
+	// EnablePullRequestAutoMerge
+	AutoMergeCapability Capability = "auto-merge"
+	// ListPullRequestReviewComments and UpdatePullRequestReviewComment
+	PullRequestReviewCommentsCapability Capability = "pull-request-review-comments"
+	// DeletePullRequestComment and DeletePullRequestReviewComments
+	DeletePullRequestCommentsCapability Capability = "delete-pull-request-comments"
+	// UploadPullRequestAttachment and ListPullRequestAttachments
+	PullRequestAttachmentsCapability Capability = "pull-request-attachments"
+	// ListPullRequestLabels, LabelPullRequest and UnlabelPullRequest. On Bitbucket, the labels are kept in the pull request description.
+	LabelsCapability Capability = "labels"
+	// CreateLabel and GetLabel. On Bitbucket, there are no repository labels, so every label exists and creating it does nothing.
+	RepositoryLabelsCapability Capability = "repository-labels"
+	// GetCommits
+	GetCommitsCapability Capability = "get-commits"
+	// GetCommitsWithQueryOptions
+	GetCommitsWithQueryOptionsCapability Capability = "get-commits-with-query-options"
+	// GetCommitBySha
+	GetCommitByShaCapability Capability = "get-commit-by-sha"
+	// GetCommitDiff
+	GetCommitDiffCapability Capability = "get-commit-diff"
+	// DownloadFileFromRepo
+	DownloadFileFromRepoCapability Capability = "download-file-from-repo"
+	// GetRepositoryEnvironmentInfo
+	RepositoryEnvironmentsCapability Capability = "repository-environments"
+	// CreateOrUpdateEnvironment
+	ManageRepositoryEnvironmentsCapability Capability = "manage-repository-environments"
+	// UploadCodeScanning
+	UploadCodeScanningCapability Capability = "upload-code-scanning"
+	// ListCodeScanningAlerts, GetCodeScanningAlert and DismissCodeScanningAlert
+	CodeScanningAlertsCapability Capability = "code-scanning-alerts"
+	// ListVulnerabilityAlerts
+	VulnerabilityAlertsCapability Capability = "vulnerability-alerts"
+	// ArchiveRepository and UnarchiveRepository
+	ArchiveRepositoryCapability Capability = "archive-repository"
+	// GetUserPermissionOnRepo
+	RepositoryPermissionsCapability Capability = "repository-permissions"
+	// GetRepositoryMirrors and SetRepositoryMirror. On GitHub, the mirrors can only be read.
+	RepositoryMirrorsCapability Capability = "repository-mirrors"
+	// CreateIssue, ListIssues, CommentOnIssue and CloseIssue
+	IssuesCapability Capability = "issues"
+	// SearchCode
+	CodeSearchCapability Capability = "code-search"
+	// UpdatePullRequestBranch
+	PullRequestBranchUpdateCapability Capability = "pull-request-branch-update"
+	// ListMilestones and SetPullRequestMilestone
+	MilestonesCapability Capability = "milestones"
+	// GetRepositoryTopics and SetRepositoryTopics. On Bitbucket Server, the topics are the labels of the repository.
+	RepositoryTopicsCapability Capability = "repository-topics"
+	// CreateGroupWebhook, UpdateGroupWebhook and DeleteGroupWebhook
+	GroupWebhooksCapability Capability = "group-webhooks"
+	// CILint
+	CILintCapability Capability = "ci-lint"
+	// ListRepositoryCollaborators
+	CollaboratorsCapability Capability = "collaborators"
+	// SquashBranchIntoSingleCommit
+	SquashBranchCapability Capability = "squash-branch"
+	// ListCommitsBetween, ListMergedPullRequestsOfCommit and GetPullRequestsByCommitRange
+	CommitRangePullRequestsCapability Capability = "commit-range-pull-requests"
+)
+
+// All the capabilities, in the order returned by the Capabilities method of the VCS clients
+var allCapabilities = []Capability{
+	SshKeysCapability,
+	DeployTokensCapability,
+	WebhooksCapability,
+	CheckRunsCapability,
+	ReopenPullRequestCapability,
+	AutoMergeCapability,
+	PullRequestReviewCommentsCapability,
+	DeletePullRequestCommentsCapability,
+	PullRequestAttachmentsCapability,
+	LabelsCapability,
+	RepositoryLabelsCapability,
+	GetCommitsCapability,
+	GetCommitsWithQueryOptionsCapability,
+	GetCommitByShaCapability,
+	GetCommitDiffCapability,
+	DownloadFileFromRepoCapability,
+	RepositoryEnvironmentsCapability,
+	ManageRepositoryEnvironmentsCapability,
+	UploadCodeScanningCapability,
+	CodeScanningAlertsCapability,
+	VulnerabilityAlertsCapability,
+	ArchiveRepositoryCapability,
+	RepositoryPermissionsCapability,
+	RepositoryMirrorsCapability,
+	IssuesCapability,
+	CodeSearchCapability,
+	PullRequestBranchUpdateCapability,
+	MilestonesCapability,
+	RepositoryTopicsCapability,
+	GroupWebhooksCapability,
+	CILintCapability,
+	CollaboratorsCapability,
+	SquashBranchCapability,
+	CommitRangePullRequestsCapability,
+}
+
+// The minimal self-hosted server version, which supports the capability, by VCS provider.
+// On Azure Repos, the version is the maximal REST API version of the server.
+var minimalCapabilityVersions = map[vcsutils.VcsProvider]map[Capability]string{
+	vcsutils.GitHub: {
+		UploadCodeScanningCapability:  "3.0",
+		CodeScanningAlertsCapability:  "3.0",
+		VulnerabilityAlertsCapability: "3.8",
+	},
+	vcsutils.BitbucketServer: {
+		DeployTokensCapability:      bitbucketServerRepositoryAccessTokensMinimalVersion,
+		WebhooksCapability:          "5.4",
+		AutoMergeCapability:         bitbucketServerAutoMergeMinimalVersion,
+		ArchiveRepositoryCapability: bitbucketServerArchiveRepositoryMinimalVersion,
+	},
+	vcsutils.AzureRepos: {
+		PullRequestAttachmentsCapability: "5.0",
+	},
+}
+
+// The capabilities of which the operations return a not supported error, by VCS provider
+var unsupportedCapabilities = map[vcsutils.VcsProvider][]Capability{
+	vcsutils.GitHub: {
+		DeployTokensCapability,
+		PullRequestAttachmentsCapability,
+	},
+	vcsutils.GitLab: {
+		CheckRunsCapability,
+		ManageRepositoryEnvironmentsCapability,
+		UploadCodeScanningCapability,
+		SquashBranchCapability,
+	},
+	vcsutils.BitbucketServer: {
+		CheckRunsCapability,
+		PullRequestAttachmentsCapability,
+		RepositoryEnvironmentsCapability,
+		ManageRepositoryEnvironmentsCapability,
+		UploadCodeScanningCapability,
+		CodeScanningAlertsCapability,
+		VulnerabilityAlertsCapability,
+		RepositoryMirrorsCapability,
+		IssuesCapability,
+		PullRequestBranchUpdateCapability,
+		MilestonesCapability,
+		GroupWebhooksCapability,
+		CILintCapability,
+		SquashBranchCapability,
+		CommitRangePullRequestsCapability,
+	},
+	vcsutils.BitbucketCloud: {
+		DeployTokensCapability,
+		CheckRunsCapability,
+		ReopenPullRequestCapability,
+		AutoMergeCapability,
+		PullRequestReviewCommentsCapability,
+		DeletePullRequestCommentsCapability,
+		PullRequestAttachmentsCapability,
+		GetCommitsCapability,
+		RepositoryEnvironmentsCapability,
+		ManageRepositoryEnvironmentsCapability,
+		UploadCodeScanningCapability,
+		CodeScanningAlertsCapability,
+		VulnerabilityAlertsCapability,
+		ArchiveRepositoryCapability,
+		RepositoryMirrorsCapability,
+		PullRequestBranchUpdateCapability,
+		MilestonesCapability,
+		RepositoryTopicsCapability,
+		GroupWebhooksCapability,
+		CILintCapability,
+		CollaboratorsCapability,
+		SquashBranchCapability,
+		CommitRangePullRequestsCapability,
+	},
+	vcsutils.AzureRepos: {
+		SshKeysCapability,
+		DeployTokensCapability,
+		WebhooksCapability,
+		CheckRunsCapability,
+		LabelsCapability,
+		RepositoryLabelsCapability,
+		GetCommitsWithQueryOptionsCapability,
+		GetCommitByShaCapability,
+		RepositoryEnvironmentsCapability,
+		ManageRepositoryEnvironmentsCapability,
+		UploadCodeScanningCapability,
+		CodeScanningAlertsCapability,
+		VulnerabilityAlertsCapability,
+		ArchiveRepositoryCapability,
+		RepositoryPermissionsCapability,
+		RepositoryMirrorsCapability,
+		IssuesCapability,
+		CodeSearchCapability,
+		MilestonesCapability,
+		RepositoryTopicsCapability,
+		GroupWebhooksCapability,
+		CILintCapability,
+		CollaboratorsCapability,
+		SquashBranchCapability,
+		CommitRangePullRequestsCapability,
+	},
+}
+
+// IsCapabilitySupported returns true if the VCS provider supports the operations of the capability.
+// Some capabilities require a minimal version of self-hosted servers, which is considered by the Capabilities method of the VCS clients.
+func IsCapabilitySupported(vcsProvider vcsutils.VcsProvider, capability Capability) bool {
+	for _, unsupportedCapability := range unsupportedCapabilities[vcsProvider] {
+		if unsupportedCapability == capability {
+			return false
+		}
+	}
+	return true
+}
+
+// getServerCapabilities returns the capabilities the VCS provider supports, considering the version of its server
+// getServerVersion - The GetServerVersion method of the VCS client
+func getServerCapabilities(ctx context.Context, vcsProvider vcsutils.VcsProvider, getServerVersion func(ctx context.Context) (string, error)) ([]Capability, error) {
+	serverVersion, err := getServerVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the server version of %s: %w", vcsProvider, err)
+	}
+	var capabilities []Capability
+	for _, capability := range allCapabilities {
+		if !IsCapabilitySupported(vcsProvider, capability) {
+			continue
+		}
+		if minimalVersion, exists := minimalCapabilityVersions[vcsProvider][capability]; exists && !isServerVersionAtLeast(serverVersion, minimalVersion) {
+			continue
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
+// validateCapabilities returns an error listing the required capabilities, which the VCS provider doesn't support
+func validateCapabilities(vcsProvider vcsutils.VcsProvider, requiredCapabilities []Capability) error {
+	var missingCapabilities []string
+	for _, capability := range requiredCapabilities {
+		if !IsCapabilitySupported(vcsProvider, capability) {
+			missingCapabilities = append(missingCapabilities, string(capability))
+		}
+	}
+	if len(missingCapabilities) > 0 {
+		return fmt.Errorf("the following required capabilities are not supported on %s: %s", vcsProvider, strings.Join(missingCapabilities, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package vcsclient
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCILintNotSupported is returned by CILint on the providers without a CI configuration validation
+var ErrCILintNotSupported = errors.New("validating the CI configuration is not supported by the VCS provider")
+
+// The valid IDs of GitHub workflow jobs
+var gitHubWorkflowJobIDRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// CILintResult is the result of the validation of a CI configuration
+type CILintResult struct {
+	// True if the configuration has no errors
+	Valid    bool
+	Errors   []CILintIssue
+	Warnings []CILintIssue
+	// The configuration with its includes expanded. GitLab only.
+	MergedYAML string
+}
+
+// CILintIssue is an error or a warning of the validation of a CI configuration
+type CILintIssue struct {
+	Message string
+	// The line of the issue in the configuration, or 0 if the provider doesn't return it
+	Line int
+}
+
+// lintGitHubWorkflow validates the structure of a GitHub Actions workflow:
+// the triggering events, and the jobs, each running steps on a runner, or calling a reusable workflow
+func lintGitHubWorkflow(content string) CILintResult {
+	var result CILintResult
+	addError := func(node *yaml.Node, format string, args ...interface{}) {
+		result.Errors = append(result.Errors, CILintIssue{Message: fmt.Sprintf(format, args...), Line: node.Line})
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		result.Errors = append(result.Errors, CILintIssue{Message: err.Error()})
+		return result
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		result.Errors = append(result.Errors, CILintIssue{Message: "the workflow must be a mapping", Line: 1})
+		return result
+	}
+	workflow := document.Content[0]
+	if getYAMLMappingValue(workflow, "on") == nil {
+		addError(workflow, "the workflow must have the 'on' events triggering it")
+	}
+	jobs := getYAMLMappingValue(workflow, "jobs")
+	switch {
+	case jobs == nil:
+		addError(workflow, "the workflow must have 'jobs'")
+	case jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0:
+		addError(jobs, "'jobs' must be a mapping of at least one job")
+	default:
+		for i := 0; i < len(jobs.Content); i += 2 {
+			lintGitHubWorkflowJob(jobs, jobs.Content[i], jobs.Content[i+1], addError)
+		}
+	}
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+func lintGitHubWorkflowJob(jobs, jobID, job *yaml.Node, addError func(node *yaml.Node, format string, args ...interface{})) {
+	if !gitHubWorkflowJobIDRegexp.MatchString(jobID.Value) {
+		addError(jobID, "job ID '%s' must start with a letter or '_', and contain only alphanumeric characters, '-' or '_'", jobID.Value)
+	}
+	if job.Kind != yaml.MappingNode {
+		addError(job, "job '%s' must be a mapping", jobID.Value)
+		return
+	}
+	if needs := getYAMLMappingValue(job, "needs"); needs != nil {
+		neededJobs := []*yaml.Node{needs}
+		if needs.Kind == yaml.SequenceNode {
+			neededJobs = needs.Content
+		}
+		for _, neededJob := range neededJobs {
+			if getYAMLMappingValue(jobs, neededJob.Value) == nil {
+				addError(neededJob, "job '%s' needs job '%s', which doesn't exist", jobID.Value, neededJob.Value)
+			}
+		}
+	}
+	// A job calling a reusable workflow has no runner and steps
+	if getYAMLMappingValue(job, "uses") != nil {
+		return
+	}
+	if getYAMLMappingValue(job, "runs-on") == nil {
+		addError(job, "job '%s' must have 'runs-on', or 'uses' to call a reusable workflow", jobID.Value)
+	}
+	steps := getYAMLMappingValue(job, "steps")
+	if steps == nil || steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		addError(job, "job '%s' must have a sequence of at least one step", jobID.Value)
+		return
+	}
+	for i, step := range steps.Content {
+		hasUses, hasRun := getYAMLMappingValue(step, "uses") != nil, getYAMLMappingValue(step, "run") != nil
+		if step.Kind != yaml.MappingNode || hasUses == hasRun {
+			addError(step, "step %d of job '%s' must have either 'uses' or 'run'", i+1, jobID.Value)
+		}
+	}
+}
+
+// getYAMLMappingValue returns the value of a key of a YAML mapping, or nil if the node isn't a mapping or the key doesn't exist
+func getYAMLMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
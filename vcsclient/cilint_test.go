@@ -0,0 +1,88 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCILintGitHub(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+
+	result, err := client.CILint(context.Background(), owner, repo1, `
+on: [push, pull_request]
+jobs:
+  scan:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: frogbot scan-pull-request
+  release:
+    needs: scan
+    uses: jfrog/workflows/.github/workflows/release.yml@main
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, CILintResult{Valid: true}, result)
+
+	result, err = client.CILint(context.Background(), owner, repo1, `
+name: Frogbot
+jobs:
+  scan:
+    needs: [build]
+    steps:
+      - name: Checkout
+  2nd-scan:
+    runs-on: ubuntu-latest
+    steps: []
+`)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, []CILintIssue{
+		{Message: "the workflow must have the 'on' events triggering it", Line: 2},
+		{Message: "job 'scan' needs job 'build', which doesn't exist", Line: 5},
+		{Message: "job 'scan' must have 'runs-on', or 'uses' to call a reusable workflow", Line: 5},
+		{Message: "step 1 of job 'scan' must have either 'uses' or 'run'", Line: 7},
+		{Message: "job ID '2nd-scan' must start with a letter or '_', and contain only alphanumeric characters, '-' or '_'", Line: 8},
+		{Message: "job '2nd-scan' must have a sequence of at least one step", Line: 9},
+	}, result.Errors)
+
+	result, err = client.CILint(context.Background(), owner, repo1, "on: [push\n")
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestCILintGitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/projects/jfrog%2Frepo-1/ci/lint", r.URL.EscapedPath())
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "scan:\n  script: frogbot\n", body["content"])
+		_, err := w.Write([]byte(`{"valid": false, "errors": ["jobs:scan config should implement a script: or a trigger: keyword"], "warnings": ["jobs:scan may allow multiple pipelines"], "merged_yaml": "scan:\n  script: frogbot\n"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	result, err := client.CILint(context.Background(), owner, repo1, "scan:\n  script: frogbot\n")
+	assert.NoError(t, err)
+	assert.Equal(t, CILintResult{
+		Errors:     []CILintIssue{{Message: "jobs:scan config should implement a script: or a trigger: keyword"}},
+		Warnings:   []CILintIssue{{Message: "jobs:scan may allow multiple pipelines"}},
+		MergedYAML: "scan:\n  script: frogbot\n",
+	}, result)
+}
+
+func TestCILintNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.CILint(context.Background(), owner, repo1, "pipelines: {}")
+	assert.ErrorIs(t, err, ErrCILintNotSupported)
+}
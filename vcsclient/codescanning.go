@@ -0,0 +1,40 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrPayloadTooLarge is returned when a payload exceeds the size limit of the VCS provider, such as a too large code scanning analysis
+type ErrPayloadTooLarge struct {
+	// The measured size of the payload in bytes. For code scanning analyses, the size of the gzip-compressed SARIF.
+	Size int
+	// The size limit in bytes, or 0 if the payload was rejected by the VCS provider with an unknown limit
+	Limit int
+}
+
+func (e *ErrPayloadTooLarge) Error() string {
+	if e.Limit == 0 {
+		return fmt.Sprintf("the payload of %d bytes was rejected by the VCS provider for being too large", e.Size)
+	}
+	return fmt.Sprintf("the payload of %d bytes exceeds the size limit of %d bytes", e.Size, e.Limit)
+}
+
+// codeScanningChunksUploader is implemented by the clients which split a too large code scanning analysis across multiple uploads
+type codeScanningChunksUploader interface {
+	UploadCodeScanningInChunks(ctx context.Context, owner, repository, branch, sarifContent string) ([]string, error)
+}
+
+// UploadCodeScanningInChunks uploads a scanning analysis like UploadCodeScanning. On GitHub, a SARIF exceeding the size limit
+// is split by its runs across multiple uploads, each in a category of its own, as described by GitHubClient.UploadCodeScanningInChunks.
+// On the other providers, the analysis is uploaded by UploadCodeScanning. Returns the IDs of the uploads.
+func UploadCodeScanningInChunks(ctx context.Context, client VcsClient, owner, repository, branch, sarifContent string) ([]string, error) {
+	if uploader, ok := getOptionalMethods[codeScanningChunksUploader](client); ok {
+		return uploader.UploadCodeScanningInChunks(ctx, owner, repository, branch, sarifContent)
+	}
+	id, err := client.UploadCodeScanning(ctx, owner, repository, branch, sarifContent)
+	if err != nil {
+		return nil, err
+	}
+	return []string{id}, nil
+}
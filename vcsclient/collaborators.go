@@ -0,0 +1,54 @@
+package vcsclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrCollaboratorsNotSupported is returned by ListRepositoryCollaborators on the providers which can't list the collaborators of a repository
+var ErrCollaboratorsNotSupported = errors.New("listing the collaborators of a repository is not supported by the VCS provider")
+
+// CollaboratorAffiliation filters the collaborators of a repository by their affiliation
+type CollaboratorAffiliation string
+
+const (
+	// All the collaborators, including the members of the organization or the project inherited by the repository
+	AllCollaborators CollaboratorAffiliation = "all"
+	// The collaborators granted a permission on the repository itself
+	DirectCollaborators CollaboratorAffiliation = "direct"
+	// The collaborators who aren't members of the organization owning the repository. Supported on GitHub only.
+	OutsideCollaborators CollaboratorAffiliation = "outside"
+)
+
+func (affiliation CollaboratorAffiliation) validate() error {
+	switch affiliation {
+	case AllCollaborators, DirectCollaborators, OutsideCollaborators:
+		return nil
+	default:
+		return fmt.Errorf("unknown collaborator affiliation %q, expected one of %q, %q or %q", affiliation, AllCollaborators, DirectCollaborators, OutsideCollaborators)
+	}
+}
+
+// CollaboratorInfo is a collaborator of a repository, with the permission level of the collaborator on the repository
+type CollaboratorInfo struct {
+	Username string
+	// The provider specific permission, normalized as by GetUserPermissionOnRepo
+	Permission RepositoryPermission
+}
+
+// getCollaboratorAffiliation returns the affiliation filtering the collaborators, AllCollaborators if it's empty,
+// or an error if the affiliation is unknown
+func getCollaboratorAffiliation(affiliation CollaboratorAffiliation) (CollaboratorAffiliation, error) {
+	if affiliation == "" {
+		return AllCollaborators, nil
+	}
+	return affiliation, affiliation.validate()
+}
+
+// sortCollaborators sorts collaborators by their usernames
+func sortCollaborators(collaborators []CollaboratorInfo) {
+	sort.Slice(collaborators, func(i, j int) bool {
+		return collaborators[i].Username < collaborators[j].Username
+	})
+}
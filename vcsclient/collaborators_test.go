@@ -0,0 +1,96 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRepositoryCollaboratorsGitHub(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/collaborators", r.URL.Path)
+		assert.Equal(t, "outside", r.URL.Query().Get("affiliation"))
+		_, err := w.Write([]byte(`[
+			{"login": "toad", "role_name": "maintain", "permissions": {"pull": true, "push": true, "maintain": true}},
+			{"login": "frogger", "role_name": "security-reviewer", "permissions": {"pull": true, "triage": true}}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1, OutsideCollaborators)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: ReadPermission}, {Username: "toad", Permission: MaintainPermission}}, collaborators)
+
+	_, err = client.ListRepositoryCollaborators(ctx, owner, repo1, "members")
+	assert.EqualError(t, err, `unknown collaborator affiliation "members", expected one of "all", "direct" or "outside"`)
+}
+
+func TestListRepositoryCollaboratorsGitLab(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/jfrog%2Frepo-1/members/all":
+			response = `[{"username": "toad", "access_level": 50}, {"username": "frogger", "access_level": 30}]`
+		case "/api/v4/projects/jfrog%2Frepo-1/members":
+			response = `[{"username": "frogger", "access_level": 30}]`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: WritePermission}, {Username: "toad", Permission: AdminPermission}}, collaborators)
+
+	collaborators, err = client.ListRepositoryCollaborators(ctx, owner, repo1, DirectCollaborators)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: WritePermission}}, collaborators)
+
+	_, err = client.ListRepositoryCollaborators(ctx, owner, repo1, OutsideCollaborators)
+	assert.ErrorIs(t, err, errGitLabOutsideCollaboratorsNotSupported)
+}
+
+func TestListRepositoryCollaboratorsBitbucketServer(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/permissions/users":
+			response = `{"isLastPage": true, "values": [{"user": {"name": "frogger"}, "permission": "REPO_WRITE"}, {"user": {"name": "toad"}, "permission": "REPO_READ"}]}`
+		case "/rest/api/1.0/projects/jfrog/permissions/users":
+			response = `{"isLastPage": true, "values": [{"user": {"name": "toad"}, "permission": "PROJECT_ADMIN"}, {"user": {"name": "yoshi"}, "permission": "PROJECT_READ"}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1, AllCollaborators)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{
+		{Username: "frogger", Permission: WritePermission},
+		{Username: "toad", Permission: AdminPermission},
+		{Username: "yoshi", Permission: ReadPermission},
+	}, collaborators)
+}
+
+func TestListRepositoryCollaboratorsNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.ListRepositoryCollaborators(context.Background(), owner, repo1, AllCollaborators)
+	assert.ErrorIs(t, err, ErrCollaboratorsNotSupported)
+}
@@ -0,0 +1,164 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// The default prefix of the commands in pull request comments
+const defaultCommentCommandPrefix = "/"
+
+// The variation selector following emoji that are displayed as pictures, which isn't added by all the providers
+const emojiVariationSelector = "\uFE0F"
+
+// CommentCommand is a command in a pull request comment, such as "/rescan high" or ":repeat:"
+type CommentCommand struct {
+	// The name of the command, without the prefix and in lower case, such as "rescan"
+	Name string
+	// The arguments following the name of the command. Quoted arguments may contain spaces.
+	Args []string
+	// The comment containing the command. Populated by ListPullRequestCommentCommands only.
+	Comment CommentInfo
+	// The permission of the author of the comment on the repository. Populated by ListPullRequestCommentCommands only.
+	AuthorPermission RepositoryPermission
+	// The error returned while getting the permission of the author, or nil if the permission was received
+	PermissionErr error
+}
+
+// CommentCommandParser extracts the commands from the content of pull request comments.
+// A command is a line starting with the prefix and the name of the command, followed by its arguments.
+// Commands in quotes and code blocks are ignored, so quoting a comment doesn't run its commands again.
+type CommentCommandParser struct {
+	// The prefix of the commands. Defaults to "/".
+	Prefix string
+	// Emoji that start a line as commands, mapped to the names of the commands.
+	// The emoji may be shortcodes, such as ":repeat:", which are used by GitHub, GitLab and Bitbucket, or Unicode characters, such as "🔁", which are used by Azure Repos.
+	EmojiCommands map[string]string
+}
+
+// Parse returns the commands in the content of a comment, in the order of their lines
+func (parser CommentCommandParser) Parse(content string) []CommentCommand {
+	prefix := parser.Prefix
+	if prefix == "" {
+		prefix = defaultCommentCommandPrefix
+	}
+	emojiCommands := make(map[string]string, len(parser.EmojiCommands))
+	for emoji, name := range parser.EmojiCommands {
+		emojiCommands[normalizeEmoji(emoji)] = strings.ToLower(name)
+	}
+
+	var commands []CommentCommand
+	inCodeBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock || strings.HasPrefix(line, ">") {
+			continue
+		}
+		fields := splitCommandArgs(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if name, found := emojiCommands[normalizeEmoji(fields[0])]; found {
+			commands = append(commands, CommentCommand{Name: name, Args: fields[1:]})
+			continue
+		}
+		if name, found := strings.CutPrefix(fields[0], prefix); found && isValidCommandName(name) {
+			commands = append(commands, CommentCommand{Name: strings.ToLower(name), Args: fields[1:]})
+		}
+	}
+	return commands
+}
+
+// ListPullRequestCommentCommands returns the commands in the comments of a pull request, with the permissions of their authors on the repository.
+// The permission of each author is received once. Failing to receive it doesn't fail the listing, and is returned in the PermissionErr of the commands.
+// On Azure Repos, the commands are extracted from each of the comments of the threads, and the permissions aren't supported.
+func ListPullRequestCommentCommands(ctx context.Context, client VcsClient, owner, repository string, pullRequestID int, parser CommentCommandParser) ([]CommentCommand, error) {
+	var comments []CommentInfo
+	var err error
+	if azureClient, isAzure := getOptionalMethods[*AzureReposClient](client); isAzure {
+		comments, err = azureClient.listPullRequestSingleComments(ctx, repository, pullRequestID)
+	} else {
+		comments, err = client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type authorPermission struct {
+		permission RepositoryPermission
+		err        error
+	}
+	authorPermissions := map[string]authorPermission{}
+	var commands []CommentCommand
+	for _, comment := range comments {
+		parsedCommands := parser.Parse(comment.Content)
+		if len(parsedCommands) == 0 {
+			continue
+		}
+		permission, found := authorPermissions[comment.Author]
+		if !found {
+			if comment.Author == "" {
+				permission.err = errors.New("the author of the comment is unknown")
+			} else {
+				permission.permission, permission.err = client.GetUserPermissionOnRepo(ctx, owner, repository, comment.Author)
+			}
+			authorPermissions[comment.Author] = permission
+		}
+		for _, command := range parsedCommands {
+			command.Comment = comment
+			command.AuthorPermission = permission.permission
+			command.PermissionErr = permission.err
+			commands = append(commands, command)
+		}
+	}
+	return commands, nil
+}
+
+// splitCommandArgs splits a line by whitespaces, keeping the whitespaces within double quotes
+func splitCommandArgs(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inQuotes, inField := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+func isValidCommandName(name string) bool {
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeEmoji(emoji string) string {
+	return strings.ToLower(strings.ReplaceAll(emoji, emojiVariationSelector, ""))
+}
@@ -0,0 +1,86 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentCommandParser_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   CommentCommandParser
+		content  string
+		expected []CommentCommand
+	}{
+		{name: "no commands", content: "Looks good to me", expected: nil},
+		{name: "command", content: "/rescan", expected: []CommentCommand{{Name: "rescan", Args: []string{}}}},
+		{name: "command with args", content: "Please\r\n  /Rescan high \"npm audit\"\r\n", expected: []CommentCommand{{Name: "rescan", Args: []string{"high", "npm audit"}}}},
+		{name: "multiple commands", content: "/rescan\n/ignore CVE-2021-44228", expected: []CommentCommand{{Name: "rescan", Args: []string{}}, {Name: "ignore", Args: []string{"CVE-2021-44228"}}}},
+		{name: "not at line start", content: "run /rescan", expected: nil},
+		{name: "path", content: "/usr/bin/env", expected: nil},
+		{name: "quoted", content: "> /rescan\nDone", expected: nil},
+		{name: "code block", content: "```\n/rescan\n```\n/ignore", expected: []CommentCommand{{Name: "ignore", Args: []string{}}}},
+		{name: "custom prefix", parser: CommentCommandParser{Prefix: "!"}, content: "/rescan\n!rescan", expected: []CommentCommand{{Name: "rescan", Args: []string{}}}},
+		{
+			name:     "emoji",
+			parser:   CommentCommandParser{EmojiCommands: map[string]string{":repeat:": "rescan", "🔁": "rescan"}},
+			content:  ":Repeat: high\n🔁️",
+			expected: []CommentCommand{{Name: "rescan", Args: []string{"high"}}, {Name: "rescan", Args: []string{}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.parser.Parse(tt.content))
+		})
+	}
+}
+
+func TestListPullRequestCommentCommands(t *testing.T) {
+	permissionRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/repos/jfrog/repo-1/issues/1/comments":
+			response = `[{"id": 1, "body": "/rescan", "user": {"login": "frogger"}}, {"id": 2, "body": "LGTM", "user": {"login": "frogger"}},
+				{"id": 3, "body": "/ignore CVE-2021-44228", "user": {"login": "frogger"}}, {"id": 4, "body": "/rescan", "user": {"login": "toad"}}]`
+		case "/repos/jfrog/repo-1/collaborators/frogger/permission":
+			permissionRequests++
+			response = `{"permission": "write"}`
+		case "/repos/jfrog/repo-1/collaborators/toad/permission":
+			permissionRequests++
+			w.WriteHeader(http.StatusNotFound)
+			return
+		default:
+			assert.Fail(t, "Unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	commands, err := ListPullRequestCommentCommands(context.Background(), client, owner, repo1, 1, CommentCommandParser{})
+	assert.NoError(t, err)
+	if assert.Len(t, commands, 3) {
+		assert.Equal(t, "rescan", commands[0].Name)
+		assert.Equal(t, int64(1), commands[0].Comment.ID)
+		assert.Equal(t, "frogger", commands[0].Comment.Author)
+		assert.Equal(t, WritePermission, commands[0].AuthorPermission)
+		assert.NoError(t, commands[0].PermissionErr)
+
+		assert.Equal(t, "ignore", commands[1].Name)
+		assert.Equal(t, []string{"CVE-2021-44228"}, commands[1].Args)
+		assert.Equal(t, WritePermission, commands[1].AuthorPermission)
+
+		assert.Equal(t, "toad", commands[2].Comment.Author)
+		assert.Equal(t, NoPermission, commands[2].AuthorPermission)
+		assert.Error(t, commands[2].PermissionErr)
+	}
+	// The permission of each author is received once
+	assert.Equal(t, 2, permissionRequests)
+}
@@ -0,0 +1,65 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The time a resolved branch head commit is reused, before the branch is fetched again
+const branchHeadCacheTTL = 30 * time.Second
+
+// Matches a full SHA-1 or SHA-256 commit hash
+var commitHashRegexp = regexp.MustCompile(`^(?:[0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`)
+
+// branchHeadResolver resolves a branch name to the hash of its head commit.
+// The resolved hashes are cached for a short time, so that consecutive calls on the same branch fetch it only once.
+type branchHeadResolver struct {
+	mutex sync.Mutex
+	cache map[string]branchHead
+}
+
+type branchHead struct {
+	hash       string
+	resolvedAt time.Time
+}
+
+// resolve returns the commit hash of the ref. A full commit hash or an empty ref is returned as is.
+// Otherwise, the ref is a branch name, optionally prefixed by 'refs/heads/', and the hash of its head commit is returned.
+// getLatestCommit - The GetLatestCommit method of the VCS client
+func (resolver *branchHeadResolver) resolve(ctx context.Context, owner, repository, ref string,
+	getLatestCommit func(ctx context.Context, owner, repository, branch string) (CommitInfo, error)) (string, error) {
+	if ref == "" || commitHashRegexp.MatchString(ref) {
+		return ref, nil
+	}
+	branch := strings.TrimPrefix(ref, "refs/heads/")
+	key := fmt.Sprintf("%s/%s/%s", owner, repository, branch)
+
+	resolver.mutex.Lock()
+	head, exists := resolver.cache[key]
+	resolver.mutex.Unlock()
+	if exists && time.Since(head.resolvedAt) < branchHeadCacheTTL && !isCacheBypassed(ctx) {
+		indicateCacheUsage(ctx, true)
+		return head.hash, nil
+	}
+
+	commit, err := getLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the head commit of branch '%s': %w", branch, err)
+	}
+	if commit.Hash == "" {
+		return "", fmt.Errorf("failed to resolve the head commit of branch '%s': no commits were found", branch)
+	}
+
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	if resolver.cache == nil {
+		resolver.cache = make(map[string]branchHead)
+	}
+	resolver.cache[key] = branchHead{hash: commit.Hash, resolvedAt: time.Now()}
+	indicateCacheUsage(ctx, false)
+	return commit.Hash, nil
+}
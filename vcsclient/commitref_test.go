@@ -0,0 +1,48 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchHeadResolver(t *testing.T) {
+	ctx := context.Background()
+	headHash := "def0123abcdef4567abcdef8987abcdef6543abc"
+	var requestedBranches []string
+	getLatestCommit := func(_ context.Context, _, _, branch string) (CommitInfo, error) {
+		requestedBranches = append(requestedBranches, branch)
+		switch branch {
+		case "master":
+			return CommitInfo{Hash: headHash}, nil
+		case "empty":
+			return CommitInfo{}, nil
+		}
+		return CommitInfo{}, errors.New("branch not found")
+	}
+	var resolver branchHeadResolver
+
+	// Commit hashes aren't resolved
+	for _, ref := range []string{"", "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69", "6d1b0f0c3ba5d0b4bd0b43f3c7d4a0a6a3a5b4e7e2a9c1b0f3d8e7a6b5c4d3e2"} {
+		hash, err := resolver.resolve(ctx, owner, repo1, ref, getLatestCommit)
+		assert.NoError(t, err)
+		assert.Equal(t, ref, hash)
+	}
+	assert.Empty(t, requestedBranches)
+
+	// The branch head is fetched once, and then taken from the cache
+	for _, ref := range []string{"master", "refs/heads/master"} {
+		hash, err := resolver.resolve(ctx, owner, repo1, ref, getLatestCommit)
+		assert.NoError(t, err)
+		assert.Equal(t, headHash, hash)
+	}
+	assert.Equal(t, []string{"master"}, requestedBranches)
+
+	_, err := resolver.resolve(ctx, owner, repo1, "empty", getLatestCommit)
+	assert.EqualError(t, err, "failed to resolve the head commit of branch 'empty': no commits were found")
+
+	_, err = resolver.resolve(ctx, owner, repo1, "missing", getLatestCommit)
+	assert.EqualError(t, err, "failed to resolve the head commit of branch 'missing': branch not found")
+}
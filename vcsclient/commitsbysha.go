@@ -0,0 +1,41 @@
+package vcsclient
+
+import (
+	"context"
+)
+
+// The operation of the *BatchError returned by GetCommitsBySha
+const getCommitsOperation = "get the commits"
+
+// commitsByShaGetter is implemented by the clients which fetch multiple commits by a request
+type commitsByShaGetter interface {
+	GetCommitsBySha(ctx context.Context, owner, repository string, shas []string) (map[string]CommitInfo, error)
+}
+
+// GetCommitsBySha gets multiple commits by their SHAs. On GitHub, the commits are fetched by a GraphQL query per 100 commits.
+// On the other providers, the commits are fetched by GetCommitBySha, at most 5 commits at a time.
+// A failure to get one of the commits doesn't stop the others. Returns the commits by their SHAs,
+// and a *BatchError with the error of each of the commits that wasn't fetched, if any.
+func GetCommitsBySha(ctx context.Context, client VcsClient, owner, repository string, shas []string) (map[string]CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	uniqueShas := uniqueItems(shas)
+	if getter, ok := getOptionalMethods[commitsByShaGetter](client); ok {
+		return getter.GetCommitsBySha(ctx, owner, repository, uniqueShas)
+	}
+
+	results, errs := runConcurrently(ctx, uniqueShas, defaultBatchConcurrency, func(sha string) (CommitInfo, error) {
+		return client.GetCommitBySha(ctx, owner, repository, sha)
+	})
+	commits := make(map[string]CommitInfo, len(uniqueShas))
+	commitErrors := map[string]error{}
+	for i, sha := range uniqueShas {
+		if errs[i] != nil {
+			commitErrors[sha] = errs[i]
+		} else {
+			commits[sha] = results[i]
+		}
+	}
+	return commits, newBatchError(getCommitsOperation, commitErrors)
+}
@@ -0,0 +1,111 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCommitsByShaGitHub(t *testing.T) {
+	const foundSha, missingSha = "6dcb09b5b57875f334f61aebed695e2e4193db5e", "0000000000000000000000000000000000000000"
+	const treeSha, invalidSha = "7638417db6d59f3c431d3e1f261cc637155684cd", "not-a-sha"
+	var graphQLRequests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.RequestURI)
+		var graphQLRequest map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&graphQLRequest))
+		graphQLRequests = append(graphQLRequests, graphQLRequest)
+		_, err := w.Write([]byte(`{"data": {"repository": {"commit0": {"__typename": "Commit", "oid": "` + foundSha + `", "message": "Fix all the bugs",
+			"url": "https://github.com/jfrog/repo-1/commit/` + foundSha + `", "committedDate": "2011-04-14T16:00:49Z",
+			"author": {"name": "Monalisa Octocat", "email": "mona@github.com"}, "committer": {"name": "Frogger"},
+			"parents": {"nodes": [{"oid": "7638417db6d59f3c431d3e1f261cc637155684cd"}]}}, "commit1": null,
+			"commit2": {"__typename": "Tree"}, "commit3": null}},
+			"errors": [{"message": "Could not resolve to a commit", "path": ["repository", "commit3"]}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	commits, err := GetCommitsBySha(context.Background(), client, owner, repo1, []string{foundSha, missingSha, foundSha, treeSha, invalidSha})
+	// The commits are fetched by a single query
+	if assert.Len(t, graphQLRequests, 1) {
+		assert.Equal(t, map[string]interface{}{"owner": owner, "repository": repo1, "sha0": foundSha, "sha1": missingSha, "sha2": treeSha, "sha3": invalidSha},
+			graphQLRequests[0]["variables"])
+		assert.Contains(t, graphQLRequests[0]["query"], "commit1: object(expression: $sha1) { ...commitFields }")
+	}
+	assert.Equal(t, map[string]CommitInfo{foundSha: {
+		Hash:          foundSha,
+		AuthorName:    "Monalisa Octocat",
+		CommitterName: "Frogger",
+		Url:           server.URL + "/repos/jfrog/repo-1/commits/" + foundSha,
+		HTMLURL:       "https://github.com/jfrog/repo-1/commit/" + foundSha,
+		Timestamp:     1302796849,
+		Message:       "Fix all the bugs",
+		ParentHashes:  []string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+		AuthorEmail:   "mona@github.com",
+	}}, commits)
+	// The errors of the response fail only the commits in their paths
+	var commitsErr *BatchError
+	if assert.ErrorAs(t, err, &commitsErr) {
+		assert.Len(t, commitsErr.Errors, 3)
+		assert.EqualError(t, commitsErr.Errors[missingSha], "commit "+missingSha+" wasn't found")
+		assert.EqualError(t, commitsErr.Errors[treeSha], treeSha+" is a Tree object rather than a commit")
+		assert.EqualError(t, commitsErr.Errors[invalidSha], "Could not resolve to a commit")
+	}
+}
+
+func TestGetCommitsByShaGitHubQueryError(t *testing.T) {
+	const sha = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"errors": [{"message": "Could not resolve to a Repository", "path": ["repository"]}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// An error which isn't of a commit fails all the commits of the query
+	commits, err := GetCommitsBySha(context.Background(), client, owner, repo1, []string{sha})
+	assert.Empty(t, commits)
+	var commitsErr *BatchError
+	if assert.ErrorAs(t, err, &commitsErr) {
+		assert.EqualError(t, commitsErr.Errors[sha], "GitHub GraphQL request failed: Could not resolve to a Repository")
+	}
+}
+
+func TestGetCommitsByShaGitLab(t *testing.T) {
+	const foundSha, missingSha = "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a", "ff4a54b88fbd387ac4d9e8cdeb54b049978e450b"
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_single_response.json"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.URL.EscapedPath(), "/api/v4/projects/jfrog%2Frepo-1/repository/commits/"))
+		if strings.HasSuffix(r.URL.Path, missingSha) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	commits, err := GetCommitsBySha(context.Background(), client, owner, repo1, []string{foundSha, missingSha})
+	if assert.Len(t, commits, 1) {
+		assert.Equal(t, "Initial commit", commits[foundSha].Message)
+	}
+	var commitsErr *BatchError
+	if assert.ErrorAs(t, err, &commitsErr) {
+		assert.Len(t, commitsErr.Errors, 1)
+		assert.Contains(t, commitsErr.Errors, missingSha)
+	}
+
+	_, err = GetCommitsBySha(context.Background(), client, owner, "", []string{foundSha})
+	assert.Error(t, err)
+}
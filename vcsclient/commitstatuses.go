@@ -0,0 +1,321 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	// The default interval between the first two polls of WaitForCommitStatuses
+	defaultCommitStatusesPollInterval = 5 * time.Second
+	// The default maximal interval between two polls of WaitForCommitStatuses
+	defaultCommitStatusesMaxPollInterval = time.Minute
+	// The maximal fraction of the poll interval randomly added or subtracted by WaitForCommitStatus and WaitForCommitStatuses
+	commitStatusPollJitter = 0.1
+)
+
+// CommitStatusesPollOptions configures WaitForCommitStatuses
+type CommitStatusesPollOptions struct {
+	// The interval between the first two polls, doubled after each poll. Defaults to 5 seconds.
+	Interval time.Duration
+	// The maximal interval between two polls. Defaults to 1 minute.
+	MaxInterval time.Duration
+	// The maximal duration to wait. Zero waits until the context is done.
+	Timeout time.Duration
+}
+
+// CommitStatusesResult is the aggregated result of the required commit statuses
+type CommitStatusesResult struct {
+	// Error if one of the statuses is an error, otherwise Fail if one of the statuses failed,
+	// otherwise InProgress if one of the required contexts has no status or an in progress status, and Pass if all of them passed.
+	State CommitStatus
+	// The latest status of each of the required contexts, by context. Contexts without a status are missing.
+	Statuses map[string]CommitStatusInfo
+}
+
+// WaitForCommitStatuses polls the commit statuses of a ref, until the statuses of all the required contexts are completed,
+// or until one of them fails or errors, which determines the aggregated result.
+// The interval between the polls grows exponentially, up to the maximal interval.
+// The polls are jittered, and postponed until the rate limit is reset if the provider rejects a poll for exceeding it.
+// A context is the title passed to SetCommitStatus, such as the name of a CI job.
+// Returns the final aggregated result, or the last polled result with an error if the timeout is reached or the context is done.
+func WaitForCommitStatuses(ctx context.Context, client VcsClient, owner, repository, ref string, contexts []string, options CommitStatusesPollOptions) (CommitStatusesResult, error) {
+	if len(contexts) == 0 {
+		return CommitStatusesResult{}, errors.New("at least one commit status context is required")
+	}
+	interval, maxInterval := options.Interval, options.MaxInterval
+	if interval <= 0 {
+		interval = defaultCommitStatusesPollInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = defaultCommitStatusesMaxPollInterval
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	var result CommitStatusesResult
+	nextInterval := func() time.Duration {
+		currentInterval := interval
+		interval = min(interval*2, maxInterval)
+		return currentInterval
+	}
+	err := pollCommitStatuses(ctx, client, owner, repository, ref, "the commit statuses of "+ref, nextInterval, func(statuses []CommitStatusInfo) (bool, error) {
+		result = aggregateCommitStatuses(statuses, contexts)
+		return result.State != InProgress, nil
+	})
+	return result, err
+}
+
+// WaitForCommitStatus polls the commit statuses of a ref, until the latest status of the context is one of the desired states.
+// A context is the title passed to SetCommitStatus, such as the name of a CI job.
+// The polls are jittered, and postponed until the rate limit is reset if the provider rejects a poll for exceeding it.
+// Returns the latest status of the context, with an error if the status is completed in an undesired state,
+// or if the context is done or its deadline is reached before the next poll.
+// pollInterval - The interval between two polls. Defaults to 5 seconds.
+func WaitForCommitStatus(ctx context.Context, client VcsClient, owner, repository, ref, statusContext string, desiredStates []CommitStatus, pollInterval time.Duration) (CommitStatusInfo, error) {
+	if statusContext == "" {
+		return CommitStatusInfo{}, errors.New("a commit status context is required")
+	}
+	if len(desiredStates) == 0 {
+		return CommitStatusInfo{}, errors.New("at least one desired commit status state is required")
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultCommitStatusesPollInterval
+	}
+
+	var status CommitStatusInfo
+	nextInterval := func() time.Duration { return pollInterval }
+	err := pollCommitStatuses(ctx, client, owner, repository, ref, fmt.Sprintf("the commit status %s of %s", statusContext, ref), nextInterval, func(statuses []CommitStatusInfo) (bool, error) {
+		latestStatus, exists := getLatestCommitStatuses(statuses)[statusContext]
+		if !exists {
+			return false, nil
+		}
+		status = latestStatus
+		if slices.Contains(desiredStates, status.State) {
+			return true, nil
+		}
+		if status.State != InProgress {
+			return true, fmt.Errorf("the commit status %s of %s was completed in an undesired state", statusContext, ref)
+		}
+		return false, nil
+	})
+	return status, err
+}
+
+// pollCommitStatuses polls the commit statuses of a ref, until handleStatuses stops the polling.
+// The polls are jittered, and postponed until the rate limit is reset if the provider rejects a poll for exceeding it.
+// The polling is stopped with an error if the context is done, or if its deadline is reached before the next poll.
+// awaited        - A description of the awaited statuses, for the errors
+// nextInterval   - Returns the interval before the next poll
+// handleStatuses - Handles the polled statuses. Returns true to stop the polling, with the error to return, if any.
+func pollCommitStatuses(ctx context.Context, client VcsClient, owner, repository, ref, awaited string,
+	nextInterval func() time.Duration, handleStatuses func(statuses []CommitStatusInfo) (bool, error)) error {
+	for {
+		// A branch is resolved to its head commit in each poll, as new commits may be pushed while waiting
+		statuses, err := client.GetCommitStatuses(WithoutCache(ctx), owner, repository, ref)
+		interval := getJitteredInterval(nextInterval())
+		switch {
+		case err == nil:
+			if done, handleErr := handleStatuses(statuses); done {
+				return handleErr
+			}
+		case ctx.Err() != nil:
+			return fmt.Errorf("stopped waiting for %s: %w", awaited, ctx.Err())
+		default:
+			retryAfter, rateLimited := getRateLimitRetryAfter(err)
+			if !rateLimited {
+				return err
+			}
+			interval = max(interval, retryAfter)
+		}
+		// Avoid sleeping until the deadline, if the next poll wouldn't start before it
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("stopped waiting for %s: %w", awaited, context.DeadlineExceeded)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("stopped waiting for %s: %w", awaited, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// GetCommitStatusesBatch gets the commit statuses of multiple refs, at most concurrency refs at a time. A non-positive concurrency defaults to 5.
+// A failure to get the statuses of one of the refs doesn't stop the others. Returns the commit statuses by the refs,
+// and a *BatchError with the error of each of the refs whose statuses weren't read, if any.
+func GetCommitStatusesBatch(ctx context.Context, client VcsClient, owner, repository string, refs []string, concurrency int) (map[string][]CommitStatusInfo, error) {
+	uniqueRefs := uniqueItems(refs)
+	results, errs := runConcurrently(ctx, uniqueRefs, concurrency, func(ref string) ([]CommitStatusInfo, error) {
+		return client.GetCommitStatuses(ctx, owner, repository, ref)
+	})
+	statuses := make(map[string][]CommitStatusInfo, len(uniqueRefs))
+	refErrors := map[string]error{}
+	for i, ref := range uniqueRefs {
+		if errs[i] != nil {
+			refErrors[ref] = errs[i]
+		} else {
+			statuses[ref] = results[i]
+		}
+	}
+	return statuses, newBatchError("get the commit statuses", refErrors)
+}
+
+// AggregateCommitState returns the summary state of the latest status of each context, in the precedence of the combined status of GitHub:
+// Error if any of the statuses is an error, otherwise Fail if any of them failed, otherwise InProgress if any of them is in progress,
+// and Pass if all of them passed. InProgress is returned when there are no statuses, since the checks may not have started yet.
+func AggregateCommitState(statuses []CommitStatusInfo) CommitStatus {
+	if len(statuses) == 0 {
+		return InProgress
+	}
+	state := Pass
+	for _, status := range getLatestCommitStatuses(statuses) {
+		state = combineCommitStates(state, status.State)
+	}
+	return state
+}
+
+// The precedence of the commit states in an aggregated state, which is the precedence of the combined status of GitHub:
+// Error over Fail, Fail over InProgress, and InProgress over Pass
+var commitStatePrecedence = map[CommitStatus]int{Pass: 0, InProgress: 1, Fail: 2, Error: 3}
+
+// combineCommitStates returns the state of the higher precedence
+func combineCommitStates(state, otherState CommitStatus) CommitStatus {
+	if commitStatePrecedence[otherState] > commitStatePrecedence[state] {
+		return otherState
+	}
+	return state
+}
+
+// combinedCommitStatusGetter is implemented by the clients which get the combined state of the commit statuses by the API of the provider
+type combinedCommitStatusGetter interface {
+	GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error)
+}
+
+// GetCombinedCommitStatus returns the summary state of the commit statuses of a ref.
+// The combined status of the provider is used where available, which is on GitHub, where errored statuses are combined into Fail.
+// On the other providers, all the statuses are fetched and aggregated by AggregateCommitState.
+func GetCombinedCommitStatus(ctx context.Context, client VcsClient, owner, repository, ref string) (CommitStatus, error) {
+	if getter, ok := getOptionalMethods[combinedCommitStatusGetter](client); ok {
+		return getter.GetCombinedCommitStatus(ctx, owner, repository, ref)
+	}
+	statuses, err := client.GetCommitStatuses(ctx, owner, repository, ref)
+	if err != nil {
+		return Error, err
+	}
+	return AggregateCommitState(statuses), nil
+}
+
+// PullRequestCommitStatusOptions configures SetPullRequestCommitStatus
+type PullRequestCommitStatusOptions struct {
+	CommitStatusOptions
+	// Set the status on the merge commit of the pull request, for checks validating the result of the merge rather than the source branch.
+	// The merge commit is exposed by GitHub (refs/pull/<id>/merge) and Azure Repos.
+	// The status is set on the head commit on the other providers, and when the pull request has no merge commit, for example because of conflicts.
+	OnMergeCommit bool
+}
+
+// pullRequestMergeCommitResolver is implemented by the clients which expose the merge commit of a pull request before it's merged.
+type pullRequestMergeCommitResolver interface {
+	GetPullRequestMergeCommit(ctx context.Context, owner, repository string, pullRequestID int) (string, error)
+}
+
+// SetPullRequestCommitStatus sets a commit status on the head commit of a pull request, or on its merge commit if requested by the options.
+// Note that GitHub displays the statuses of the head commit only on the page of the pull request.
+// Returns the hash of the commit the status was set on.
+func SetPullRequestCommitStatus(ctx context.Context, client VcsClient, commitStatus CommitStatus, owner, repository string, pullRequestID int, title, description, detailsURL string, options PullRequestCommitStatusOptions) (string, error) {
+	var ref string
+	if resolver, ok := getOptionalMethods[pullRequestMergeCommitResolver](client); ok && options.OnMergeCommit {
+		mergeCommit, err := resolver.GetPullRequestMergeCommit(ctx, owner, repository, pullRequestID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the merge commit of pull request %d: %w", pullRequestID, err)
+		}
+		ref = mergeCommit
+	}
+	if ref == "" {
+		pullRequest, err := client.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get pull request %d: %w", pullRequestID, err)
+		}
+		if ref = pullRequest.HeadCommitHash; ref == "" {
+			return "", fmt.Errorf("the head commit of pull request %d is missing", pullRequestID)
+		}
+	}
+	return ref, client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, options.CommitStatusOptions)
+}
+
+// getJitteredInterval randomly adds or subtracts up to 10% of the interval, to spread the polls of concurrent waiters
+func getJitteredInterval(interval time.Duration) time.Duration {
+	jitter := time.Duration((rand.Float64()*2 - 1) * commitStatusPollJitter * float64(interval)) // #nosec G404
+	return interval + jitter
+}
+
+// getRateLimitRetryAfter returns true if the request was rejected for exceeding the rate limit of the provider,
+// and the duration to wait before the next request, if provided by the provider.
+func getRateLimitRetryAfter(err error) (time.Duration, bool) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return time.Until(rateLimitErr.Rate.Reset.Time), true
+	}
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseRateLimitErr) {
+		return abuseRateLimitErr.GetRetryAfter(), true
+	}
+	var gitlabErr *gitlab.ErrorResponse
+	if errors.As(err, &gitlabErr) && gitlabErr.Response != nil && gitlabErr.Response.StatusCode == http.StatusTooManyRequests {
+		retryAfterSeconds, _ := strconv.Atoi(gitlabErr.Response.Header.Get("Retry-After"))
+		return time.Duration(retryAfterSeconds) * time.Second, true
+	}
+	// The errors of the other providers contain the response status
+	return 0, strings.Contains(err.Error(), strconv.Itoa(http.StatusTooManyRequests)+" "+http.StatusText(http.StatusTooManyRequests))
+}
+
+// getLatestCommitStatuses returns the latest status of each context
+func getLatestCommitStatuses(statuses []CommitStatusInfo) map[string]CommitStatusInfo {
+	latestStatuses := make(map[string]CommitStatusInfo)
+	for _, status := range statuses {
+		if latestStatus, exists := latestStatuses[status.Context]; !exists || getCommitStatusTime(status).After(getCommitStatusTime(latestStatus)) {
+			latestStatuses[status.Context] = status
+		}
+	}
+	return latestStatuses
+}
+
+// aggregateCommitStatuses aggregates the latest status of each of the required contexts, in the precedence of AggregateCommitState.
+// A required context without a status is in progress.
+func aggregateCommitStatuses(statuses []CommitStatusInfo, contexts []string) CommitStatusesResult {
+	latestStatuses := getLatestCommitStatuses(statuses)
+
+	result := CommitStatusesResult{State: Pass, Statuses: make(map[string]CommitStatusInfo)}
+	for _, statusContext := range contexts {
+		status, exists := latestStatuses[statusContext]
+		if !exists {
+			result.State = combineCommitStates(result.State, InProgress)
+			continue
+		}
+		result.Statuses[statusContext] = status
+		result.State = combineCommitStates(result.State, status.State)
+	}
+	return result
+}
+
+func getCommitStatusTime(status CommitStatusInfo) time.Time {
+	if status.LastUpdatedAt.IsZero() {
+		return status.CreatedAt
+	}
+	return status.LastUpdatedAt
+}
@@ -0,0 +1,331 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForCommitStatuses(t *testing.T) {
+	// The build is pending in the first poll, and completed in the second one
+	var pollsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/commits/main/status?page=1&per_page=100", r.RequestURI)
+		pollsCount++
+		buildState := "pending"
+		if pollsCount > 1 {
+			buildState = "success"
+		}
+		_, err := fmt.Fprintf(w, `{"statuses": [
+			{"state": "%s", "context": "ci/build", "updated_at": "2024-01-01T10:00:00Z"},
+			{"state": "failure", "context": "ci/lint", "updated_at": "2024-01-01T09:00:00Z"},
+			{"state": "success", "context": "ci/lint", "updated_at": "2024-01-01T09:30:00Z"},
+			{"state": "failure", "context": "ci/optional", "updated_at": "2024-01-01T09:00:00Z"}
+		]}`, buildState)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	result, err := WaitForCommitStatuses(context.Background(), client, owner, repo1, "main", []string{"ci/build", "ci/lint"}, CommitStatusesPollOptions{Interval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pollsCount)
+	assert.Equal(t, Pass, result.State)
+	assert.Len(t, result.Statuses, 2)
+	assert.Equal(t, Pass, result.Statuses["ci/lint"].State)
+
+	_, err = WaitForCommitStatuses(context.Background(), client, owner, repo1, "main", nil, CommitStatusesPollOptions{})
+	assert.Error(t, err)
+}
+
+func TestWaitForCommitStatusesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"statuses": [{"state": "success", "context": "ci/build"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// The status of ci/test is never reported
+	result, err := WaitForCommitStatuses(context.Background(), client, owner, repo1, "main", []string{"ci/build", "ci/test"},
+		CommitStatusesPollOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 50 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, InProgress, result.State)
+	assert.Contains(t, result.Statuses, "ci/build")
+	assert.NotContains(t, result.Statuses, "ci/test")
+}
+
+func TestWaitForCommitStatusesRateLimit(t *testing.T) {
+	commitHash := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	// The poll is rejected by the rate limit, and then the build is completed
+	var pollsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pollsCount++
+		if pollsCount == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, err := w.Write([]byte(`{"values": [{"state": "SUCCESSFUL", "key": "ci/build"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	result, err := WaitForCommitStatuses(context.Background(), client, owner, repo1, commitHash, []string{"ci/build"}, CommitStatusesPollOptions{Interval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pollsCount)
+	assert.Equal(t, Pass, result.State)
+
+	// The next poll wouldn't start before the deadline
+	_, err = WaitForCommitStatuses(context.Background(), client, owner, repo1, commitHash, []string{"ci/test"}, CommitStatusesPollOptions{Interval: time.Minute, Timeout: time.Second})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 3, pollsCount)
+}
+
+func TestWaitForCommitStatusesOfBranch(t *testing.T) {
+	commitsResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+	assert.NoError(t, err)
+	var commitsRequestsCount, pollsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := commitsResponse
+		if strings.Contains(r.RequestURI, "/commits?") {
+			commitsRequestsCount++
+		} else {
+			pollsCount++
+			buildState := "INPROGRESS"
+			if pollsCount > 1 {
+				buildState = "SUCCESSFUL"
+			}
+			response = []byte(fmt.Sprintf(`{"values": [{"state": "%s", "key": "ci/build"}], "isLastPage": true}`, buildState))
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	result, err := WaitForCommitStatuses(context.Background(), client, owner, repo1, "master", []string{"ci/build"}, CommitStatusesPollOptions{Interval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, Pass, result.State)
+	// The head commit of the branch is fetched again in each poll
+	assert.Equal(t, 2, pollsCount)
+	assert.Equal(t, 2, commitsRequestsCount)
+}
+
+func TestAggregateCommitStatuses(t *testing.T) {
+	statuses := []CommitStatusInfo{
+		{Context: "build", State: Pass},
+		{Context: "lint", State: Fail},
+		{Context: "scan", State: Error},
+		{Context: "test", State: InProgress},
+	}
+	tests := []struct {
+		contexts      []string
+		expectedState CommitStatus
+	}{
+		{contexts: []string{"build"}, expectedState: Pass},
+		{contexts: []string{"build", "lint"}, expectedState: Fail},
+		{contexts: []string{"lint", "scan"}, expectedState: Error},
+		// A failed or errored context determines the state, even while other contexts are in progress
+		{contexts: []string{"scan", "test"}, expectedState: Error},
+		{contexts: []string{"lint", "deploy"}, expectedState: Fail},
+		{contexts: []string{"build", "deploy"}, expectedState: InProgress},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedState, aggregateCommitStatuses(statuses, test.contexts).State, test.contexts)
+	}
+}
+
+func TestAggregateCommitState(t *testing.T) {
+	oldTime, newTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		statuses      []CommitStatusInfo
+		expectedState CommitStatus
+	}{
+		{statuses: nil, expectedState: InProgress},
+		{statuses: []CommitStatusInfo{{Context: "build", State: Pass}, {Context: "lint", State: Pass}}, expectedState: Pass},
+		{statuses: []CommitStatusInfo{{Context: "build", State: Pass}, {Context: "test", State: InProgress}}, expectedState: InProgress},
+		{statuses: []CommitStatusInfo{{Context: "lint", State: Fail}, {Context: "test", State: InProgress}}, expectedState: Fail},
+		{statuses: []CommitStatusInfo{{Context: "lint", State: Fail}, {Context: "scan", State: Error}}, expectedState: Error},
+		// The latest status of each context is aggregated
+		{statuses: []CommitStatusInfo{{Context: "build", State: Pass, CreatedAt: newTime}, {Context: "build", State: Fail, CreatedAt: oldTime}}, expectedState: Pass},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedState, AggregateCommitState(test.statuses), test.statuses)
+	}
+}
+
+func TestGetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	sha := "abcdef0123abcdef4567abcdef8987abcdef6543"
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.RequestURI)
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/commits/" + sha + "/status?page=1&per_page=1":
+			response = `{"state": "pending", "total_count": 2, "statuses": [{"state": "success", "context": "ci/build"}]}`
+		case "/rest/build-status/1.0/commits/" + sha:
+			response = `{"values": [{"state": "SUCCESSFUL", "key": "build"}, {"state": "FAILED", "key": "test"}], "isLastPage": true}`
+		default:
+			assert.Fail(t, "Unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// The combined status of GitHub is read from its first page
+	state, err := GetCombinedCommitStatus(ctx, buildClient(t, vcsutils.GitHub, false, server), owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, InProgress, state)
+	assert.Len(t, requests, 1)
+
+	state, err = GetCombinedCommitStatus(ctx, buildClient(t, vcsutils.BitbucketServer, true, server), owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, Fail, state)
+}
+
+func TestWaitForCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	commitHash := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	// The poll is rejected by the rate limit, then the build is pending, and then completed
+	var pollsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/build-status/1.0/commits/"+commitHash, r.RequestURI)
+		pollsCount++
+		switch pollsCount {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		case 2:
+			_, err := w.Write([]byte(`{"values": [{"state": "INPROGRESS", "key": "ci/build"}, {"state": "FAILED", "key": "ci/lint"}]}`))
+			assert.NoError(t, err)
+		default:
+			_, err := w.Write([]byte(`{"values": [{"state": "SUCCESSFUL", "key": "ci/build"}, {"state": "FAILED", "key": "ci/lint"}]}`))
+			assert.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	status, err := WaitForCommitStatus(ctx, client, owner, repo1, commitHash, "ci/build", []CommitStatus{Pass}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pollsCount)
+	assert.Equal(t, Pass, status.State)
+
+	// The status was completed in an undesired state
+	status, err = WaitForCommitStatus(ctx, client, owner, repo1, commitHash, "ci/lint", []CommitStatus{Pass}, time.Millisecond)
+	assert.Error(t, err)
+	assert.Equal(t, Fail, status.State)
+
+	// The next poll wouldn't start before the deadline
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = WaitForCommitStatus(timeoutCtx, client, owner, repo1, commitHash, "ci/test", []CommitStatus{Pass}, time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = WaitForCommitStatus(ctx, client, owner, repo1, commitHash, "ci/build", nil, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestSetPullRequestCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	headCommit, mergeCommit := "6dcb09b5b57875f334f61aebed695e2e4193db5e", "e5bd3914e2e596debea16f433f57875b5b90bcd6"
+	mergeable := true
+	var statusRefs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/pulls/1":
+			_, err := fmt.Fprintf(w, `{"number": 1, "mergeable": %t, "merge_commit_sha": "%s",
+				"head": {"sha": "%s", "label": "jfrog:feature", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}},
+				"base": {"label": "jfrog:main", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}}`, mergeable, mergeCommit, headCommit)
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.RequestURI, "/repos/jfrog/repo-1/statuses/"):
+			statusRefs = append(statusRefs, strings.TrimPrefix(r.RequestURI, "/repos/jfrog/repo-1/statuses/"))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	ref, err := SetPullRequestCommitStatus(ctx, client, Pass, owner, repo1, 1, "ci/merge", "Merge result passed", "", PullRequestCommitStatusOptions{OnMergeCommit: true})
+	assert.NoError(t, err)
+	assert.Equal(t, mergeCommit, ref)
+
+	ref, err = SetPullRequestCommitStatus(ctx, client, Pass, owner, repo1, 1, "ci/build", "Build passed", "", PullRequestCommitStatusOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, headCommit, ref)
+
+	// A pull request with conflicts has no merge commit, so the status falls back to the head commit
+	mergeable = false
+	ref, err = SetPullRequestCommitStatus(ctx, client, Fail, owner, repo1, 1, "ci/merge", "Conflicts", "", PullRequestCommitStatusOptions{OnMergeCommit: true})
+	assert.NoError(t, err)
+	assert.Equal(t, headCommit, ref)
+	assert.Equal(t, []string{mergeCommit, headCommit, headCommit}, statusRefs)
+}
+
+func TestGetCommitStatusesBatch(t *testing.T) {
+	var concurrentRequests, maxConcurrentRequests, requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		current := atomic.AddInt32(&concurrentRequests, 1)
+		defer atomic.AddInt32(&concurrentRequests, -1)
+		for {
+			maxSoFar := atomic.LoadInt32(&maxConcurrentRequests)
+			if current <= maxSoFar || atomic.CompareAndSwapInt32(&maxConcurrentRequests, maxSoFar, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		ref, found := strings.CutPrefix(r.URL.Path, "/repos/jfrog/repo-1/commits/")
+		assert.True(t, found, r.RequestURI)
+		ref = strings.TrimSuffix(ref, "/status")
+		if ref == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := fmt.Fprintf(w, `{"statuses": [{"state": "success", "context": "ci/%s"}]}`, ref)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	refs := []string{"sha-1", "sha-2", "missing", "sha-3", "sha-1"}
+	statuses, err := GetCommitStatusesBatch(context.Background(), client, owner, repo1, refs, 2)
+	assert.Len(t, statuses, 3)
+	for _, ref := range []string{"sha-1", "sha-2", "sha-3"} {
+		if assert.Len(t, statuses[ref], 1) {
+			assert.Equal(t, "ci/"+ref, statuses[ref][0].Context)
+			assert.Equal(t, Pass, statuses[ref][0].State)
+		}
+	}
+	var batchError *BatchError
+	if assert.ErrorAs(t, err, &batchError) {
+		assert.Len(t, batchError.Errors, 1)
+		assert.Error(t, batchError.Errors["missing"])
+		assert.ErrorContains(t, err, "failed to get the commit statuses: missing: ")
+	}
+	// The duplicate ref is read once
+	assert.Equal(t, int32(4), requests)
+	assert.LessOrEqual(t, maxConcurrentRequests, int32(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	statuses, err = GetCommitStatusesBatch(ctx, client, owner, repo1, []string{"sha-1"}, 0)
+	assert.Empty(t, statuses)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(4), requests)
+}
@@ -0,0 +1,57 @@
+package vcsclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The metadata of a commit status is appended to its description after this marker, as a JSON object
+const commitStatusMetadataMarker = "metadata:"
+
+// ErrCommitStatusMetadataCorrupted is returned by DecodeCommitStatusMetadata if the metadata of a status can't be decoded,
+// for example because the provider truncated the description
+var ErrCommitStatusMetadataCorrupted = errors.New("the metadata of the commit status is corrupted")
+
+// encodeCommitStatusDescription appends the metadata to the description of a commit status, as a JSON object after the metadata marker.
+// The description is returned as is if there's no metadata.
+func encodeCommitStatusDescription(description string, metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return description, nil
+	}
+	encodedMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the metadata of the commit status: %w", err)
+	}
+	if description == "" {
+		return commitStatusMetadataMarker + string(encodedMetadata), nil
+	}
+	return description + " " + commitStatusMetadataMarker + string(encodedMetadata), nil
+}
+
+// DecodeCommitStatusMetadata returns the metadata set by CommitStatusOptions.Metadata on a commit status,
+// and the description of the status without the metadata. A status without metadata returns nil metadata and its description as is.
+// Returns ErrCommitStatusMetadataCorrupted if the status has metadata which can't be decoded.
+func DecodeCommitStatusMetadata(status CommitStatusInfo) (map[string]string, string, error) {
+	description := status.Description
+	corrupted := false
+	for start := 0; start < len(description); {
+		index := strings.Index(description[start:], commitStatusMetadataMarker+"{")
+		if index < 0 {
+			break
+		}
+		index += start
+		var metadata map[string]string
+		if err := json.Unmarshal([]byte(description[index+len(commitStatusMetadataMarker):]), &metadata); err == nil {
+			return metadata, strings.TrimSuffix(description[:index], " "), nil
+		}
+		// The marker may be a part of the description itself, so the following markers are checked as well
+		corrupted = true
+		start = index + len(commitStatusMetadataMarker)
+	}
+	if corrupted {
+		return nil, description, ErrCommitStatusMetadataCorrupted
+	}
+	return nil, description, nil
+}
@@ -0,0 +1,69 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitStatusMetadata(t *testing.T) {
+	metadata := map[string]string{"scanId": "scan-1", "policyVersion": "2"}
+	tests := []struct {
+		description         string
+		expectedDescription string
+	}{
+		{description: "Scan passed", expectedDescription: `Scan passed metadata:{"policyVersion":"2","scanId":"scan-1"}`},
+		{description: "", expectedDescription: `metadata:{"policyVersion":"2","scanId":"scan-1"}`},
+		// The marker may be a part of the description itself
+		{description: "Parsed metadata:{broken", expectedDescription: `Parsed metadata:{broken metadata:{"policyVersion":"2","scanId":"scan-1"}`},
+	}
+	for _, test := range tests {
+		description, err := encodeCommitStatusDescription(test.description, metadata)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedDescription, description)
+
+		decodedMetadata, decodedDescription, err := DecodeCommitStatusMetadata(CommitStatusInfo{Description: description})
+		assert.NoError(t, err)
+		assert.Equal(t, metadata, decodedMetadata)
+		assert.Equal(t, test.description, decodedDescription)
+	}
+
+	description, err := encodeCommitStatusDescription("Scan passed", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Scan passed", description)
+	decodedMetadata, decodedDescription, err := DecodeCommitStatusMetadata(CommitStatusInfo{Description: description})
+	assert.NoError(t, err)
+	assert.Nil(t, decodedMetadata)
+	assert.Equal(t, "Scan passed", decodedDescription)
+
+	// Truncated by the provider
+	decodedMetadata, decodedDescription, err = DecodeCommitStatusMetadata(CommitStatusInfo{Description: `Scan passed metadata:{"scanId":"sc`})
+	assert.ErrorIs(t, err, ErrCommitStatusMetadataCorrupted)
+	assert.Nil(t, decodedMetadata)
+	assert.Equal(t, `Scan passed metadata:{"scanId":"sc`, decodedDescription)
+}
+
+func TestSetCommitStatusWithMetadata(t *testing.T) {
+	var sentDescription string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/statuses/main", r.URL.Path)
+		var status struct {
+			Description string `json:"description"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		sentDescription = status.Description
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.SetCommitStatusWithOptions(context.Background(), Pass, owner, repo1, "main", "Scan", "Scan passed", "",
+		CommitStatusOptions{Metadata: map[string]string{"scanId": "scan-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `Scan passed metadata:{"scanId":"scan-1"}`, sentDescription)
+}
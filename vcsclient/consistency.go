@@ -0,0 +1,92 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// The default number of list attempts of ListUntilFound
+	defaultListUntilFoundAttempts = 5
+	// The default interval between the list attempts of ListUntilFound
+	defaultListUntilFoundInterval = time.Second
+)
+
+type cacheBypassKey struct{}
+
+type cacheIndicationKey struct{}
+
+// WithoutCache returns a context bypassing the internal caches of the client, such as the cached heads of branches, the server version and the response cache.
+// The reads sent with the context fetch fresh results from the VCS provider, and refresh the caches.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// WithCacheIndication returns a context indicating whether the reads sent with it were served from the internal caches of the client.
+// gotFromCache is set to true when a read is served from a cache, and to false when it is fetched from the VCS provider.
+// Reads which aren't cached by the client don't set it.
+func WithCacheIndication(ctx context.Context, gotFromCache *bool) context.Context {
+	return context.WithValue(ctx, cacheIndicationKey{}, gotFromCache)
+}
+
+// isCacheBypassed returns true if the context was returned from WithoutCache
+func isCacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypassed
+}
+
+// indicateCacheUsage reports whether a read was served from a cache, to the indication set on the context by WithCacheIndication
+func indicateCacheUsage(ctx context.Context, gotFromCache bool) {
+	if indication, ok := ctx.Value(cacheIndicationKey{}).(*bool); ok && indication != nil {
+		*indication = gotFromCache
+	}
+}
+
+// ReadAfterWriteOptions bounds the attempts of ListUntilFound
+type ReadAfterWriteOptions struct {
+	// The maximal number of list attempts. Defaults to 5.
+	MaxAttempts int
+	// The interval between two attempts. Defaults to 1 second.
+	Interval time.Duration
+}
+
+// ListUntilFound lists resources until one of them matches, to read a resource right after creating it.
+// Some providers serve stale list results for a short time after a write, so a just-created resource may be missing from the first lists.
+// The lists bypass the internal caches of the client.
+// Returns the resources of the last attempt, with an error if no resource matched in all the attempts.
+// list    - Lists the resources, for example by calling ListPullRequestComments of the client
+// matches - Returns true for the expected resource, for example by comparing its ID to the ID of the created resource
+func ListUntilFound[T any](ctx context.Context, list func(ctx context.Context) ([]T, error), matches func(T) bool, options ReadAfterWriteOptions) ([]T, error) {
+	maxAttempts, interval := options.MaxAttempts, options.Interval
+	if maxAttempts <= 0 {
+		maxAttempts = defaultListUntilFoundAttempts
+	}
+	if interval <= 0 {
+		interval = defaultListUntilFoundInterval
+	}
+	ctx = WithoutCache(ctx)
+
+	var items []T
+	for attempt := 1; ; attempt++ {
+		var err error
+		if items, err = list(ctx); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if matches(item) {
+				return items, nil
+			}
+		}
+		if attempt == maxAttempts {
+			return items, fmt.Errorf("the expected resource wasn't listed after %d attempts", maxAttempts)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return items, fmt.Errorf("stopped waiting for the expected resource to be listed: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheBypassAndIndication(t *testing.T) {
+	var gotFromCache bool
+	ctx := WithCacheIndication(context.Background(), &gotFromCache)
+	fetches := 0
+	fetchVersion := func(_ context.Context) (string, error) {
+		fetches++
+		return "8.9.0", nil
+	}
+	var resolver serverVersionResolver
+
+	_, err := resolver.resolve(ctx, fetchVersion)
+	assert.NoError(t, err)
+	assert.False(t, gotFromCache)
+	_, err = resolver.resolve(ctx, fetchVersion)
+	assert.NoError(t, err)
+	assert.True(t, gotFromCache)
+	assert.Equal(t, 1, fetches)
+
+	// The cache is bypassed, and the fetched version is cached again
+	_, err = resolver.resolve(WithoutCache(ctx), fetchVersion)
+	assert.NoError(t, err)
+	assert.False(t, gotFromCache)
+	assert.Equal(t, 2, fetches)
+
+	var branchHeads branchHeadResolver
+	getLatestCommit := func(_ context.Context, _, _, _ string) (CommitInfo, error) {
+		fetches++
+		return CommitInfo{Hash: "def0123abcdef4567abcdef8987abcdef6543abc"}, nil
+	}
+	_, err = branchHeads.resolve(ctx, owner, repo1, "master", getLatestCommit)
+	assert.NoError(t, err)
+	assert.False(t, gotFromCache)
+	_, err = branchHeads.resolve(ctx, owner, repo1, "master", getLatestCommit)
+	assert.NoError(t, err)
+	assert.True(t, gotFromCache)
+	_, err = branchHeads.resolve(WithoutCache(ctx), owner, repo1, "master", getLatestCommit)
+	assert.NoError(t, err)
+	assert.False(t, gotFromCache)
+	assert.Equal(t, 4, fetches)
+}
+
+func TestListUntilFound(t *testing.T) {
+	ctx := context.Background()
+	options := ReadAfterWriteOptions{MaxAttempts: 3, Interval: time.Millisecond}
+	attempts := 0
+	list := func(ctx context.Context) ([]CommentInfo, error) {
+		assert.True(t, isCacheBypassed(ctx))
+		attempts++
+		// The created comment is listed from the second attempt
+		if attempts < 2 {
+			return []CommentInfo{{ID: 1}}, nil
+		}
+		return []CommentInfo{{ID: 1}, {ID: 2}}, nil
+	}
+	isCreatedComment := func(comment CommentInfo) bool { return comment.ID == 2 }
+
+	comments, err := ListUntilFound(ctx, list, isCreatedComment, options)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, 2, attempts)
+
+	// The attempts are bounded
+	attempts = 0
+	comments, err = ListUntilFound(ctx, list, func(comment CommentInfo) bool { return comment.ID == 3 }, options)
+	assert.EqualError(t, err, "the expected resource wasn't listed after 3 attempts")
+	assert.Len(t, comments, 2)
+	assert.Equal(t, 3, attempts)
+
+	_, err = ListUntilFound(ctx, func(_ context.Context) ([]CommentInfo, error) {
+		return nil, errors.New("list failed")
+	}, isCreatedComment, options)
+	assert.EqualError(t, err, "list failed")
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	attempts = 0
+	_, err = ListUntilFound(cancelledCtx, list, func(comment CommentInfo) bool { return comment.ID == 3 }, ReadAfterWriteOptions{MaxAttempts: 3, Interval: time.Minute})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
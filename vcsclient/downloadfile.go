@@ -0,0 +1,94 @@
+package vcsclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// ErrFileTooLarge is returned when a file downloaded by DownloadFileFromRepoStream exceeds the maximal size
+var ErrFileTooLarge = errors.New("the file exceeds the maximal size")
+
+// limitedWriter fails the writes exceeding the maximal size, after writing the bytes within it
+type limitedWriter struct {
+	writer    io.Writer
+	maxBytes  int64
+	remaining int64
+}
+
+// newLimitedWriter limits the bytes written to the writer. A non-positive maxBytes means no limit.
+func newLimitedWriter(writer io.Writer, maxBytes int64) io.Writer {
+	if maxBytes <= 0 {
+		return writer
+	}
+	return &limitedWriter{writer: writer, maxBytes: maxBytes, remaining: maxBytes}
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) <= w.remaining {
+		n, err := w.writer.Write(p)
+		w.remaining -= int64(n)
+		return n, err
+	}
+	n, err := w.writer.Write(p[:w.remaining])
+	w.remaining -= int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, fmt.Errorf("%w of %d bytes", ErrFileTooLarge, w.maxBytes)
+}
+
+// copyWithLimit streams the reader to the writer, failing with ErrFileTooLarge if more than maxBytes are read
+func copyWithLimit(writer io.Writer, reader io.Reader, maxBytes int64) error {
+	_, err := io.Copy(newLimitedWriter(writer, maxBytes), reader)
+	return err
+}
+
+// escapeFilePath escapes each of the segments of a path in a repository, keeping the separators between them
+func escapeFilePath(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// DownloadFileFromRepoWithContentInfo downloads a file from a repository like DownloadFileFromRepo, and detects whether its content is binary,
+// and the encoding of a text file, whose content is normalized to UTF-8 without a byte order mark, such as for parsing manifests.
+// The detection is the same for all the providers, and is described by vcsutils.DetectFileContent.
+// Returns the raw content of the file, its content info and the HTTP status code of the download.
+func DownloadFileFromRepoWithContentInfo(ctx context.Context, client VcsClient, owner, repository, branch, path string) ([]byte, vcsutils.FileContentInfo, int, error) {
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repository, branch, path)
+	if err != nil {
+		return content, vcsutils.FileContentInfo{}, statusCode, err
+	}
+	return content, vcsutils.DetectFileContent(content), statusCode, nil
+}
+
+// DownloadFilesFromRepo downloads files from a repository at a branch, at most concurrency files at a time. A non-positive concurrency defaults to 5.
+// A failure to download one of the files doesn't stop the others. Returns the contents of the downloaded files by their paths,
+// and a *BatchError with the error of each of the files that wasn't downloaded, if any.
+func DownloadFilesFromRepo(ctx context.Context, client VcsClient, owner, repository, branch string, paths []string, concurrency int) (map[string][]byte, error) {
+	uniquePaths := uniqueItems(paths)
+	results, errs := runConcurrently(ctx, uniquePaths, concurrency, func(path string) ([]byte, error) {
+		var content bytes.Buffer
+		err := client.DownloadFileFromRepoStream(ctx, owner, repository, branch, path, &content, 0)
+		return content.Bytes(), err
+	})
+	contents := make(map[string][]byte, len(uniquePaths))
+	fileErrors := map[string]error{}
+	for i, path := range uniquePaths {
+		if errs[i] != nil {
+			fileErrors[path] = errs[i]
+		} else {
+			contents[path] = results[i]
+		}
+	}
+	return contents, newBatchError("download files from the repository", fileErrors)
+}
@@ -0,0 +1,101 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadFilesFromRepo(t *testing.T) {
+	var concurrentRequests, maxConcurrentRequests, requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		current := atomic.AddInt32(&concurrentRequests, 1)
+		defer atomic.AddInt32(&concurrentRequests, -1)
+		for {
+			maxSoFar := atomic.LoadInt32(&maxConcurrentRequests)
+			if current <= maxSoFar || atomic.CompareAndSwapInt32(&maxConcurrentRequests, maxSoFar, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		path, found := strings.CutPrefix(r.URL.Path, "/rest/api/1.0/projects/jfrog/repos/repo-1/raw/")
+		assert.True(t, found, r.RequestURI)
+		assert.Equal(t, "branch-1", r.URL.Query().Get("at"))
+		if path == "go.sum" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte("content of " + path))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	paths := []string{"package.json", "go.mod", "go.sum", "frontend/package.json", "pom.xml", "go.mod"}
+	contents, err := DownloadFilesFromRepo(context.Background(), client, owner, repo1, branch1, paths, 2)
+	assert.Equal(t, map[string][]byte{
+		"package.json":          []byte("content of package.json"),
+		"go.mod":                []byte("content of go.mod"),
+		"frontend/package.json": []byte("content of frontend/package.json"),
+		"pom.xml":               []byte("content of pom.xml"),
+	}, contents)
+	var downloadFilesError *BatchError
+	if assert.ErrorAs(t, err, &downloadFilesError) {
+		assert.Len(t, downloadFilesError.Errors, 1)
+		assert.Error(t, downloadFilesError.Errors["go.sum"])
+		assert.ErrorContains(t, err, "failed to download files from the repository: go.sum: ")
+	}
+	// The duplicate path is downloaded once
+	assert.Equal(t, int32(5), requests)
+	assert.LessOrEqual(t, maxConcurrentRequests, int32(2))
+
+	contents, err = DownloadFilesFromRepo(context.Background(), client, owner, repo1, branch1, []string{"go.mod"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"go.mod": []byte("content of go.mod")}, contents)
+}
+
+func TestDownloadFileFromRepoWithContentInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/1.0/projects/jfrog/repos/repo-1/raw/missing.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/rest/api/1.0/projects/jfrog/repos/repo-1/raw/package.json", r.URL.Path)
+		_, err := w.Write([]byte("\xEF\xBB\xBF{\"name\": \"frogbot\"}"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	content, contentInfo, statusCode, err := DownloadFileFromRepoWithContentInfo(context.Background(), client, owner, repo1, branch1, "package.json")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []byte("\xEF\xBB\xBF{\"name\": \"frogbot\"}"), content)
+	assert.Equal(t, vcsutils.FileContentInfo{Encoding: vcsutils.UTF8Encoding, HasBOM: true, NormalizedContent: []byte(`{"name": "frogbot"}`)}, contentInfo)
+
+	_, _, statusCode, err = DownloadFileFromRepoWithContentInfo(context.Background(), client, owner, repo1, branch1, "missing.json")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestDownloadFilesFromRepoCanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "Unexpected request", r.RequestURI)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, true, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	contents, err := DownloadFilesFromRepo(ctx, client, owner, repo1, branch1, []string{"go.mod", "go.sum"}, 2)
+	assert.Empty(t, contents)
+	assert.ErrorIs(t, err, context.Canceled)
+}
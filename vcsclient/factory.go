@@ -1,6 +1,8 @@
 package vcsclient
 
 import (
+	"crypto/tls"
+
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
@@ -9,6 +11,8 @@ type ClientBuilder struct {
 	vcsProvider vcsutils.VcsProvider
 	vcsInfo     VcsInfo
 	logger      vcsutils.Log
+	// The capabilities validated by Build, if the strict mode is set
+	requiredCapabilities []Capability
 }
 
 // NewClientBuilder creates new ClientBuilder
@@ -46,8 +50,103 @@ func (builder *ClientBuilder) Project(project string) *ClientBuilder {
 	return builder
 }
 
+// TLSConfig sets the TLS configuration used by all the requests to the VCS provider
+func (builder *ClientBuilder) TLSConfig(tlsConfig *tls.Config) *ClientBuilder {
+	builder.vcsInfo.TLSConfig = tlsConfig
+	return builder
+}
+
+// ClientCertificates sets client certificates for mutual TLS authentication
+func (builder *ClientBuilder) ClientCertificates(certificates ...tls.Certificate) *ClientBuilder {
+	if builder.vcsInfo.TLSConfig == nil {
+		builder.vcsInfo.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	} else {
+		builder.vcsInfo.TLSConfig = builder.vcsInfo.TLSConfig.Clone()
+	}
+	builder.vcsInfo.TLSConfig.Certificates = append(builder.vcsInfo.TLSConfig.Certificates, certificates...)
+	return builder
+}
+
+// SkipDotGitCreation sets whether to skip the creation of the .git folder in DownloadRepository
+func (builder *ClientBuilder) SkipDotGitCreation(skip bool) *ClientBuilder {
+	builder.vcsInfo.SkipDotGitCreation = skip
+	return builder
+}
+
+// DisableRateLimitRetries sets whether to disable the retries of requests rejected for exceeding the rate limit.
+// Relevant for GitHub.
+func (builder *ClientBuilder) DisableRateLimitRetries(disable bool) *ClientBuilder {
+	builder.vcsInfo.DisableRateLimitRetries = disable
+	return builder
+}
+
+// HTTPTracing sets whether to log the method, URL, status, duration and remaining rate limit of each HTTP request at the debug level
+func (builder *ClientBuilder) HTTPTracing(enable bool) *ClientBuilder {
+	builder.vcsInfo.HTTPTracing = enable
+	return builder
+}
+
+// Metrics sets the receiver of the measurements of the API calls sent to the VCS provider, such as their latency and the remaining rate limit
+func (builder *ClientBuilder) Metrics(metrics Metrics) *ClientBuilder {
+	builder.vcsInfo.Metrics = metrics
+	return builder
+}
+
+// TracePropagator sets the propagator injecting the trace context of the requests sent to the VCS provider into their headers,
+// such as the W3C traceparent header of an OpenTelemetry trace
+func (builder *ClientBuilder) TracePropagator(propagator TracePropagator) *ClientBuilder {
+	builder.vcsInfo.TracePropagator = propagator
+	return builder
+}
+
+// ResponseCache sets the cache of the JSON responses of the GET requests, which are revalidated by conditional requests with the ETag and Last-Modified of the cached responses.
+// Reduces the rate limit consumption of repeated reads, such as polling GetRepositoryInfo or GetCommitStatuses.
+func (builder *ClientBuilder) ResponseCache(cache *ResponseCache) *ClientBuilder {
+	builder.vcsInfo.ResponseCache = cache
+	return builder
+}
+
+// ProviderNativeOrder sets whether to keep the results of the list APIs in the order returned by the VCS provider,
+// instead of the deterministic order documented by each of the VcsClient methods
+func (builder *ClientBuilder) ProviderNativeOrder(enable bool) *ClientBuilder {
+	builder.vcsInfo.ProviderNativeOrder = enable
+	return builder
+}
+
+// OAuthClientCredentials sets the OAuth2 consumer key and secret, used to fetch and refresh access tokens.
+// Relevant for Bitbucket Cloud.
+func (builder *ClientBuilder) OAuthClientCredentials(clientID, clientSecret string) *ClientBuilder {
+	builder.vcsInfo.OAuthClientID = clientID
+	builder.vcsInfo.OAuthClientSecret = clientSecret
+	return builder
+}
+
+// OAuthRefreshToken sets the OAuth2 refresh token, used with the OAuth2 client credentials to fetch and refresh access tokens.
+// Relevant for Bitbucket Cloud.
+func (builder *ClientBuilder) OAuthRefreshToken(refreshToken string) *ClientBuilder {
+	builder.vcsInfo.OAuthRefreshToken = refreshToken
+	return builder
+}
+
+// TokenProvider sets the provider of the access tokens, used instead of the token to authenticate the requests.
+// Relevant for Azure Repos.
+func (builder *ClientBuilder) TokenProvider(tokenProvider TokenProvider) *ClientBuilder {
+	builder.vcsInfo.TokenProvider = tokenProvider
+	return builder
+}
+
+// Strict sets the capabilities required by the consumer of the client.
+// Build fails with an error listing the capabilities the VCS provider doesn't support, instead of failing on their operations at runtime.
+func (builder *ClientBuilder) Strict(requiredCapabilities ...Capability) *ClientBuilder {
+	builder.requiredCapabilities = append(builder.requiredCapabilities, requiredCapabilities...)
+	return builder
+}
+
 // Build builds the VcsClient
 func (builder *ClientBuilder) Build() (VcsClient, error) {
+	if err := validateCapabilities(builder.vcsProvider, builder.requiredCapabilities); err != nil {
+		return nil, err
+	}
 	switch builder.vcsProvider {
 	case vcsutils.GitHub:
 		return NewGitHubClient(builder.vcsInfo, builder.logger)
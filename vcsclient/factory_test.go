@@ -1,6 +1,12 @@
 package vcsclient
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/jfrog/froggit-go/vcsutils"
@@ -36,3 +42,135 @@ func TestNegativeBitbucketCloudClient(t *testing.T) {
 	assert.Nil(t, vcsClient)
 	assert.Error(t, err)
 }
+
+func TestClientBuilderTLSConfig(t *testing.T) {
+	tests := []struct {
+		vcsProvider   vcsutils.VcsProvider
+		response      interface{}
+		expectedURI   string
+		createHandler createHandlerFunc
+		basicAuth     bool
+	}{
+		{vcsProvider: vcsutils.GitHub, response: "It's Not Easy Being Green", expectedURI: "/zen", createHandler: createGitHubHandler},
+		{vcsProvider: vcsutils.GitLab, response: []interface{}{}, expectedURI: "/api/v4/projects", createHandler: createGitLabHandler},
+		{vcsProvider: vcsutils.BitbucketServer, response: map[string]interface{}{}, expectedURI: "/rest/api/1.0/admin/users?limit=1", createHandler: createBitbucketServerHandler},
+		{vcsProvider: vcsutils.BitbucketCloud, response: map[string]interface{}{}, expectedURI: "/user", createHandler: createBitbucketCloudHandler, basicAuth: true},
+		{vcsProvider: vcsutils.AzureRepos, response: "", expectedURI: "", createHandler: createAzureReposHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vcsProvider.String(), func(t *testing.T) {
+			response, err := json.Marshal(tt.response)
+			assert.NoError(t, err)
+			server := httptest.NewTLSServer(tt.createHandler(t, tt.expectedURI, response, http.StatusOK))
+			defer server.Close()
+			// The TLS configuration of the test server's client trusts the server's self-signed certificate
+			serverTLSConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+			clientBuilder := NewClientBuilder(tt.vcsProvider).ApiEndpoint(server.URL).Token(token)
+			if tt.basicAuth {
+				clientBuilder = clientBuilder.Username(username)
+			}
+			client, err := clientBuilder.TLSConfig(serverTLSConfig).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+
+			client, err = clientBuilder.TLSConfig(nil).Build()
+			assert.NoError(t, err)
+			assert.Error(t, client.TestConnection(context.Background()))
+		})
+	}
+}
+
+func TestClientBuilderHTTPTracing(t *testing.T) {
+	tests := []struct {
+		vcsProvider   vcsutils.VcsProvider
+		response      interface{}
+		expectedURI   string
+		createHandler createHandlerFunc
+		basicAuth     bool
+	}{
+		{vcsProvider: vcsutils.GitHub, response: "It's Not Easy Being Green", expectedURI: "/zen", createHandler: createGitHubHandler},
+		{vcsProvider: vcsutils.GitLab, response: []interface{}{}, expectedURI: "/api/v4/projects", createHandler: createGitLabHandler},
+		{vcsProvider: vcsutils.BitbucketServer, response: map[string]interface{}{}, expectedURI: "/rest/api/1.0/admin/users?limit=1", createHandler: createBitbucketServerHandler},
+		{vcsProvider: vcsutils.BitbucketCloud, response: map[string]interface{}{}, expectedURI: "/user", createHandler: createBitbucketCloudHandler, basicAuth: true},
+		{vcsProvider: vcsutils.AzureRepos, response: "", expectedURI: "", createHandler: createAzureReposHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vcsProvider.String(), func(t *testing.T) {
+			response, err := json.Marshal(tt.response)
+			assert.NoError(t, err)
+			server := httptest.NewServer(tt.createHandler(t, tt.expectedURI, response, http.StatusOK))
+			defer server.Close()
+
+			logger := &debugRecordingLogger{}
+			clientBuilder := NewClientBuilder(tt.vcsProvider).ApiEndpoint(server.URL).Token(token).Logger(logger)
+			if tt.basicAuth {
+				clientBuilder = clientBuilder.Username(username)
+			}
+			client, err := clientBuilder.HTTPTracing(true).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotEmpty(t, logger.messages)
+			for _, message := range logger.messages {
+				assert.Contains(t, message, "url="+server.URL)
+				assert.Contains(t, message, "status=200")
+				assert.NotContains(t, message, token)
+			}
+
+			logger.messages = nil
+			client, err = clientBuilder.HTTPTracing(false).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.Empty(t, logger.messages)
+		})
+	}
+}
+
+// debugRecordingLogger records the messages logged at the debug level
+type debugRecordingLogger struct {
+	vcsutils.EmptyLogger
+	messages []string
+}
+
+func (logger *debugRecordingLogger) Debug(a ...interface{}) {
+	logger.messages = append(logger.messages, fmt.Sprint(a...))
+}
+
+func TestClientBuilderClientCertificates(t *testing.T) {
+	certificate := tls.Certificate{Certificate: [][]byte{[]byte("certificate")}}
+	tlsConfig := &tls.Config{ServerName: "server", MinVersion: tls.VersionTLS12}
+	clientBuilder := NewClientBuilder(vcsutils.GitHub).TLSConfig(tlsConfig).ClientCertificates(certificate)
+	assert.Equal(t, "server", clientBuilder.vcsInfo.TLSConfig.ServerName)
+	assert.Equal(t, []tls.Certificate{certificate}, clientBuilder.vcsInfo.TLSConfig.Certificates)
+	// The provided TLS configuration should not be modified
+	assert.Empty(t, tlsConfig.Certificates)
+
+	clientBuilder = NewClientBuilder(vcsutils.GitHub).ClientCertificates(certificate)
+	assert.Equal(t, []tls.Certificate{certificate}, clientBuilder.vcsInfo.TLSConfig.Certificates)
+}
+
+func TestClientBuilderStrict(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Strict(CheckRunsCapability, CodeScanningAlertsCapability).Build()
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	client, err = NewClientBuilder(vcsutils.AzureRepos).Strict(WebhooksCapability).Strict(CheckRunsCapability, GetCommitsCapability, LabelsCapability).Build()
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "the following required capabilities are not supported on Azure Repos: webhooks, check-runs, labels")
+
+	// Without the strict mode, the operations fail only when called
+	client, err = NewClientBuilder(vcsutils.AzureRepos).Build()
+	assert.NoError(t, err)
+	_, err = client.CreateCheckRun(context.Background(), owner, repo1, CheckRunInfo{})
+	assert.Error(t, err)
+}
+
+func TestIsCapabilitySupported(t *testing.T) {
+	assert.True(t, IsCapabilitySupported(vcsutils.GitLab, CodeScanningAlertsCapability))
+	assert.False(t, IsCapabilitySupported(vcsutils.GitLab, UploadCodeScanningCapability))
+	assert.True(t, IsCapabilitySupported(vcsutils.BitbucketServer, ReopenPullRequestCapability))
+	assert.False(t, IsCapabilitySupported(vcsutils.BitbucketCloud, ReopenPullRequestCapability))
+	assert.True(t, IsCapabilitySupported(vcsutils.AzureRepos, PullRequestBranchUpdateCapability))
+	assert.False(t, IsCapabilitySupported(vcsutils.BitbucketServer, PullRequestBranchUpdateCapability))
+	assert.False(t, IsCapabilitySupported(vcsutils.GitLab, SquashBranchCapability))
+}
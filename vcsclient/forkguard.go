@@ -0,0 +1,224 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// ErrUntrustedForkSource is returned by the clients created by NewForkGuardedClient for operations refused on pull requests from untrusted forks
+var ErrUntrustedForkSource = errors.New("the operation is refused on pull requests from untrusted forks")
+
+// IsTrustedSource returns true if the source branch of the pull request is in the target repository, or in a repository of one of the trusted owners.
+// The owners are compared case-insensitively. A pull request with an unknown source owner isn't trusted.
+// Pull requests from forks of untrusted owners may contain code of any user, so operations exposing secrets,
+// running commands or writing to the repository shouldn't be run for them.
+func IsTrustedSource(pullRequest PullRequestInfo, trustedOwners []string) bool {
+	source, target := pullRequest.Source, pullRequest.Target
+	if source.Owner == "" {
+		return false
+	}
+	if strings.EqualFold(source.Owner, target.Owner) && strings.EqualFold(source.Repository, target.Repository) {
+		return true
+	}
+	for _, trustedOwner := range trustedOwners {
+		if strings.EqualFold(source.Owner, trustedOwner) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForkPolicy decides whether an operation on a pull request is allowed, and returns an error to refuse it.
+// The operation is the name of the VcsClient method, such as MergePullRequest.
+type ForkPolicy func(ctx context.Context, operation string, pullRequest PullRequestInfo) error
+
+// RefuseUntrustedForks returns a ForkPolicy refusing all the operations on pull requests whose source isn't trusted, as decided by IsTrustedSource
+func RefuseUntrustedForks(trustedOwners ...string) ForkPolicy {
+	return func(_ context.Context, operation string, pullRequest PullRequestInfo) error {
+		if IsTrustedSource(pullRequest, trustedOwners) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s of pull request %d from %s/%s", ErrUntrustedForkSource, operation, pullRequest.ID, pullRequest.Source.Owner, pullRequest.Source.Repository)
+	}
+}
+
+// forkGuardedClient checks the fork policy before the write operations on existing pull requests, and delegates all the operations to the wrapped client
+type forkGuardedClient struct {
+	VcsClient
+	policy ForkPolicy
+}
+
+// NewForkGuardedClient wraps a client, so that the write operations on existing pull requests are run only if allowed by the policy,
+// such as updating, merging, commenting on or labeling a pull request. The pull request is fetched by GetPullRequestByID before each of them.
+// The other operations are delegated to the wrapped client as is. The wrapped client is returned by Unwrap.
+// The package helpers, such as SyncPullRequestReviewComments, use the provider-specific methods of the wrapped client, guarded as well.
+func NewForkGuardedClient(client VcsClient, policy ForkPolicy) VcsClient {
+	return &forkGuardedClient{VcsClient: client, policy: policy}
+}
+
+// Unwrap returns the wrapped client, for example to call the provider-specific methods of *GitHubClient
+func (client *forkGuardedClient) Unwrap() VcsClient {
+	return client.VcsClient
+}
+
+// getOptionalMethods returns the client as T, if it implements the optional methods of T, such as a faster implementation of a helper.
+// The methods of a client created by NewForkGuardedClient are implemented by its wrapped client. The write methods among them
+// are returned guarded by the fork policy, and the read methods are returned as implemented by the wrapped client.
+func getOptionalMethods[T any](client VcsClient) (T, bool) {
+	guardedClient, isGuarded := client.(*forkGuardedClient)
+	if !isGuarded {
+		methods, ok := client.(T)
+		return methods, ok
+	}
+	methods, ok := getOptionalMethods[T](guardedClient.VcsClient)
+	if !ok {
+		return methods, false
+	}
+	if guardedMethods, isGuardedMethods := any(guardedClient).(T); isGuardedMethods {
+		return guardedMethods, true
+	}
+	return methods, true
+}
+
+func (client *forkGuardedClient) checkPolicy(ctx context.Context, operation, owner, repository string, pullRequestID int) error {
+	pullRequest, err := client.VcsClient.GetPullRequestByID(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request %d to check the fork policy: %w", pullRequestID, err)
+	}
+	return client.policy(ctx, operation, pullRequest)
+}
+
+func (client *forkGuardedClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	if err := client.checkPolicy(ctx, "UpdatePullRequest", owner, repository, prId); err != nil {
+		return err
+	}
+	return client.VcsClient.UpdatePullRequest(ctx, owner, repository, title, body, targetBranchName, prId, state)
+}
+
+func (client *forkGuardedClient) ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "ClosePullRequest", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.ClosePullRequest(ctx, owner, repository, pullRequestID)
+}
+
+func (client *forkGuardedClient) ReopenPullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "ReopenPullRequest", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.ReopenPullRequest(ctx, owner, repository, pullRequestID)
+}
+
+func (client *forkGuardedClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod MergeMethod) error {
+	if err := client.checkPolicy(ctx, "EnablePullRequestAutoMerge", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.EnablePullRequestAutoMerge(ctx, owner, repository, pullRequestID, mergeMethod)
+}
+
+func (client *forkGuardedClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error {
+	if err := client.checkPolicy(ctx, "MergePullRequest", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.MergePullRequest(ctx, owner, repository, pullRequestID, options)
+}
+
+func (client *forkGuardedClient) UpdatePullRequestBranch(ctx context.Context, owner, repository string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "UpdatePullRequestBranch", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.UpdatePullRequestBranch(ctx, owner, repository, pullRequestID)
+}
+
+func (client *forkGuardedClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "AddPullRequestComment", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.AddPullRequestComment(ctx, owner, repository, content, pullRequestID)
+}
+
+func (client *forkGuardedClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	if err := client.checkPolicy(ctx, "AddPullRequestReviewComments", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.AddPullRequestReviewComments(ctx, owner, repository, pullRequestID, comments...)
+}
+
+func (client *forkGuardedClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	if err := client.checkPolicy(ctx, "DeletePullRequestReviewComments", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.DeletePullRequestReviewComments(ctx, owner, repository, pullRequestID, comments...)
+}
+
+// ResolvePullRequestReviewComments is looked up by getOptionalMethods only if the wrapped client implements it
+func (client *forkGuardedClient) ResolvePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	resolver, ok := getOptionalMethods[reviewCommentsResolver](client.VcsClient)
+	if !ok {
+		return errors.New("resolving review comments is not supported by the VCS provider")
+	}
+	if err := client.checkPolicy(ctx, "ResolvePullRequestReviewComments", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return resolver.ResolvePullRequestReviewComments(ctx, owner, repository, pullRequestID, comments...)
+}
+
+func (client *forkGuardedClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
+	if err := client.checkPolicy(ctx, "DeletePullRequestComment", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.DeletePullRequestComment(ctx, owner, repository, pullRequestID, commentID)
+}
+
+func (client *forkGuardedClient) UpdatePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	if err := client.checkPolicy(ctx, "UpdatePullRequestComment", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.UpdatePullRequestComment(ctx, owner, repository, pullRequestID, commentID, content)
+}
+
+func (client *forkGuardedClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error {
+	if err := client.checkPolicy(ctx, "UpdatePullRequestReviewComment", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.UpdatePullRequestReviewComment(ctx, owner, repository, pullRequestID, comment, content)
+}
+
+func (client *forkGuardedClient) ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error {
+	if err := client.checkPolicy(ctx, "ReplyToPullRequestReviewComment", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.ReplyToPullRequestReviewComment(ctx, owner, repository, pullRequestID, threadID, content)
+}
+
+func (client *forkGuardedClient) UploadPullRequestAttachment(ctx context.Context, owner, repository string, pullRequestID int, fileName string, content []byte) (PullRequestAttachmentInfo, error) {
+	if err := client.checkPolicy(ctx, "UploadPullRequestAttachment", owner, repository, pullRequestID); err != nil {
+		return PullRequestAttachmentInfo{}, err
+	}
+	return client.VcsClient.UploadPullRequestAttachment(ctx, owner, repository, pullRequestID, fileName, content)
+}
+
+func (client *forkGuardedClient) LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "LabelPullRequest", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.LabelPullRequest(ctx, owner, repository, name, pullRequestID)
+}
+
+func (client *forkGuardedClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	if err := client.checkPolicy(ctx, "UnlabelPullRequest", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.UnlabelPullRequest(ctx, owner, repository, name, pullRequestID)
+}
+
+func (client *forkGuardedClient) SetPullRequestMilestone(ctx context.Context, owner, repository string, pullRequestID int, milestoneID int64) error {
+	if err := client.checkPolicy(ctx, "SetPullRequestMilestone", owner, repository, pullRequestID); err != nil {
+		return err
+	}
+	return client.VcsClient.SetPullRequestMilestone(ctx, owner, repository, pullRequestID, milestoneID)
+}
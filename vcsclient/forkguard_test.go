@@ -0,0 +1,96 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrustedSource(t *testing.T) {
+	target := BranchInfo{Name: "main", Repository: "repo-1", Owner: "jfrog"}
+	tests := []struct {
+		name          string
+		source        BranchInfo
+		trustedOwners []string
+		expected      bool
+	}{
+		{name: "same repository", source: BranchInfo{Name: "feature", Repository: "repo-1", Owner: "jfrog"}, expected: true},
+		{name: "same repository different case", source: BranchInfo{Name: "feature", Repository: "Repo-1", Owner: "JFrog"}, expected: true},
+		{name: "untrusted fork", source: BranchInfo{Name: "feature", Repository: "repo-1", Owner: "frogger"}, expected: false},
+		{name: "trusted fork", source: BranchInfo{Name: "feature", Repository: "repo-1", Owner: "frogger"}, trustedOwners: []string{"Frogger"}, expected: true},
+		{name: "unknown source owner", source: BranchInfo{Name: "feature", Repository: "repo-1"}, trustedOwners: []string{""}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTrustedSource(PullRequestInfo{Source: tt.source, Target: target}, tt.trustedOwners))
+		})
+	}
+}
+
+func TestNewForkGuardedClient(t *testing.T) {
+	ctx := context.Background()
+	var comments []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && (r.RequestURI == "/repos/jfrog/repo-1/pulls/1" || r.RequestURI == "/repos/jfrog/repo-1/pulls/2"):
+			sourceOwner := "jfrog"
+			if r.RequestURI == "/repos/jfrog/repo-1/pulls/2" {
+				sourceOwner = "frogger"
+			}
+			_, err := fmt.Fprintf(w, `{"number": 1, "head": {"label": "%s:feature", "repo": {"name": "repo-1", "owner": {"login": "%s"}}},
+				"base": {"label": "jfrog:main", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}}`, sourceOwner, sourceOwner)
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/issues/1/comments":
+			comments = append(comments, r.RequestURI)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := NewForkGuardedClient(buildClient(t, vcsutils.GitHub, false, server), RefuseUntrustedForks())
+
+	assert.NoError(t, client.AddPullRequestComment(ctx, owner, repo1, "Scanned", 1))
+	assert.Len(t, comments, 1)
+
+	err := client.AddPullRequestComment(ctx, owner, repo1, "Scanned", 2)
+	assert.ErrorIs(t, err, ErrUntrustedForkSource)
+	assert.ErrorContains(t, err, "AddPullRequestComment of pull request 1 from frogger/repo-1")
+	assert.ErrorIs(t, client.LabelPullRequest(ctx, owner, repo1, "scanned", 2), ErrUntrustedForkSource)
+	assert.ErrorIs(t, client.DeletePullRequestComment(ctx, owner, repo1, 2, 1), ErrUntrustedForkSource)
+	assert.ErrorIs(t, client.DeletePullRequestReviewComments(ctx, owner, repo1, 2, CommentInfo{ID: 1}), ErrUntrustedForkSource)
+	assert.ErrorIs(t, client.UpdatePullRequestBranch(ctx, owner, repo1, 2), ErrUntrustedForkSource)
+	assert.ErrorIs(t, client.SetPullRequestMilestone(ctx, owner, repo1, 2, 1), ErrUntrustedForkSource)
+	assert.Len(t, comments, 1)
+
+	// The optional methods of the wrapped client are used by the helpers
+	_, isMergeCommitResolver := getOptionalMethods[pullRequestMergeCommitResolver](client)
+	assert.True(t, isMergeCommitResolver)
+	_, isReviewCommentsResolver := getOptionalMethods[reviewCommentsResolver](client)
+	assert.False(t, isReviewCommentsResolver)
+
+	// The wrapped client is returned by Unwrap
+	_, isGitHubClient := client.(interface{ Unwrap() VcsClient }).Unwrap().(*GitHubClient)
+	assert.True(t, isGitHubClient)
+}
+
+func TestNewForkGuardedClientOptionalMethods(t *testing.T) {
+	client := NewForkGuardedClient(&AzureReposClient{}, RefuseUntrustedForks())
+
+	// The write methods are guarded by the policy
+	resolver, isReviewCommentsResolver := getOptionalMethods[reviewCommentsResolver](client)
+	assert.True(t, isReviewCommentsResolver)
+	assert.Same(t, client, resolver)
+
+	// The read methods are called on the wrapped client
+	mergeCommitResolver, isMergeCommitResolver := getOptionalMethods[pullRequestMergeCommitResolver](client)
+	assert.True(t, isMergeCommitResolver)
+	assert.IsType(t, &AzureReposClient{}, mergeCommitResolver)
+	_, isAzureClient := getOptionalMethods[*AzureReposClient](client)
+	assert.True(t, isAzureClient)
+}
@@ -27,10 +27,25 @@ const (
 	retriesIntervalMilliSecs = 60000
 	// https://github.com/orgs/community/discussions/27190
 	githubPrContentSizeLimit = 65536
+	// https://docs.github.com/en/rest/checks/runs#update-a-check-run
+	githubCheckRunAnnotationsLimit = 50
+	// https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning#file-limits
+	githubSarifRunsLimit = 20
+	// The maximal page size of the combined commit status
+	githubCommitStatusesPageSize = 100
 )
 
+// The maximal size of a gzip-compressed SARIF uploaded to GitHub.
+// https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/uploading-a-sarif-file-to-github#uploading-a-code-scanning-analysis-with-github-actions
+var githubSarifUploadSizeLimit = 10 * 1024 * 1024
+
 var rateLimitRetryStatuses = []int{http.StatusForbidden, http.StatusTooManyRequests}
 
+var errGitHubPullRequestAttachmentsNotSupported = errors.New("pull request attachments are not supported on GitHub")
+var errGitHubBypassPoliciesNotSupported = errors.New("bypassing the branch protection rules is not supported on GitHub")
+var errGitHubDeployTokensNotSupported = errors.New("deploy tokens are not supported on GitHub, where the repositories are accessed by deploy keys or GitHub App installation tokens")
+var errGitHubSetRepositoryMirrorNotSupported = errors.New("setting repository mirrors is not supported on GitHub, where the mirrors are configured by the site administrator")
+
 type GitHubRateLimitExecutionHandler func() (*github.Response, error)
 
 type GitHubRateLimitRetryExecutor struct {
@@ -52,6 +67,8 @@ type GitHubClient struct {
 	rateLimitRetryExecutor GitHubRateLimitRetryExecutor
 	logger                 vcsutils.Log
 	ghClient               *github.Client
+	// Caches the GitHub Enterprise Server version
+	serverVersion serverVersionResolver
 }
 
 // NewGitHubClient create a new GitHubClient
@@ -72,21 +89,72 @@ func NewGitHubClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitHubClient, error
 		nil
 }
 
-func (client *GitHubClient) runWithRateLimitRetries(handler func() (*github.Response, error)) error {
-	client.rateLimitRetryExecutor.GitHubRateLimitExecutionHandler = handler
-	return client.rateLimitRetryExecutor.Execute()
+// Raw returns the go-github client used by the GitHubClient, to call the endpoints which aren't wrapped by the GitHubClient.
+// The client is shared with the GitHubClient, and reuses its authentication, TLS configuration, HTTP tracing, metrics and trace propagation.
+// The requests rejected for exceeding the rate limit aren't retried, and the results aren't sorted.
+func (client *GitHubClient) Raw() *github.Client {
+	return client.ghClient
+}
+
+func (client *GitHubClient) runWithRateLimitRetries(ctx context.Context, handler func() (*github.Response, error)) error {
+	if client.vcsInfo.DisableRateLimitRetries || isRetryDisabled(ctx) {
+		_, err := handler()
+		return err
+	}
+	// The executor is copied, so that the client can be used by concurrent goroutines
+	rateLimitRetryExecutor := client.rateLimitRetryExecutor
+	rateLimitRetryExecutor.Context = ctx
+	rateLimitRetryExecutor.GitHubRateLimitExecutionHandler = handler
+	return rateLimitRetryExecutor.Execute()
 }
 
 // TestConnection on GitHub
 func (client *GitHubClient) TestConnection(ctx context.Context) error {
+	ctx = labelAPICalls(ctx, "TestConnection")
 	_, _, err := client.ghClient.Meta.Zen(ctx)
 	return err
 }
 
+// GetAuthenticatedUser on GitHub
+func (client *GitHubClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	ctx = labelAPICalls(ctx, "GetAuthenticatedUser")
+	user, _, err := client.ghClient.Users.Get(ctx, "")
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{ID: strconv.FormatInt(user.GetID(), 10), Login: user.GetLogin(), DisplayName: user.GetName(), Email: user.GetEmail()}, nil
+}
+
+// GetServerVersion on GitHub
+func (client *GitHubClient) GetServerVersion(ctx context.Context) (string, error) {
+	ctx = labelAPICalls(ctx, "GetServerVersion")
+	return client.serverVersion.resolve(ctx, func(ctx context.Context) (string, error) {
+		// The installed version is returned by GitHub Enterprise Server only
+		var meta struct {
+			InstalledVersion string `json:"installed_version"`
+		}
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			request, err := client.ghClient.NewRequest(http.MethodGet, "meta", nil)
+			if err != nil {
+				return nil, err
+			}
+			return client.ghClient.Do(ctx, request, &meta)
+		})
+		return meta.InstalledVersion, err
+	})
+}
+
+// Capabilities on GitHub
+func (client *GitHubClient) Capabilities(ctx context.Context) ([]Capability, error) {
+	ctx = labelAPICalls(ctx, "Capabilities")
+	return getServerCapabilities(ctx, vcsutils.GitHub, client.GetServerVersion)
+}
+
 func buildGithubClient(vcsInfo VcsInfo, logger vcsutils.Log) (*github.Client, error) {
-	httpClient := &http.Client{}
+	httpClient := newHTTPClient(vcsutils.GitHub, vcsInfo, logger)
 	if vcsInfo.Token != "" {
-		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token}))
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token}))
 	}
 	ghClient := github.NewClient(httpClient)
 	if vcsInfo.APIEndpoint != "" {
@@ -102,6 +170,7 @@ func buildGithubClient(vcsInfo VcsInfo, logger vcsutils.Log) (*github.Client, er
 
 // AddSshKeyToRepository on GitHub
 func (client *GitHubClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	ctx = labelAPICalls(ctx, "AddSshKeyToRepository")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -119,34 +188,162 @@ func (client *GitHubClient) AddSshKeyToRepository(ctx context.Context, owner, re
 		ReadOnly: &readOnly,
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		_, ghResponse, err := client.ghClient.Repositories.CreateKey(ctx, owner, repository, &key)
 		return ghResponse, err
 	})
 }
 
+// CreateDeployToken on GitHub
+func (client *GitHubClient) CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error) {
+	return DeployTokenInfo{}, errGitHubDeployTokensNotSupported
+}
+
 // ListRepositories on GitHub
 func (client *GitHubClient) ListRepositories(ctx context.Context) (results map[string][]string, err error) {
+	ctx = labelAPICalls(ctx, "ListRepositories")
+	repositories, err := client.listAllRepositories(ctx)
+	if err != nil {
+		return
+	}
 	results = make(map[string][]string)
+	for _, repo := range repositories {
+		results[*repo.Owner.Login] = append(results[*repo.Owner.Login], *repo.Name)
+	}
+	results = orderRepositories(client.vcsInfo, results)
+	return
+}
+
+// ListRepositoryListings on GitHub
+func (client *GitHubClient) ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryListings")
+	repositories, err := client.listAllRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return orderRepositoryListings(client.vcsInfo, mapGitHubRepositoriesToListings(repositories)), nil
+}
+
+func mapGitHubRepositoriesToListings(repositories []*github.Repository) []RepositoryListing {
+	listings := make([]RepositoryListing, 0, len(repositories))
+	for _, repo := range repositories {
+		ownerType := UserNamespace
+		if repo.GetOwner().GetType() == "Organization" {
+			ownerType = OrganizationNamespace
+		}
+		listings = append(listings, RepositoryListing{
+			Owner:         repo.GetOwner().GetLogin(),
+			OwnerType:     ownerType,
+			Name:          repo.GetName(),
+			Visibility:    getGitHubRepositoryVisibility(repo),
+			DefaultBranch: repo.GetDefaultBranch(),
+		})
+	}
+	return listings
+}
+
+// SearchRepositories on GitHub, by the repositories search API. The search results are limited to 1000 repositories.
+func (client *GitHubClient) SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "SearchRepositories")
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	var qualifiers []string
+	if options.Name != "" {
+		qualifiers = append(qualifiers, options.Name+" in:name")
+	}
+	if options.Topic != "" {
+		qualifiers = append(qualifiers, "topic:"+options.Topic)
+	}
+	if options.Owner != "" {
+		qualifiers = append(qualifiers, "user:"+options.Owner)
+	}
+	query := strings.Join(qualifiers, " ")
+	var repositories []*github.Repository
 	for nextPage := 1; ; nextPage++ {
-		var repositoriesInPage []*github.Repository
+		var searchResult *github.RepositoriesSearchResult
 		var ghResponse *github.Response
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-			repositoriesInPage, ghResponse, err = client.executeListRepositoriesInPage(ctx, nextPage)
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			searchResult, ghResponse, err = client.ghClient.Search.Repositories(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
 			return ghResponse, err
 		})
 		if err != nil {
-			return
+			return nil, err
+		}
+		repositories = append(repositories, searchResult.Repositories...)
+		if ghResponse.NextPage == 0 {
+			return mapGitHubRepositoriesToListings(repositories), nil
+		}
+	}
+}
+
+// SearchCode on GitHub, by the code search API. The search results are limited to 1000 files, and have no line numbers.
+func (client *GitHubClient) SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error) {
+	ctx = labelAPICalls(ctx, "SearchCode")
+	if err := options.validate(query); err != nil {
+		return nil, err
+	}
+	if options.Repository != "" {
+		query += fmt.Sprintf(" repo:%s/%s", options.Owner, options.Repository)
+	} else if options.Owner != "" {
+		query += " user:" + options.Owner
+	}
+	var results []CodeSearchResult
+	for nextPage := 1; ; nextPage++ {
+		var searchResult *github.CodeSearchResult
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			searchResult, ghResponse, err = client.ghClient.Search.Code(ctx, query, &github.SearchOptions{TextMatch: true, ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
 		}
+		for _, codeResult := range searchResult.CodeResults {
+			results = append(results, mapGitHubCodeResultToCodeSearchResults(codeResult)...)
+		}
+		if ghResponse.NextPage == 0 {
+			return results, nil
+		}
+	}
+}
 
-		for _, repo := range repositoriesInPage {
-			results[*repo.Owner.Login] = append(results[*repo.Owner.Login], *repo.Name)
+// mapGitHubCodeResultToCodeSearchResults returns a result per text match in the file
+func mapGitHubCodeResultToCodeSearchResults(codeResult *github.CodeResult) []CodeSearchResult {
+	result := CodeSearchResult{
+		Owner:      codeResult.GetRepository().GetOwner().GetLogin(),
+		Repository: codeResult.GetRepository().GetName(),
+		Path:       codeResult.GetPath(),
+	}
+	if len(codeResult.TextMatches) == 0 {
+		return []CodeSearchResult{result}
+	}
+	results := make([]CodeSearchResult, 0, len(codeResult.TextMatches))
+	for _, textMatch := range codeResult.TextMatches {
+		result.Fragment = textMatch.GetFragment()
+		results = append(results, result)
+	}
+	return results
+}
+
+func (client *GitHubClient) listAllRepositories(ctx context.Context) (repositories []*github.Repository, err error) {
+	for nextPage := 1; ; nextPage++ {
+		var repositoriesInPage []*github.Repository
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			repositoriesInPage, ghResponse, err = client.executeListRepositoriesInPage(ctx, nextPage)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
 		}
+		repositories = append(repositories, repositoriesInPage...)
 		if nextPage+1 > ghResponse.LastPage {
-			break
+			return repositories, nil
 		}
 	}
-	return
 }
 
 func (client *GitHubClient) executeListRepositoriesInPage(ctx context.Context, page int) ([]*github.Repository, *github.Response, error) {
@@ -154,18 +351,105 @@ func (client *GitHubClient) executeListRepositoriesInPage(ctx context.Context, p
 	return client.ghClient.Repositories.List(ctx, "", options)
 }
 
+// ListNamespaces on GitHub returns the authenticated user and the organizations of the user
+func (client *GitHubClient) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	ctx = labelAPICalls(ctx, "ListNamespaces")
+	user, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []NamespaceInfo{{Name: user.Login, DisplayName: user.DisplayName, Kind: UserNamespace}}
+	for nextPage := 1; ; nextPage++ {
+		var organizations []*github.Organization
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			organizations, ghResponse, err = client.ghClient.Organizations.List(ctx, "", &github.ListOptions{Page: nextPage})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, organization := range organizations {
+			namespaces = append(namespaces, NamespaceInfo{Name: organization.GetLogin(), DisplayName: organization.GetName(), Kind: OrganizationNamespace})
+		}
+		if nextPage+1 > ghResponse.LastPage {
+			break
+		}
+	}
+	return orderNamespaces(client.vcsInfo, namespaces), nil
+}
+
 // ListBranches on GitHub
-func (client *GitHubClient) ListBranches(ctx context.Context, owner, repository string) (branchList []string, err error) {
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+func (client *GitHubClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranches")
+	return client.ListBranchesWithOptions(ctx, owner, repository, ListBranchesOptions{})
+}
+
+// ListBranchesWithOptions on GitHub. The protected branches are filtered by the branches API.
+func (client *GitHubClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListBranchesOptions) (branchList []string, err error) {
+	ctx = labelAPICalls(ctx, "ListBranchesWithOptions")
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
-		branchList, ghResponse, err = client.executeListBranch(ctx, owner, repository)
+		branchList, ghResponse, err = client.executeListBranch(ctx, owner, repository, options)
 		return ghResponse, err
 	})
+	branchList = orderNames(client.vcsInfo, branchList)
 	return
 }
 
-func (client *GitHubClient) executeListBranch(ctx context.Context, owner, repository string) ([]string, *github.Response, error) {
-	branches, ghResponse, err := client.ghClient.Repositories.ListBranches(ctx, owner, repository, nil)
+// GetBranchInfo on GitHub
+func (client *GitHubClient) GetBranchInfo(ctx context.Context, owner, repository, branch string) (RepositoryBranchInfo, error) {
+	ctx = labelAPICalls(ctx, "GetBranchInfo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	var branchDetails *github.Branch
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		branchDetails, ghResponse, err = client.ghClient.Repositories.GetBranch(ctx, owner, repository, branch, 0)
+		return ghResponse, err
+	})
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repository)
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	return RepositoryBranchInfo{
+		Name:           branchDetails.GetName(),
+		HeadCommitHash: branchDetails.GetCommit().GetSHA(),
+		Protected:      branchDetails.GetProtected(),
+		Default:        defaultBranch == branchDetails.GetName(),
+	}, nil
+}
+
+// GetDefaultBranch on GitHub
+func (client *GitHubClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	ctx = labelAPICalls(ctx, "GetDefaultBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	var repo *github.Repository
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return repo.GetDefaultBranch(), nil
+}
+
+func (client *GitHubClient) executeListBranch(ctx context.Context, owner, repository string, options ListBranchesOptions) ([]string, *github.Response, error) {
+	var listOptions *github.BranchListOptions
+	if options.ProtectedOnly {
+		listOptions = &github.BranchListOptions{Protected: vcsutils.PointerOf(true)}
+	}
+	branches, ghResponse, err := client.ghClient.Repositories.ListBranches(ctx, owner, repository, listOptions)
 	if err != nil {
 		return []string{}, ghResponse, err
 	}
@@ -180,11 +464,12 @@ func (client *GitHubClient) executeListBranch(ctx context.Context, owner, reposi
 // CreateWebhook on GitHub
 func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
 	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateWebhook")
 	token := vcsutils.CreateToken()
 	hook := createGitHubHook(token, payloadURL, webhookEvents...)
 	var ghResponseHook *github.Hook
 	var err error
-	if err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	if err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		ghResponseHook, ghResponse, err = client.ghClient.Repositories.CreateHook(ctx, owner, repository, hook)
 		return ghResponse, err
@@ -198,13 +483,14 @@ func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository
 // UpdateWebhook on GitHub
 func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateWebhook")
 	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
 	if err != nil {
 		return err
 	}
 
 	hook := createGitHubHook(token, payloadURL, webhookEvents...)
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		_, ghResponse, err = client.ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, hook)
 		return ghResponse, err
@@ -213,19 +499,126 @@ func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository
 
 // DeleteWebhook on GitHub
 func (client *GitHubClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteWebhook")
 	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
 	if err != nil {
 		return err
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		return client.ghClient.Repositories.DeleteHook(ctx, owner, repository, webhookIDInt64)
 	})
 }
 
+// CILint on GitHub validates a workflow by the client, since GitHub has no API validating workflows
+func (client *GitHubClient) CILint(_ context.Context, _, _, content string) (CILintResult, error) {
+	return lintGitHubWorkflow(content), nil
+}
+
+// GetRepositoryTopics on GitHub
+func (client *GitHubClient) GetRepositoryTopics(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryTopics")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var topics []string
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		topics, ghResponse, err = client.ghClient.Repositories.ListAllTopics(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(topics)
+	return topics, nil
+}
+
+// SetRepositoryTopics on GitHub
+func (client *GitHubClient) SetRepositoryTopics(ctx context.Context, owner, repository string, topics []string) error {
+	ctx = labelAPICalls(ctx, "SetRepositoryTopics")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Repositories.ReplaceAllTopics(ctx, owner, repository, normalizeRepositoryTopics(topics))
+		return ghResponse, err
+	})
+}
+
+// CreateGroupWebhook on GitHub creates a webhook of an organization
+func (client *GitHubClient) CreateGroupWebhook(ctx context.Context, group, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group, "payloadURL": payloadURL})
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	var ghResponseHook *github.Hook
+	if err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		ghResponseHook, ghResponse, err = client.ghClient.Organizations.CreateHook(ctx, group, hook)
+		return ghResponse, err
+	}); err != nil {
+		return "", "", err
+	}
+	return strconv.FormatInt(ghResponseHook.GetID(), 10), token, nil
+}
+
+// UpdateGroupWebhook on GitHub updates a webhook of an organization
+func (client *GitHubClient) UpdateGroupWebhook(ctx context.Context, group, _, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group, "payloadURL": payloadURL})
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		_, ghResponse, err = client.ghClient.Organizations.EditHook(ctx, group, webhookIDInt64, hook)
+		return ghResponse, err
+	})
+}
+
+// DeleteGroupWebhook on GitHub deletes a webhook of an organization
+func (client *GitHubClient) DeleteGroupWebhook(ctx context.Context, group, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group})
+	if err != nil {
+		return err
+	}
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Organizations.DeleteHook(ctx, group, webhookIDInt64)
+	})
+}
+
 // SetCommitStatus on GitHub
 func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
 	title, description, detailsURL string) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatus")
+	return client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, CommitStatusOptions{})
+}
+
+// SetCommitStatusWithOptions on GitHub, where the title identifies the status
+func (client *GitHubClient) SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string, options CommitStatusOptions) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatusWithOptions")
+	description, err := encodeCommitStatusDescription(description, options.Metadata)
+	if err != nil {
+		return err
+	}
 	state := getGitHubCommitState(commitStatus)
 	status := &github.RepoStatus{
 		Context:     &title,
@@ -234,46 +627,176 @@ func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus Co
 		Description: &description,
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		_, ghResponse, err := client.ghClient.Repositories.CreateStatus(ctx, owner, repository, ref, status)
 		return ghResponse, err
 	})
 }
 
 // GetCommitStatuses on GitHub
+// GitHub accepts branch names, so the ref is passed as is.
+// All the pages of the statuses are fetched.
 func (client *GitHubClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (statusInfoList []CommitStatusInfo, err error) {
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	ctx = labelAPICalls(ctx, "GetCommitStatuses")
+	statusesPage, err := client.ListCommitStatusesWithPagination(ctx, owner, repository, ref, CommitStatusesListOptions{AllPages: true})
+	return statusesPage.Statuses, err
+}
+
+// CommitStatusesListOptions configures ListCommitStatusesWithPagination
+type CommitStatusesListOptions struct {
+	// The page to get, starting from 1. Defaults to the first page.
+	Page int
+	// The number of statuses in a page, up to 100. Defaults to 100.
+	PerPage int
+	// Get all the pages, starting from Page
+	AllPages bool
+}
+
+// CommitStatusesPage is a page of the combined commit status of a ref, returned by ListCommitStatusesWithPagination
+type CommitStatusesPage struct {
+	// The combined state of the latest statuses of all the contexts of the ref, including the contexts in the other pages.
+	// Fail if any of the statuses failed or errored, InProgress if any of them is pending, and Pass if all of them passed.
+	CombinedState CommitStatus
+	Statuses      []CommitStatusInfo
+	// The total number of the statuses, in all the pages
+	TotalCount int
+	// The next page, or 0 if there are no more pages
+	NextPage int
+}
+
+// ListCommitStatusesWithPagination on GitHub gets a page of the latest statuses of each context of a ref, or all the pages if requested by the options.
+// The combined status of GitHub returns up to 100 statuses in a page.
+func (client *GitHubClient) ListCommitStatusesWithPagination(ctx context.Context, owner, repository, ref string, options CommitStatusesListOptions) (CommitStatusesPage, error) {
+	ctx = labelAPICalls(ctx, "ListCommitStatusesWithPagination")
+	listOptions := &github.ListOptions{Page: max(options.Page, 1), PerPage: options.PerPage}
+	if listOptions.PerPage <= 0 || listOptions.PerPage > githubCommitStatusesPageSize {
+		listOptions.PerPage = githubCommitStatusesPageSize
+	}
+	var statusesPage CommitStatusesPage
+	for {
+		var combinedStatus *github.CombinedStatus
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			combinedStatus, ghResponse, err = client.ghClient.Repositories.GetCombinedStatus(ctx, owner, repository, ref, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return CommitStatusesPage{}, err
+		}
+		statusesPage.CombinedState = commitStatusAsStringToStatus(combinedStatus.GetState())
+		statusesPage.TotalCount = combinedStatus.GetTotalCount()
+		statusesPage.NextPage = ghResponse.NextPage
+		for _, singleStatus := range combinedStatus.Statuses {
+			statusesPage.Statuses = append(statusesPage.Statuses, CommitStatusInfo{
+				State:         commitStatusAsStringToStatus(singleStatus.GetState()),
+				Context:       singleStatus.GetContext(),
+				Description:   singleStatus.GetDescription(),
+				DetailsUrl:    singleStatus.GetTargetURL(),
+				Creator:       singleStatus.GetCreator().GetName(),
+				LastUpdatedAt: singleStatus.GetUpdatedAt().Time,
+				CreatedAt:     singleStatus.GetCreatedAt().Time,
+			})
+		}
+		if !options.AllPages || ghResponse.NextPage == 0 {
+			return statusesPage, nil
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+}
+
+// GetCombinedCommitStatus on GitHub returns the combined state of the latest statuses of all the contexts of a ref.
+// The state is read from the first page of the combined status, without fetching the rest of the statuses.
+func (client *GitHubClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	ctx = labelAPICalls(ctx, "GetCombinedCommitStatus")
+	statusesPage, err := client.ListCommitStatusesWithPagination(ctx, owner, repository, ref, CommitStatusesListOptions{PerPage: 1})
+	if err != nil {
+		return Error, err
+	}
+	return statusesPage.CombinedState, nil
+}
+
+// CreateCheckRun on GitHub
+// GitHub accepts up to 50 annotations per request, so the rest of the annotations are added by following update requests.
+func (client *GitHubClient) CreateCheckRun(ctx context.Context, owner, repository string, checkRun CheckRunInfo) (int64, error) {
+	ctx = labelAPICalls(ctx, "CreateCheckRun")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": checkRun.Name, "headSHA": checkRun.HeadSHA})
+	if err != nil {
+		return 0, err
+	}
+	annotationsBatches := splitGitHubCheckRunAnnotations(checkRun.Annotations)
+	options := github.CreateCheckRunOptions{
+		Name:        checkRun.Name,
+		HeadSHA:     checkRun.HeadSHA,
+		DetailsURL:  vcsutils.GetNilIfZeroVal(checkRun.DetailsURL),
+		Status:      vcsutils.PointerOf(getGitHubCheckRunStatus(checkRun.Status)),
+		Conclusion:  getGitHubCheckRunConclusion(checkRun),
+		CompletedAt: getGitHubCheckRunCompletedAt(checkRun),
+		Output:      getGitHubCheckRunOutput(checkRun, annotationsBatches[0]),
+	}
+	var createdCheckRun *github.CheckRun
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
-		statusInfoList, ghResponse, err = client.executeGetCommitStatuses(ctx, owner, repository, ref)
+		createdCheckRun, ghResponse, err = client.ghClient.Checks.CreateCheckRun(ctx, owner, repository, options)
 		return ghResponse, err
 	})
-	return
+	if err != nil {
+		return 0, fmt.Errorf("could not create check run: %w", err)
+	}
+	checkRunID := createdCheckRun.GetID()
+	for _, annotations := range annotationsBatches[1:] {
+		if err = client.executeUpdateCheckRun(ctx, owner, repository, checkRunID, checkRun, annotations); err != nil {
+			return checkRunID, err
+		}
+	}
+	return checkRunID, nil
 }
 
-func (client *GitHubClient) executeGetCommitStatuses(ctx context.Context, owner, repository, ref string) (statusInfoList []CommitStatusInfo, ghResponse *github.Response, err error) {
-	statuses, ghResponse, err := client.ghClient.Repositories.GetCombinedStatus(ctx, owner, repository, ref, nil)
+// UpdateCheckRun on GitHub
+func (client *GitHubClient) UpdateCheckRun(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo) error {
+	ctx = labelAPICalls(ctx, "UpdateCheckRun")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": checkRun.Name})
 	if err != nil {
-		return
+		return err
 	}
+	for _, annotations := range splitGitHubCheckRunAnnotations(checkRun.Annotations) {
+		if err = client.executeUpdateCheckRun(ctx, owner, repository, checkRunID, checkRun, annotations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	for _, singleStatus := range statuses.Statuses {
-		statusInfoList = append(statusInfoList, CommitStatusInfo{
-			State:         commitStatusAsStringToStatus(*singleStatus.State),
-			Description:   singleStatus.GetDescription(),
-			DetailsUrl:    singleStatus.GetTargetURL(),
-			Creator:       singleStatus.GetCreator().GetName(),
-			LastUpdatedAt: singleStatus.GetUpdatedAt().Time,
-			CreatedAt:     singleStatus.GetCreatedAt().Time,
-		})
+func (client *GitHubClient) executeUpdateCheckRun(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo, annotations []*github.CheckRunAnnotation) error {
+	options := github.UpdateCheckRunOptions{
+		Name:        checkRun.Name,
+		DetailsURL:  vcsutils.GetNilIfZeroVal(checkRun.DetailsURL),
+		Status:      vcsutils.PointerOf(getGitHubCheckRunStatus(checkRun.Status)),
+		Conclusion:  getGitHubCheckRunConclusion(checkRun),
+		CompletedAt: getGitHubCheckRunCompletedAt(checkRun),
+		Output:      getGitHubCheckRunOutput(checkRun, annotations),
 	}
-	return
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Checks.UpdateCheckRun(ctx, owner, repository, checkRunID, options)
+		if err != nil {
+			err = fmt.Errorf("could not update check run: %w", err)
+		}
+		return ghResponse, err
+	})
 }
 
 // DownloadRepository on GitHub
-func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) (err error) {
+func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	ctx = labelAPICalls(ctx, "DownloadRepository")
+	return client.DownloadRepositoryWithOptions(ctx, owner, repository, branch, localPath, DownloadRepositoryOptions{})
+}
+
+// DownloadRepositoryWithOptions on GitHub
+func (client *GitHubClient) DownloadRepositoryWithOptions(ctx context.Context, owner, repository, branch, localPath string, options DownloadRepositoryOptions) (err error) {
+	ctx = labelAPICalls(ctx, "DownloadRepositoryWithOptions")
 	// Get the archive download link from GitHub
 	var baseURL *url.URL
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		baseURL, ghResponse, err = client.executeGetArchiveLink(ctx, owner, repository, branch)
 		return ghResponse, err
@@ -283,7 +806,7 @@ func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repos
 	}
 
 	// Download the archive
-	httpResponse, err := executeDownloadArchiveFromLink(baseURL.String())
+	httpResponse, err := executeDownloadArchiveFromLink(newHTTPClient(vcsutils.GitHub, client.vcsInfo, client.logger), baseURL.String())
 	if err != nil {
 		return
 	}
@@ -291,10 +814,15 @@ func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repos
 	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
 
 	// Untar the archive
-	if err = vcsutils.Untar(localPath, httpResponse.Body, true); err != nil {
+	stats, err := vcsutils.UntarWithStats(localPath, httpResponse.Body, true, options.Filter, options.ExtractionProgress)
+	if err != nil {
 		return
 	}
+	reportRepositoryExtraction(client.logger, options, stats)
 	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	if client.vcsInfo.SkipDotGitCreation {
+		return
+	}
 
 	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
@@ -311,8 +839,7 @@ func (client *GitHubClient) executeGetArchiveLink(ctx context.Context, owner, re
 		&github.RepositoryContentGetOptions{Ref: branch}, 5)
 }
 
-func executeDownloadArchiveFromLink(baseURL string) (*http.Response, error) {
-	httpClient := &http.Client{}
+func executeDownloadArchiveFromLink(httpClient *http.Client, baseURL string) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodGet, baseURL, nil)
 	if err != nil {
 		return nil, err
@@ -334,7 +861,8 @@ func (client *GitHubClient) GetPullRequestDetailsSizeLimit() int {
 
 // CreatePullRequest on GitHub
 func (client *GitHubClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	ctx = labelAPICalls(ctx, "CreatePullRequest")
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		return client.executeCreatePullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description)
 	})
 }
@@ -354,6 +882,7 @@ func (client *GitHubClient) executeCreatePullRequest(ctx context.Context, owner,
 
 // UpdatePullRequest on GitHub
 func (client *GitHubClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, id int, state vcsutils.PullRequestState) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequest")
 	client.logger.Debug(vcsutils.UpdatingPullRequest, id)
 	var baseRef *github.PullRequestBranch
 	if targetBranchName != "" {
@@ -366,58 +895,785 @@ func (client *GitHubClient) UpdatePullRequest(ctx context.Context, owner, reposi
 		Base:  baseRef,
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		_, ghResponse, err := client.ghClient.PullRequests.Edit(ctx, owner, repository, id, pullRequest)
 		return ghResponse, err
 	})
 }
 
-// ListOpenPullRequestsWithBody on GitHub
-func (client *GitHubClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
+// ClosePullRequest on GitHub
+func (client *GitHubClient) ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ClosePullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Closed)
 }
 
-// ListOpenPullRequests on GitHub
-func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+// ReopenPullRequest on GitHub
+func (client *GitHubClient) ReopenPullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ReopenPullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Open)
 }
 
-func (client *GitHubClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
-	var pullRequests []*github.PullRequest
-	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
-	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+func (client *GitHubClient) setPullRequestState(ctx context.Context, owner, repository string, pullRequestID int, state vcsutils.PullRequestState) error {
+	var pullRequest *github.PullRequest
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		var err error
-		pullRequests, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{State: "open"})
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
 		return ghResponse, err
 	})
 	if err != nil {
-		return []PullRequestInfo{}, err
+		return err
 	}
-
-	return mapGitHubPullRequestToPullRequestInfoList(pullRequests, withBody)
+	if err = validatePullRequestStateTransition(pullRequestID, pullRequest.GetMerged(), vcsutils.PullRequestState(pullRequest.GetState()), state); err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, pullRequestID)
+	// Only the non-nil fields are edited, so the title and the body are kept as is
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.Edit(ctx, owner, repository, pullRequestID, &github.PullRequest{State: vcsutils.MapPullRequestState(&state)})
+		return ghResponse, err
+	})
 }
 
-func (client *GitHubClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
+// MergePullRequest on GitHub
+func (client *GitHubClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error {
+	ctx = labelAPICalls(ctx, "MergePullRequest")
+	if options.BypassPolicies {
+		return errGitHubBypassPoliciesNotSupported
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, pullRequestID)
+	mergeOptions := &github.PullRequestOptions{CommitTitle: options.CommitTitle, MergeMethod: string(options.Method)}
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.Merge(ctx, owner, repository, pullRequestID, options.CommitBody, mergeOptions)
+		return ghResponse, err
+	})
+	if err != nil || !options.DeleteSourceBranch {
+		return err
+	}
+
+	// The source branch may be in a forked repository
 	var pullRequest *github.PullRequest
-	var ghResponse *github.Response
-	var err error
-	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestId)
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
 		return ghResponse, err
 	})
 	if err != nil {
-		return PullRequestInfo{}, err
+		return fmt.Errorf("pull request %d was merged, but its source branch wasn't deleted: %w", pullRequestID, err)
 	}
-
-	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
-		return PullRequestInfo{}, err
+	head := pullRequest.GetHead()
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Git.DeleteRef(ctx, head.GetRepo().GetOwner().GetLogin(), head.GetRepo().GetName(), "heads/"+head.GetRef())
+	})
+	if err != nil {
+		return fmt.Errorf("pull request %d was merged, but its source branch wasn't deleted: %w", pullRequestID, err)
+	}
+	return nil
+}
+
+// UpdatePullRequestBranch on GitHub, by merging the target branch into the source branch in the background
+func (client *GitHubClient) UpdatePullRequestBranch(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.UpdateBranch(ctx, owner, repository, pullRequestID, nil)
+		return ghResponse, err
+	})
+	// The update is accepted and scheduled by a 202 response
+	var ghAcceptedError *github.AcceptedError
+	if errors.As(err, &ghAcceptedError) {
+		return nil
+	}
+	var ghErrorResponse *github.ErrorResponse
+	if errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity {
+		message := strings.ToLower(ghErrorResponse.Message)
+		switch {
+		case strings.Contains(message, "no new commits"):
+			return nil
+		case strings.Contains(message, "conflict"):
+			return &PullRequestBranchConflictError{PullRequestID: pullRequestID, Message: ghErrorResponse.Message}
+		}
+	}
+	return err
+}
+
+// ListMilestones on GitHub
+func (client *GitHubClient) ListMilestones(ctx context.Context, owner, repository string, state MilestoneState) ([]MilestoneInfo, error) {
+	ctx = labelAPICalls(ctx, "ListMilestones")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	if state == "" {
+		state = MilestoneOpen
+	}
+	var milestones []MilestoneInfo
+	for nextPage := 1; ; nextPage++ {
+		var ghMilestones []*github.Milestone
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			ghMilestones, ghResponse, err = client.ghClient.Issues.ListMilestones(ctx, owner, repository, &github.MilestoneListOptions{
+				State:       string(state),
+				ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+			})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ghMilestone := range ghMilestones {
+			milestones = append(milestones, mapGitHubMilestoneToMilestoneInfo(ghMilestone))
+		}
+		if ghResponse.NextPage == 0 {
+			sortMilestonesByDueDate(milestones)
+			return milestones, nil
+		}
+	}
+}
+
+func mapGitHubMilestoneToMilestoneInfo(ghMilestone *github.Milestone) MilestoneInfo {
+	milestone := MilestoneInfo{
+		ID:          int64(ghMilestone.GetNumber()),
+		Title:       ghMilestone.GetTitle(),
+		Description: ghMilestone.GetDescription(),
+		State:       MilestoneState(ghMilestone.GetState()),
+		URL:         ghMilestone.GetHTMLURL(),
+	}
+	if ghMilestone.DueOn != nil {
+		milestone.DueDate = &ghMilestone.DueOn.Time
+	}
+	return milestone
+}
+
+// SetPullRequestMilestone on GitHub, by setting the milestone of the issue of the pull request
+func (client *GitHubClient) SetPullRequestMilestone(ctx context.Context, owner, repository string, pullRequestID int, milestoneID int64) error {
+	ctx = labelAPICalls(ctx, "SetPullRequestMilestone")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		if milestoneID == 0 {
+			_, ghResponse, err = client.ghClient.Issues.RemoveMilestone(ctx, owner, repository, pullRequestID)
+		} else {
+			_, ghResponse, err = client.ghClient.Issues.Edit(ctx, owner, repository, pullRequestID, &github.IssueRequest{Milestone: vcsutils.PointerOf(int(milestoneID))})
+		}
+		return ghResponse, err
+	})
+}
+
+// The GraphQL mutation enabling the auto merge of a pull request, which isn't supported by the REST API
+const gitHubEnableAutoMergeMutation = `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) { clientMutationId }
+}`
+
+// EnablePullRequestAutoMerge on GitHub
+func (client *GitHubClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod MergeMethod) error {
+	ctx = labelAPICalls(ctx, "EnablePullRequestAutoMerge")
+	var pullRequest *github.PullRequest
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+		return ghResponse, err
+	})
+	if err != nil {
+		return err
+	}
+	variables := map[string]interface{}{"pullRequestId": pullRequest.GetNodeID()}
+	if mergeMethod != "" {
+		variables["mergeMethod"] = strings.ToUpper(string(mergeMethod))
+	}
+	client.logger.Debug(vcsutils.EnablingAutoMerge, pullRequestID)
+	return client.sendGraphQLRequest(ctx, gitHubEnableAutoMergeMutation, variables, nil)
+}
+
+// gitHubGraphQLError is an error of the response to a GraphQL request
+type gitHubGraphQLError struct {
+	Message string `json:"message"`
+	// The path of the field of the response which failed, such as ["repository", "commit0"]
+	Path []interface{} `json:"path"`
+}
+
+// gitHubGraphQLErrors is returned by sendGraphQLRequest when the response has errors.
+// The data of the fields that didn't fail is decoded nevertheless.
+type gitHubGraphQLErrors []gitHubGraphQLError
+
+func (e gitHubGraphQLErrors) Error() string {
+	var errorMessages []string
+	for _, responseError := range e {
+		errorMessages = append(errorMessages, responseError.Message)
+	}
+	return fmt.Sprintf("GitHub GraphQL request failed: %s", strings.Join(errorMessages, ", "))
+}
+
+// sendGraphQLRequest sends a GraphQL query or mutation, and returns the errors of the response as gitHubGraphQLErrors.
+// data - Optional pointer to decode the data of the response into
+func (client *GitHubClient) sendGraphQLRequest(ctx context.Context, query string, variables map[string]interface{}, data interface{}) error {
+	// The GraphQL API of GitHub Enterprise Server is at '/api/graphql', next to the '/api/v3' REST API
+	graphQLURL := client.ghClient.BaseURL.JoinPath("graphql")
+	if strings.HasSuffix(client.ghClient.BaseURL.Path, "/api/v3/") {
+		graphQLURL = client.ghClient.BaseURL.JoinPath("..", "graphql")
+	}
+	var response struct {
+		Data   interface{}         `json:"data"`
+		Errors gitHubGraphQLErrors `json:"errors"`
+	}
+	response.Data = data
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		request, err := client.ghClient.NewRequest(http.MethodPost, graphQLURL.String(), map[string]interface{}{"query": query, "variables": variables})
+		if err != nil {
+			return nil, err
+		}
+		return client.ghClient.Do(ctx, request, &response)
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+	return nil
+}
+
+// The GraphQL query of a page of the open pull requests with their details, which takes several REST calls per pull request
+const gitHubOpenPullRequestsDetailedQuery = `query($owner: String!, $repository: String!, $cursor: String) {
+  repository(owner: $owner, name: $repository) {
+    pullRequests(states: OPEN, first: 50, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number title body url updatedAt mergeable headRefName headRefOid baseRefName
+        author { login }
+        headRepository { name owner { login } }
+        baseRepository { name owner { login } }
+        labels(first: 100) { nodes { name } }
+        reviewRequests(first: 100) { nodes { requestedReviewer { ... on User { login } ... on Team { slug } } } }
+        commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+      }
+    }
+  }
+}`
+
+type gitHubGraphQLRepositoryRef struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+type gitHubGraphQLPullRequest struct {
+	Number      int64     `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	URL         string    `json:"url"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Mergeable   string    `json:"mergeable"`
+	HeadRefName string    `json:"headRefName"`
+	HeadRefOid  string    `json:"headRefOid"`
+	BaseRefName string    `json:"baseRefName"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	HeadRepository gitHubGraphQLRepositoryRef `json:"headRepository"`
+	BaseRepository gitHubGraphQLRepositoryRef `json:"baseRepository"`
+	Labels         struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// ListOpenPullRequestsDetailed on GitHub, by a GraphQL query per 50 pull requests.
+// The head commit status is the rollup of the statuses and the check runs of the commit.
+func (client *GitHubClient) ListOpenPullRequestsDetailed(ctx context.Context, owner, repository string) ([]DetailedPullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsDetailed")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var pullRequests []DetailedPullRequestInfo
+	variables := map[string]interface{}{"owner": owner, "repository": repository}
+	for {
+		var data struct {
+			Repository struct {
+				PullRequests struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []gitHubGraphQLPullRequest `json:"nodes"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+		if err := client.sendGraphQLRequest(ctx, gitHubOpenPullRequestsDetailedQuery, variables, &data); err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range data.Repository.PullRequests.Nodes {
+			pullRequests = append(pullRequests, mapGitHubGraphQLPullRequest(pullRequest))
+		}
+		if !data.Repository.PullRequests.PageInfo.HasNextPage {
+			return pullRequests, nil
+		}
+		variables["cursor"] = data.Repository.PullRequests.PageInfo.EndCursor
+	}
+}
+
+func mapGitHubGraphQLPullRequest(pullRequest gitHubGraphQLPullRequest) DetailedPullRequestInfo {
+	detailedPullRequest := DetailedPullRequestInfo{
+		PullRequestInfo: PullRequestInfo{
+			ID:             pullRequest.Number,
+			Body:           pullRequest.Body,
+			URL:            pullRequest.URL,
+			HTMLURL:        pullRequest.URL,
+			Source:         BranchInfo{Name: pullRequest.HeadRefName, Repository: pullRequest.HeadRepository.Name, Owner: pullRequest.HeadRepository.Owner.Login},
+			Target:         BranchInfo{Name: pullRequest.BaseRefName, Repository: pullRequest.BaseRepository.Name, Owner: pullRequest.BaseRepository.Owner.Login},
+			HeadCommitHash: pullRequest.HeadRefOid,
+			Author:         pullRequest.Author.Login,
+			UpdatedAt:      pullRequest.UpdatedAt.UTC(),
+		},
+		Title:            pullRequest.Title,
+		Labels:           []string{},
+		Reviewers:        []string{},
+		Mergeable:        MergeableUnknown,
+		HeadCommitStatus: InProgress,
+	}
+	switch pullRequest.Mergeable {
+	case "MERGEABLE":
+		detailedPullRequest.Mergeable = Mergeable
+	case "CONFLICTING":
+		detailedPullRequest.Mergeable = Conflicting
+	}
+	for _, label := range pullRequest.Labels.Nodes {
+		detailedPullRequest.Labels = append(detailedPullRequest.Labels, label.Name)
+	}
+	sort.Strings(detailedPullRequest.Labels)
+	for _, reviewRequest := range pullRequest.ReviewRequests.Nodes {
+		reviewer := reviewRequest.RequestedReviewer.Login
+		if reviewer == "" {
+			reviewer = reviewRequest.RequestedReviewer.Slug
+		}
+		detailedPullRequest.Reviewers = append(detailedPullRequest.Reviewers, reviewer)
+	}
+	sort.Strings(detailedPullRequest.Reviewers)
+	if commits := pullRequest.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+		detailedPullRequest.HeadCommitStatus = mapGitHubStatusCheckRollupState(commits[0].Commit.StatusCheckRollup.State)
+	}
+	return detailedPullRequest
+}
+
+// mapGitHubStatusCheckRollupState maps the combined state of the statuses and the check runs of a commit
+func mapGitHubStatusCheckRollupState(state string) CommitStatus {
+	switch state {
+	case "SUCCESS":
+		return Pass
+	case "FAILURE":
+		return Fail
+	case "ERROR":
+		return Error
+	default:
+		return InProgress
+	}
+}
+
+// ListOpenPullRequestsWithBody on GitHub
+func (client *GitHubClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithBody")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{WithBody: true})
+}
+
+// ListOpenPullRequests on GitHub
+func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequests")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{})
+}
+
+// ListOpenPullRequestsWithQueryOptions on GitHub
+func (client *GitHubClient) ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithQueryOptions")
+	return client.getOpenPullRequests(ctx, owner, repository, options)
+}
+
+func (client *GitHubClient) getOpenPullRequests(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	var pullRequests []*github.PullRequest
+	// The head branch is filtered in the 'user:ref-name' format
+	var head string
+	if options.SourceBranch != "" {
+		head = owner + ":" + options.SourceBranch
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		pullRequests, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
+			State: "open",
+			Head:  head,
+			Base:  options.TargetBranch,
+			ListOptions: github.ListOptions{
+				Page:    options.Page,
+				PerPage: options.PerPage,
+			},
+		})
+		return ghResponse, err
+	})
+	if err != nil {
+		return []PullRequestInfo{}, err
+	}
+
+	pullRequestsInfo, err := mapGitHubPullRequestToPullRequestInfoList(pullRequests, options.WithBody)
+	return orderPullRequests(client.vcsInfo, pullRequestsInfo), err
+}
+
+// ListPullRequests on GitHub. The API doesn't filter by author, and lists the merged pull requests as closed,
+// so all the pages are fetched and filtered by the client when filtering by author, or by the closed or merged states.
+func (client *GitHubClient) ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequests")
+	listOptions := &github.PullRequestListOptions{State: "open", Sort: "created", Direction: "desc", Base: options.TargetBranch}
+	switch options.State {
+	case PullRequestsClosed, PullRequestsMerged:
+		listOptions.State = "closed"
+	case PullRequestsAll:
+		listOptions.State = "all"
+	}
+	if options.Sort == SortByUpdated {
+		listOptions.Sort = "updated"
+	}
+	if options.Ascending {
+		listOptions.Direction = "asc"
+	}
+	if options.SourceBranch != "" {
+		listOptions.Head = owner + ":" + options.SourceBranch
+	}
+	filterByClient := options.Author != "" || options.State == PullRequestsClosed || options.State == PullRequestsMerged
+	listOptions.ListOptions = github.ListOptions{Page: options.Page, PerPage: options.PerPage}
+	if filterByClient {
+		listOptions.ListOptions = github.ListOptions{Page: 1, PerPage: 100}
+	}
+	var pullRequests []*github.PullRequest
+	for {
+		var pullRequestsPage []*github.PullRequest
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			pullRequestsPage, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequestsPage {
+			if matchesGitHubPullRequestFilters(pullRequest, options) {
+				pullRequests = append(pullRequests, pullRequest)
+			}
+		}
+		if !filterByClient || ghResponse.NextPage == 0 {
+			break
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+	pullRequestsInfo, err := mapGitHubPullRequestToPullRequestInfoList(pullRequests, options.WithBody)
+	if err != nil || !filterByClient {
+		return pullRequestsInfo, err
+	}
+	return paginate(pullRequestsInfo, options.ListOptions), nil
+}
+
+func matchesGitHubPullRequestFilters(pullRequest *github.PullRequest, options PullRequestListOptions) bool {
+	if options.Author != "" && pullRequest.GetUser().GetLogin() != options.Author {
+		return false
+	}
+	switch options.State {
+	case PullRequestsClosed:
+		return pullRequest.MergedAt == nil
+	case PullRequestsMerged:
+		return pullRequest.MergedAt != nil
+	default:
+		return true
+	}
+}
+
+func (client *GitHubClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestByID")
+	var pullRequest *github.PullRequest
+	var ghResponse *github.Response
+	var err error
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestId)
+		return ghResponse, err
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+
+	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
+		return PullRequestInfo{}, err
 	}
 
 	return mapGitHubPullRequestToPullRequestInfo(pullRequest, false)
 }
 
+// ListCommitsBetween on GitHub returns the hashes of the commits reachable from toRef but not from fromRef, from the oldest to the newest
+func (client *GitHubClient) ListCommitsBetween(ctx context.Context, owner, repository, fromRef, toRef string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListCommitsBetween")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "fromRef": fromRef, "toRef": toRef})
+	if err != nil {
+		return nil, err
+	}
+	var commits []string
+	listOptions := &github.ListOptions{Page: 1, PerPage: vcsutils.NumberOfCommitsToFetch}
+	for listOptions.Page > 0 {
+		var comparison *github.CommitsComparison
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			comparison, ghResponse, err = client.ghClient.Repositories.CompareCommits(ctx, owner, repository, fromRef, toRef, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range comparison.Commits {
+			commits = append(commits, commit.GetSHA())
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+	return commits, nil
+}
+
+// ListMergedPullRequestsOfCommit on GitHub returns the merged pull requests associated with a commit, with their merge commit hashes
+func (client *GitHubClient) ListMergedPullRequestsOfCommit(ctx context.Context, owner, repository, sha string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListMergedPullRequestsOfCommit")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return nil, err
+	}
+	var pullRequests []PullRequestInfo
+	listOptions := &github.ListOptions{Page: 1, PerPage: vcsutils.NumberOfCommitsToFetch}
+	for listOptions.Page > 0 {
+		var ghPullRequests []*github.PullRequest
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			ghPullRequests, ghResponse, err = client.ghClient.PullRequests.ListPullRequestsWithCommit(ctx, owner, repository, sha, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ghPullRequest := range ghPullRequests {
+			if ghPullRequest.MergedAt == nil {
+				continue
+			}
+			pullRequest, err := mapGitHubPullRequestToPullRequestInfo(ghPullRequest, false)
+			if err != nil {
+				return nil, err
+			}
+			pullRequest.MergeCommitHash = ghPullRequest.GetMergeCommitSHA()
+			pullRequests = append(pullRequests, pullRequest)
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+	return pullRequests, nil
+}
+
+// GetPullRequestMergeCommit on GitHub returns the hash of the test merge commit of a pull request, which is the head of refs/pull/<id>/merge.
+// Returns an empty string if the pull request isn't mergeable, or if its mergeability isn't computed yet.
+func (client *GitHubClient) GetPullRequestMergeCommit(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	ctx = labelAPICalls(ctx, "GetPullRequestMergeCommit")
+	var pullRequest *github.PullRequest
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	if !pullRequest.GetMergeable() {
+		return "", nil
+	}
+	return pullRequest.GetMergeCommitSHA(), nil
+}
+
+// SquashBranchIntoSingleCommit on GitHub, by the Git database API.
+// The tree of the head commit of the branch is committed on top of the merge base, authored by the authenticated user,
+// and the branch is force-updated to the new commit. Commits pushed to the branch while it's squashed are discarded.
+// A branch with a single commit since the base branch is kept as is.
+func (client *GitHubClient) SquashBranchIntoSingleCommit(ctx context.Context, owner, repository, branch, baseBranch, message string) (string, error) {
+	ctx = labelAPICalls(ctx, "SquashBranchIntoSingleCommit")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "baseBranch": baseBranch})
+	if err != nil {
+		return "", err
+	}
+	var headBranch *github.Branch
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		headBranch, ghResponse, err = client.ghClient.Repositories.GetBranch(ctx, owner, repository, branch, 0)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	headCommit := headBranch.GetCommit()
+
+	var messages []string
+	var mergeBase string
+	listOptions := &github.ListOptions{Page: 1, PerPage: vcsutils.NumberOfCommitsToFetch}
+	for listOptions.Page > 0 {
+		var comparison *github.CommitsComparison
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			comparison, ghResponse, err = client.ghClient.Repositories.CompareCommits(ctx, owner, repository, baseBranch, headCommit.GetSHA(), listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return "", err
+		}
+		if comparison.GetAheadBy() <= 1 {
+			return headCommit.GetSHA(), nil
+		}
+		mergeBase = comparison.GetMergeBaseCommit().GetSHA()
+		if message != "" {
+			break
+		}
+		for _, commit := range comparison.Commits {
+			messages = append(messages, commit.GetCommit().GetMessage())
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+	if message == "" {
+		message = strings.Join(messages, "\n\n")
+	}
+
+	var squashedCommit *github.Commit
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		squashedCommit, ghResponse, err = client.ghClient.Git.CreateCommit(ctx, owner, repository, &github.Commit{
+			Message: &message,
+			Tree:    &github.Tree{SHA: headCommit.GetCommit().GetTree().SHA},
+			Parents: []*github.Commit{{SHA: &mergeBase}},
+		}, nil)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Git.UpdateRef(ctx, owner, repository, &github.Reference{
+			Ref:    github.String(vcsutils.AddBranchPrefix(branch)),
+			Object: &github.GitObject{SHA: squashedCommit.SHA},
+		}, true)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return squashedCommit.GetSHA(), nil
+}
+
+// CreateIssue on GitHub
+func (client *GitHubClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "CreateIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title}); err != nil {
+		return IssueInfo{}, err
+	}
+	var issue *github.Issue
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		issue, ghResponse, err = client.ghClient.Issues.Create(ctx, owner, repository, &github.IssueRequest{Title: &title, Body: &body})
+		return ghResponse, err
+	})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGitHubIssueToIssueInfo(issue), nil
+}
+
+// ListIssues on GitHub. The issues API returns the pull requests as well, so they are filtered out.
+func (client *GitHubClient) ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "ListIssues")
+	if state == "" {
+		state = IssueOpen
+	}
+	var issues []IssueInfo
+	for nextPage := 1; ; nextPage++ {
+		var issuesPage []*github.Issue
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			issuesPage, ghResponse, err = client.ghClient.Issues.ListByRepo(ctx, owner, repository, &github.IssueListByRepoOptions{
+				State:       string(state),
+				ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+			})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issuesPage {
+			if !issue.IsPullRequest() {
+				issues = append(issues, mapGitHubIssueToIssueInfo(issue))
+			}
+		}
+		if ghResponse.NextPage == 0 {
+			return orderIssues(client.vcsInfo, issues), nil
+		}
+	}
+}
+
+// CommentOnIssue on GitHub
+func (client *GitHubClient) CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CommentOnIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.CreateComment(ctx, owner, repository, issueID, &github.IssueComment{Body: &content})
+		return ghResponse, err
+	})
+}
+
+// CloseIssue on GitHub
+func (client *GitHubClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CloseIssue")
+	state := string(IssueClosed)
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.Edit(ctx, owner, repository, issueID, &github.IssueRequest{State: &state})
+		return ghResponse, err
+	})
+}
+
+func mapGitHubIssueToIssueInfo(issue *github.Issue) IssueInfo {
+	return IssueInfo{
+		ID:        int64(issue.GetNumber()),
+		Title:     issue.GetTitle(),
+		Body:      issue.GetBody(),
+		State:     IssueState(issue.GetState()),
+		URL:       issue.GetHTMLURL(),
+		Author:    issue.GetUser().GetLogin(),
+		CreatedAt: issue.GetCreatedAt().Time,
+	}
+}
+
 func mapGitHubPullRequestToPullRequestInfo(ghPullRequest *github.PullRequest, withBody bool) (PullRequestInfo, error) {
 	var sourceBranch, targetBranch string
 	var err1, err2 error
@@ -456,9 +1712,10 @@ func mapGitHubPullRequestToPullRequestInfo(ghPullRequest *github.PullRequest, wi
 	}
 
 	return PullRequestInfo{
-		ID:   int64(vcsutils.DefaultIfNotNil(ghPullRequest.Number)),
-		URL:  vcsutils.DefaultIfNotNil(ghPullRequest.HTMLURL),
-		Body: body,
+		ID:      int64(vcsutils.DefaultIfNotNil(ghPullRequest.Number)),
+		URL:     vcsutils.DefaultIfNotNil(ghPullRequest.HTMLURL),
+		HTMLURL: vcsutils.DefaultIfNotNil(ghPullRequest.HTMLURL),
+		Body:    body,
 		Source: BranchInfo{
 			Name:       sourceBranch,
 			Repository: sourceRepoName,
@@ -469,6 +1726,9 @@ func mapGitHubPullRequestToPullRequestInfo(ghPullRequest *github.PullRequest, wi
 			Repository: targetRepoName,
 			Owner:      targetRepoOwner,
 		},
+		HeadCommitHash: ghPullRequest.GetHead().GetSHA(),
+		Author:         ghPullRequest.GetUser().GetLogin(),
+		UpdatedAt:      ghPullRequest.GetUpdatedAt().Time,
 	}, nil
 }
 
@@ -483,12 +1743,13 @@ func extractBranchFromLabel(label string) (string, error) {
 
 // AddPullRequestComment on GitHub
 func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestComment")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
 	if err != nil {
 		return err
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		// We use the Issues API to add a regular comment. The PullRequests API adds a code review comment.
 		_, ghResponse, err = client.ghClient.Issues.CreateComment(ctx, owner, repository, pullRequestID, &github.IssueComment{Body: &content})
@@ -498,6 +1759,7 @@ func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, re
 
 // AddPullRequestReviewComments on GitHub
 func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestReviewComments")
 	prID := strconv.Itoa(pullRequestID)
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": prID})
 	if err != nil {
@@ -509,7 +1771,7 @@ func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, ow
 
 	var commits []*github.RepositoryCommit
 	var ghResponse *github.Response
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		commits, ghResponse, err = client.ghClient.PullRequests.ListCommits(ctx, owner, repository, pullRequestID, nil)
 		return ghResponse, err
 	})
@@ -523,7 +1785,7 @@ func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, ow
 	latestCommitSHA := commits[len(commits)-1].GetSHA()
 
 	for _, comment := range comments {
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 			ghResponse, err = client.executeCreatePullRequestReviewComment(ctx, owner, repository, latestCommitSHA, pullRequestID, comment)
 			return ghResponse, err
 		})
@@ -557,31 +1819,55 @@ func (client *GitHubClient) executeCreatePullRequestReviewComment(ctx context.Co
 
 // ListPullRequestReviewComments on GitHub
 func (client *GitHubClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestReviewComments")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return nil, err
 	}
 
 	commentsInfoList := []CommentInfo{}
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	listOptions := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{Page: 1, PerPage: 100}}
+	for listOptions.Page > 0 {
+		var pageComments []CommentInfo
 		var ghResponse *github.Response
-		commentsInfoList, ghResponse, err = client.executeListPullRequestReviewComments(ctx, owner, repository, pullRequestID)
-		return ghResponse, err
-	})
-	return commentsInfoList, err
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			pageComments, ghResponse, err = client.executeListPullRequestReviewComments(ctx, owner, repository, pullRequestID, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return []CommentInfo{}, err
+		}
+		commentsInfoList = append(commentsInfoList, pageComments...)
+		listOptions.Page = ghResponse.NextPage
+	}
+	return orderComments(client.vcsInfo, commentsInfoList), nil
 }
 
-func (client *GitHubClient) executeListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, *github.Response, error) {
-	commentsList, ghResponse, err := client.ghClient.PullRequests.ListComments(ctx, owner, repository, pullRequestID, nil)
+func (client *GitHubClient) executeListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int,
+	listOptions *github.PullRequestListCommentsOptions) ([]CommentInfo, *github.Response, error) {
+	commentsList, ghResponse, err := client.ghClient.PullRequests.ListComments(ctx, owner, repository, pullRequestID, listOptions)
 	if err != nil {
 		return []CommentInfo{}, ghResponse, err
 	}
 	commentsInfoList := []CommentInfo{}
 	for _, comment := range commentsList {
+		// Replies are always added to the first comment of the thread
+		threadID := comment.GetInReplyTo()
+		if threadID == 0 {
+			threadID = comment.GetID()
+		}
+		// The start line is set only on a comment spanning several lines
+		line := comment.GetStartLine()
+		if line == 0 {
+			line = comment.GetLine()
+		}
 		commentsInfoList = append(commentsInfoList, CommentInfo{
-			ID:      comment.GetID(),
-			Content: comment.GetBody(),
-			Created: comment.GetCreatedAt().Time,
+			ID:       comment.GetID(),
+			ThreadID: strconv.FormatInt(threadID, 10),
+			Content:  comment.GetBody(),
+			Created:  comment.GetCreatedAt().Time,
+			FilePath: comment.GetPath(),
+			Line:     line,
 		})
 	}
 	return commentsInfoList, ghResponse, nil
@@ -589,13 +1875,14 @@ func (client *GitHubClient) executeListPullRequestReviewComments(ctx context.Con
 
 // ListPullRequestComments on GitHub
 func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestComments")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return []CommentInfo{}, err
 	}
 
 	var commentsList []*github.IssueComment
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		commentsList, ghResponse, err = client.ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, &github.IssueListCommentsOptions{})
 		return ghResponse, err
@@ -605,11 +1892,13 @@ func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner,
 		return []CommentInfo{}, err
 	}
 
-	return mapGitHubIssuesCommentToCommentInfoList(commentsList)
+	commentsInfoList, err := mapGitHubIssuesCommentToCommentInfoList(commentsList)
+	return orderComments(client.vcsInfo, commentsInfoList), err
 }
 
 // DeletePullRequestReviewComments on GitHub
 func (client *GitHubClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, _ int, comments ...CommentInfo) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestReviewComments")
 	for _, comment := range comments {
 		commentID := comment.ID
 		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commentID": strconv.FormatInt(commentID, 10)})
@@ -617,7 +1906,7 @@ func (client *GitHubClient) DeletePullRequestReviewComments(ctx context.Context,
 			return err
 		}
 
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 			return client.executeDeletePullRequestReviewComment(ctx, owner, repository, commentID)
 		})
 		if err != nil {
@@ -638,11 +1927,12 @@ func (client *GitHubClient) executeDeletePullRequestReviewComment(ctx context.Co
 
 // DeletePullRequestComment on GitHub
 func (client *GitHubClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _, commentID int) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestComment")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return err
 	}
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		return client.executeDeletePullRequestComment(ctx, owner, repository, commentID)
 	})
 }
@@ -664,8 +1954,69 @@ func (client *GitHubClient) executeDeletePullRequestComment(ctx context.Context,
 	return ghResponse, nil
 }
 
+// UpdatePullRequestComment on GitHub
+func (client *GitHubClient) UpdatePullRequestComment(ctx context.Context, owner, repository string, _, commentID int, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		// Regular pull request comments are issue comments
+		_, ghResponse, err := client.ghClient.Issues.EditComment(ctx, owner, repository, int64(commentID), &github.IssueComment{Body: &content})
+		return ghResponse, err
+	})
+}
+
+// UpdatePullRequestReviewComment on GitHub
+func (client *GitHubClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, _ int, comment CommentInfo, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestReviewComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.EditComment(ctx, owner, repository, comment.ID, &github.PullRequestComment{Body: &content})
+		if err != nil {
+			err = fmt.Errorf("could not update pull request review comment: %w", err)
+		}
+		return ghResponse, err
+	})
+}
+
+// ReplyToPullRequestReviewComment on GitHub
+func (client *GitHubClient) ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error {
+	ctx = labelAPICalls(ctx, "ReplyToPullRequestReviewComment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "threadID": threadID, "content": content})
+	if err != nil {
+		return err
+	}
+	commentID, err := strconv.ParseInt(threadID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", threadID, err)
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.CreateCommentInReplyTo(ctx, owner, repository, pullRequestID, content, commentID)
+		if err != nil {
+			err = fmt.Errorf("could not reply to pull request review comment: %w", err)
+		}
+		return ghResponse, err
+	})
+}
+
+// UploadPullRequestAttachment on GitHub
+func (client *GitHubClient) UploadPullRequestAttachment(_ context.Context, _, _ string, _ int, _ string, _ []byte) (PullRequestAttachmentInfo, error) {
+	return PullRequestAttachmentInfo{}, errGitHubPullRequestAttachmentsNotSupported
+}
+
+// ListPullRequestAttachments on GitHub
+func (client *GitHubClient) ListPullRequestAttachments(_ context.Context, _, _ string, _ int) ([]PullRequestAttachmentInfo, error) {
+	return nil, errGitHubPullRequestAttachmentsNotSupported
+}
+
 // GetLatestCommit on GitHub
 func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLatestCommit")
 	commits, err := client.GetCommits(ctx, owner, repository, branch)
 	if err != nil {
 		return CommitInfo{}, err
@@ -679,6 +2030,7 @@ func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, reposito
 
 // GetCommits on GitHub
 func (client *GitHubClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommits")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -689,7 +2041,7 @@ func (client *GitHubClient) GetCommits(ctx context.Context, owner, repository, b
 	}
 
 	var commitsInfo []CommitInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		listOptions := &github.CommitsListOptions{
 			SHA: branch,
@@ -701,11 +2053,12 @@ func (client *GitHubClient) GetCommits(ctx context.Context, owner, repository, b
 		commitsInfo, ghResponse, err = client.executeGetCommits(ctx, owner, repository, listOptions)
 		return ghResponse, err
 	})
-	return commitsInfo, err
+	return orderCommits(client.vcsInfo, commitsInfo), err
 }
 
 // GetCommitsWithQueryOptions on GitHub
 func (client *GitHubClient) GetCommitsWithQueryOptions(ctx context.Context, owner, repository string, listOptions GitCommitsQueryOptions) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitsWithQueryOptions")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -714,12 +2067,12 @@ func (client *GitHubClient) GetCommitsWithQueryOptions(ctx context.Context, owne
 		return nil, err
 	}
 	var commitsInfo []CommitInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		commitsInfo, ghResponse, err = client.executeGetCommits(ctx, owner, repository, convertToGitHubCommitsListOptions(listOptions))
 		return ghResponse, err
 	})
-	return commitsInfo, err
+	return orderCommits(client.vcsInfo, commitsInfo), err
 }
 
 func convertToGitHubCommitsListOptions(listOptions GitCommitsQueryOptions) *github.CommitsListOptions {
@@ -749,13 +2102,14 @@ func (client *GitHubClient) executeGetCommits(ctx context.Context, owner, reposi
 
 // GetRepositoryInfo on GitHub
 func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryInfo")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return RepositoryInfo{}, err
 	}
 
 	var repo *github.Repository
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
 		return ghResponse, err
@@ -767,8 +2121,168 @@ func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, reposi
 	return RepositoryInfo{RepositoryVisibility: getGitHubRepositoryVisibility(repo), CloneInfo: CloneInfo{HTTP: repo.GetCloneURL(), SSH: repo.GetSSHURL()}}, nil
 }
 
+// ArchiveRepository on GitHub
+func (client *GitHubClient) ArchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "ArchiveRepository")
+	return client.editRepository(ctx, owner, repository, &github.Repository{Archived: vcsutils.PointerOf(true)})
+}
+
+// UnarchiveRepository on GitHub
+func (client *GitHubClient) UnarchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "UnarchiveRepository")
+	return client.editRepository(ctx, owner, repository, &github.Repository{Archived: vcsutils.PointerOf(false)})
+}
+
+// RenameRepository on GitHub
+func (client *GitHubClient) RenameRepository(ctx context.Context, owner, repository, newName string) error {
+	ctx = labelAPICalls(ctx, "RenameRepository")
+	if err := validateParametersNotBlank(map[string]string{"newName": newName}); err != nil {
+		return err
+	}
+	return client.editRepository(ctx, owner, repository, &github.Repository{Name: &newName})
+}
+
+// GetUserPermissionOnRepo on GitHub
+func (client *GitHubClient) GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	ctx = labelAPICalls(ctx, "GetUserPermissionOnRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
+	}
+	var permissionLevel *github.RepositoryPermissionLevel
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		permissionLevel, ghResponse, err = client.ghClient.Repositories.GetPermissionLevel(ctx, owner, repository, username)
+		return ghResponse, err
+	})
+	if err != nil {
+		return NoPermission, err
+	}
+	return mapGitHubRepositoryPermission(permissionLevel), nil
+}
+
+// ListRepositoryCollaborators on GitHub
+func (client *GitHubClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string, affiliation CollaboratorAffiliation) ([]CollaboratorInfo, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryCollaborators")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	affiliation, err := getCollaboratorAffiliation(affiliation)
+	if err != nil {
+		return nil, err
+	}
+	var collaborators []CollaboratorInfo
+	for nextPage := 1; ; nextPage++ {
+		var users []*github.User
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			users, ghResponse, err = client.ghClient.Repositories.ListCollaborators(ctx, owner, repository,
+				&github.ListCollaboratorsOptions{Affiliation: string(affiliation), ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			collaborators = append(collaborators, CollaboratorInfo{Username: user.GetLogin(), Permission: mapGitHubCollaboratorPermission(user)})
+		}
+		if ghResponse.NextPage == 0 {
+			sortCollaborators(collaborators)
+			return collaborators, nil
+		}
+	}
+}
+
+// mapGitHubCollaboratorPermission maps the role of a listed collaborator, or the highest of the permissions of the collaborator
+// if the role is a custom role, which is based on one of the permissions
+func mapGitHubCollaboratorPermission(user *github.User) RepositoryPermission {
+	switch user.GetRoleName() {
+	case "admin":
+		return AdminPermission
+	case "maintain":
+		return MaintainPermission
+	case "write":
+		return WritePermission
+	case "triage", "read":
+		return ReadPermission
+	}
+	permissions := user.GetPermissions()
+	switch {
+	case permissions["admin"]:
+		return AdminPermission
+	case permissions["maintain"]:
+		return MaintainPermission
+	case permissions["push"]:
+		return WritePermission
+	case permissions["pull"], permissions["triage"]:
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// mapGitHubRepositoryPermission maps the permission level of a collaborator.
+// The permission of the response has no maintain level, so the maintain role is taken from the permissions of the user.
+func mapGitHubRepositoryPermission(permissionLevel *github.RepositoryPermissionLevel) RepositoryPermission {
+	permission := permissionLevel.GetPermission()
+	if permission != "admin" && permissionLevel.GetUser().GetPermissions()["maintain"] {
+		return MaintainPermission
+	}
+	switch permission {
+	case "admin":
+		return AdminPermission
+	case "write":
+		return WritePermission
+	case "read":
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetRepositoryMirrors on GitHub returns the repository the repository is mirrored from.
+// Mirrors are available on GitHub Enterprise, so a repository which isn't a mirror has no mirrors.
+func (client *GitHubClient) GetRepositoryMirrors(ctx context.Context, owner, repository string) ([]RepositoryMirrorInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryMirrors")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var repo *github.Repository
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if repo.GetMirrorURL() == "" {
+		return []RepositoryMirrorInfo{}, nil
+	}
+	return []RepositoryMirrorInfo{{URL: repo.GetMirrorURL(), Enabled: true}}, nil
+}
+
+// SetRepositoryMirror on GitHub
+func (client *GitHubClient) SetRepositoryMirror(_ context.Context, _, _ string, _ RepositoryMirrorInfo) error {
+	return errGitHubSetRepositoryMirrorNotSupported
+}
+
+func (client *GitHubClient) editRepository(ctx context.Context, owner, repository string, repositoryChanges *github.Repository) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Repositories.Edit(ctx, owner, repository, repositoryChanges)
+		return ghResponse, err
+	})
+}
+
 // GetCommitBySha on GitHub
 func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitBySha")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -779,7 +2293,7 @@ func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repositor
 	}
 
 	var commit *github.RepositoryCommit
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		commit, ghResponse, err = client.ghClient.Repositories.GetCommit(ctx, owner, repository, sha, nil)
 		return ghResponse, err
@@ -791,14 +2305,162 @@ func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repositor
 	return mapGitHubCommitToCommitInfo(commit), nil
 }
 
+// The maximal number of commits fetched by a GraphQL query of GetCommitsBySha
+const gitHubCommitsByShaBatchSize = 100
+
+// The fields of the commits fetched by the GraphQL query of GetCommitsBySha. The type of the object is fetched
+// along with them, since an object which isn't a commit, such as a tree, has none of the fields.
+const gitHubGraphQLCommitFragment = `fragment commitFields on GitObject {
+  __typename
+  ... on Commit {
+    oid message url committedDate
+    author { name email }
+    committer { name }
+    parents(first: 100) { nodes { oid } }
+  }
+}`
+
+type gitHubGraphQLCommit struct {
+	Typename      string    `json:"__typename"`
+	Oid           string    `json:"oid"`
+	Message       string    `json:"message"`
+	URL           string    `json:"url"`
+	CommittedDate time.Time `json:"committedDate"`
+	Author        struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+	Committer struct {
+		Name string `json:"name"`
+	} `json:"committer"`
+	Parents struct {
+		Nodes []struct {
+			Oid string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+}
+
+// GetCommitsBySha on GitHub, by a GraphQL query per 100 commits, in which each commit is an aliased object of the repository.
+// The errors of the response are mapped to the commits by the aliases in their paths.
+func (client *GitHubClient) GetCommitsBySha(ctx context.Context, owner, repository string, shas []string) (map[string]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitsBySha")
+	commits := make(map[string]CommitInfo, len(shas))
+	commitErrors := map[string]error{}
+	for start := 0; start < len(shas); start += gitHubCommitsByShaBatchSize {
+		batch := shas[start:min(start+gitHubCommitsByShaBatchSize, len(shas))]
+		variables := map[string]interface{}{"owner": owner, "repository": repository}
+		var variableDefinitions, objects strings.Builder
+		for i, sha := range batch {
+			variables[fmt.Sprintf("sha%d", i)] = sha
+			fmt.Fprintf(&variableDefinitions, ", $sha%d: String!", i)
+			fmt.Fprintf(&objects, "    commit%d: object(expression: $sha%d) { ...commitFields }\n", i, i)
+		}
+		query := fmt.Sprintf("query($owner: String!, $repository: String!%s) {\n  repository(owner: $owner, name: $repository) {\n%s  }\n}\n%s",
+			variableDefinitions.String(), objects.String(), gitHubGraphQLCommitFragment)
+		var data struct {
+			Repository map[string]*gitHubGraphQLCommit `json:"repository"`
+		}
+		aliasErrors, err := getGitHubGraphQLCommitErrors(client.sendGraphQLRequest(ctx, query, variables, &data))
+		if err != nil {
+			for _, sha := range batch {
+				commitErrors[sha] = err
+			}
+			continue
+		}
+		for i, sha := range batch {
+			alias := fmt.Sprintf("commit%d", i)
+			commit := data.Repository[alias]
+			switch {
+			case aliasErrors[alias] != nil:
+				commitErrors[sha] = aliasErrors[alias]
+			case commit == nil:
+				commitErrors[sha] = fmt.Errorf("commit %s wasn't found", sha)
+			case commit.Typename != "Commit":
+				commitErrors[sha] = fmt.Errorf("%s is a %s object rather than a commit", sha, commit.Typename)
+			default:
+				commits[sha] = client.mapGitHubGraphQLCommitToCommitInfo(owner, repository, commit)
+			}
+		}
+	}
+	return commits, newBatchError(getCommitsOperation, commitErrors)
+}
+
+// getGitHubGraphQLCommitErrors maps the errors of the GraphQL query of GetCommitsBySha to the aliases of the commits in their paths.
+// Returns an error failing all the commits of the query if the query failed, or if any of its errors isn't of a commit.
+func getGitHubGraphQLCommitErrors(err error) (map[string]error, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var graphQLErrors gitHubGraphQLErrors
+	if !errors.As(err, &graphQLErrors) {
+		return nil, err
+	}
+	aliasErrors := make(map[string]error, len(graphQLErrors))
+	for _, graphQLError := range graphQLErrors {
+		if len(graphQLError.Path) < 2 || graphQLError.Path[0] != "repository" {
+			return nil, err
+		}
+		alias, ok := graphQLError.Path[1].(string)
+		if !ok {
+			return nil, err
+		}
+		aliasErrors[alias] = errors.Join(aliasErrors[alias], errors.New(graphQLError.Message))
+	}
+	return aliasErrors, nil
+}
+
+func (client *GitHubClient) mapGitHubGraphQLCommitToCommitInfo(owner, repository string, commit *gitHubGraphQLCommit) CommitInfo {
+	parents := make([]string, len(commit.Parents.Nodes))
+	for i, parent := range commit.Parents.Nodes {
+		parents[i] = parent.Oid
+	}
+	return CommitInfo{
+		Hash:          commit.Oid,
+		AuthorName:    commit.Author.Name,
+		CommitterName: commit.Committer.Name,
+		// The API URL of the commit, as returned by the REST API
+		Url:          client.ghClient.BaseURL.JoinPath("repos", owner, repository, "commits", commit.Oid).String(),
+		HTMLURL:      commit.URL,
+		Timestamp:    commit.CommittedDate.UTC().Unix(),
+		Message:      commit.Message,
+		ParentHashes: parents,
+		AuthorEmail:  commit.Author.Email,
+	}
+}
+
+// GetCommitDiff on GitHub
+func (client *GitHubClient) GetCommitDiff(ctx context.Context, owner, repository, sha string) (CommitDiffInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitDiff")
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+
+	var diff string
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		diff, ghResponse, err = client.ghClient.Repositories.GetCommitRaw(ctx, owner, repository, sha, github.RawOptions{Type: github.Diff})
+		return ghResponse, err
+	})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+	return CommitDiffInfo{Diff: diff, Files: parseUnifiedDiffFiles(diff)}, nil
+}
+
 // CreateLabel on GitHub
 func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	ctx = labelAPICalls(ctx, "CreateLabel")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
 	if err != nil {
 		return err
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		_, ghResponse, err = client.ghClient.Issues.CreateLabel(ctx, owner, repository, &github.Label{
 			Name:        &labelInfo.Name,
@@ -811,13 +2473,14 @@ func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository s
 
 // GetLabel on GitHub
 func (client *GitHubClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLabel")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
 	if err != nil {
 		return nil, err
 	}
 
 	var labelInfo *LabelInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		labelInfo, ghResponse, err = client.executeGetLabel(ctx, owner, repository, name)
 		return ghResponse, err
@@ -842,78 +2505,254 @@ func (client *GitHubClient) executeGetLabel(ctx context.Context, owner, reposito
 	return labelInfo, ghResponse, nil
 }
 
-// ListPullRequestLabels on GitHub
-func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// ListPullRequestLabels on GitHub
+func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestLabels")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []string{}
+	for nextPage := 0; ; nextPage++ {
+		options := &github.ListOptions{Page: nextPage}
+		var labels []*github.Label
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			labels, ghResponse, err = client.ghClient.Issues.ListLabelsByIssue(ctx, owner, repository, pullRequestID, options)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			results = append(results, *label.Name)
+		}
+		if nextPage+1 >= ghResponse.LastPage {
+			break
+		}
+	}
+	return orderNames(client.vcsInfo, results), nil
+}
+
+// LabelPullRequest on GitHub
+func (client *GitHubClient) LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "LabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.AddLabelsToIssue(ctx, owner, repository, pullRequestID, []string{name})
+		return ghResponse, err
+	})
+}
+
+// UnlabelPullRequest on GitHub
+func (client *GitHubClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "UnlabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Issues.RemoveLabelForIssue(ctx, owner, repository, pullRequestID, name)
+	})
+}
+
+// UploadCodeScanning to GitHub Security tab.
+// A SARIF exceeding the size limit of GitHub fails with ErrPayloadTooLarge. Use UploadCodeScanningInChunks to split it across multiple uploads instead.
+func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, sarifContent string) (id string, err error) {
+	ctx = labelAPICalls(ctx, "UploadCodeScanning")
+	ids, err := client.uploadCodeScanning(ctx, owner, repository, branch, sarifContent, false)
+	if err != nil {
+		return
+	}
+	return ids[0], nil
+}
+
+// UploadCodeScanningInChunks uploads a scanning analysis to GitHub Security tab, like UploadCodeScanning.
+// A SARIF exceeding the size limit of GitHub is split by its runs across multiple uploads, instead of failing with ErrPayloadTooLarge.
+// GitHub replaces the previous analysis of the same tool and category on each upload, so the runs of each upload are categorized separately,
+// by appending 'chunk-<number>/' to the category of their runAutomationDetails.id.
+// The chunk categories depend on the number of chunks, so when a later analysis is split into fewer chunks, or isn't split,
+// the analyses of the chunk categories left behind stay open along with their alerts. Close them by uploading a SARIF
+// with a run of the same tool and no results to each of these categories.
+// A single run exceeding the size limit can't be split, and fails the upload with ErrPayloadTooLarge.
+// Returns the IDs of the uploads, which is a single ID if the SARIF wasn't split.
+func (client *GitHubClient) UploadCodeScanningInChunks(ctx context.Context, owner, repository, branch, sarifContent string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "UploadCodeScanningInChunks")
+	return client.uploadCodeScanning(ctx, owner, repository, branch, sarifContent, true)
+}
+
+func (client *GitHubClient) uploadCodeScanning(ctx context.Context, owner, repository, branch, sarifContent string, splitRuns bool) (ids []string, err error) {
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return
+	}
+
+	commitSHA := commit.Hash
+	branch = vcsutils.AddBranchPrefix(branch)
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
+
+	encodedSarif, err := encodeScanningResult(sarifContent)
+	if err != nil {
+		return
+	}
+	var payloadTooLargeErr *ErrPayloadTooLarge
+	if size := getEncodedScanningResultSize(encodedSarif); size > githubSarifUploadSizeLimit {
+		err = &ErrPayloadTooLarge{Size: size, Limit: githubSarifUploadSizeLimit}
+	} else {
+		var id string
+		if id, err = client.uploadEncodedCodeScanning(ctx, owner, repository, branch, commitSHA, encodedSarif); err == nil {
+			return []string{id}, nil
+		}
+	}
+	if !errors.As(err, &payloadTooLargeErr) || !splitRuns {
+		return
+	}
+	chunkSizeLimit := githubSarifUploadSizeLimit
+	if payloadTooLargeErr.Limit == 0 {
+		// The upload was rejected by GitHub with a lower limit, as configured on some GitHub Enterprise Server instances
+		chunkSizeLimit = payloadTooLargeErr.Size / 2
+	}
+	return client.uploadCodeScanningChunks(ctx, owner, repository, branch, commitSHA, sarifContent, chunkSizeLimit)
+}
+
+// uploadCodeScanningChunks splits the runs of the SARIF into chunks, each within the size limit and the runs limit of GitHub, and uploads each chunk separately
+func (client *GitHubClient) uploadCodeScanningChunks(ctx context.Context, owner, repository, branch, commitSHA, sarifContent string, sizeLimit int) (ids []string, err error) {
+	var sarif map[string]json.RawMessage
+	if err = json.Unmarshal([]byte(sarifContent), &sarif); err != nil {
+		return
+	}
+	var runs []json.RawMessage
+	if err = json.Unmarshal(sarif["runs"], &runs); err != nil {
+		return
+	}
+	chunks, err := splitScanningResultRuns(sarif, runs, sizeLimit)
 	if err != nil {
-		return nil, err
+		return
+	}
+	client.logger.Debug("splitting the code scanning analysis into", len(chunks), "uploads")
+	for _, chunk := range chunks {
+		var chunkID string
+		if chunkID, err = client.uploadEncodedCodeScanning(ctx, owner, repository, branch, commitSHA, chunk); err != nil {
+			return
+		}
+		ids = append(ids, chunkID)
 	}
+	return
+}
 
-	results := []string{}
-	for nextPage := 0; ; nextPage++ {
-		options := &github.ListOptions{Page: nextPage}
-		var labels []*github.Label
-		var ghResponse *github.Response
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-			labels, ghResponse, err = client.ghClient.Issues.ListLabelsByIssue(ctx, owner, repository, pullRequestID, options)
-			return ghResponse, err
-		})
-		if err != nil {
-			return nil, err
+// splitScanningResultRuns greedily groups consecutive runs into encoded SARIF documents, each within the size limit and the runs limit of GitHub.
+// The runs of each document are categorized by the number of the document.
+func splitScanningResultRuns(sarif map[string]json.RawMessage, runs []json.RawMessage, sizeLimit int) (chunks []string, err error) {
+	var chunkRuns []json.RawMessage
+	var encodedChunk string
+	for _, run := range runs {
+		candidateRuns := append(slices.Clone(chunkRuns), run)
+		var encodedCandidate string
+		if encodedCandidate, err = encodeScanningResultChunk(sarif, candidateRuns, len(chunks)+1); err != nil {
+			return
 		}
-		for _, label := range labels {
-			results = append(results, *label.Name)
+		if len(candidateRuns) <= githubSarifRunsLimit && getEncodedScanningResultSize(encodedCandidate) <= sizeLimit {
+			chunkRuns, encodedChunk = candidateRuns, encodedCandidate
+			continue
 		}
-		if nextPage+1 >= ghResponse.LastPage {
-			break
+		if len(chunkRuns) > 0 {
+			chunks = append(chunks, encodedChunk)
+		}
+		chunkRuns = []json.RawMessage{run}
+		if encodedChunk, err = encodeScanningResultChunk(sarif, chunkRuns, len(chunks)+1); err != nil {
+			return
+		}
+		if size := getEncodedScanningResultSize(encodedChunk); size > sizeLimit {
+			return nil, &ErrPayloadTooLarge{Size: size, Limit: sizeLimit}
 		}
 	}
-	return results, nil
+	if len(chunkRuns) > 0 {
+		chunks = append(chunks, encodedChunk)
+	}
+	return
 }
 
-// UnlabelPullRequest on GitHub
-func (client *GitHubClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// encodeScanningResultChunk encodes a SARIF document of the runs, which are categorized by the number of the chunk
+func encodeScanningResultChunk(sarif map[string]json.RawMessage, runs []json.RawMessage, chunkNumber int) (string, error) {
+	chunk := make(map[string]json.RawMessage, len(sarif))
+	for key, value := range sarif {
+		chunk[key] = value
+	}
+	chunkRuns := make([]json.RawMessage, len(runs))
+	for i, run := range runs {
+		var err error
+		if chunkRuns[i], err = addScanningResultRunCategory(run, fmt.Sprintf("chunk-%d/", chunkNumber)); err != nil {
+			return "", err
+		}
+	}
+	encodedRuns, err := json.Marshal(chunkRuns)
 	if err != nil {
-		return err
+		return "", err
 	}
-
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		return client.ghClient.Issues.RemoveLabelForIssue(ctx, owner, repository, pullRequestID, name)
-	})
+	chunk["runs"] = encodedRuns
+	content, err := json.Marshal(chunk)
+	if err != nil {
+		return "", err
+	}
+	return encodeScanningResult(string(content))
 }
 
-// UploadCodeScanning to GitHub Security tab
-func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, sarifContent string) (id string, err error) {
-	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+// addScanningResultRunCategory appends a suffix to the category of a run, which is the prefix of its runAutomationDetails.id up to its last slash
+func addScanningResultRunCategory(run json.RawMessage, categorySuffix string) (json.RawMessage, error) {
+	var runFields map[string]json.RawMessage
+	if err := json.Unmarshal(run, &runFields); err != nil {
+		return nil, err
+	}
+	automationDetails := map[string]json.RawMessage{}
+	if encodedDetails, exists := runFields["automationDetails"]; exists {
+		if err := json.Unmarshal(encodedDetails, &automationDetails); err != nil {
+			return nil, err
+		}
+	}
+	var id string
+	if encodedID, exists := automationDetails["id"]; exists {
+		if err := json.Unmarshal(encodedID, &id); err != nil {
+			return nil, err
+		}
+	}
+	categoryEnd := strings.LastIndex(id, "/") + 1
+	encodedID, err := json.Marshal(id[:categoryEnd] + categorySuffix + id[categoryEnd:])
 	if err != nil {
-		return
+		return nil, err
 	}
+	automationDetails["id"] = encodedID
+	if runFields["automationDetails"], err = json.Marshal(automationDetails); err != nil {
+		return nil, err
+	}
+	return json.Marshal(runFields)
+}
 
-	commitSHA := commit.Hash
-	branch = vcsutils.AddBranchPrefix(branch)
-	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
-
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+func (client *GitHubClient) uploadEncodedCodeScanning(ctx context.Context, owner, repository, branch, commitSHA, encodedSarif string) (id string, err error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
-		id, ghResponse, err = client.executeUploadCodeScanning(ctx, owner, repository, branch, commitSHA, sarifContent)
+		id, ghResponse, err = client.executeUploadCodeScanning(ctx, owner, repository, branch, commitSHA, encodedSarif)
 		return ghResponse, err
 	})
 	return
 }
 
-func (client *GitHubClient) executeUploadCodeScanning(ctx context.Context, owner, repository, branch, commitSHA, sarifContent string) (id string, ghResponse *github.Response, err error) {
-	encodedSarif, err := encodeScanningResult(sarifContent)
-	if err != nil {
-		return
-	}
-
+func (client *GitHubClient) executeUploadCodeScanning(ctx context.Context, owner, repository, branch, commitSHA, encodedSarif string) (id string, ghResponse *github.Response, err error) {
 	sarifID, ghResponse, err := client.ghClient.CodeScanning.UploadSarif(ctx, owner, repository, &github.SarifAnalysis{
 		CommitSHA: &commitSHA,
 		Ref:       &branch,
 		Sarif:     &encodedSarif,
 	})
 
+	if ghResponse != nil && ghResponse.Response != nil && ghResponse.StatusCode == http.StatusRequestEntityTooLarge {
+		err = &ErrPayloadTooLarge{Size: getEncodedScanningResultSize(encodedSarif)}
+		return
+	}
 	// According to go-github API - successful ghResponse will return 202 status code
 	// The body of the ghResponse will appear in the error, and the Sarif struct will be empty.
 	if err != nil && ghResponse.Response.StatusCode != http.StatusAccepted {
@@ -940,9 +2779,171 @@ func handleGitHubUploadSarifID(sarifID *github.SarifID, uploadSarifErr error) (i
 	return
 }
 
+// ListCodeScanningAlerts on GitHub
+func (client *GitHubClient) ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error) {
+	ctx = labelAPICalls(ctx, "ListCodeScanningAlerts")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var alertsInfo []CodeScanningAlertInfo
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		alertsInfo, ghResponse, err = client.executeListCodeScanningAlerts(ctx, owner, repository, convertToGitHubAlertListOptions(options))
+		return ghResponse, err
+	})
+	return alertsInfo, err
+}
+
+func (client *GitHubClient) executeListCodeScanningAlerts(ctx context.Context, owner, repository string, listOptions *github.AlertListOptions) ([]CodeScanningAlertInfo, *github.Response, error) {
+	alerts, ghResponse, err := client.ghClient.CodeScanning.ListAlertsForRepo(ctx, owner, repository, listOptions)
+	if err != nil {
+		return nil, ghResponse, err
+	}
+	alertsInfo := make([]CodeScanningAlertInfo, 0, len(alerts))
+	for _, alert := range alerts {
+		alertsInfo = append(alertsInfo, mapGitHubAlertToCodeScanningAlertInfo(alert))
+	}
+	return alertsInfo, ghResponse, nil
+}
+
+func convertToGitHubAlertListOptions(options CodeScanningAlertsQueryOptions) *github.AlertListOptions {
+	state := options.State
+	if state == "" {
+		state = AlertOpen
+	}
+	return &github.AlertListOptions{
+		State:    string(state),
+		Ref:      vcsutils.AddBranchPrefix(options.Branch),
+		Severity: options.Severity,
+		ToolName: options.ToolName,
+		ListOptions: github.ListOptions{
+			Page:    options.Page,
+			PerPage: options.PerPage,
+		},
+	}
+}
+
+// GetCodeScanningAlert on GitHub
+func (client *GitHubClient) GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCodeScanningAlert")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return CodeScanningAlertInfo{}, err
+	}
+	var alert *github.Alert
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		alert, ghResponse, err = client.ghClient.CodeScanning.GetAlert(ctx, owner, repository, alertID)
+		return ghResponse, err
+	})
+	if err != nil {
+		return CodeScanningAlertInfo{}, err
+	}
+	return mapGitHubAlertToCodeScanningAlertInfo(alert), nil
+}
+
+// DismissCodeScanningAlert on GitHub
+func (client *GitHubClient) DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, reason AlertDismissalReason, comment string) error {
+	ctx = labelAPICalls(ctx, "DismissCodeScanningAlert")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "reason": string(reason)})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.CodeScanning.UpdateAlert(ctx, owner, repository, alertID, &github.CodeScanningAlertState{
+			State:            string(AlertDismissed),
+			DismissedReason:  vcsutils.PointerOf(string(reason)),
+			DismissedComment: vcsutils.GetNilIfZeroVal(comment),
+		})
+		return ghResponse, err
+	})
+}
+
+func mapGitHubAlertToCodeScanningAlertInfo(alert *github.Alert) CodeScanningAlertInfo {
+	rule := alert.GetRule()
+	severity := rule.GetSecuritySeverityLevel()
+	if severity == "" {
+		severity = rule.GetSeverity()
+	}
+	closedAt := alert.GetFixedAt().Time
+	if closedAt.IsZero() {
+		closedAt = alert.GetDismissedAt().Time
+	}
+	location := alert.GetMostRecentInstance().GetLocation()
+	return CodeScanningAlertInfo{
+		ID:        int64(alert.GetNumber()),
+		RuleID:    rule.GetID(),
+		Title:     rule.GetDescription(),
+		State:     CodeScanningAlertState(alert.GetState()),
+		Severity:  severity,
+		ToolName:  alert.GetTool().GetName(),
+		Path:      location.GetPath(),
+		StartLine: location.GetStartLine(),
+		EndLine:   location.GetEndLine(),
+		URL:       alert.GetHTMLURL(),
+		CreatedAt: alert.GetCreatedAt().Time,
+		ClosedAt:  closedAt,
+	}
+}
+
+// ListVulnerabilityAlerts on GitHub lists the open Dependabot alerts
+func (client *GitHubClient) ListVulnerabilityAlerts(ctx context.Context, owner, repository string) (alertsInfo []VulnerabilityAlertInfo, err error) {
+	ctx = labelAPICalls(ctx, "ListVulnerabilityAlerts")
+	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return
+	}
+	// The Dependabot alerts API is paginated by cursors
+	options := &github.ListAlertsOptions{
+		State:             vcsutils.PointerOf("open"),
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+	}
+	for {
+		var alerts []*github.DependabotAlert
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			alerts, ghResponse, err = client.ghClient.Dependabot.ListRepoAlerts(ctx, owner, repository, options)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			alertsInfo = append(alertsInfo, mapGitHubDependabotAlertToVulnerabilityAlertInfo(alert))
+		}
+		if ghResponse.After == "" {
+			return
+		}
+		options.After = ghResponse.After
+	}
+}
+
+func mapGitHubDependabotAlertToVulnerabilityAlertInfo(alert *github.DependabotAlert) VulnerabilityAlertInfo {
+	advisory := alert.GetSecurityAdvisory()
+	vulnerability := alert.GetSecurityVulnerability()
+	severity := vulnerability.GetSeverity()
+	if severity == "" {
+		severity = advisory.GetSeverity()
+	}
+	return VulnerabilityAlertInfo{
+		ID:                     int64(alert.GetNumber()),
+		PackageName:            alert.GetDependency().GetPackage().GetName(),
+		Ecosystem:              alert.GetDependency().GetPackage().GetEcosystem(),
+		VulnerableVersionRange: vulnerability.GetVulnerableVersionRange(),
+		FixedVersion:           vulnerability.GetFirstPatchedVersion().GetIdentifier(),
+		Severity:               severity,
+		CVE:                    advisory.GetCVEID(),
+		Summary:                advisory.GetSummary(),
+		ManifestPath:           alert.GetDependency().GetManifestPath(),
+		URL:                    alert.GetHTMLURL(),
+	}
+}
+
 // DownloadFileFromRepo on GitHub
 func (client *GitHubClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, err error) {
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepo")
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		content, statusCode, ghResponse, err = client.executeDownloadFileFromRepo(ctx, owner, repository, branch, path)
 		return ghResponse, err
@@ -974,15 +2975,65 @@ func (client *GitHubClient) executeDownloadFileFromRepo(ctx context.Context, own
 	return
 }
 
+// DownloadFileFromRepoStream on GitHub
+func (client *GitHubClient) DownloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) (err error) {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepoStream")
+	if err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path}); err != nil {
+		return
+	}
+	// Only the request is retried, since the writer can't be rewound once the streaming starts
+	var body io.ReadCloser
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		body, ghResponse, err = client.ghClient.Repositories.DownloadContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: branch})
+		return ghResponse, err
+	})
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, body.Close())
+	}()
+	return copyWithLimit(writer, body, maxBytes)
+}
+
+// FileExistsInRepo on GitHub
+func (client *GitHubClient) FileExistsInRepo(ctx context.Context, owner, repository, branch, path string) (bool, error) {
+	ctx = labelAPICalls(ctx, "FileExistsInRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path}); err != nil {
+		return false, err
+	}
+	contentsURL := fmt.Sprintf("repos/%s/%s/contents/%s", url.PathEscape(owner), url.PathEscape(repository), escapeFilePath(path))
+	if branch != "" {
+		contentsURL += "?ref=" + url.QueryEscape(branch)
+	}
+	exists := false
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		// The HEAD request returns the status of the contents, without their body
+		request, err := client.ghClient.NewRequest(http.MethodHead, contentsURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		ghResponse, err := client.ghClient.Do(ctx, request, nil)
+		if ghResponse != nil && ghResponse.Response != nil && ghResponse.Response.StatusCode == http.StatusNotFound {
+			return ghResponse, nil
+		}
+		exists = err == nil
+		return ghResponse, err
+	})
+	return exists, err
+}
+
 // GetRepositoryEnvironmentInfo on GitHub
 func (client *GitHubClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryEnvironmentInfo")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
 	if err != nil {
 		return RepositoryEnvironmentInfo{}, err
 	}
 
 	var repositoryEnvInfo *RepositoryEnvironmentInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
 		repositoryEnvInfo, ghResponse, err = client.executeGetRepositoryEnvironmentInfo(ctx, owner, repository, name)
 		return ghResponse, err
@@ -1005,16 +3056,143 @@ func (client *GitHubClient) executeGetRepositoryEnvironmentInfo(ctx context.Cont
 		return &RepositoryEnvironmentInfo{}, ghResponse, err
 	}
 
+	deploymentBranchPolicy, policiesResponse, err := client.getGitHubDeploymentBranchPolicy(ctx, owner, repository, environment)
+	if err != nil {
+		return &RepositoryEnvironmentInfo{}, policiesResponse, err
+	}
+
 	return &RepositoryEnvironmentInfo{
-			Name:      environment.GetName(),
-			Url:       environment.GetURL(),
-			Reviewers: reviewers,
+			Name:                   environment.GetName(),
+			Url:                    environment.GetURL(),
+			Reviewers:              reviewers,
+			WaitTimer:              extractGitHubEnvironmentWaitTimer(environment),
+			DeploymentBranchPolicy: deploymentBranchPolicy,
 		},
 		ghResponse,
 		nil
 }
 
+// getGitHubDeploymentBranchPolicy returns the deployment branch policy of an environment, with the name patterns of its custom branch policies
+func (client *GitHubClient) getGitHubDeploymentBranchPolicy(ctx context.Context, owner, repository string, environment *github.Environment) (*DeploymentBranchPolicy, *github.Response, error) {
+	if environment.DeploymentBranchPolicy == nil {
+		return nil, nil, nil
+	}
+	if environment.DeploymentBranchPolicy.GetProtectedBranches() {
+		return &DeploymentBranchPolicy{ProtectedBranches: true}, nil, nil
+	}
+	branchPolicies, ghResponse, err := client.ghClient.Repositories.ListDeploymentBranchPolicies(ctx, owner, repository, environment.GetName())
+	if err != nil {
+		return nil, ghResponse, err
+	}
+	deploymentBranchPolicy := &DeploymentBranchPolicy{CustomBranchPatterns: []string{}}
+	for _, branchPolicy := range branchPolicies.BranchPolicies {
+		// Tag policies don't restrict the deploying branches
+		if branchPolicy.GetType() != "tag" {
+			deploymentBranchPolicy.CustomBranchPatterns = append(deploymentBranchPolicy.CustomBranchPatterns, branchPolicy.GetName())
+		}
+	}
+	return deploymentBranchPolicy, ghResponse, nil
+}
+
+// CreateOrUpdateEnvironment on GitHub
+// The reviewers are users and teams of the owner, and the custom branch policies of an existing environment are replaced by the custom branch patterns.
+func (client *GitHubClient) CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error {
+	ctx = labelAPICalls(ctx, "CreateOrUpdateEnvironment")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": environment.Name})
+	if err != nil {
+		return err
+	}
+	environmentRequest := &github.CreateUpdateEnvironment{WaitTimer: &environment.WaitTimer}
+	for _, reviewer := range environment.Reviewers {
+		reviewerID, err := client.getGitHubEnvironmentReviewerID(ctx, owner, reviewer)
+		if err != nil {
+			return err
+		}
+		environmentRequest.Reviewers = append(environmentRequest.Reviewers, &github.EnvReviewers{Type: vcsutils.PointerOf(string(reviewer.Type)), ID: &reviewerID})
+	}
+	if policy := environment.DeploymentBranchPolicy; policy != nil {
+		environmentRequest.DeploymentBranchPolicy = &github.BranchPolicy{
+			ProtectedBranches:    vcsutils.PointerOf(policy.ProtectedBranches),
+			CustomBranchPolicies: vcsutils.PointerOf(!policy.ProtectedBranches),
+		}
+	}
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Repositories.CreateUpdateEnvironment(ctx, owner, repository, environment.Name, environmentRequest)
+		return ghResponse, err
+	})
+	if err != nil || environment.DeploymentBranchPolicy == nil || environment.DeploymentBranchPolicy.ProtectedBranches {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.executeSetDeploymentBranchPolicies(ctx, owner, repository, environment.Name, environment.DeploymentBranchPolicy.CustomBranchPatterns)
+	})
+}
+
+// getGitHubEnvironmentReviewerID returns the ID of an environment reviewer, getting the user by its login, or the team of the owner by its slug, if the ID is missing
+func (client *GitHubClient) getGitHubEnvironmentReviewerID(ctx context.Context, owner string, reviewer EnvironmentReviewer) (int64, error) {
+	if reviewer.Type != EnvironmentReviewerUser && reviewer.Type != EnvironmentReviewerTeam {
+		return 0, fmt.Errorf("environment reviewer %s has an unsupported type: %q", reviewer.Name, reviewer.Type)
+	}
+	if reviewer.ID != 0 {
+		return reviewer.ID, nil
+	}
+	var reviewerID int64
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		if reviewer.Type == EnvironmentReviewerTeam {
+			team, ghResponse, err := client.ghClient.Teams.GetTeamBySlug(ctx, owner, reviewer.Name)
+			reviewerID = team.GetID()
+			return ghResponse, err
+		}
+		user, ghResponse, err := client.ghClient.Users.Get(ctx, reviewer.Name)
+		reviewerID = user.GetID()
+		return ghResponse, err
+	})
+	return reviewerID, err
+}
+
+// executeSetDeploymentBranchPolicies replaces the custom branch policies of an environment by policies of the branch name patterns
+func (client *GitHubClient) executeSetDeploymentBranchPolicies(ctx context.Context, owner, repository, name string, branchPatterns []string) (*github.Response, error) {
+	branchPolicies, ghResponse, err := client.ghClient.Repositories.ListDeploymentBranchPolicies(ctx, owner, repository, name)
+	if err != nil {
+		return ghResponse, err
+	}
+	existingPatterns := datastructures.MakeSet[string]()
+	for _, branchPolicy := range branchPolicies.BranchPolicies {
+		if branchPolicy.GetType() == "tag" {
+			continue
+		}
+		if slices.Contains(branchPatterns, branchPolicy.GetName()) {
+			existingPatterns.Add(branchPolicy.GetName())
+			continue
+		}
+		if ghResponse, err = client.ghClient.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repository, name, branchPolicy.GetID()); err != nil {
+			return ghResponse, err
+		}
+	}
+	for _, branchPattern := range branchPatterns {
+		if existingPatterns.Exists(branchPattern) {
+			continue
+		}
+		request := &github.DeploymentBranchPolicyRequest{Name: vcsutils.PointerOf(branchPattern), Type: vcsutils.PointerOf("branch")}
+		if _, ghResponse, err = client.ghClient.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repository, name, request); err != nil {
+			return ghResponse, err
+		}
+	}
+	return ghResponse, nil
+}
+
 func (client *GitHubClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFiles")
+	modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	return getModifiedFileNames(modifiedFiles), nil
+}
+
+// GetModifiedFilesWithDetails on GitHub
+func (client *GitHubClient) GetModifiedFilesWithDetails(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFilesWithDetails")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -1025,16 +3203,16 @@ func (client *GitHubClient) GetModifiedFiles(ctx context.Context, owner, reposit
 		return nil, err
 	}
 
-	var fileNamesList []string
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	var modifiedFiles []ModifiedFileInfo
+	err = client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
 		var ghResponse *github.Response
-		fileNamesList, ghResponse, err = client.executeGetModifiedFiles(ctx, owner, repository, refBefore, refAfter)
+		modifiedFiles, ghResponse, err = client.executeGetModifiedFiles(ctx, owner, repository, refBefore, refAfter)
 		return ghResponse, err
 	})
-	return fileNamesList, err
+	return modifiedFiles, err
 }
 
-func (client *GitHubClient) executeGetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, *github.Response, error) {
+func (client *GitHubClient) executeGetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, *github.Response, error) {
 	// According to the https://docs.github.com/en/rest/commits/commits?apiVersion=2022-11-28#compare-two-commits
 	// the list of changed files is always returned with the first page fully,
 	// so we don't need to iterate over other pages to get additional info about the files.
@@ -1050,38 +3228,66 @@ func (client *GitHubClient) executeGetModifiedFiles(ctx context.Context, owner,
 		return nil, ghResponse, err
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	modifiedFiles := make([]ModifiedFileInfo, 0, len(comparison.Files))
 	for _, file := range comparison.Files {
-		fileNamesSet.Add(vcsutils.DefaultIfNotNil(file.Filename))
-		fileNamesSet.Add(vcsutils.DefaultIfNotNil(file.PreviousFilename))
+		modifiedFiles = append(modifiedFiles, ModifiedFileInfo{
+			Path:         vcsutils.DefaultIfNotNil(file.Filename),
+			Status:       mapGitHubFileStatus(vcsutils.DefaultIfNotNil(file.Status)),
+			PreviousPath: vcsutils.DefaultIfNotNil(file.PreviousFilename),
+			Additions:    vcsutils.DefaultIfNotNil(file.Additions),
+			Deletions:    vcsutils.DefaultIfNotNil(file.Deletions),
+		})
 	}
+	return sortModifiedFiles(modifiedFiles), ghResponse, nil
+}
 
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-
-	return fileNamesList, ghResponse, nil
+func mapGitHubFileStatus(status string) FileChangeStatus {
+	switch status {
+	case "added", "copied":
+		return FileAdded
+	case "removed":
+		return FileRemoved
+	case "renamed":
+		return FileRenamed
+	default:
+		return FileModified
+	}
 }
 
-// Extract code reviewers from environment
-func extractGitHubEnvironmentReviewers(environment *github.Environment) ([]string, error) {
-	var reviewers []string
+// Extract the user and team reviewers from the protection rules of an environment
+func extractGitHubEnvironmentReviewers(environment *github.Environment) ([]EnvironmentReviewer, error) {
+	var reviewers []EnvironmentReviewer
 	protectionRules := environment.ProtectionRules
 	if protectionRules == nil {
 		return reviewers, nil
 	}
-	reviewerStruct := repositoryEnvironmentReviewer{}
 	for _, rule := range protectionRules {
 		for _, reviewer := range rule.Reviewers {
+			// The reviewer is decoded as a user or a team, by the type of the reviewer
+			reviewerStruct := repositoryEnvironmentReviewer{}
 			if err := mapstructure.Decode(reviewer.Reviewer, &reviewerStruct); err != nil {
-				return []string{}, err
+				return []EnvironmentReviewer{}, err
 			}
-			reviewers = append(reviewers, reviewerStruct.Login)
+			environmentReviewer := EnvironmentReviewer{Type: EnvironmentReviewerUser, Name: reviewerStruct.Login, ID: reviewerStruct.ID}
+			if reviewer.GetType() == string(EnvironmentReviewerTeam) {
+				environmentReviewer.Type, environmentReviewer.Name = EnvironmentReviewerTeam, reviewerStruct.Slug
+			}
+			reviewers = append(reviewers, environmentReviewer)
 		}
 	}
 	return reviewers, nil
 }
 
+// Extract the wait timer from the protection rules of an environment
+func extractGitHubEnvironmentWaitTimer(environment *github.Environment) int {
+	for _, rule := range environment.ProtectionRules {
+		if rule.GetType() == "wait_timer" {
+			return rule.GetWaitTimer()
+		}
+	}
+	return 0
+}
+
 func createGitHubHook(token, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) *github.Hook {
 	return &github.Hook{
 		Events: getGitHubWebhookEvents(webhookEvents...),
@@ -1108,16 +3314,115 @@ func getGitHubWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 }
 
 func getGitHubRepositoryVisibility(repo *github.Repository) RepositoryVisibility {
-	switch *repo.Visibility {
+	switch repo.GetVisibility() {
 	case "public":
 		return Public
 	case "internal":
 		return Internal
+	case "":
+		// Older GitHub Enterprise servers don't return the visibility, only whether the repository is private
+		if !repo.GetPrivate() {
+			return Public
+		}
+		return Private
 	default:
 		return Private
 	}
 }
 
+func getGitHubCheckRunStatus(status CheckRunStatus) string {
+	switch status {
+	case CheckRunInProgress:
+		return "in_progress"
+	case CheckRunCompleted:
+		return "completed"
+	}
+	return "queued"
+}
+
+func getGitHubCheckRunConclusion(checkRun CheckRunInfo) *string {
+	if checkRun.Status != CheckRunCompleted {
+		return nil
+	}
+	var conclusion string
+	switch checkRun.Conclusion {
+	case CheckRunSuccess:
+		conclusion = "success"
+	case CheckRunFailure:
+		conclusion = "failure"
+	case CheckRunNeutral:
+		conclusion = "neutral"
+	case CheckRunCancelled:
+		conclusion = "cancelled"
+	case CheckRunSkipped:
+		conclusion = "skipped"
+	case CheckRunTimedOut:
+		conclusion = "timed_out"
+	case CheckRunActionRequired:
+		conclusion = "action_required"
+	}
+	return &conclusion
+}
+
+func getGitHubCheckRunCompletedAt(checkRun CheckRunInfo) *github.Timestamp {
+	if checkRun.Status != CheckRunCompleted {
+		return nil
+	}
+	return &github.Timestamp{Time: time.Now()}
+}
+
+func getGitHubAnnotationLevel(severity AnnotationSeverity) string {
+	switch severity {
+	case AnnotationWarning:
+		return "warning"
+	case AnnotationFailure:
+		return "failure"
+	}
+	return "notice"
+}
+
+// getGitHubCheckRunOutput returns nil if the check run has no output details.
+// GitHub requires a title in the output, so the check run name is used if no title is provided.
+func getGitHubCheckRunOutput(checkRun CheckRunInfo, annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	if checkRun.Title == "" && checkRun.Summary == "" && checkRun.Text == "" && len(annotations) == 0 {
+		return nil
+	}
+	title := checkRun.Title
+	if title == "" {
+		title = checkRun.Name
+	}
+	return &github.CheckRunOutput{
+		Title:       &title,
+		Summary:     &checkRun.Summary,
+		Text:        vcsutils.GetNilIfZeroVal(checkRun.Text),
+		Annotations: annotations,
+	}
+}
+
+// splitGitHubCheckRunAnnotations splits the annotations to batches in the size GitHub accepts in a single request.
+// At least one batch is always returned, so that a check run without annotations is still sent.
+func splitGitHubCheckRunAnnotations(annotations []CheckRunAnnotation) [][]*github.CheckRunAnnotation {
+	batches := [][]*github.CheckRunAnnotation{nil}
+	for i, annotation := range annotations {
+		if i > 0 && i%githubCheckRunAnnotationsLimit == 0 {
+			batches = append(batches, nil)
+		}
+		endLine := annotation.EndLine
+		if endLine == 0 {
+			endLine = annotation.StartLine
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], &github.CheckRunAnnotation{
+			Path:            vcsutils.PointerOf(annotation.Path),
+			StartLine:       vcsutils.PointerOf(annotation.StartLine),
+			EndLine:         &endLine,
+			AnnotationLevel: vcsutils.PointerOf(getGitHubAnnotationLevel(annotation.Severity)),
+			Title:           vcsutils.GetNilIfZeroVal(annotation.Title),
+			Message:         vcsutils.PointerOf(annotation.Message),
+		})
+	}
+	return batches
+}
+
 func getGitHubCommitState(commitState CommitStatus) string {
 	switch commitState {
 	case Pass:
@@ -1143,6 +3448,7 @@ func mapGitHubCommitToCommitInfo(commit *github.RepositoryCommit) CommitInfo {
 		AuthorName:    details.GetAuthor().GetName(),
 		CommitterName: details.GetCommitter().GetName(),
 		Url:           commit.GetURL(),
+		HTMLURL:       commit.GetHTMLURL(),
 		Timestamp:     details.GetCommitter().GetDate().UTC().Unix(),
 		Message:       details.GetMessage(),
 		ParentHashes:  parents,
@@ -1156,6 +3462,7 @@ func mapGitHubIssuesCommentToCommentInfoList(commentsList []*github.IssueComment
 			ID:      comment.GetID(),
 			Content: comment.GetBody(),
 			Created: comment.GetCreatedAt().Time,
+			Author:  comment.GetUser().GetLogin(),
 		})
 	}
 	return
@@ -1182,8 +3489,15 @@ func encodeScanningResult(data string) (string, error) {
 	return compressedScan, err
 }
 
+// getEncodedScanningResultSize returns the gzip-compressed size of a scanning result encoded by encodeScanningResult
+func getEncodedScanningResultSize(encodedScan string) int {
+	return len(encodedScan)/4*3 - strings.Count(encodedScan, "=")
+}
+
 type repositoryEnvironmentReviewer struct {
+	ID    int64  `mapstructure:"id"`
 	Login string `mapstructure:"login"`
+	Slug  string `mapstructure:"slug"`
 }
 
 func shouldRetryIfRateLimitExceeded(ghResponse *github.Response, requestError error) bool {
@@ -1201,11 +3515,18 @@ func shouldRetryIfRateLimitExceeded(ghResponse *github.Response, requestError er
 		return false
 	}
 
-	body, err := io.ReadAll(ghResponse.Body)
-	if err != nil {
-		return false
+	return isRateLimitExceededResponse(ghResponse.Response)
+}
+
+// isRateLimitExceededResponse returns true if the response headers indicate that the request exceeded a rate limit.
+// The body isn't considered, since other rejections, such as a missing SSO authorization, may mention the rate limit.
+// Instances without rate limiting, such as some GitHub Enterprise Server instances, don't return the rate limit headers.
+func isRateLimitExceededResponse(response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
 	}
-	return strings.Contains(string(body), "rate limit")
+	// The primary rate limit is exhausted, or a secondary rate limit is exceeded
+	return response.Header.Get("X-RateLimit-Remaining") == "0" || response.Header.Get("Retry-After") != ""
 }
 
 func isRateLimitAbuseError(requestError error) bool {
@@ -10,15 +10,18 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-github/v56/github"
+	"github.com/grokify/mogo/encoding/base64"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,6 +38,58 @@ func TestGitHubClient_Connection(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_Raw(t *testing.T) {
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte("It's Not Easy Being Green"), "/zen", createGitHubHandler)
+	defer cleanUp()
+
+	// The raw client is authenticated by the token of the client
+	zen, _, err := client.(*GitHubClient).Raw().Meta.Zen(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "It's Not Easy Being Green", zen)
+}
+
+func TestGitHubClient_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	response := map[string]interface{}{"id": 1, "login": "frogbot", "name": "Frogbot", "email": "frogbot@jfrog.com"}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/user", createGitHubHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{ID: "1", Login: "frogbot", DisplayName: "Frogbot", Email: "frogbot@jfrog.com"}, user)
+
+	_, err = createBadGitHubClient(t).GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetServerVersion(t *testing.T) {
+	ctx := context.Background()
+	// GitHub Enterprise Server 3.7 doesn't support the REST API of Dependabot alerts
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, map[string]interface{}{"installed_version": "3.7.12", "verifiable_password_authentication": true}, "/meta", createGitHubHandler)
+	defer cleanUp()
+	serverVersion, err := client.GetServerVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.7.12", serverVersion)
+	capabilities, err := client.Capabilities(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, capabilities, CodeScanningAlertsCapability)
+	assert.NotContains(t, capabilities, VulnerabilityAlertsCapability)
+	assert.NotContains(t, capabilities, PullRequestAttachmentsCapability)
+
+	// GitHub.com has no version
+	cloudClient, cloudCleanUp := createServerAndClient(t, vcsutils.GitHub, false, map[string]interface{}{"verifiable_password_authentication": true}, "/meta", createGitHubHandler)
+	defer cloudCleanUp()
+	serverVersion, err = cloudClient.GetServerVersion(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, serverVersion)
+	capabilities, err = cloudClient.Capabilities(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, capabilities, VulnerabilityAlertsCapability)
+
+	_, err = createBadGitHubClient(t).GetServerVersion(ctx)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ConnectionWhenContextCancelled(t *testing.T) {
 	ctx := context.Background()
 	ctxWithCancel, cancel := context.WithCancel(ctx)
@@ -72,6 +127,108 @@ func TestGitHubClient_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListRepositoryListings(t *testing.T) {
+	ctx := context.Background()
+	userType, organizationType := "User", "Organization"
+	publicVisibility, internalVisibility := "public", "internal"
+	defaultBranch := "main"
+	repositories := []github.Repository{
+		{Name: &repo1, Owner: &github.User{Login: &username, Type: &userType}, Visibility: &publicVisibility, DefaultBranch: &defaultBranch},
+		{Name: &repo2, Owner: &github.User{Login: github.String(owner), Type: &organizationType}, Visibility: &internalVisibility},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, repositories, "/user/repos?page=1", createGitHubHandler)
+	defer cleanUp()
+
+	listings, err := client.ListRepositoryListings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{
+		{Owner: username, OwnerType: UserNamespace, Name: repo1, Visibility: Public, DefaultBranch: defaultBranch},
+		{Owner: owner, OwnerType: OrganizationNamespace, Name: repo2, Visibility: Internal},
+	}, listings)
+
+	_, err = createBadGitHubClient(t).ListRepositoryListings(ctx)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SearchRepositories(t *testing.T) {
+	ctx := context.Background()
+	organizationType := "Organization"
+	searchResult := github.RepositoriesSearchResult{Repositories: []*github.Repository{
+		{Name: &repo1, Owner: &github.User{Login: github.String(owner), Type: &organizationType}, Private: github.Bool(true), DefaultBranch: github.String("main")},
+	}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, searchResult,
+		"/search/repositories?page=1&per_page=100&q=repo+in%3Aname+topic%3Afrogbot-enabled+user%3Ajfrog", createGitHubHandler)
+	defer cleanUp()
+
+	listings, err := client.SearchRepositories(ctx, RepositorySearchOptions{Owner: owner, Name: "repo", Topic: "frogbot-enabled"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryListing{{Owner: owner, OwnerType: OrganizationNamespace, Name: repo1, Visibility: Private, DefaultBranch: "main"}}, listings)
+
+	_, err = client.SearchRepositories(ctx, RepositorySearchOptions{})
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).SearchRepositories(ctx, RepositorySearchOptions{Topic: "frogbot-enabled"})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SearchCode(t *testing.T) {
+	ctx := context.Background()
+	searchResult := github.CodeSearchResult{CodeResults: []*github.CodeResult{{
+		Path:        github.String("go.mod"),
+		Repository:  &github.Repository{Name: &repo1, Owner: &github.User{Login: github.String(owner)}},
+		TextMatches: []*github.TextMatch{{Fragment: github.String("require github.com/jfrog/froggit-go v1.0.0")}, {Fragment: github.String("replace github.com/jfrog/froggit-go")}},
+	}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, searchResult,
+		"/search/code?page=1&per_page=100&q=froggit-go+repo%3Ajfrog%2Frepo-1", createGitHubHandler)
+	defer cleanUp()
+
+	results, err := client.SearchCode(ctx, "froggit-go", CodeSearchOptions{Owner: owner, Repository: repo1})
+	assert.NoError(t, err)
+	assert.Equal(t, []CodeSearchResult{
+		{Owner: owner, Repository: repo1, Path: "go.mod", Fragment: "require github.com/jfrog/froggit-go v1.0.0"},
+		{Owner: owner, Repository: repo1, Path: "go.mod", Fragment: "replace github.com/jfrog/froggit-go"},
+	}, results)
+
+	_, err = client.SearchCode(ctx, "froggit-go", CodeSearchOptions{Repository: repo1})
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).SearchCode(ctx, "froggit-go", CodeSearchOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListNamespaces(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/user":
+			response = `{"id": 1, "login": "frogbot", "name": "Frogbot"}`
+		case "/user/orgs?page=1":
+			w.Header().Set("Link", `<https://api.github.com/user/orgs?page=2>; rel="next", <https://api.github.com/user/orgs?page=2>; rel="last"`)
+			response = `[{"login": "jfrog", "name": "JFrog"}]`
+		case "/user/orgs?page=2":
+			response = `[{"login": "frogs"}]`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	namespaces, err := client.ListNamespaces(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []NamespaceInfo{
+		{Name: "frogbot", DisplayName: "Frogbot", Kind: UserNamespace},
+		{Name: "frogs", Kind: OrganizationNamespace},
+		{Name: "jfrog", DisplayName: "JFrog", Kind: OrganizationNamespace},
+	}, namespaces)
+
+	_, err = createBadGitHubClient(t).ListNamespaces(ctx)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListRepositoriesWithPagination(t *testing.T) {
 	ctx := context.Background()
 	const repo = "repo"
@@ -90,6 +247,8 @@ func TestGitHubClient_ListRepositoriesWithPagination(t *testing.T) {
 	actualRepositories, err := client.ListRepositories(ctx)
 	assert.NoError(t, err)
 	assert.Equal(t, len(repos), len(actualRepositories[username]))
+	// The repositories are sorted lexicographically
+	sort.Strings(repoNames)
 	assert.Equal(t, repoNames, actualRepositories[username])
 
 	// Test Case 2 - No Items to return
@@ -119,6 +278,61 @@ func TestGitHubClient_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListProtectedBranches(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/branches", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("protected"))
+		_, err := w.Write([]byte(`[{"name": "release", "protected": true}, {"name": "main", "protected": true}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	branches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListBranchesOptions{ProtectedOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main", "release"}, branches)
+}
+
+func TestGitHubClient_GetBranchInfo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/branches/master":
+			response = `{"name": "master", "commit": {"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}, "protected": true}`
+		case "/repos/jfrog/repo-1":
+			response = `{"name": "repo-1", "default_branch": "master"}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	branchInfo, err := client.GetBranchInfo(ctx, owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryBranchInfo{Name: "master", HeadCommitHash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Protected: true, Default: true}, branchInfo)
+
+	_, err = createBadGitHubClient(t).GetBranchInfo(ctx, owner, repo1, "master")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Repository{DefaultBranch: github.String("main")}, "/repos/jfrog/repo-1", createGitHubHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	_, err = createBadGitHubClient(t).GetDefaultBranch(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int63() // #nosec G404
@@ -177,6 +391,78 @@ func TestGitHubClient_CreateCommitStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_CreateCheckRun(t *testing.T) {
+	ctx := context.Background()
+	annotations := make([]CheckRunAnnotation, 60)
+	for i := range annotations {
+		annotations[i] = CheckRunAnnotation{Path: "main.go", StartLine: i + 1, Severity: AnnotationFailure, Message: "Vulnerable dependency"}
+	}
+	checkRun := CheckRunInfo{
+		Name:        "JFrog Frogbot",
+		HeadSHA:     "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Status:      CheckRunCompleted,
+		Conclusion:  CheckRunFailure,
+		Summary:     "Found 60 issues",
+		Annotations: annotations,
+	}
+	var requests []github.CreateCheckRunOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var options github.CreateCheckRunOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		requests = append(requests, options)
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/repos/jfrog/repo-1/check-runs", r.RequestURI)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			assert.Equal(t, "/repos/jfrog/repo-1/check-runs/4", r.RequestURI)
+		}
+		_, err := w.Write([]byte(`{"id":4}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	checkRunID, err := client.CreateCheckRun(ctx, owner, repo1, checkRun)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), checkRunID)
+	// GitHub accepts up to 50 annotations per request, so the rest of the annotations are added with an update request
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, checkRun.HeadSHA, requests[0].HeadSHA)
+		assert.Equal(t, "completed", requests[0].GetStatus())
+		assert.Equal(t, "failure", requests[0].GetConclusion())
+		assert.NotNil(t, requests[0].CompletedAt)
+		assert.Equal(t, "JFrog Frogbot", requests[0].Output.GetTitle())
+		assert.Equal(t, "Found 60 issues", requests[0].Output.GetSummary())
+		assert.Len(t, requests[0].Output.Annotations, 50)
+		assert.Equal(t, "failure", requests[0].Output.Annotations[0].GetAnnotationLevel())
+		assert.Equal(t, 1, requests[0].Output.Annotations[0].GetEndLine())
+		assert.Len(t, requests[1].Output.Annotations, 10)
+		assert.Equal(t, 51, requests[1].Output.Annotations[0].GetStartLine())
+	}
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.Error(t, err)
+	_, err = createBadGitHubClient(t).CreateCheckRun(ctx, owner, repo1, checkRun)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UpdateCheckRun(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil,
+		fmt.Sprintf("/repos/%v/%v/check-runs/4", owner, repo1), http.StatusOK,
+		[]byte(`{"name":"JFrog Frogbot","status":"in_progress","output":{"title":"Scanning","summary":""}}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "JFrog Frogbot", Status: CheckRunInProgress, Title: "Scanning"})
+	assert.NoError(t, err)
+	err = client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{})
+	assert.Error(t, err)
+	err = createBadGitHubClient(t).UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "JFrog Frogbot"})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_getRepositoryVisibility(t *testing.T) {
 	visibility := "public"
 	assert.Equal(t, Public, getGitHubRepositoryVisibility(&github.Repository{Visibility: &visibility}))
@@ -236,6 +522,67 @@ func TestGitHubClient_DownloadFileFromRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_DownloadFileFromRepoStream(t *testing.T) {
+	ctx := context.Background()
+	content := strings.Repeat("frog", 1000)
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/jfrog/repo-1/contents/":
+			assert.Equal(t, "branch-1", r.URL.Query().Get("ref"))
+			_, err := w.Write([]byte(fmt.Sprintf(`[{"name": "package-lock.json", "download_url": "%s/download/package-lock.json"}]`, serverURL)))
+			assert.NoError(t, err)
+		case "/download/package-lock.json":
+			_, err := w.Write([]byte(content))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "Unexpected request", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "package-lock.json", &buffer, 0))
+	assert.Equal(t, content, buffer.String())
+
+	buffer.Reset()
+	err := client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "package-lock.json", &buffer, 100)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, content[:100], buffer.String())
+
+	assert.Error(t, client.DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "go.sum", &buffer, 0))
+	assert.Error(t, createBadGitHubClient(t).DownloadFileFromRepoStream(ctx, owner, repo1, branch1, "package-lock.json", &buffer, 0))
+}
+
+func TestGitHubClient_FileExistsInRepo(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{name: "exists", statusCode: http.StatusOK, expectedExists: true},
+		{name: "not found", statusCode: http.StatusNotFound, expectedExists: false},
+		{name: "error", statusCode: http.StatusUnauthorized, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, nil,
+				"/repos/jfrog/repo-1/contents/dir/package.json?ref=branch-1", tt.statusCode, createGitHubHandler)
+			defer cleanUp()
+			exists, err := client.FileExistsInRepo(ctx, owner, repo1, branch1, "dir/package.json")
+			assert.Equal(t, tt.expectedError, err != nil)
+			assert.Equal(t, tt.expectedExists, exists)
+		})
+	}
+
+	_, err := createBadGitHubClient(t).FileExistsInRepo(ctx, owner, repo1, branch1, "package.json")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, "/repos/jfrog/repo-1/pulls", createGitHubHandler)
@@ -264,6 +611,128 @@ func TestGitHubClient_UpdatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ClosePullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestID := 3
+	var editRequestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%d", pullRequestID), r.RequestURI)
+		if r.Method == http.MethodPatch {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&editRequestBody))
+		}
+		_, err := w.Write([]byte(`{"number": 3, "state": "open", "title": "PR title", "body": "PR body"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.ClosePullRequest(ctx, owner, repo1, pullRequestID)
+	assert.NoError(t, err)
+	// Only the state is sent, so the title and the body are kept as is
+	assert.Equal(t, map[string]interface{}{"state": "closed"}, editRequestBody)
+
+	err = client.ReopenPullRequest(ctx, owner, repo1, pullRequestID)
+	assert.EqualError(t, err, "pull request 3 is already open")
+
+	err = createBadGitHubClient(t).ClosePullRequest(ctx, owner, repo1, pullRequestID)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ReopenPullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestID := 3
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{State: github.String("closed")}, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%d", pullRequestID), createGitHubHandler)
+	defer cleanUp()
+
+	err := client.ReopenPullRequest(ctx, owner, repo1, pullRequestID)
+	assert.NoError(t, err)
+
+	err = client.ClosePullRequest(ctx, owner, repo1, pullRequestID)
+	assert.EqualError(t, err, "pull request 3 is already closed")
+
+	mergedClient, mergedCleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{State: github.String("closed"), Merged: github.Bool(true)}, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%d", pullRequestID), createGitHubHandler)
+	defer mergedCleanUp()
+	err = mergedClient.ReopenPullRequest(ctx, owner, repo1, pullRequestID)
+	assert.EqualError(t, err, "pull request 3 is merged and its state can't be changed")
+}
+
+func TestGitHubClient_EnablePullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	var graphQLRequests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/pulls/1":
+			response = `{"number": 1, "node_id": "PR_kwDOAbc123"}`
+		case "/graphql":
+			assert.Equal(t, http.MethodPost, r.Method)
+			var graphQLRequest map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&graphQLRequest))
+			graphQLRequests = append(graphQLRequests, graphQLRequest)
+			response = `{"data": {"enablePullRequestAutoMerge": {"clientMutationId": null}}}`
+			if len(graphQLRequests) > 1 {
+				response = `{"data": null, "errors": [{"message": "Pull request is in clean status"}]}`
+			}
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	assert.NoError(t, client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge))
+	assert.Len(t, graphQLRequests, 1)
+	assert.Contains(t, graphQLRequests[0]["query"], "enablePullRequestAutoMerge")
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_kwDOAbc123", "mergeMethod": "SQUASH"}, graphQLRequests[0]["variables"])
+
+	err := client.EnablePullRequestAutoMerge(ctx, owner, repo1, 1, "")
+	assert.EqualError(t, err, "GitHub GraphQL request failed: Pull request is in clean status")
+	assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_kwDOAbc123"}, graphQLRequests[1]["variables"])
+
+	err = createBadGitHubClient(t).EnablePullRequestAutoMerge(ctx, owner, repo1, 1, SquashMerge)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.RequestURI+" "+string(body)))
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "PUT /repos/jfrog/repo-1/pulls/1/merge":
+			response = `{"merged": true}`
+		case "GET /repos/jfrog/repo-1/pulls/1":
+			// The source branch is in a forked repository
+			response = `{"number": 1, "head": {"ref": "feature", "repo": {"name": "repo-1-fork", "owner": {"login": "forker"}}}}`
+		case "DELETE /repos/forker/repo-1-fork/git/refs/heads/feature":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	err := client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{Method: SquashMerge, CommitTitle: "Upgrade lodash", CommitBody: "Fixes CVE-2021-23337", DeleteSourceBranch: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`PUT /repos/jfrog/repo-1/pulls/1/merge {"commit_message":"Fixes CVE-2021-23337","commit_title":"Upgrade lodash","merge_method":"squash"}`,
+		"GET /repos/jfrog/repo-1/pulls/1",
+		"DELETE /repos/forker/repo-1-fork/git/refs/heads/feature",
+	}, requests)
+
+	err = client.MergePullRequest(ctx, owner, repo1, 1, MergeOptions{BypassPolicies: true})
+	assert.ErrorIs(t, err, errGitHubBypassPoliciesNotSupported)
+}
+
 func TestGitHubClient_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{}, "/repos/jfrog/repo-1/issues/1/comments", createGitHubHandler)
@@ -314,13 +783,14 @@ func TestGitHubClient_ListPullRequestReviewComments(t *testing.T) {
 	id := int64(1)
 	body := "test"
 	created := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.PullRequestComment{{ID: &id, Body: &body, CreatedAt: &github.Timestamp{Time: created}}}, "/repos/jfrog/repo-1/pulls/1/comments", createGitHubHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.PullRequestComment{{ID: &id, Body: &body, CreatedAt: &github.Timestamp{Time: created}}}, "/repos/jfrog/repo-1/pulls/1/comments?page=1&per_page=100", createGitHubHandler)
 	defer cleanUp()
 
 	commentInfo, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
 	assert.NoError(t, err)
 	assert.Len(t, commentInfo, 1)
 	assert.Equal(t, id, commentInfo[0].ID)
+	assert.Equal(t, "1", commentInfo[0].ThreadID)
 	assert.Equal(t, body, commentInfo[0].Content)
 	assert.Equal(t, created, commentInfo[0].Created)
 
@@ -329,6 +799,32 @@ func TestGitHubClient_ListPullRequestReviewComments(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListPullRequestReviewCommentsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/pulls/1/comments?page=1&per_page=100":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/jfrog/repo-1/pulls/1/comments?page=2&per_page=100>; rel="next"`, r.Host))
+			response = `[{"id": 1, "body": "first", "path": "go.mod", "line": 3}]`
+		case "/repos/jfrog/repo-1/pulls/1/comments?page=2&per_page=100":
+			response = `[{"id": 2, "body": "reply", "in_reply_to_id": 1, "path": "go.mod", "line": 3}]`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	comments, err := client.ListPullRequestReviewComments(context.Background(), owner, repo1, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, CommentInfo{ID: 1, ThreadID: "1", Content: "first", FilePath: "go.mod", Line: 3}, comments[0])
+		assert.Equal(t, CommentInfo{ID: 2, ThreadID: "1", Content: "reply", FilePath: "go.mod", Line: 3}, comments[1])
+	}
+}
+
 func TestGitHubClient_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
@@ -346,6 +842,7 @@ func TestGitHubClient_GetLatestCommit(t *testing.T) {
 		AuthorName:    "Monalisa Octocat",
 		CommitterName: "Joconde Octocat",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -373,6 +870,7 @@ func TestGitHubClient_GetCommits(t *testing.T) {
 		AuthorName:    "Monalisa Octocat",
 		CommitterName: "Joconde Octocat",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -383,6 +881,7 @@ func TestGitHubClient_GetCommits(t *testing.T) {
 		AuthorName:    "Leonardo De Vinci",
 		CommitterName: "Leonardo De Vinci",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -416,6 +915,7 @@ func TestGitHubClient_GetCommitsWithQueryOptions(t *testing.T) {
 		AuthorName:    "Monalisa Octocat",
 		CommitterName: "Joconde Octocat",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -426,6 +926,7 @@ func TestGitHubClient_GetCommitsWithQueryOptions(t *testing.T) {
 		AuthorName:    "Leonardo De Vinci",
 		CommitterName: "Leonardo De Vinci",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -524,6 +1025,13 @@ func TestGitHubClient_AddSshKeyToRepositoryReadWrite(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitHubClient_CreateDeployToken(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+	_, err = client.CreateDeployToken(context.Background(), owner, repo1, "frogbot", []DeployTokenScope{ReadRepositoryScope}, time.Time{})
+	assert.ErrorIs(t, err, errGitHubDeployTokensNotSupported)
+}
+
 func TestGitHubClient_GetCommitBySha(t *testing.T) {
 	ctx := context.Background()
 	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
@@ -542,6 +1050,7 @@ func TestGitHubClient_GetCommitBySha(t *testing.T) {
 		AuthorName:    "Monalisa Octocat",
 		CommitterName: "Joconde Octocat",
 		Url:           "https://api.github.com/repos/octocat/Hello-World/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		HTMLURL:       "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
 		Timestamp:     1302796850,
 		Message:       "Fix all the bugs",
 		ParentHashes:  []string{"5dcb09b5b57875f334f61aebed695e2e4193db5e"},
@@ -552,6 +1061,23 @@ func TestGitHubClient_GetCommitBySha(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_GetCommitDiff(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	diff, err := os.ReadFile(filepath.Join("testdata", "commit_diff.patch"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, diff, fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo1, sha), createGitHubHandler)
+	defer cleanUp()
+
+	commitDiff, err := client.GetCommitDiff(ctx, owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, string(diff), commitDiff.Diff)
+	assert.Len(t, commitDiff.Files, 3)
+
+	_, err = createBadGitHubClient(t).GetCommitDiff(ctx, owner, repo1, sha)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetCommitByWrongSha(t *testing.T) {
 	ctx := context.Background()
 	sha := "5dcb09b5b57875f334f61aebed695e2e4193db5e"
@@ -592,6 +1118,67 @@ func TestGitHubClient_GetRepositoryInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ArchiveAndRenameRepository(t *testing.T) {
+	ctx := context.Background()
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/repos/jfrog/repo-1", r.RequestURI)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requestBodies = append(requestBodies, strings.TrimSpace(string(body)))
+		_, err = w.Write([]byte(`{"name": "repo-1"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	assert.NoError(t, client.ArchiveRepository(ctx, owner, repo1))
+	assert.NoError(t, client.UnarchiveRepository(ctx, owner, repo1))
+	assert.NoError(t, client.RenameRepository(ctx, owner, repo1, "repo-2"))
+	assert.Equal(t, []string{`{"archived":true}`, `{"archived":false}`, `{"name":"repo-2"}`}, requestBodies)
+	assert.Error(t, client.RenameRepository(ctx, owner, repo1, ""))
+
+	assert.Error(t, createBadGitHubClient(t).ArchiveRepository(ctx, owner, repo1))
+}
+
+func TestGitHubClient_GetUserPermissionOnRepo(t *testing.T) {
+	ctx := context.Background()
+	for response, expectedPermission := range map[string]RepositoryPermission{
+		`{"permission": "admin", "user": {"login": "frogger", "permissions": {"admin": true, "maintain": true, "push": true, "pull": true}}}`:  AdminPermission,
+		`{"permission": "write", "user": {"login": "frogger", "permissions": {"admin": false, "maintain": true, "push": true, "pull": true}}}`: MaintainPermission,
+		`{"permission": "write", "user": {"login": "frogger", "permissions": {"admin": false, "maintain": false, "push": true}}}`:              WritePermission,
+		`{"permission": "read", "user": {"login": "frogger"}}`:                                                                                 ReadPermission,
+		`{"permission": "none", "user": {"login": "frogger"}}`:                                                                                 NoPermission,
+	} {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(response), "/repos/jfrog/repo-1/collaborators/frogger/permission", createGitHubHandler)
+		permission, err := client.GetUserPermissionOnRepo(ctx, owner, repo1, username)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPermission, permission, response)
+		cleanUp()
+	}
+
+	_, err := createBadGitHubClient(t).GetUserPermissionOnRepo(ctx, owner, repo1, username)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_RepositoryMirrors(t *testing.T) {
+	ctx := context.Background()
+	mirrorURL := "https://gitlab.example.com/jfrog/repo-1.git"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Repository{Name: &repo1, MirrorURL: &mirrorURL}, "/repos/jfrog/repo-1", createGitHubHandler)
+	defer cleanUp()
+
+	mirrors, err := client.GetRepositoryMirrors(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryMirrorInfo{{URL: mirrorURL, Enabled: true}}, mirrors)
+
+	err = client.SetRepositoryMirror(ctx, owner, repo1, RepositoryMirrorInfo{URL: mirrorURL, Enabled: true})
+	assert.ErrorIs(t, err, errGitHubSetRepositoryMirrorNotSupported)
+
+	_, err = createBadGitHubClient(t).GetRepositoryMirrors(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Label{}, fmt.Sprintf("/repos/jfrog/%s/labels", repo1), createGitHubHandler)
@@ -668,10 +1255,14 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     1347,
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:             1347,
+		Source:         BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:         BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
+		URL:            "https://github.com/octocat/Hello-World/pull/1347",
+		HTMLURL:        "https://github.com/octocat/Hello-World/pull/1347",
+		HeadCommitHash: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Author:         "octocat",
+		UpdatedAt:      time.Date(2011, time.January, 26, 19, 1, 12, 0, time.UTC),
 	}, result[0])
 
 	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
@@ -683,17 +1274,87 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     1347,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:             1347,
+		Body:           "hello world",
+		Source:         BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:         BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
+		URL:            "https://github.com/octocat/Hello-World/pull/1347",
+		HTMLURL:        "https://github.com/octocat/Hello-World/pull/1347",
+		HeadCommitHash: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Author:         "octocat",
+		UpdatedAt:      time.Date(2011, time.January, 26, 19, 1, 12, 0, time.UTC),
 	}, result[0])
 
 	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListOpenPullRequestsWithQueryOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls?base=master&head=%s%%3Anew-topic&page=2&per_page=10&state=open", owner, repo1, owner), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithQueryOptions(ctx, owner, repo1, PullRequestsQueryOptions{
+		SourceBranch: "new-topic",
+		TargetBranch: "master",
+		WithBody:     true,
+		ListOptions:  ListOptions{Page: 2, PerPage: 10},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "hello world", result[0].Body)
+}
+
+func TestGitHubClient_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/pulls?base=master&direction=asc&head=jfrog%3Anew-topic&page=2&per_page=10&sort=updated&state=all",
+			"/repos/jfrog/repo-1/pulls?direction=desc&page=1&per_page=100&sort=created&state=closed":
+			_, err := w.Write(response)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	result, err := client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{
+		State:        PullRequestsAll,
+		SourceBranch: "new-topic",
+		TargetBranch: "master",
+		Sort:         SortByUpdated,
+		Ascending:    true,
+		ListOptions:  ListOptions{Page: 2, PerPage: 10},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Empty(t, result[0].Body)
+
+	// The merged pull requests are listed as closed, and are told apart by the client
+	result, err = client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{State: PullRequestsMerged, Author: "octocat", WithBody: true})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	result, err = client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{State: PullRequestsClosed})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	result, err = client.ListPullRequests(ctx, owner, repo1, PullRequestListOptions{State: PullRequestsMerged, Author: username})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = createBadGitHubClient(t).ListPullRequests(ctx, owner, repo1, PullRequestListOptions{})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetPullRequestByID(t *testing.T) {
 	ctx := context.Background()
 	pullRequestId := 1347
@@ -710,10 +1371,14 @@ func TestGitHubClient_GetPullRequestByID(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: forkedOwner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:             int64(pullRequestId),
+		Source:         BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:         BranchInfo{Name: "master", Repository: "Hello-World", Owner: forkedOwner},
+		URL:            "https://github.com/octocat/Hello-World/pull/1347",
+		HTMLURL:        "https://github.com/octocat/Hello-World/pull/1347",
+		HeadCommitHash: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Author:         "octocat",
+		UpdatedAt:      time.Date(2011, time.January, 26, 19, 1, 12, 0, time.UTC),
 	}, result)
 
 	// Bad Labels
@@ -738,6 +1403,58 @@ func TestGitHubClient_GetPullRequestByID(t *testing.T) {
 
 }
 
+func TestGitHubClient_Issues(t *testing.T) {
+	ctx := context.Background()
+	issueJSON := `{"number": 3, "title": "Vulnerable dependencies", "body": "Upgrade lodash", "state": "%s",
+		"html_url": "https://github.com/jfrog/repo-1/issues/3", "user": {"login": "frogger"}, "created_at": "2024-01-02T10:00:00Z"}`
+	pullRequestJSON := `{"number": 4, "state": "open", "pull_request": {"url": "https://api.github.com/repos/jfrog/repo-1/pulls/4"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/issues":
+			response = fmt.Sprintf(issueJSON, "open")
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/issues?page=1&per_page=100&state=open":
+			response = "[" + fmt.Sprintf(issueJSON, "open") + ", " + pullRequestJSON + "]"
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/issues/3/comments":
+			response = `{"id": 1, "body": "Still vulnerable"}`
+		case r.Method == http.MethodPatch && r.RequestURI == "/repos/jfrog/repo-1/issues/3":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"state": "closed"}`, string(body))
+			response = fmt.Sprintf(issueJSON, "closed")
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependencies", "Upgrade lodash")
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{
+		ID:        3,
+		Title:     "Vulnerable dependencies",
+		Body:      "Upgrade lodash",
+		State:     IssueOpen,
+		URL:       "https://github.com/jfrog/repo-1/issues/3",
+		Author:    "frogger",
+		CreatedAt: time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC),
+	}, issue)
+
+	// The pull requests returned by the issues API are filtered out
+	issues, err := client.ListIssues(ctx, owner, repo1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{issue}, issues)
+
+	assert.NoError(t, client.CommentOnIssue(ctx, owner, repo1, "Still vulnerable", 3))
+	assert.NoError(t, client.CloseIssue(ctx, owner, repo1, 3))
+
+	_, err = createBadGitHubClient(t).CreateIssue(ctx, owner, repo1, "Vulnerable dependencies", "")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListPullRequestComments(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_comments_list_response.json"))
@@ -755,6 +1472,7 @@ func TestGitHubClient_ListPullRequestComments(t *testing.T) {
 		ID:      10,
 		Content: "Great stuff!",
 		Created: expectedCreated,
+		Author:  "octocat",
 	}, result[0])
 
 	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
@@ -773,6 +1491,18 @@ func TestGitHubClient_UnlabelPullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.Label{{Name: github.String(labelName)}}, "/repos/jfrog/repo-1/issues/1/labels", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).LabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_UploadScanningAnalysis(t *testing.T) {
 	ctx := context.Background()
 	scan := "{\n    \"version\": \"2.1.0\",\n    \"$schema\": \"https://json.schemastore.org/sarif-2.1.0-rtm.5.json\",\n    \"runs\": [\n      {\n        \"tool\": {\n          \"driver\": {\n            \"informationUri\": \"https://jfrog.com/xray/\",\n            \"name\": \"Xray\",\n            \"rules\": [\n              {\n                \"id\": \"XRAY-174176\",\n                \"shortDescription\": null,\n                \"fullDescription\": {\n                  \"text\": \"json Package for Node.js lib/json.js _parseString() Function -d Argument Handling Local Code Execution Weakness\"\n                },\n                \"properties\": {\n                  \"security-severity\": \"8\"\n                }\n              }\n            ]\n          }\n        },\n        \"results\": [\n          {\n            \"ruleId\": \"XRAY-174176\",\n            \"ruleIndex\": 1,\n            \"message\": {\n              \"text\": \"json 9.0.6. Fixed in Versions: [11.0.0]\"\n            },\n            \"locations\": [\n              {\n                \"physicalLocation\": {\n                  \"artifactLocation\": {\n                    \"uri\": \"package.json\"\n                  }\n                }\n              }\n            ]\n          }\n        ]\n      }\n    ]\n  }"
@@ -791,6 +1521,230 @@ func TestGitHubClient_UploadScanningAnalysis(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_UploadCodeScanningPayloadTooLarge(t *testing.T) {
+	ctx := context.Background()
+	runs := make([]json.RawMessage, 3)
+	for i := range runs {
+		results := make([]string, 0, 50)
+		for j := 0; j < 50; j++ {
+			results = append(results, fmt.Sprintf(`{"ruleId": "XRAY-%d", "message": {"text": "%d-%d"}}`, i*1000+j*37, i, j*j))
+		}
+		runs[i] = json.RawMessage(fmt.Sprintf(`{"tool": {"driver": {"name": "Xray-%d"}}, "results": [%s]}`, i, strings.Join(results, ",")))
+	}
+	runs[0] = json.RawMessage(strings.Replace(string(runs[0]), "{", `{"automationDetails": {"id": "xray/scan-1"}, `, 1))
+	sarif := map[string]json.RawMessage{"version": json.RawMessage(`"2.1.0"`)}
+	scan, err := json.Marshal(map[string]any{"version": "2.1.0", "runs": runs})
+	assert.NoError(t, err)
+	encodedRun, err := encodeScanningResultChunk(sarif, runs[:1], 1)
+	assert.NoError(t, err)
+	encodedScan, err := encodeScanningResult(string(scan))
+	assert.NoError(t, err)
+
+	// Allow a single run, but not two runs, per upload
+	previousLimit := githubSarifUploadSizeLimit
+	githubSarifUploadSizeLimit = getEncodedScanningResultSize(encodedRun) + 10
+	defer func() { githubSarifUploadSizeLimit = previousLimit }()
+
+	var uploadedRuns []int
+	var uploadedCategories []string
+	serverSizeLimit := math.MaxInt
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.RequestURI, "/commits") {
+			_, err := w.Write([]byte(`[{"sha": "66d9a06b02a9f3f5fb47bb026a6fa5577647d96e"}]`))
+			assert.NoError(t, err)
+			return
+		}
+		var analysis github.SarifAnalysis
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&analysis))
+		if getEncodedScanningResultSize(analysis.GetSarif()) > serverSizeLimit {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		var uploadedSarif struct {
+			Runs []struct {
+				AutomationDetails struct{ ID string }
+			}
+		}
+		assert.NoError(t, base64.DecodeGunzipJSON(analysis.GetSarif(), &uploadedSarif))
+		uploadedRuns = append(uploadedRuns, len(uploadedSarif.Runs))
+		for _, run := range uploadedSarif.Runs {
+			uploadedCategories = append(uploadedCategories, run.AutomationDetails.ID)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id": "id-%d"}`, len(uploadedRuns))))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// Without splitting, the size is measured before uploading
+	_, err = client.UploadCodeScanning(ctx, owner, repo1, "master", string(scan))
+	var payloadTooLargeErr *ErrPayloadTooLarge
+	assert.ErrorAs(t, err, &payloadTooLargeErr)
+	assert.Equal(t, getEncodedScanningResultSize(encodedScan), payloadTooLargeErr.Size)
+	assert.Equal(t, githubSarifUploadSizeLimit, payloadTooLargeErr.Limit)
+	assert.Empty(t, uploadedRuns)
+
+	// With splitting, each run is uploaded separately, in a category of its own
+	ids, err := client.(*GitHubClient).UploadCodeScanningInChunks(ctx, owner, repo1, "master", string(scan))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-1", "id-2", "id-3"}, ids)
+	assert.Equal(t, []int{1, 1, 1}, uploadedRuns)
+	assert.Equal(t, []string{"xray/chunk-1/scan-1", "chunk-2/", "chunk-3/"}, uploadedCategories)
+
+	// A payload rejected by the server is reported with its size
+	uploadedRuns = nil
+	githubSarifUploadSizeLimit = previousLimit
+	serverSizeLimit = getEncodedScanningResultSize(encodedScan) - 1
+	_, err = client.UploadCodeScanning(ctx, owner, repo1, "master", string(scan))
+	assert.ErrorAs(t, err, &payloadTooLargeErr)
+	assert.Equal(t, getEncodedScanningResultSize(encodedScan), payloadTooLargeErr.Size)
+	assert.Zero(t, payloadTooLargeErr.Limit)
+
+	// With splitting, a rejected payload falls back to multiple uploads
+	ids, err = client.(*GitHubClient).UploadCodeScanningInChunks(ctx, owner, repo1, "master", string(scan))
+	assert.NoError(t, err)
+	assert.Greater(t, len(ids), 1)
+	assert.Len(t, uploadedRuns, len(ids))
+
+	// A SARIF within the size limit is uploaded as is, also through the clients wrapping the GitHub client
+	uploadedRuns, uploadedCategories = nil, nil
+	serverSizeLimit = math.MaxInt
+	ids, err = UploadCodeScanningInChunks(ctx, NewForkGuardedClient(client, RefuseUntrustedForks()), owner, repo1, "master", string(scan))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-1"}, ids)
+	assert.Equal(t, []string{"xray/scan-1", "", ""}, uploadedCategories)
+}
+
+func TestGitHubClient_ListCodeScanningAlerts(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "code_scanning_alerts_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/code-scanning/alerts?page=2&per_page=2&ref=refs%%2Fheads%%2Fmain&state=open", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	alerts, err := client.ListCodeScanningAlerts(ctx, owner, repo1, CodeScanningAlertsQueryOptions{
+		State:       AlertOpen,
+		Branch:      "main",
+		ListOptions: ListOptions{Page: 2, PerPage: 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []CodeScanningAlertInfo{
+		{
+			ID:        4,
+			RuleID:    "XRAY-174176",
+			Title:     "json Package for Node.js lib/json.js _parseString() Function -d Argument Handling Local Code Execution Weakness",
+			State:     AlertOpen,
+			Severity:  "high",
+			ToolName:  "Xray",
+			Path:      "package.json",
+			StartLine: 12,
+			EndLine:   13,
+			URL:       "https://github.com/jfrog/repo-1/security/code-scanning/4",
+			CreatedAt: time.Date(2023, 2, 13, 12, 29, 18, 0, time.UTC),
+		},
+		{
+			ID:        3,
+			RuleID:    "XRAY-520",
+			Title:     "lodash prototype pollution",
+			State:     AlertFixed,
+			Severity:  "warning",
+			ToolName:  "Xray",
+			URL:       "https://github.com/jfrog/repo-1/security/code-scanning/3",
+			CreatedAt: time.Date(2023, 2, 12, 9, 10, 0, 0, time.UTC),
+			ClosedAt:  time.Date(2023, 2, 14, 10, 0, 0, 0, time.UTC),
+		},
+	}, alerts)
+
+	_, err = createBadGitHubClient(t).ListCodeScanningAlerts(ctx, owner, repo1, CodeScanningAlertsQueryOptions{})
+	assert.Error(t, err)
+}
+
+func TestConvertToGitHubAlertListOptions(t *testing.T) {
+	assert.Equal(t, string(AlertOpen), convertToGitHubAlertListOptions(CodeScanningAlertsQueryOptions{}).State)
+	assert.Equal(t, string(AlertFixed), convertToGitHubAlertListOptions(CodeScanningAlertsQueryOptions{State: AlertFixed}).State)
+}
+
+func TestGitHubClient_GetCodeScanningAlert(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "code_scanning_alerts_response.json"))
+	assert.NoError(t, err)
+	var alerts []json.RawMessage
+	assert.NoError(t, json.Unmarshal(response, &alerts))
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(alerts[1]),
+		fmt.Sprintf("/repos/%s/%s/code-scanning/alerts/3", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	alert, err := client.GetCodeScanningAlert(ctx, owner, repo1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), alert.ID)
+	assert.Equal(t, AlertFixed, alert.State)
+	assert.Equal(t, time.Date(2023, 2, 14, 10, 0, 0, 0, time.UTC), alert.ClosedAt)
+
+	_, err = createBadGitHubClient(t).GetCodeScanningAlert(ctx, owner, repo1, 3)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DismissCodeScanningAlert(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"state":"dismissed","dismissed_reason":"won't fix","dismissed_comment":"Fixed by the base image"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte(`{"number": 3, "state": "dismissed"}`),
+		fmt.Sprintf("/repos/%s/%s/code-scanning/alerts/3", owner, repo1), http.StatusOK, expectedBody, http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DismissCodeScanningAlert(ctx, owner, repo1, 3, DismissWontFix, "Fixed by the base image")
+	assert.NoError(t, err)
+
+	err = client.DismissCodeScanningAlert(ctx, owner, repo1, 3, "", "")
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).DismissCodeScanningAlert(ctx, owner, repo1, 3, DismissWontFix, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListVulnerabilityAlerts(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "dependabot_alerts_response.json"))
+	assert.NoError(t, err)
+	var requestedURIs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURIs = append(requestedURIs, r.RequestURI)
+		// The first page links to the next page by a cursor
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/%s/%s/dependabot/alerts?after=Y3Vyc29y>; rel="next"`, "http://"+r.Host, owner, repo1))
+			_, err = w.Write([]byte("[]"))
+		} else {
+			_, err = w.Write(response)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	alerts, err := client.ListVulnerabilityAlerts(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("/repos/%s/%s/dependabot/alerts?per_page=100&state=open", owner, repo1),
+		fmt.Sprintf("/repos/%s/%s/dependabot/alerts?after=Y3Vyc29y&per_page=100&state=open", owner, repo1),
+	}, requestedURIs)
+	assert.Equal(t, []VulnerabilityAlertInfo{{
+		ID:                     2,
+		PackageName:            "lodash",
+		Ecosystem:              "npm",
+		VulnerableVersionRange: "< 4.17.21",
+		FixedVersion:           "4.17.21",
+		Severity:               "high",
+		CVE:                    "CVE-2021-23337",
+		Summary:                "Command Injection in lodash",
+		ManifestPath:           "package-lock.json",
+		URL:                    "https://github.com/jfrog/repo-1/security/dependabot/2",
+	}}, alerts)
+
+	_, err = createBadGitHubClient(t).ListVulnerabilityAlerts(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	ctx := context.Background()
 
@@ -803,26 +1757,121 @@ func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, envName, repositoryEnvironmentInfo.Name)
 	assert.Equal(t, "https://api.github.com/repos/superfrog/test-repo/environments/frogbot", repositoryEnvironmentInfo.Url)
-	assert.Equal(t, []string{"superfrog"}, repositoryEnvironmentInfo.Reviewers)
+	assert.Equal(t, []EnvironmentReviewer{
+		{Type: EnvironmentReviewerUser, Name: "superfrog", ID: 11367982},
+		{Type: EnvironmentReviewerTeam, Name: "frog-reviewers", ID: 1},
+	}, repositoryEnvironmentInfo.Reviewers)
+	assert.Zero(t, repositoryEnvironmentInfo.WaitTimer)
+	assert.Nil(t, repositoryEnvironmentInfo.DeploymentBranchPolicy)
 
 	_, err = createBadGitHubClient(t).GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_EnvironmentProtectionRules(t *testing.T) {
+	ctx := context.Background()
+	var environmentRequest map[string]interface{}
+	var createdPatterns, deletedPolicies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/environments/frogbot":
+			response = `{"name": "frogbot", "protection_rules": [{"type": "wait_timer", "wait_timer": 30},
+				{"type": "required_reviewers", "reviewers": [{"type": "User", "reviewer": {"login": "frogger", "id": 7}},
+				{"type": "Team", "reviewer": {"slug": "qa", "name": "QA", "id": 9}}]}],
+				"deployment_branch_policy": {"protected_branches": false, "custom_branch_policies": true}}`
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/environments/frogbot/deployment-branch-policies":
+			response = `{"total_count": 3, "branch_policies": [
+				{"id": 1, "name": "release/*", "type": "branch"}, {"id": 2, "name": "main"}, {"id": 3, "name": "v*", "type": "tag"}]}`
+		case r.Method == http.MethodGet && r.RequestURI == "/users/frogger":
+			response = `{"login": "frogger", "id": 7}`
+		case r.Method == http.MethodGet && r.RequestURI == "/orgs/jfrog/teams/release-managers":
+			response = `{"slug": "release-managers", "id": 12}`
+		case r.Method == http.MethodPut && r.RequestURI == "/repos/jfrog/repo-1/environments/frogbot":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&environmentRequest))
+			response = `{"name": "frogbot"}`
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/environments/frogbot/deployment-branch-policies":
+			var policyRequest map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&policyRequest))
+			createdPatterns = append(createdPatterns, policyRequest["name"])
+			response = "{}"
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.RequestURI, "/repos/jfrog/repo-1/environments/frogbot/deployment-branch-policies/"):
+			deletedPolicies = append(deletedPolicies, strings.TrimPrefix(r.RequestURI, "/repos/jfrog/repo-1/environments/frogbot/deployment-branch-policies/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	environmentInfo, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, environmentInfo.WaitTimer)
+	assert.Equal(t, &DeploymentBranchPolicy{CustomBranchPatterns: []string{"release/*", "main"}}, environmentInfo.DeploymentBranchPolicy)
+	assert.Equal(t, []EnvironmentReviewer{
+		{Type: EnvironmentReviewerUser, Name: username, ID: 7},
+		{Type: EnvironmentReviewerTeam, Name: "qa", ID: 9},
+	}, environmentInfo.Reviewers)
+
+	// The reviewers returned by GetRepositoryEnvironmentInfo are sent by their IDs, and the reviewers without IDs are resolved by their names.
+	// The custom branch policies missing from the patterns are deleted, and the new patterns are created.
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{
+		Name: envName,
+		Reviewers: append(environmentInfo.Reviewers,
+			EnvironmentReviewer{Type: EnvironmentReviewerUser, Name: username}, EnvironmentReviewer{Type: EnvironmentReviewerTeam, Name: "release-managers"}),
+		WaitTimer:              10,
+		DeploymentBranchPolicy: &DeploymentBranchPolicy{CustomBranchPatterns: []string{"main", "hotfix/*"}},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, environmentRequest["wait_timer"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "User", "id": float64(7)},
+		map[string]interface{}{"type": "Team", "id": float64(9)},
+		map[string]interface{}{"type": "User", "id": float64(7)},
+		map[string]interface{}{"type": "Team", "id": float64(12)},
+	}, environmentRequest["reviewers"])
+	assert.Equal(t, map[string]interface{}{"protected_branches": false, "custom_branch_policies": true}, environmentRequest["deployment_branch_policy"])
+	assert.Equal(t, []string{"hotfix/*"}, createdPatterns)
+	assert.Equal(t, []string{"1"}, deletedPolicies)
+
+	// Without a deployment branch policy, all the branches are allowed to deploy
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{Name: envName})
+	assert.NoError(t, err)
+	assert.Nil(t, environmentRequest["deployment_branch_policy"])
+	assert.Nil(t, environmentRequest["reviewers"])
+
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{})
+	assert.Error(t, err)
+
+	err = client.CreateOrUpdateEnvironment(ctx, owner, repo1, RepositoryEnvironmentInfo{
+		Name:      envName,
+		Reviewers: []EnvironmentReviewer{{Type: EnvironmentReviewerRole, Name: "Maintainers", ID: 40}},
+	})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ExtractGitHubEnvironmentReviewers(t *testing.T) {
-	reviewer1, reviewer2 := "reviewer-1", "reviewer-2"
+	reviewer1, reviewer2, team := "reviewer-1", "reviewer-2", "team-1"
 	environment := &github.Environment{
 		ProtectionRules: []*github.ProtectionRule{{
 			Reviewers: []*github.RequiredReviewer{
-				{Reviewer: &repositoryEnvironmentReviewer{Login: reviewer1}},
-				{Reviewer: &repositoryEnvironmentReviewer{Login: reviewer2}},
+				{Type: vcsutils.PointerOf("User"), Reviewer: &repositoryEnvironmentReviewer{Login: reviewer1, ID: 1}},
+				{Type: vcsutils.PointerOf("User"), Reviewer: map[string]interface{}{"login": reviewer2, "id": float64(2)}},
+				{Type: vcsutils.PointerOf("Team"), Reviewer: map[string]interface{}{"slug": team, "name": "Team 1", "id": float64(3)}},
 			},
 		}},
 	}
 
 	actualReviewers, err := extractGitHubEnvironmentReviewers(environment)
 	assert.NoError(t, err)
-	assert.Equal(t, []string{reviewer1, reviewer2}, actualReviewers)
+	assert.Equal(t, []EnvironmentReviewer{
+		{Type: EnvironmentReviewerUser, Name: reviewer1, ID: 1},
+		{Type: EnvironmentReviewerUser, Name: reviewer2, ID: 2},
+		{Type: EnvironmentReviewerTeam, Name: team, ID: 3},
+	}, actualReviewers)
 }
 
 func TestGitHubClient_GetModifiedFiles(t *testing.T) {
@@ -867,6 +1916,28 @@ func TestGitHubClient_GetModifiedFiles(t *testing.T) {
 		)
 	})
 
+	t.Run("with details", func(t *testing.T) {
+		response, err := os.ReadFile(filepath.Join("testdata", "github", "compare_commits.json"))
+		assert.NoError(t, err)
+
+		client, cleanUp := createServerAndClient(
+			t,
+			vcsutils.GitHub,
+			false,
+			response,
+			"/repos/jfrog/repo-1/compare/sha-1...sha-2?per_page=1",
+			createGitHubHandler,
+		)
+		defer cleanUp()
+
+		modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repo1, "sha-1", "sha-2")
+		assert.NoError(t, err)
+		assert.Len(t, modifiedFiles, 17)
+		assert.Equal(t, ModifiedFileInfo{Path: "README.md", Status: FileModified, Additions: 25, Deletions: 6}, modifiedFiles[0])
+		assert.Equal(t, ModifiedFileInfo{Path: "vcsclient/testdata/github/repository_environment_response.json", Status: FileAdded, Additions: 42}, modifiedFiles[14])
+		assert.Equal(t, "vcsclient/vcsclient_old.go", modifiedFiles[16].PreviousPath)
+	})
+
 	t.Run("validation fails", func(t *testing.T) {
 		client := GitHubClient{}
 		_, err := client.GetModifiedFiles(ctx, "", repo1, "sha-1", "sha-2")
@@ -900,7 +1971,7 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
 	t.Run("Empty response", func(t *testing.T) {
-		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref), createGitHubHandler)
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/jfrog/%s/commits/%s/status?page=1&per_page=100", repo1, ref), createGitHubHandler)
 		defer cleanUp()
 		_, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
 		assert.NoError(t, err)
@@ -909,7 +1980,7 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "github", "commits_statuses.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref),
+			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status?page=1&per_page=100", repo1, ref),
 			createGitHubHandler)
 		defer cleanUp()
 		commitStatuses, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
@@ -924,7 +1995,7 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "github", "commits_statuses_bad_json.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref),
+			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status?page=1&per_page=100", repo1, ref),
 			createGitHubHandler)
 		defer cleanUp()
 		_, err = client.GetCommitStatuses(ctx, owner, repo1, ref)
@@ -939,6 +2010,43 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestGitHubClient_ListCommitStatusesWithPagination(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	var requestedPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/commits/main/status", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		response := `{"state": "failure", "total_count": 3, "statuses": [{"state": "failure", "context": "ci/test"}]}`
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/jfrog/repo-1/commits/main/status?page=2&per_page=2>; rel="next"`, serverURL))
+			response = `{"state": "failure", "total_count": 3, "statuses": [{"state": "success", "context": "ci/build"}, {"state": "pending", "context": "ci/lint"}]}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.GitHub, false, server).(*GitHubClient)
+
+	statusesPage, err := client.ListCommitStatusesWithPagination(ctx, owner, repo1, "main", CommitStatusesListOptions{PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, Fail, statusesPage.CombinedState)
+	assert.Equal(t, 3, statusesPage.TotalCount)
+	assert.Equal(t, 2, statusesPage.NextPage)
+	assert.Equal(t, []CommitStatusInfo{{State: Pass, Context: "ci/build"}, {State: InProgress, Context: "ci/lint"}}, statusesPage.Statuses)
+
+	requestedPages = nil
+	statusesPage, err = client.ListCommitStatusesWithPagination(ctx, owner, repo1, "main", CommitStatusesListOptions{PerPage: 2, AllPages: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, requestedPages)
+	assert.Zero(t, statusesPage.NextPage)
+	assert.Len(t, statusesPage.Statuses, 3)
+	assert.Equal(t, "ci/test", statusesPage.Statuses[2].Context)
+}
+
 func TestGitHubClient_DeletePullRequestReviewComments(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createGitHubHandlerWithoutExpectedURI)
@@ -961,6 +2069,55 @@ func TestGitHubClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil,
+		fmt.Sprintf("/repos/%v/%v/issues/comments/1", owner, repo1), http.StatusOK,
+		[]byte(`{"body":"Updated content"}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, 2, 1, "Updated content")
+	assert.NoError(t, err)
+	err = createBadGitHubClient(t).UpdatePullRequestComment(ctx, owner, repo1, 2, 1, "Updated content")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UpdatePullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil,
+		fmt.Sprintf("/repos/%v/%v/pulls/comments/1", owner, repo1), http.StatusOK,
+		[]byte(`{"body":"Updated content"}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestReviewComment(ctx, owner, repo1, 2, CommentInfo{ID: 1}, "Updated content")
+	assert.NoError(t, err)
+	err = createBadGitHubClient(t).UpdatePullRequestReviewComment(ctx, owner, repo1, 2, CommentInfo{ID: 1}, "Updated content")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ReplyToPullRequestReviewComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil,
+		fmt.Sprintf("/repos/%v/%v/pulls/2/comments", owner, repo1), http.StatusCreated,
+		[]byte(`{"body":"Reply content","in_reply_to":1}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+	err := client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 2, "1", "Reply content")
+	assert.NoError(t, err)
+	err = client.ReplyToPullRequestReviewComment(ctx, owner, repo1, 2, "not-a-number", "Reply content")
+	assert.Error(t, err)
+	err = createBadGitHubClient(t).ReplyToPullRequestReviewComment(ctx, owner, repo1, 2, "1", "Reply content")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_PullRequestAttachments(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+
+	_, err = client.UploadPullRequestAttachment(ctx, owner, repo1, 1, "report.json", []byte("{}"))
+	assert.ErrorIs(t, err, errGitHubPullRequestAttachmentsNotSupported)
+	_, err = client.ListPullRequestAttachments(ctx, owner, repo1, 1)
+	assert.ErrorIs(t, err, errGitHubPullRequestAttachmentsNotSupported)
+}
+
 func createBadGitHubClient(t *testing.T) VcsClient {
 	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint("https://badendpoint").Build()
 	assert.NoError(t, err)
@@ -1170,11 +2327,54 @@ func TestShouldRetryIfRateLimitExceeded(t *testing.T) {
 	toRetry = shouldRetryIfRateLimitExceeded(mockResponse, abuseRateLimitErr)
 	assert.False(t, toRetry)
 
-	// Test case 4: Response body contains 'rate limit'
+	// Test case 4: Response headers indicate that the primary rate limit is exhausted
 	mockResponse.StatusCode = http.StatusForbidden
-	mockResponse.Body = io.NopCloser(bytes.NewReader([]byte("This response contains rate limit")))
+	mockResponse.Header = http.Header{"X-Ratelimit-Remaining": []string{"0"}}
 	toRetry = shouldRetryIfRateLimitExceeded(mockResponse, nil)
 	assert.True(t, toRetry)
+
+	// Test case 5: Response headers indicate that a secondary rate limit is exceeded
+	mockResponse.Header = http.Header{"Retry-After": []string{"60"}}
+	toRetry = shouldRetryIfRateLimitExceeded(mockResponse, nil)
+	assert.True(t, toRetry)
+
+	// Test case 6: A forbidden response without rate limit headers, such as a missing SSO authorization, isn't retried
+	mockResponse.Header = http.Header{"X-Ratelimit-Remaining": []string{"4999"}}
+	mockResponse.Body = io.NopCloser(bytes.NewReader([]byte("Resource protected by organization SAML enforcement. Authorize the token to avoid the rate limit of anonymous requests")))
+	toRetry = shouldRetryIfRateLimitExceeded(mockResponse, nil)
+	assert.False(t, toRetry)
+}
+
+func TestGitHubClient_DisableRateLimitRetries(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).DisableRateLimitRetries(true).Build()
+	assert.NoError(t, err)
+	gitHubClient, ok := client.(*GitHubClient)
+	assert.True(t, ok)
+
+	attempts := 0
+	err = gitHubClient.runWithRateLimitRetries(context.Background(), func() (*github.Response, error) {
+		attempts++
+		response := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"60"}}}
+		return &github.Response{Response: response}, errors.New("too many requests")
+	})
+	assert.EqualError(t, err, "too many requests")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestGitHubClient_WithNoRetry(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+	gitHubClient, ok := client.(*GitHubClient)
+	assert.True(t, ok)
+
+	attempts := 0
+	err = gitHubClient.runWithRateLimitRetries(WithNoRetry(context.Background()), func() (*github.Response, error) {
+		attempts++
+		response := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{"X-Ratelimit-Remaining": []string{"0"}}}
+		return &github.Response{Response: response}, errors.New("rate limit exceeded")
+	})
+	assert.EqualError(t, err, "rate limit exceeded")
+	assert.Equal(t, 1, attempts)
 }
 
 func TestIsRateLimitAbuseError(t *testing.T) {
@@ -0,0 +1,161 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// The maximal page size of the GitHub Actions variables and secrets lists
+const githubActionsListPageSize = 30
+
+// RepositoryVariableInfo is a non-secret configuration variable of a repository, which is available to its GitHub Actions workflows
+type RepositoryVariableInfo struct {
+	Name  string
+	Value string
+	// The time of the last update of the variable. Ignored when creating or updating the variable.
+	Updated time.Time
+}
+
+// CreateOrUpdateRepositoryVariable on GitHub creates a GitHub Actions variable of a repository, or updates the value of the existing variable.
+// Requires a write permission on the repository, without admin rights on the organization.
+func (client *GitHubClient) CreateOrUpdateRepositoryVariable(ctx context.Context, owner, repository string, variable RepositoryVariableInfo) error {
+	ctx = labelAPICalls(ctx, "CreateOrUpdateRepositoryVariable")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": variable.Name}); err != nil {
+		return err
+	}
+	actionsVariable := &github.ActionsVariable{Name: variable.Name, Value: variable.Value}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		ghResponse, err := client.ghClient.Actions.UpdateRepoVariable(ctx, owner, repository, actionsVariable)
+		if ghResponse == nil || ghResponse.Response == nil || ghResponse.StatusCode != http.StatusNotFound {
+			return ghResponse, err
+		}
+		return client.ghClient.Actions.CreateRepoVariable(ctx, owner, repository, actionsVariable)
+	})
+}
+
+// ListRepositoryVariables on GitHub returns the GitHub Actions variables of a repository
+func (client *GitHubClient) ListRepositoryVariables(ctx context.Context, owner, repository string) ([]RepositoryVariableInfo, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryVariables")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var variables []RepositoryVariableInfo
+	for page := 1; page > 0; {
+		var actionsVariables *github.ActionsVariables
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			actionsVariables, ghResponse, err = client.ghClient.Actions.ListRepoVariables(ctx, owner, repository, &github.ListOptions{Page: page, PerPage: githubActionsListPageSize})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, variable := range actionsVariables.Variables {
+			variables = append(variables, RepositoryVariableInfo{Name: variable.Name, Value: variable.Value, Updated: variable.GetUpdatedAt().Time})
+		}
+		page = ghResponse.NextPage
+	}
+	return variables, nil
+}
+
+// DeleteRepositoryVariable on GitHub deletes a GitHub Actions variable of a repository
+func (client *GitHubClient) DeleteRepositoryVariable(ctx context.Context, owner, repository, name string) error {
+	ctx = labelAPICalls(ctx, "DeleteRepositoryVariable")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Actions.DeleteRepoVariable(ctx, owner, repository, name)
+	})
+}
+
+// CreateOrUpdateRepositorySecret on GitHub creates or updates a GitHub Actions secret of a repository.
+// The value is encrypted by the public key of the repository before it's sent, as required by GitHub.
+// Requires a write permission on the repository, without admin rights on the organization.
+func (client *GitHubClient) CreateOrUpdateRepositorySecret(ctx context.Context, owner, repository, name, value string) error {
+	ctx = labelAPICalls(ctx, "CreateOrUpdateRepositorySecret")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return err
+	}
+	var publicKey *github.PublicKey
+	err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		publicKey, ghResponse, err = client.ghClient.Actions.GetRepoPublicKey(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return err
+	}
+	encryptedValue, err := encryptGitHubSecret(publicKey.GetKey(), value)
+	if err != nil {
+		return err
+	}
+	encryptedSecret := &github.EncryptedSecret{Name: name, KeyID: publicKey.GetKeyID(), EncryptedValue: encryptedValue}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Actions.CreateOrUpdateRepoSecret(ctx, owner, repository, encryptedSecret)
+	})
+}
+
+// ListRepositorySecrets on GitHub returns the names of the GitHub Actions secrets of a repository. The values of the secrets can't be read.
+func (client *GitHubClient) ListRepositorySecrets(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListRepositorySecrets")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	var names []string
+	for page := 1; page > 0; {
+		var secrets *github.Secrets
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+			var err error
+			secrets, ghResponse, err = client.ghClient.Actions.ListRepoSecrets(ctx, owner, repository, &github.ListOptions{Page: page, PerPage: githubActionsListPageSize})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets.Secrets {
+			names = append(names, secret.Name)
+		}
+		page = ghResponse.NextPage
+	}
+	return names, nil
+}
+
+// DeleteRepositorySecret on GitHub deletes a GitHub Actions secret of a repository
+func (client *GitHubClient) DeleteRepositorySecret(ctx context.Context, owner, repository, name string) error {
+	ctx = labelAPICalls(ctx, "DeleteRepositorySecret")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(ctx, func() (*github.Response, error) {
+		return client.ghClient.Actions.DeleteRepoSecret(ctx, owner, repository, name)
+	})
+}
+
+// encryptGitHubSecret encrypts the value of a secret by a libsodium sealed box of the base64 encoded public key of the repository
+func encryptGitHubSecret(encodedPublicKey, value string) (string, error) {
+	decodedPublicKey, err := base64.StdEncoding.DecodeString(encodedPublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the public key of the repository: %w", err)
+	}
+	var publicKey [32]byte
+	if len(decodedPublicKey) != len(publicKey) {
+		return "", fmt.Errorf("the public key of the repository has %d bytes instead of %d", len(decodedPublicKey), len(publicKey))
+	}
+	copy(publicKey[:], decodedPublicKey)
+	encryptedValue, err := box.SealAnonymous(nil, []byte(value), &publicKey, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encryptedValue), nil
+}
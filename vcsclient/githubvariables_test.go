@@ -0,0 +1,144 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestGitHubClient_CreateOrUpdateRepositoryVariable(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	var createdVariable map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		switch {
+		case r.Method == http.MethodPatch && r.RequestURI == "/repos/jfrog/repo-1/actions/variables/EXISTING":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPatch && r.RequestURI == "/repos/jfrog/repo-1/actions/variables/NEW":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/actions/variables":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&createdVariable))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server).(*GitHubClient)
+
+	assert.NoError(t, client.CreateOrUpdateRepositoryVariable(ctx, owner, repo1, RepositoryVariableInfo{Name: "EXISTING", Value: "1"}))
+	assert.Equal(t, []string{"PATCH /repos/jfrog/repo-1/actions/variables/EXISTING"}, requests)
+
+	requests = nil
+	assert.NoError(t, client.CreateOrUpdateRepositoryVariable(ctx, owner, repo1, RepositoryVariableInfo{Name: "NEW", Value: "2"}))
+	assert.Equal(t, []string{"PATCH /repos/jfrog/repo-1/actions/variables/NEW", "POST /repos/jfrog/repo-1/actions/variables"}, requests)
+	assert.Equal(t, map[string]string{"name": "NEW", "value": "2"}, createdVariable)
+
+	assert.Error(t, client.CreateOrUpdateRepositoryVariable(ctx, "other", repo1, RepositoryVariableInfo{Name: "NEW", Value: "2"}))
+	assert.Error(t, client.CreateOrUpdateRepositoryVariable(ctx, owner, repo1, RepositoryVariableInfo{Value: "2"}))
+}
+
+func TestGitHubClient_ListRepositoryVariables(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/actions/variables", r.URL.Path)
+		response := `{"total_count": 2, "variables": [{"name": "SECOND", "value": "2", "updated_at": "2024-01-02T00:00:00Z"}]}`
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/jfrog/repo-1/actions/variables?page=2>; rel="next"`, serverURL))
+			response = `{"total_count": 2, "variables": [{"name": "FIRST", "value": "1", "updated_at": "2024-01-01T00:00:00Z"}]}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	client := buildClient(t, vcsutils.GitHub, false, server).(*GitHubClient)
+
+	variables, err := client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryVariableInfo{
+		{Name: "FIRST", Value: "1", Updated: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "SECOND", Value: "2", Updated: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}, variables)
+}
+
+func TestGitHubClient_DeleteRepositoryVariable(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, nil, "/repos/jfrog/repo-1/actions/variables/NAME", http.StatusOK, createGitHubHandler)
+	defer cleanUp()
+	assert.NoError(t, client.(*GitHubClient).DeleteRepositoryVariable(ctx, owner, repo1, "NAME"))
+	assert.Error(t, createBadGitHubClient(t).(*GitHubClient).DeleteRepositoryVariable(ctx, owner, repo1, "NAME"))
+}
+
+func TestGitHubClient_CreateOrUpdateRepositorySecret(t *testing.T) {
+	ctx := context.Background()
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	var decryptedValue string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/actions/secrets/public-key":
+			_, err := w.Write([]byte(fmt.Sprintf(`{"key_id": "key-1", "key": "%s"}`, base64.StdEncoding.EncodeToString(publicKey[:]))))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut && r.RequestURI == "/repos/jfrog/repo-1/actions/secrets/TOKEN":
+			var secret struct {
+				KeyID          string `json:"key_id"`
+				EncryptedValue string `json:"encrypted_value"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&secret))
+			assert.Equal(t, "key-1", secret.KeyID)
+			encryptedValue, err := base64.StdEncoding.DecodeString(secret.EncryptedValue)
+			assert.NoError(t, err)
+			value, decrypted := box.OpenAnonymous(nil, encryptedValue, publicKey, privateKey)
+			assert.True(t, decrypted)
+			decryptedValue = string(value)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "Unexpected request", r.Method, r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server).(*GitHubClient)
+
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "TOKEN", "s3cr3t"))
+	assert.Equal(t, "s3cr3t", decryptedValue)
+
+	assert.Error(t, createBadGitHubClient(t).(*GitHubClient).CreateOrUpdateRepositorySecret(ctx, owner, repo1, "TOKEN", "s3cr3t"))
+}
+
+func TestGitHubClient_ListRepositorySecrets(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"total_count": 2, "secrets": [{"name": "TOKEN"}, {"name": "PASSWORD"}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/actions/secrets?page=1&per_page=30", createGitHubHandler)
+	defer cleanUp()
+
+	names, err := client.(*GitHubClient).ListRepositorySecrets(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TOKEN", "PASSWORD"}, names)
+}
+
+func TestGitHubClient_DeleteRepositorySecret(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, nil, "/repos/jfrog/repo-1/actions/secrets/TOKEN", http.StatusOK, createGitHubHandler)
+	defer cleanUp()
+	assert.NoError(t, client.(*GitHubClient).DeleteRepositorySecret(ctx, owner, repo1, "TOKEN"))
+}
+
+func TestEncryptGitHubSecret(t *testing.T) {
+	_, err := encryptGitHubSecret("not base64!", "value")
+	assert.Error(t, err)
+	_, err = encryptGitHubSecret(base64.StdEncoding.EncodeToString([]byte("short")), "value")
+	assert.EqualError(t, err, "the public key of the repository has 5 bytes instead of 32")
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/xanzy/go-gitlab"
+	"io"
 	"net/http"
-	"sort"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,17 +23,24 @@ type GitLabClient struct {
 	glClient *gitlab.Client
 	vcsInfo  VcsInfo
 	logger   vcsutils.Log
+	// Resolves the branch names passed to GetCommitStatuses, which requires a commit hash
+	branchHeads branchHeadResolver
+	// Caches the GitLab version, which determines the endpoints used by some of the operations
+	serverVersion serverVersionResolver
 }
 
+// The minimal GitLab version with the merge request diffs endpoint, which replaces the deprecated merge request changes endpoint
+const gitLabMergeRequestDiffsMinimalVersion = "15.7"
+
 // NewGitLabClient create a new GitLabClient
 func NewGitLabClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitLabClient, error) {
 	var client *gitlab.Client
 	var err error
+	options := []gitlab.ClientOptionFunc{gitlab.WithHTTPClient(newHTTPClient(vcsutils.GitLab, vcsInfo, logger)), gitlab.WithCustomRetry(checkGitLabRetry)}
 	if vcsInfo.APIEndpoint != "" {
-		client, err = gitlab.NewClient(vcsInfo.Token, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
-	} else {
-		client, err = gitlab.NewClient(vcsInfo.Token)
+		options = append(options, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
 	}
+	client, err = gitlab.NewClient(vcsInfo.Token, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,36 +52,278 @@ func NewGitLabClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitLabClient, error
 	}, nil
 }
 
+// Raw returns the go-gitlab client used by the GitLabClient, to call the endpoints which aren't wrapped by the GitLabClient.
+// The client is shared with the GitLabClient, and reuses its authentication, TLS configuration, HTTP tracing, metrics, trace propagation
+// and retry policy. The results aren't sorted.
+func (client *GitLabClient) Raw() *gitlab.Client {
+	return client.glClient
+}
+
+// checkGitLabRetry is the retry policy of the GitLab requests, which retries the requests rejected for exceeding the rate limit or by a server error,
+// like the default policy of go-gitlab, unless they were sent with a context returned from WithNoRetry.
+func checkGitLabRetry(ctx context.Context, response *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil || isRetryDisabled(ctx) {
+		return false, err
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError, nil
+}
+
 // TestConnection on GitLab
 func (client *GitLabClient) TestConnection(ctx context.Context) error {
+	ctx = labelAPICalls(ctx, "TestConnection")
 	_, _, err := client.glClient.Projects.ListProjects(nil, gitlab.WithContext(ctx))
 	return err
 }
 
+// GetAuthenticatedUser on GitLab
+func (client *GitLabClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	ctx = labelAPICalls(ctx, "GetAuthenticatedUser")
+	user, _, err := client.glClient.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return UserInfo{}, err
+	}
+	email := user.Email
+	if email == "" {
+		email = user.PublicEmail
+	}
+	return UserInfo{ID: strconv.Itoa(user.ID), Login: user.Username, DisplayName: user.Name, Email: email}, nil
+}
+
+// GetServerVersion on GitLab
+func (client *GitLabClient) GetServerVersion(ctx context.Context) (string, error) {
+	ctx = labelAPICalls(ctx, "GetServerVersion")
+	return client.serverVersion.resolve(ctx, func(ctx context.Context) (string, error) {
+		version, _, err := client.glClient.Version.GetVersion(gitlab.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		return version.Version, nil
+	})
+}
+
+// Capabilities on GitLab
+func (client *GitLabClient) Capabilities(ctx context.Context) ([]Capability, error) {
+	ctx = labelAPICalls(ctx, "Capabilities")
+	return getServerCapabilities(ctx, vcsutils.GitLab, client.GetServerVersion)
+}
+
 // ListRepositories on GitLab
 func (client *GitLabClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
-	simple := true
+	ctx = labelAPICalls(ctx, "ListRepositories")
+	projects, err := client.listMemberProjects(ctx, true)
+	if err != nil {
+		return nil, err
+	}
 	results := make(map[string][]string)
+	for _, project := range projects {
+		owner := project.Namespace.Path
+		results[owner] = append(results[owner], project.Path)
+	}
+	return orderRepositories(client.vcsInfo, results), nil
+}
+
+// ListRepositoryListings on GitLab
+func (client *GitLabClient) ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryListings")
+	// The simple representation of the projects doesn't include their visibility
+	projects, err := client.listMemberProjects(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return orderRepositoryListings(client.vcsInfo, mapGitLabProjectsToListings(projects)), nil
+}
+
+func mapGitLabProjectsToListings(projects []*gitlab.Project) []RepositoryListing {
+	listings := make([]RepositoryListing, 0, len(projects))
+	for _, project := range projects {
+		ownerType := GroupNamespace
+		if project.Namespace.Kind == "user" {
+			ownerType = UserNamespace
+		}
+		listings = append(listings, RepositoryListing{
+			Owner:         project.Namespace.FullPath,
+			OwnerType:     ownerType,
+			Name:          project.Path,
+			Visibility:    getGitLabProjectVisibility(project),
+			DefaultBranch: project.DefaultBranch,
+		})
+	}
+	return listings
+}
+
+// SearchRepositories on GitLab, by the search and topic filters of the projects API.
+// The projects of an owner group include the projects of its subgroups. Without an owner, the projects of the memberships of the user are searched.
+func (client *GitLabClient) SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error) {
+	ctx = labelAPICalls(ctx, "SearchRepositories")
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	var search, topic *string
+	if options.Name != "" {
+		search = &options.Name
+	}
+	if options.Topic != "" {
+		topic = &options.Topic
+	}
+	var results []*gitlab.Project
+	for pageID := 1; ; pageID++ {
+		projects, response, err := client.searchProjectsInPage(ctx, options.Owner, search, topic, gitlab.ListOptions{Page: pageID})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, projects...)
+		if pageID >= response.TotalPages {
+			return mapGitLabProjectsToListings(results), nil
+		}
+	}
+}
+
+func (client *GitLabClient) searchProjectsInPage(ctx context.Context, owner string, search, topic *string, listOptions gitlab.ListOptions) ([]*gitlab.Project, *gitlab.Response, error) {
+	if owner == "" {
+		membership := true
+		return client.glClient.Projects.ListProjects(&gitlab.ListProjectsOptions{ListOptions: listOptions, Membership: &membership, Search: search, Topic: topic}, gitlab.WithContext(ctx))
+	}
+	includeSubGroups := true
+	projects, response, err := client.glClient.Groups.ListGroupProjects(owner, &gitlab.ListGroupProjectsOptions{ListOptions: listOptions, IncludeSubGroups: &includeSubGroups, Search: search, Topic: topic}, gitlab.WithContext(ctx))
+	if err != nil && response != nil && response.StatusCode == http.StatusNotFound {
+		// The owner isn't a group, but may be a user
+		return client.glClient.Projects.ListUserProjects(owner, &gitlab.ListProjectsOptions{ListOptions: listOptions, Search: search, Topic: topic}, gitlab.WithContext(ctx))
+	}
+	return projects, response, err
+}
+
+// SearchCode on GitLab, by the blobs scope of the search API. Searching without a repository requires the advanced search.
+// The projects of an owner group include the projects of its subgroups.
+func (client *GitLabClient) SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error) {
+	ctx = labelAPICalls(ctx, "SearchCode")
+	if err := options.validate(query); err != nil {
+		return nil, err
+	}
+	var blobs []*gitlab.Blob
+	for pageID := 1; ; pageID++ {
+		searchOptions := &gitlab.SearchOptions{ListOptions: gitlab.ListOptions{Page: pageID, PerPage: 100}}
+		var pageBlobs []*gitlab.Blob
+		var response *gitlab.Response
+		var err error
+		switch {
+		case options.Repository != "":
+			pageBlobs, response, err = client.glClient.Search.BlobsByProject(getProjectID(options.Owner, options.Repository), query, searchOptions, gitlab.WithContext(ctx))
+		case options.Owner != "":
+			pageBlobs, response, err = client.glClient.Search.BlobsByGroup(options.Owner, query, searchOptions, gitlab.WithContext(ctx))
+		default:
+			pageBlobs, response, err = client.glClient.Search.Blobs(query, searchOptions, gitlab.WithContext(ctx))
+		}
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, pageBlobs...)
+		if response.NextPage == 0 {
+			break
+		}
+	}
+	// The blobs reference their project by ID, so the path of each project is read once
+	projects := map[int]*gitlab.Project{}
+	results := make([]CodeSearchResult, 0, len(blobs))
+	for _, blob := range blobs {
+		project, exists := projects[blob.ProjectID]
+		if !exists {
+			var err error
+			if project, _, err = client.glClient.Projects.GetProject(blob.ProjectID, nil, gitlab.WithContext(ctx)); err != nil {
+				return nil, err
+			}
+			projects[blob.ProjectID] = project
+		}
+		result := CodeSearchResult{Repository: project.Path, Path: blob.Path, Line: blob.Startline, Fragment: blob.Data}
+		if project.Namespace != nil {
+			result.Owner = project.Namespace.FullPath
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// listMemberProjects returns the projects of which the authenticated user is a member
+func (client *GitLabClient) listMemberProjects(ctx context.Context, simple bool) ([]*gitlab.Project, error) {
 	membership := true
+	var results []*gitlab.Project
 	for pageID := 1; ; pageID++ {
 		options := &gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{Page: pageID}, Simple: &simple, Membership: &membership}
 		projects, response, err := client.glClient.Projects.ListProjects(options, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
-		for _, project := range projects {
-			owner := project.Namespace.Path
-			results[owner] = append(results[owner], project.Path)
+		results = append(results, projects...)
+		if pageID >= response.TotalPages {
+			return results, nil
+		}
+	}
+}
+
+// ListNamespaces on GitLab returns the namespace of the authenticated user, and the groups of the user with their subgroups
+func (client *GitLabClient) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	ctx = labelAPICalls(ctx, "ListNamespaces")
+	user, _, err := client.glClient.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []NamespaceInfo{{Name: user.Username, DisplayName: user.Name, Kind: UserNamespace}}
+	// The subgroups of a group inherit the membership of the user, so they are traversed from the top level groups
+	topLevelOnly := true
+	minAccessLevel := gitlab.GuestPermissions
+	visitedGroups := make(map[int]bool)
+	for pageID := 1; ; pageID++ {
+		options := &gitlab.ListGroupsOptions{ListOptions: gitlab.ListOptions{Page: pageID}, MinAccessLevel: &minAccessLevel, TopLevelOnly: &topLevelOnly}
+		groups, response, err := client.glClient.Groups.ListGroups(options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			descendantGroups, err := client.listDescendantGroups(ctx, group.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, namespaceGroup := range append([]*gitlab.Group{group}, descendantGroups...) {
+				if !visitedGroups[namespaceGroup.ID] {
+					visitedGroups[namespaceGroup.ID] = true
+					namespaces = append(namespaces, NamespaceInfo{Name: namespaceGroup.FullPath, DisplayName: namespaceGroup.FullName, Kind: GroupNamespace})
+				}
+			}
 		}
 		if pageID >= response.TotalPages {
 			break
 		}
 	}
-	return results, nil
+	return orderNamespaces(client.vcsInfo, namespaces), nil
+}
+
+func (client *GitLabClient) listDescendantGroups(ctx context.Context, groupID int) ([]*gitlab.Group, error) {
+	var descendantGroups []*gitlab.Group
+	for pageID := 1; ; pageID++ {
+		options := &gitlab.ListDescendantGroupsOptions{ListOptions: gitlab.ListOptions{Page: pageID}}
+		groups, response, err := client.glClient.Groups.ListDescendantGroups(groupID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		descendantGroups = append(descendantGroups, groups...)
+		if pageID >= response.TotalPages {
+			return descendantGroups, nil
+		}
+	}
 }
 
 // ListBranches on GitLab
 func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranches")
+	return client.ListBranchesWithOptions(ctx, owner, repository, ListBranchesOptions{})
+}
+
+// ListBranchesWithOptions on GitLab. The protected branches are filtered by the protected flag of the listed branches,
+// which considers the wildcard protected branches too, such as "release/*".
+func (client *GitLabClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListBranchesOptions) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListBranchesWithOptions")
 	branches, _, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), nil,
 		gitlab.WithContext(ctx))
 	if err != nil {
@@ -81,13 +332,52 @@ func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository
 
 	results := make([]string, 0, len(branches))
 	for _, branch := range branches {
+		if options.ProtectedOnly && !branch.Protected {
+			continue
+		}
 		results = append(results, branch.Name)
 	}
-	return results, nil
+	return orderNames(client.vcsInfo, results), nil
+}
+
+// GetBranchInfo on GitLab
+func (client *GitLabClient) GetBranchInfo(ctx context.Context, owner, repository, branch string) (RepositoryBranchInfo, error) {
+	ctx = labelAPICalls(ctx, "GetBranchInfo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	branchDetails, _, err := client.glClient.Branches.GetBranch(getProjectID(owner, repository), branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryBranchInfo{}, err
+	}
+	info := RepositoryBranchInfo{Name: branchDetails.Name, Protected: branchDetails.Protected, Default: branchDetails.Default}
+	if branchDetails.Commit != nil {
+		info.HeadCommitHash = branchDetails.Commit.ID
+	}
+	return info, nil
+}
+
+// GetDefaultBranch on GitLab
+func (client *GitLabClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	ctx = labelAPICalls(ctx, "GetDefaultBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+// SquashBranchIntoSingleCommit on GitLab
+func (client *GitLabClient) SquashBranchIntoSingleCommit(_ context.Context, _, _, _, _, _ string) (string, error) {
+	return "", ErrBranchSquashNotSupported
 }
 
 // AddSshKeyToRepository on GitLab
 func (client *GitLabClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	ctx = labelAPICalls(ctx, "AddSshKeyToRepository")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -111,9 +401,42 @@ func (client *GitLabClient) AddSshKeyToRepository(ctx context.Context, owner, re
 	return err
 }
 
+// CreateDeployToken on GitLab creates a project deploy token. The username of the token is generated by GitLab.
+// Creating the token requires the maintainer role on the project.
+func (client *GitLabClient) CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error) {
+	ctx = labelAPICalls(ctx, "CreateDeployToken")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name}); err != nil {
+		return DeployTokenInfo{}, err
+	}
+	if len(scopes) == 0 {
+		return DeployTokenInfo{}, errors.New("at least one scope of the token is required")
+	}
+	gitLabScopes := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope == WriteRepositoryScope {
+			return DeployTokenInfo{}, errGitLabWriteRepositoryDeployTokensNotSupported
+		}
+		gitLabScopes = append(gitLabScopes, string(scope))
+	}
+	options := &gitlab.CreateProjectDeployTokenOptions{Name: &name, Scopes: &gitLabScopes}
+	if !expiresAt.IsZero() {
+		options.ExpiresAt = &expiresAt
+	}
+	deployToken, _, err := client.glClient.DeployTokens.CreateProjectDeployToken(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil {
+		return DeployTokenInfo{}, err
+	}
+	tokenInfo := DeployTokenInfo{ID: strconv.Itoa(deployToken.ID), Name: deployToken.Name, Username: deployToken.Username, Token: deployToken.Token}
+	if deployToken.ExpiresAt != nil {
+		tokenInfo.ExpiresAt = *deployToken.ExpiresAt
+	}
+	return tokenInfo, nil
+}
+
 // CreateWebhook on GitLab
 func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string,
 	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateWebhook")
 	token := vcsutils.CreateToken()
 	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
 	options := &gitlab.AddProjectHookOptions{
@@ -135,6 +458,7 @@ func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository
 // UpdateWebhook on GitLab
 func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateWebhook")
 	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
 	options := &gitlab.EditProjectHookOptions{
 		Token:                  &token,
@@ -155,6 +479,7 @@ func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository
 
 // DeleteWebhook on GitLab
 func (client *GitLabClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteWebhook")
 	intWebhook, err := strconv.Atoi(webhookID)
 	if err != nil {
 		return err
@@ -164,24 +489,155 @@ func (client *GitLabClient) DeleteWebhook(ctx context.Context, owner, repository
 	return err
 }
 
+// CILint on GitLab validates the CI configuration in the context of the project, as if it was committed to the default branch
+func (client *GitLabClient) CILint(ctx context.Context, owner, repository, content string) (CILintResult, error) {
+	ctx = labelAPICalls(ctx, "CILint")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return CILintResult{}, err
+	}
+	lintResult, _, err := client.glClient.Validate.ProjectNamespaceLint(getProjectID(owner, repository), &gitlab.ProjectNamespaceLintOptions{Content: &content}, gitlab.WithContext(ctx))
+	if err != nil {
+		return CILintResult{}, err
+	}
+	result := CILintResult{Valid: lintResult.Valid, MergedYAML: lintResult.MergedYaml}
+	for _, lintError := range lintResult.Errors {
+		result.Errors = append(result.Errors, CILintIssue{Message: lintError})
+	}
+	for _, warning := range lintResult.Warnings {
+		result.Warnings = append(result.Warnings, CILintIssue{Message: warning})
+	}
+	return result, nil
+}
+
+// GetRepositoryTopics on GitLab
+func (client *GitLabClient) GetRepositoryTopics(ctx context.Context, owner, repository string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryTopics")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(project.Topics)
+	return project.Topics, nil
+}
+
+// SetRepositoryTopics on GitLab
+func (client *GitLabClient) SetRepositoryTopics(ctx context.Context, owner, repository string, topics []string) error {
+	ctx = labelAPICalls(ctx, "SetRepositoryTopics")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	topics = normalizeRepositoryTopics(topics)
+	_, _, err := client.glClient.Projects.EditProject(getProjectID(owner, repository), &gitlab.EditProjectOptions{Topics: &topics}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CreateGroupWebhook on GitLab
+func (client *GitLabClient) CreateGroupWebhook(ctx context.Context, group, branch, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	ctx = labelAPICalls(ctx, "CreateGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group, "payloadURL": payloadURL})
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
+	options := &gitlab.AddGroupHookOptions{
+		Token:                  &token,
+		URL:                    &projectHook.URL,
+		MergeRequestsEvents:    &projectHook.MergeRequestsEvents,
+		PushEvents:             &projectHook.PushEvents,
+		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
+		TagPushEvents:          &projectHook.TagPushEvents,
+	}
+	response, _, err := client.glClient.Groups.AddGroupHook(group, options, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.Itoa(response.ID), token, nil
+}
+
+// UpdateGroupWebhook on GitLab
+func (client *GitLabClient) UpdateGroupWebhook(ctx context.Context, group, branch, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	ctx = labelAPICalls(ctx, "UpdateGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group, "payloadURL": payloadURL})
+	if err != nil {
+		return err
+	}
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return err
+	}
+	projectHook := createProjectHook(branch, payloadURL, webhookEvents...)
+	options := &gitlab.EditGroupHookOptions{
+		Token:                  &token,
+		URL:                    &projectHook.URL,
+		MergeRequestsEvents:    &projectHook.MergeRequestsEvents,
+		PushEvents:             &projectHook.PushEvents,
+		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
+		TagPushEvents:          &projectHook.TagPushEvents,
+	}
+	_, _, err = client.glClient.Groups.EditGroupHook(group, intWebhook, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteGroupWebhook on GitLab
+func (client *GitLabClient) DeleteGroupWebhook(ctx context.Context, group, webhookID string) error {
+	ctx = labelAPICalls(ctx, "DeleteGroupWebhook")
+	err := validateParametersNotBlank(map[string]string{"group": group})
+	if err != nil {
+		return err
+	}
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Groups.DeleteGroupHook(group, intWebhook, gitlab.WithContext(ctx))
+	return err
+}
+
 // SetCommitStatus on GitLab
 func (client *GitLabClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
 	title, description, detailsURL string) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatus")
+	return client.SetCommitStatusWithOptions(ctx, commitStatus, owner, repository, ref, title, description, detailsURL, CommitStatusOptions{})
+}
+
+// SetCommitStatusWithOptions on GitLab, where the title identifies the status in the pipeline
+func (client *GitLabClient) SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string, statusOptions CommitStatusOptions) error {
+	ctx = labelAPICalls(ctx, "SetCommitStatusWithOptions")
+	description, err := encodeCommitStatusDescription(description, statusOptions.Metadata)
+	if err != nil {
+		return err
+	}
 	options := &gitlab.SetCommitStatusOptions{
 		State:       gitlab.BuildStateValue(getGitLabCommitState(commitStatus)),
 		Ref:         &ref,
 		Name:        &title,
 		Description: &description,
 		TargetURL:   &detailsURL,
+		Coverage:    statusOptions.Coverage,
 	}
-	_, _, err := client.glClient.Commits.SetCommitStatus(getProjectID(owner, repository), ref, options,
+	if statusOptions.PipelineID != 0 {
+		options.PipelineID = &statusOptions.PipelineID
+	}
+	_, _, err = client.glClient.Commits.SetCommitStatus(getProjectID(owner, repository), ref, options,
 		gitlab.WithContext(ctx))
 	return err
 }
 
 // GetCommitStatuses on GitLab
-func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository, ref string) (status []CommitStatusInfo, err error) {
-	statuses, _, err := client.glClient.Commits.GetCommitStatuses(repository, ref, nil, gitlab.WithContext(ctx))
+func (client *GitLabClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	ctx = labelAPICalls(ctx, "GetCommitStatuses")
+	ref, err = client.branchHeads.resolve(ctx, owner, repository, ref, client.GetLatestCommit)
+	if err != nil {
+		return nil, err
+	}
+	statuses, _, err := client.glClient.Commits.GetCommitStatuses(getProjectID(owner, repository), ref, nil, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +645,7 @@ func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository
 	for _, singleStatus := range statuses {
 		results = append(results, CommitStatusInfo{
 			State:         commitStatusAsStringToStatus(singleStatus.Status),
+			Context:       singleStatus.Name,
 			Description:   singleStatus.Description,
 			DetailsUrl:    singleStatus.TargetURL,
 			Creator:       singleStatus.Author.Name,
@@ -199,12 +656,31 @@ func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository
 	return results, nil
 }
 
+// CreateCheckRun on GitLab
+func (client *GitLabClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errGitLabCheckRunsNotSupported
+}
+
+// UpdateCheckRun on GitLab
+func (client *GitLabClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errGitLabCheckRunsNotSupported
+}
+
 // DownloadRepository on GitLab
 func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	ctx = labelAPICalls(ctx, "DownloadRepository")
+	return client.DownloadRepositoryWithOptions(ctx, owner, repository, branch, localPath, DownloadRepositoryOptions{})
+}
+
+// DownloadRepositoryWithOptions on GitLab
+func (client *GitLabClient) DownloadRepositoryWithOptions(ctx context.Context, owner, repository, branch, localPath string, downloadOptions DownloadRepositoryOptions) error {
+	ctx = labelAPICalls(ctx, "DownloadRepositoryWithOptions")
 	format := "tar.gz"
 	options := &gitlab.ArchiveOptions{
 		Format: &format,
 		SHA:    &branch,
+		// The archive of a single directory keeps the directory path, so the filter still applies to the extracted files
+		Path: vcsutils.GetNilIfZeroVal(downloadOptions.Filter.GetIncludedDir()),
 	}
 	response, _, err := client.glClient.Repositories.Archive(getProjectID(owner, repository), options,
 		gitlab.WithContext(ctx))
@@ -212,17 +688,20 @@ func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repos
 		return err
 	}
 	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, bytes.NewReader(response), true)
+	stats, err := vcsutils.UntarWithStats(localPath, bytes.NewReader(response), true, downloadOptions.Filter, downloadOptions.ExtractionProgress)
 	if err != nil {
 		return err
 	}
+	reportRepositoryExtraction(client.logger, downloadOptions, stats)
+	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	if client.vcsInfo.SkipDotGitCreation {
+		return nil
+	}
 
 	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
 	}
-
-	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
 	return vcsutils.CreateDotGitFolderWithRemote(localPath, vcsutils.RemoteName, repositoryInfo.CloneInfo.HTTP)
 }
 
@@ -237,6 +716,7 @@ func (client *GitLabClient) GetPullRequestDetailsSizeLimit() int {
 // CreatePullRequest on GitLab
 func (client *GitLabClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
 	title, description string) error {
+	ctx = labelAPICalls(ctx, "CreatePullRequest")
 	options := &gitlab.CreateMergeRequestOptions{
 		Title:        &title,
 		Description:  &description,
@@ -251,6 +731,7 @@ func (client *GitLabClient) CreatePullRequest(ctx context.Context, owner, reposi
 
 // UpdatePullRequest on GitLab
 func (client *GitLabClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequest")
 	options := &gitlab.UpdateMergeRequestOptions{
 		Title:        &title,
 		Description:  &body,
@@ -262,28 +743,253 @@ func (client *GitLabClient) UpdatePullRequest(ctx context.Context, owner, reposi
 	return err
 }
 
+// ClosePullRequest on GitLab
+func (client *GitLabClient) ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ClosePullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Closed)
+}
+
+// ReopenPullRequest on GitLab
+func (client *GitLabClient) ReopenPullRequest(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "ReopenPullRequest")
+	return client.setPullRequestState(ctx, owner, repository, pullRequestID, vcsutils.Open)
+}
+
+func (client *GitLabClient) setPullRequestState(ctx context.Context, owner, repository string, pullRequestID int, state vcsutils.PullRequestState) error {
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("an error occurred while fetching merge request %d: %w", pullRequestID, err)
+	}
+	// A merge request is 'locked' while it is being merged
+	currentState := vcsutils.Open
+	if mergeRequest.State == "closed" {
+		currentState = vcsutils.Closed
+	}
+	if err = validatePullRequestStateTransition(pullRequestID, mergeRequest.State == "merged", currentState, state); err != nil {
+		return err
+	}
+	client.logger.Debug("updating state of merge request ID:", pullRequestID)
+	options := &gitlab.UpdateMergeRequestOptions{StateEvent: mapGitLabPullRequestState(&state)}
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// EnablePullRequestAutoMerge on GitLab sets the merge request to be merged when its pipeline succeeds
+func (client *GitLabClient) EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod MergeMethod) error {
+	ctx = labelAPICalls(ctx, "EnablePullRequestAutoMerge")
+	options, err := newGitLabAcceptMergeRequestOptions(mergeMethod)
+	if err != nil {
+		return err
+	}
+	options.MergeWhenPipelineSucceeds = vcsutils.PointerOf(true)
+	client.logger.Debug(vcsutils.EnablingAutoMerge, pullRequestID)
+	_, _, err = client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// MergePullRequest on GitLab
+func (client *GitLabClient) MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error {
+	ctx = labelAPICalls(ctx, "MergePullRequest")
+	if options.BypassPolicies {
+		return errGitLabBypassPoliciesNotSupported
+	}
+	acceptOptions, err := newGitLabAcceptMergeRequestOptions(options.Method)
+	if err != nil {
+		return err
+	}
+	if options.DeleteSourceBranch {
+		acceptOptions.ShouldRemoveSourceBranch = vcsutils.PointerOf(true)
+	}
+	if commitMessage := options.commitMessage(); commitMessage != "" {
+		if options.Method == SquashMerge {
+			acceptOptions.SquashCommitMessage = &commitMessage
+		} else {
+			acceptOptions.MergeCommitMessage = &commitMessage
+		}
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, pullRequestID)
+	_, _, err = client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), pullRequestID, acceptOptions, gitlab.WithContext(ctx))
+	return err
+}
+
+// newGitLabAcceptMergeRequestOptions returns the options of accepting a merge request by the merge method.
+// GitLab merges by a merge commit or a fast-forward, as set by the project, and can squash the commits beforehand.
+func newGitLabAcceptMergeRequestOptions(mergeMethod MergeMethod) (*gitlab.AcceptMergeRequestOptions, error) {
+	options := &gitlab.AcceptMergeRequestOptions{}
+	switch mergeMethod {
+	case RebaseMerge:
+		return nil, errGitLabRebaseMergeNotSupported
+	case SquashMerge:
+		options.Squash = vcsutils.PointerOf(true)
+	case MergeCommit:
+		options.Squash = vcsutils.PointerOf(false)
+	}
+	return options, nil
+}
+
+// UpdatePullRequestBranch on GitLab, by rebasing the source branch onto the target branch, and waiting for the rebase to complete
+func (client *GitLabClient) UpdatePullRequestBranch(ctx context.Context, owner, repository string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestBranch")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	projectID := getProjectID(owner, repository)
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(projectID, pullRequestID, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if mergeRequest.HasConflicts {
+		return &PullRequestBranchConflictError{PullRequestID: pullRequestID}
+	}
+	if _, err = client.glClient.MergeRequests.RebaseMergeRequest(projectID, pullRequestID, &gitlab.RebaseMergeRequestOptions{}, gitlab.WithContext(ctx)); err != nil {
+		return err
+	}
+	return waitForPullRequestBranchUpdate(ctx, pullRequestID, func() (bool, error) {
+		mergeRequest, _, err = client.glClient.MergeRequests.GetMergeRequest(projectID, pullRequestID,
+			&gitlab.GetMergeRequestsOptions{IncludeRebaseInProgress: vcsutils.PointerOf(true)}, gitlab.WithContext(ctx))
+		if err != nil || mergeRequest.RebaseInProgress {
+			return false, err
+		}
+		// A failed rebase is reported by the merge error of the merge request
+		if mergeRequest.MergeError != "" {
+			return false, &PullRequestBranchConflictError{PullRequestID: pullRequestID, Message: mergeRequest.MergeError}
+		}
+		return true, nil
+	})
+}
+
+// ListMilestones on GitLab, including the milestones of the groups of the project
+func (client *GitLabClient) ListMilestones(ctx context.Context, owner, repository string, state MilestoneState) ([]MilestoneInfo, error) {
+	ctx = labelAPICalls(ctx, "ListMilestones")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	// GitLab names the open milestones active
+	glState := "active"
+	if state == MilestoneClosed {
+		glState = "closed"
+	}
+	var milestones []MilestoneInfo
+	for nextPage := 1; ; nextPage++ {
+		glMilestones, response, err := client.glClient.Milestones.ListMilestones(getProjectID(owner, repository), &gitlab.ListMilestonesOptions{
+			ListOptions:             gitlab.ListOptions{Page: nextPage, PerPage: 100},
+			State:                   &glState,
+			IncludeParentMilestones: vcsutils.PointerOf(true),
+		}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, glMilestone := range glMilestones {
+			milestones = append(milestones, mapGitLabMilestoneToMilestoneInfo(glMilestone))
+		}
+		if response.NextPage == 0 {
+			sortMilestonesByDueDate(milestones)
+			return milestones, nil
+		}
+	}
+}
+
+func mapGitLabMilestoneToMilestoneInfo(glMilestone *gitlab.Milestone) MilestoneInfo {
+	milestone := MilestoneInfo{
+		ID:          int64(glMilestone.ID),
+		Title:       glMilestone.Title,
+		Description: glMilestone.Description,
+		State:       MilestoneClosed,
+		URL:         glMilestone.WebURL,
+	}
+	if glMilestone.State == "active" {
+		milestone.State = MilestoneOpen
+	}
+	if glMilestone.DueDate != nil {
+		milestone.DueDate = vcsutils.PointerOf(time.Time(*glMilestone.DueDate))
+	}
+	return milestone
+}
+
+// SetPullRequestMilestone on GitLab, where a zero milestone ID unassigns the milestone of the merge request
+func (client *GitLabClient) SetPullRequestMilestone(ctx context.Context, owner, repository string, pullRequestID int, milestoneID int64) error {
+	ctx = labelAPICalls(ctx, "SetPullRequestMilestone")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	_, _, err := client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID,
+		&gitlab.UpdateMergeRequestOptions{MilestoneID: vcsutils.PointerOf(int(milestoneID))}, gitlab.WithContext(ctx))
+	return err
+}
+
 // ListOpenPullRequestsWithBody on GitLab
 func (client *GitLabClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithBody")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{WithBody: true})
 }
 
 // ListOpenPullRequests on GitLab
 func (client *GitLabClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+	ctx = labelAPICalls(ctx, "ListOpenPullRequests")
+	return client.getOpenPullRequests(ctx, owner, repository, PullRequestsQueryOptions{})
+}
+
+// ListOpenPullRequestsWithQueryOptions on GitLab
+func (client *GitLabClient) ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListOpenPullRequestsWithQueryOptions")
+	return client.getOpenPullRequests(ctx, owner, repository, options)
 }
 
-func (client *GitLabClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
+func (client *GitLabClient) getOpenPullRequests(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error) {
 	openState := "opened"
 	allScope := "all"
-	options := &gitlab.ListProjectMergeRequestsOptions{
-		State: &openState,
-		Scope: &allScope,
+	listOptions := &gitlab.ListProjectMergeRequestsOptions{
+		State:        &openState,
+		Scope:        &allScope,
+		SourceBranch: vcsutils.GetNilIfZeroVal(options.SourceBranch),
+		TargetBranch: vcsutils.GetNilIfZeroVal(options.TargetBranch),
+		ListOptions: gitlab.ListOptions{
+			Page:    options.Page,
+			PerPage: options.PerPage,
+		},
 	}
-	mergeRequests, _, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	mergeRequests, _, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
 	if err != nil {
 		return []PullRequestInfo{}, err
 	}
-	return client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, withBody)
+	pullRequests, err := client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, options.WithBody)
+	return orderPullRequests(client.vcsInfo, pullRequests), err
+}
+
+// ListPullRequests on GitLab
+func (client *GitLabClient) ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequests")
+	state := "opened"
+	switch options.State {
+	case PullRequestsClosed, PullRequestsMerged, PullRequestsAll:
+		state = string(options.State)
+	}
+	orderBy, sort := "created_at", "desc"
+	if options.Sort == SortByUpdated {
+		orderBy = "updated_at"
+	}
+	if options.Ascending {
+		sort = "asc"
+	}
+	allScope := "all"
+	listOptions := &gitlab.ListProjectMergeRequestsOptions{
+		State:          &state,
+		Scope:          &allScope,
+		OrderBy:        &orderBy,
+		Sort:           &sort,
+		SourceBranch:   vcsutils.GetNilIfZeroVal(options.SourceBranch),
+		TargetBranch:   vcsutils.GetNilIfZeroVal(options.TargetBranch),
+		AuthorUsername: vcsutils.GetNilIfZeroVal(options.Author),
+		ListOptions: gitlab.ListOptions{
+			Page:    options.Page,
+			PerPage: options.PerPage,
+		},
+	}
+	mergeRequests, _, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, options.WithBody)
 }
 
 // GetPullRequestInfoById on GitLab
@@ -302,8 +1008,140 @@ func (client *GitLabClient) GetPullRequestByID(_ context.Context, owner, reposit
 	return
 }
 
+// ListCommitsBetween on GitLab returns the hashes of the commits reachable from toRef but not from fromRef, from the oldest to the newest
+func (client *GitLabClient) ListCommitsBetween(ctx context.Context, owner, repository, fromRef, toRef string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListCommitsBetween")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "fromRef": fromRef, "toRef": toRef})
+	if err != nil {
+		return nil, err
+	}
+	comparison, _, err := client.glClient.Repositories.Compare(getProjectID(owner, repository), &gitlab.CompareOptions{From: &fromRef, To: &toRef}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]string, 0, len(comparison.Commits))
+	for _, commit := range comparison.Commits {
+		commits = append(commits, commit.ID)
+	}
+	return commits, nil
+}
+
+// ListMergedPullRequestsOfCommit on GitLab returns the merged merge requests associated with a commit, with their merge commit hashes.
+// The merge commit hash is the squashed commit of merge requests squashed without a merge commit, and the head commit of fast-forward merges.
+func (client *GitLabClient) ListMergedPullRequestsOfCommit(ctx context.Context, owner, repository, sha string) ([]PullRequestInfo, error) {
+	ctx = labelAPICalls(ctx, "ListMergedPullRequestsOfCommit")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sha": sha})
+	if err != nil {
+		return nil, err
+	}
+	mergeRequests, _, err := client.glClient.Commits.ListMergeRequestsByCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var pullRequests []PullRequestInfo
+	for _, mergeRequest := range mergeRequests {
+		if mergeRequest.State != "merged" {
+			continue
+		}
+		pullRequest, err := client.mapGitLabMergeRequestToPullRequestInfo(mergeRequest, false, owner, repository)
+		if err != nil {
+			return nil, err
+		}
+		pullRequest.MergeCommitHash = getGitLabMergeRequestMergeCommit(mergeRequest)
+		pullRequests = append(pullRequests, pullRequest)
+	}
+	return pullRequests, nil
+}
+
+func getGitLabMergeRequestMergeCommit(mergeRequest *gitlab.MergeRequest) string {
+	switch {
+	case mergeRequest.MergeCommitSHA != "":
+		return mergeRequest.MergeCommitSHA
+	case mergeRequest.SquashCommitSHA != "":
+		return mergeRequest.SquashCommitSHA
+	default:
+		return mergeRequest.SHA
+	}
+}
+
+// CreateIssue on GitLab
+func (client *GitLabClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "CreateIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title}); err != nil {
+		return IssueInfo{}, err
+	}
+	issue, _, err := client.glClient.Issues.CreateIssue(getProjectID(owner, repository), &gitlab.CreateIssueOptions{Title: &title, Description: &body}, gitlab.WithContext(ctx))
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGitLabIssueToIssueInfo(issue), nil
+}
+
+// ListIssues on GitLab
+func (client *GitLabClient) ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error) {
+	ctx = labelAPICalls(ctx, "ListIssues")
+	// The state of the open issues is 'opened' on GitLab
+	glState := "opened"
+	if state == IssueClosed {
+		glState = "closed"
+	}
+	var issues []IssueInfo
+	for pageID := 1; ; pageID++ {
+		issuesPage, response, err := client.glClient.Issues.ListProjectIssues(getProjectID(owner, repository), &gitlab.ListProjectIssuesOptions{
+			ListOptions: gitlab.ListOptions{Page: pageID, PerPage: 100},
+			State:       &glState,
+		}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issuesPage {
+			issues = append(issues, mapGitLabIssueToIssueInfo(issue))
+		}
+		if response.NextPage == 0 {
+			return orderIssues(client.vcsInfo, issues), nil
+		}
+	}
+}
+
+// CommentOnIssue on GitLab
+func (client *GitLabClient) CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CommentOnIssue")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	_, _, err := client.glClient.Notes.CreateIssueNote(getProjectID(owner, repository), issueID, &gitlab.CreateIssueNoteOptions{Body: &content}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CloseIssue on GitLab
+func (client *GitLabClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	ctx = labelAPICalls(ctx, "CloseIssue")
+	stateEvent := "close"
+	_, _, err := client.glClient.Issues.UpdateIssue(getProjectID(owner, repository), issueID, &gitlab.UpdateIssueOptions{StateEvent: &stateEvent}, gitlab.WithContext(ctx))
+	return err
+}
+
+func mapGitLabIssueToIssueInfo(issue *gitlab.Issue) IssueInfo {
+	issueInfo := IssueInfo{
+		ID:        int64(issue.IID),
+		Title:     issue.Title,
+		Body:      issue.Description,
+		State:     IssueOpen,
+		URL:       issue.WebURL,
+		CreatedAt: vcsutils.DefaultIfNotNil(issue.CreatedAt),
+	}
+	if issue.State == "closed" {
+		issueInfo.State = IssueClosed
+	}
+	if issue.Author != nil {
+		issueInfo.Author = issue.Author.Username
+	}
+	return issueInfo
+}
+
 // AddPullRequestComment on GitLab
 func (client *GitLabClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestComment")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
 	if err != nil {
 		return err
@@ -319,6 +1157,7 @@ func (client *GitLabClient) AddPullRequestComment(ctx context.Context, owner, re
 
 // AddPullRequestReviewComments adds comments to a pull request on GitLab.
 func (client *GitLabClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	ctx = labelAPICalls(ctx, "AddPullRequestReviewComments")
 	// Validate parameters
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
 		return err
@@ -358,77 +1197,146 @@ func (client *GitLabClient) getMergeRequestDiffVersions(ctx context.Context, pro
 }
 
 func (client *GitLabClient) getMergeRequestDiff(ctx context.Context, projectID string, pullRequestID int) ([]*gitlab.MergeRequestDiff, error) {
-	mergeRequestChanges, _, err := client.glClient.MergeRequests.ListMergeRequestDiffs(projectID, pullRequestID, nil, gitlab.WithContext(ctx))
-	return mergeRequestChanges, err
+	if !supportsServerVersion(ctx, client.GetServerVersion, gitLabMergeRequestDiffsMinimalVersion, client.logger) {
+		mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequestChanges(projectID, pullRequestID, nil, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return mergeRequest.Changes, nil
+	}
+	var mergeRequestChanges []*gitlab.MergeRequestDiff
+	options := &gitlab.ListMergeRequestDiffsOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for pageID := 1; ; pageID++ {
+		options.Page = pageID
+		diffs, response, err := client.glClient.MergeRequests.ListMergeRequestDiffs(projectID, pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		mergeRequestChanges = append(mergeRequestChanges, diffs...)
+		if response.NextPage == 0 {
+			return mergeRequestChanges, nil
+		}
+	}
 }
 
 func (client *GitLabClient) addPullRequestReviewComment(ctx context.Context, projectID string, pullRequestID int, comment PullRequestComment, versions []*gitlab.MergeRequestDiffVersion, mergeRequestChanges []*gitlab.MergeRequestDiff) error {
-	// Find the corresponding change in merge request
-	var newPath, oldPath string
-	var newLine int
-	var diffFound bool
+	diff := findGitLabMergeRequestFileDiff(mergeRequestChanges, comment.PullRequestDiff)
+	if diff == nil {
+		return fmt.Errorf("could not find changes to %s in the current merge request", comment.NewFilePath)
+	}
+	latestVersion := getGitLabLatestDiffVersion(versions)
+	if latestVersion == nil {
+		return errors.New("could not find the diff versions of the current merge request")
+	}
+	diffPosition, err := getGitLabReviewCommentPosition(diff, latestVersion, comment.PullRequestDiff)
+	if err != nil {
+		return err
+	}
 
+	client.logger.Debug(fmt.Sprintf("Create merge request discussion sent. newPath: %v newLine: %v oldPath: %v, oldLine: %v",
+		*diffPosition.NewPath, vcsutils.DefaultIfNotNil(diffPosition.NewLine), *diffPosition.OldPath, vcsutils.DefaultIfNotNil(diffPosition.OldLine)))
+	_, _, err = client.createMergeRequestDiscussion(ctx, projectID, comment.Content, pullRequestID, diffPosition)
+	if err == nil {
+		return nil
+	}
+	// The old line may be wrong if the text of the diff wasn't returned, or didn't match the position GitLab expects
+	fallbackPosition := getGitLabFallbackReviewCommentPosition(diff, diffPosition, comment.PullRequestDiff)
+	if fallbackPosition == nil {
+		return fmt.Errorf("could not create a merge request discussion thread: %w", err)
+	}
+	client.logger.Debug(fmt.Sprintf("Create merge request discussion second attempt sent. newPath: %v newLine: %v oldPath: %v, oldLine: %v",
+		*fallbackPosition.NewPath, vcsutils.DefaultIfNotNil(fallbackPosition.NewLine), *fallbackPosition.OldPath, vcsutils.DefaultIfNotNil(fallbackPosition.OldLine)))
+	if _, _, err = client.createMergeRequestDiscussion(ctx, projectID, comment.Content, pullRequestID, fallbackPosition); err != nil {
+		return fmt.Errorf("could not create a merge request discussion thread: %w", err)
+	}
+	return nil
+}
+
+// findGitLabMergeRequestFileDiff returns the diff of the commented file in the merge request, or nil if the file wasn't changed.
+// The file of a renamed file diff may also be commented by its original path.
+func findGitLabMergeRequestFileDiff(mergeRequestChanges []*gitlab.MergeRequestDiff, commentDiff PullRequestDiff) *gitlab.MergeRequestDiff {
 	for _, diff := range mergeRequestChanges {
-		if diff.NewPath != comment.NewFilePath {
-			continue
+		if diff.NewPath == commentDiff.NewFilePath {
+			return diff
 		}
-
-		diffFound = true
-		newLine = comment.NewStartLine
-		newPath = diff.NewPath
-
-		// New files don't have old data
-		if !diff.NewFile {
-			oldPath = diff.OldPath
+	}
+	for _, diff := range mergeRequestChanges {
+		if diff.RenamedFile && (diff.OldPath == commentDiff.NewFilePath || (commentDiff.OriginalFilePath != "" && diff.OldPath == commentDiff.OriginalFilePath)) {
+			return diff
 		}
-		break
 	}
+	return nil
+}
 
-	// If no matching change is found, return an error
-	if !diffFound {
-		return fmt.Errorf("could not find changes to %s in the current merge request", comment.NewFilePath)
+// getGitLabLatestDiffVersion returns the diff version of the latest push to the merge request, which the comments are positioned on
+func getGitLabLatestDiffVersion(versions []*gitlab.MergeRequestDiffVersion) *gitlab.MergeRequestDiffVersion {
+	var latestVersion *gitlab.MergeRequestDiffVersion
+	for _, version := range versions {
+		if latestVersion == nil || version.ID > latestVersion.ID {
+			latestVersion = version
+		}
 	}
+	return latestVersion
+}
 
-	// Create a NotePosition for the comment
-	latestVersion := versions[0]
-	diffPosition := &gitlab.PositionOptions{
-		StartSHA:     &latestVersion.StartCommitSHA,
-		HeadSHA:      &latestVersion.HeadCommitSHA,
-		BaseSHA:      &latestVersion.BaseCommitSHA,
+// getGitLabReviewCommentPosition returns the position of a review comment in the diff of a file.
+// GitLab rejects positions that don't match the diff, so both paths are always set, and the lines are set according to the type of the commented line:
+// - An added line has a new line only.
+// - An unchanged line, inside or outside the hunks of the diff, has a new line and the matching old line, which is shifted in files with previous changes.
+// - A line of a deleted file has an old line only.
+// The text of the diff of collapsed and too large files isn't returned, so the old line of the comment, or the same line, is assumed for their lines.
+func getGitLabReviewCommentPosition(diff *gitlab.MergeRequestDiff, version *gitlab.MergeRequestDiffVersion, commentDiff PullRequestDiff) (*gitlab.PositionOptions, error) {
+	position := &gitlab.PositionOptions{
+		BaseSHA:      &version.BaseCommitSHA,
+		StartSHA:     &version.StartCommitSHA,
+		HeadSHA:      &version.HeadCommitSHA,
 		PositionType: vcsutils.PointerOf("text"),
-		NewLine:      &newLine,
-		NewPath:      &newPath,
-		OldLine:      &newLine,
-		OldPath:      &oldPath,
+		NewPath:      &diff.NewPath,
+		OldPath:      &diff.OldPath,
 	}
-
-	// The GitLab REST API for creating a merge request discussion has strange behavior:
-	// If the API call is not constructed precisely according to these rules, it may fail with an unclear error.
-	// In all cases, 'new_path' and 'new_line' parameters are required.
-	// - When commenting on a new file, do not include 'old_path' and 'old_line' parameters.
-	// - When commenting on an existing file that has changed in the diff, omit 'old_path' and 'old_line' parameters.
-	// - When commenting on an existing file that hasn't changed in the diff, include 'old_path' and 'old_line' parameters.
-
-	client.logger.Debug(fmt.Sprintf("Create merge request discussion sent. newPath: %v newLine: %v oldPath: %v, oldLine: %v",
-		newPath, newLine, oldPath, newLine))
-	// Attempt to create a merge request discussion thread
-	_, _, err := client.createMergeRequestDiscussion(ctx, projectID, comment.Content, pullRequestID, diffPosition)
-
-	// Retry without oldLine and oldPath if the GitLab API call fails
-	if err != nil {
-		diffPosition.OldLine = nil
-		diffPosition.OldPath = nil
-		client.logger.Debug(fmt.Sprintf("Create merge request discussion second attempt sent. newPath: %v newLine: %v oldPath: %v, oldLine: %v",
-			newPath, newLine, oldPath, newLine))
-		_, _, err = client.createMergeRequestDiscussion(ctx, projectID, comment.Content, pullRequestID, diffPosition)
+	if diff.DeletedFile {
+		if commentDiff.OriginalStartLine <= 0 {
+			return nil, fmt.Errorf("could not comment on the deleted file %s without its original line", diff.OldPath)
+		}
+		position.OldLine = vcsutils.PointerOf(commentDiff.OriginalStartLine)
+		return position, nil
+	}
+	position.NewLine = vcsutils.PointerOf(commentDiff.NewStartLine)
+	if diff.NewFile {
+		return position, nil
+	}
+	if diff.Diff == "" {
+		position.OldLine = vcsutils.PointerOf(getGitLabAssumedOldLine(commentDiff))
+		return position, nil
 	}
+	if oldLine := mapUnifiedDiffNewLine(diff.Diff, commentDiff.NewStartLine); oldLine > 0 {
+		position.OldLine = &oldLine
+	}
+	return position, nil
+}
 
-	// If the comment creation still fails, return an error
-	if err != nil {
-		return fmt.Errorf("could not create a merge request discussion thread: %w", err)
+// getGitLabFallbackReviewCommentPosition returns the position of a review comment to retry with, after GitLab rejected its position.
+// A line positioned as unchanged is retried as an added line, and a line positioned as added is retried as unchanged.
+// Returns nil for the lines of new and deleted files, which have a single possible position.
+func getGitLabFallbackReviewCommentPosition(diff *gitlab.MergeRequestDiff, position *gitlab.PositionOptions, commentDiff PullRequestDiff) *gitlab.PositionOptions {
+	if diff.NewFile || diff.DeletedFile {
+		return nil
+	}
+	fallbackPosition := *position
+	if position.OldLine != nil {
+		fallbackPosition.OldLine = nil
+	} else {
+		fallbackPosition.OldLine = vcsutils.PointerOf(getGitLabAssumedOldLine(commentDiff))
 	}
+	return &fallbackPosition
+}
 
-	return nil
+// getGitLabAssumedOldLine returns the old line of a commented line whose diff is unknown: the original line of the comment, or the same line
+func getGitLabAssumedOldLine(commentDiff PullRequestDiff) int {
+	if commentDiff.OriginalStartLine > 0 {
+		return commentDiff.OriginalStartLine
+	}
+	return commentDiff.NewStartLine
 }
 
 func (client *GitLabClient) createMergeRequestDiscussion(ctx context.Context, projectID, content string, pullRequestID int, position *gitlab.PositionOptions) (*gitlab.Discussion, *gitlab.Response, error) {
@@ -440,6 +1348,7 @@ func (client *GitLabClient) createMergeRequestDiscussion(ctx context.Context, pr
 
 // ListPullRequestReviewComments on GitLab
 func (client *GitLabClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestReviewComments")
 	// Validate parameters
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
 		return nil, err
@@ -447,21 +1356,26 @@ func (client *GitLabClient) ListPullRequestReviewComments(ctx context.Context, o
 
 	projectID := getProjectID(owner, repository)
 
-	discussions, _, err := client.glClient.Discussions.ListMergeRequestDiscussions(projectID, pullRequestID, &gitlab.ListMergeRequestDiscussionsOptions{}, gitlab.WithContext(ctx))
-	if err != nil {
-		return nil, fmt.Errorf("failed fetching the list of merge requests discussions: %w", err)
-	}
-
+	// Each note keeps the ID of its discussion, which is required for replying to the discussion
 	var commentsInfo []CommentInfo
-	for _, discussion := range discussions {
-		commentsInfo = append(commentsInfo, mapGitLabNotesToCommentInfoList(discussion.Notes, discussion.ID)...)
+	for pageID := 1; ; pageID++ {
+		options := &gitlab.ListMergeRequestDiscussionsOptions{Page: pageID, PerPage: gitlabDiscussionsPageSize}
+		discussions, response, err := client.glClient.Discussions.ListMergeRequestDiscussions(projectID, pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching the list of merge requests discussions: %w", err)
+		}
+		for _, discussion := range discussions {
+			commentsInfo = append(commentsInfo, mapGitLabNotesToCommentInfoList(discussion.Notes, discussion.ID)...)
+		}
+		if pageID >= response.TotalPages {
+			return orderComments(client.vcsInfo, commentsInfo), nil
+		}
 	}
-
-	return commentsInfo, nil
 }
 
 // ListPullRequestComments on GitLab
 func (client *GitLabClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestComments")
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
 		return nil, err
 	}
@@ -470,39 +1384,146 @@ func (client *GitLabClient) ListPullRequestComments(ctx context.Context, owner,
 	if err != nil {
 		return []CommentInfo{}, err
 	}
-	return mapGitLabNotesToCommentInfoList(commentsList, ""), nil
+	return orderComments(client.vcsInfo, mapGitLabNotesToCommentInfoList(commentsList, "")), nil
 }
 
 // DeletePullRequestReviewComment on GitLab
 func (client *GitLabClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestReviewComments")
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
 		return err
 	}
-	for _, comment := range comments {
-		var commentID int64
-		if err := validateParametersNotBlank(map[string]string{"commentID": strconv.FormatInt(commentID, 10), "discussionID": comment.ThreadID}); err != nil {
-			return err
+	for _, comment := range comments {
+		var commentID int64
+		if err := validateParametersNotBlank(map[string]string{"commentID": strconv.FormatInt(commentID, 10), "discussionID": comment.ThreadID}); err != nil {
+			return err
+		}
+		if _, err := client.glClient.Discussions.DeleteMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, comment.ThreadID, int(commentID), gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("an error occurred while deleting pull request review comment: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeletePullRequestComment on GitLab
+func (client *GitLabClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
+	ctx = labelAPICalls(ctx, "DeletePullRequestComment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	if _, err := client.glClient.Notes.DeleteMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// UpdatePullRequestComment on GitLab
+func (client *GitLabClient) UpdatePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestComment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	options := &gitlab.UpdateMergeRequestNoteOptions{Body: &content}
+	if _, _, err := client.glClient.Notes.UpdateMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, options, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request comment: %w", err)
+	}
+	return nil
+}
+
+// UpdatePullRequestReviewComment on GitLab
+func (client *GitLabClient) UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error {
+	ctx = labelAPICalls(ctx, "UpdatePullRequestReviewComment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "discussionID": comment.ThreadID, "content": content}); err != nil {
+		return err
+	}
+	options := &gitlab.UpdateMergeRequestDiscussionNoteOptions{Body: &content}
+	if _, _, err := client.glClient.Discussions.UpdateMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, comment.ThreadID, int(comment.ID), options, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request review comment: %w", err)
+	}
+	return nil
+}
+
+// ReplyToPullRequestReviewComment on GitLab
+// The thread ID is the ID of the merge request discussion.
+func (client *GitLabClient) ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error {
+	ctx = labelAPICalls(ctx, "ReplyToPullRequestReviewComment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "discussionID": threadID, "content": content}); err != nil {
+		return err
+	}
+	options := &gitlab.AddMergeRequestDiscussionNoteOptions{Body: &content}
+	if _, _, err := client.glClient.Discussions.AddMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, threadID, options, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while replying to pull request review comment: %w", err)
+	}
+	return nil
+}
+
+// UploadPullRequestAttachment on GitLab
+// GitLab uploads files to the project. The file is listed in the merge request attachments once the returned markdown is added to the merge request description or to one of its comments.
+func (client *GitLabClient) UploadPullRequestAttachment(ctx context.Context, owner, repository string, _ int, fileName string, content []byte) (PullRequestAttachmentInfo, error) {
+	ctx = labelAPICalls(ctx, "UploadPullRequestAttachment")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "fileName": fileName}); err != nil {
+		return PullRequestAttachmentInfo{}, err
+	}
+	projectFile, _, err := client.glClient.Projects.UploadFile(getProjectID(owner, repository), bytes.NewReader(content), fileName, gitlab.WithContext(ctx))
+	if err != nil {
+		return PullRequestAttachmentInfo{}, fmt.Errorf("an error occurred while uploading pull request attachment: %w", err)
+	}
+	attachmentInfo := PullRequestAttachmentInfo{
+		Name:     projectFile.Alt,
+		URL:      strings.TrimSuffix(client.glClient.BaseURL().String(), "/api/v4/") + projectFile.FullPath,
+		Markdown: projectFile.Markdown,
+	}
+	return attachmentInfo, nil
+}
+
+// ListPullRequestAttachments on GitLab
+// The attachments are the uploaded files referenced in the merge request description and comments.
+func (client *GitLabClient) ListPullRequestAttachments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestAttachmentInfo, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestAttachments")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	contents := []string{mergeRequest.Description}
+	for pageID := 1; ; pageID++ {
+		options := &gitlab.ListMergeRequestNotesOptions{ListOptions: gitlab.ListOptions{Page: pageID, PerPage: gitlabNotesPageSize}}
+		notes, response, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
 		}
-		if _, err := client.glClient.Discussions.DeleteMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, comment.ThreadID, int(commentID), gitlab.WithContext(ctx)); err != nil {
-			return fmt.Errorf("an error occurred while deleting pull request review comment: %w", err)
+		for _, note := range notes {
+			contents = append(contents, note.Body)
+		}
+		if pageID >= response.TotalPages {
+			break
 		}
 	}
-	return nil
+	projectURL, _, _ := strings.Cut(mergeRequest.WebURL, "/-/merge_requests/")
+	return getGitLabUploadedAttachments(projectURL, contents...), nil
 }
 
-// DeletePullRequestComment on GitLab
-func (client *GitLabClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
-	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
-		return err
-	}
-	if _, err := client.glClient.Notes.DeleteMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, gitlab.WithContext(ctx)); err != nil {
-		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+// getGitLabUploadedAttachments extracts the links to files uploaded to the project from markdown contents
+func getGitLabUploadedAttachments(projectURL string, contents ...string) []PullRequestAttachmentInfo {
+	var attachments []PullRequestAttachmentInfo
+	uploadedFilesURLs := datastructures.MakeSet[string]()
+	for _, content := range contents {
+		for _, match := range gitlabUploadMarkdownRegexp.FindAllStringSubmatch(content, -1) {
+			if uploadedFilesURLs.Exists(match[2]) {
+				continue
+			}
+			uploadedFilesURLs.Add(match[2])
+			attachments = append(attachments, PullRequestAttachmentInfo{Name: match[1], URL: projectURL + match[2], Markdown: match[0]})
+		}
 	}
-	return nil
+	return attachments
 }
 
 // GetLatestCommit on GitLab
 func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLatestCommit")
 	commits, err := client.GetCommits(ctx, owner, repository, branch)
 	if err != nil {
 		return CommitInfo{}, err
@@ -517,6 +1538,7 @@ func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, reposito
 
 // GetCommits on GitLab
 func (client *GitLabClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommits")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -537,6 +1559,7 @@ func (client *GitLabClient) GetCommits(ctx context.Context, owner, repository, b
 }
 
 func (client *GitLabClient) GetCommitsWithQueryOptions(ctx context.Context, owner, repository string, listOptions GitCommitsQueryOptions) ([]CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitsWithQueryOptions")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -571,11 +1594,12 @@ func (client *GitLabClient) getCommitsWithQueryOptions(ctx context.Context, owne
 		commitInfo := mapGitLabCommitToCommitInfo(commit)
 		commitsInfo = append(commitsInfo, commitInfo)
 	}
-	return commitsInfo, nil
+	return orderCommits(client.vcsInfo, commitsInfo), nil
 }
 
 // GetRepositoryInfo on GitLab
 func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryInfo")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return RepositoryInfo{}, err
@@ -589,8 +1613,176 @@ func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, reposi
 	return RepositoryInfo{RepositoryVisibility: getGitLabProjectVisibility(project), CloneInfo: CloneInfo{HTTP: project.HTTPURLToRepo, SSH: project.SSHURLToRepo}}, nil
 }
 
+// ArchiveRepository on GitLab
+func (client *GitLabClient) ArchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "ArchiveRepository")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Projects.ArchiveProject(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	return err
+}
+
+// UnarchiveRepository on GitLab
+func (client *GitLabClient) UnarchiveRepository(ctx context.Context, owner, repository string) error {
+	ctx = labelAPICalls(ctx, "UnarchiveRepository")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Projects.UnarchiveProject(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	return err
+}
+
+// RenameRepository on GitLab renames both the name and the path of the project
+func (client *GitLabClient) RenameRepository(ctx context.Context, owner, repository, newName string) error {
+	ctx = labelAPICalls(ctx, "RenameRepository")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "newName": newName})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Projects.EditProject(getProjectID(owner, repository), &gitlab.EditProjectOptions{
+		Name: &newName,
+		Path: &newName,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetUserPermissionOnRepo on GitLab, considering the memberships inherited from the groups of the project
+func (client *GitLabClient) GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	ctx = labelAPICalls(ctx, "GetUserPermissionOnRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
+	}
+	users, _, err := client.glClient.Users.ListUsers(&gitlab.ListUsersOptions{Username: &username}, gitlab.WithContext(ctx))
+	if err != nil {
+		return NoPermission, err
+	}
+	if len(users) == 0 {
+		return NoPermission, fmt.Errorf("user %s wasn't found", username)
+	}
+	member, response, err := client.glClient.ProjectMembers.GetInheritedProjectMember(getProjectID(owner, repository), users[0].ID, gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return NoPermission, nil
+		}
+		return NoPermission, err
+	}
+	return mapGitLabAccessLevel(member.AccessLevel), nil
+}
+
+// ListRepositoryCollaborators on GitLab returns the members of the project. The members of all the collaborators include the members inherited from the ancestor groups.
+func (client *GitLabClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string, affiliation CollaboratorAffiliation) ([]CollaboratorInfo, error) {
+	ctx = labelAPICalls(ctx, "ListRepositoryCollaborators")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	affiliation, err := getCollaboratorAffiliation(affiliation)
+	if err != nil {
+		return nil, err
+	}
+	if affiliation == OutsideCollaborators {
+		return nil, errGitLabOutsideCollaboratorsNotSupported
+	}
+	var collaborators []CollaboratorInfo
+	options := &gitlab.ListProjectMembersOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for pageID := 1; ; pageID++ {
+		options.Page = pageID
+		var members []*gitlab.ProjectMember
+		var response *gitlab.Response
+		if affiliation == DirectCollaborators {
+			members, response, err = client.glClient.ProjectMembers.ListProjectMembers(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		} else {
+			members, response, err = client.glClient.ProjectMembers.ListAllProjectMembers(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			collaborators = append(collaborators, CollaboratorInfo{Username: member.Username, Permission: mapGitLabAccessLevel(member.AccessLevel)})
+		}
+		if response.NextPage == 0 {
+			sortCollaborators(collaborators)
+			return collaborators, nil
+		}
+	}
+}
+
+func mapGitLabAccessLevel(accessLevel gitlab.AccessLevelValue) RepositoryPermission {
+	switch {
+	case accessLevel >= gitlab.OwnerPermissions:
+		return AdminPermission
+	case accessLevel >= gitlab.MaintainerPermissions:
+		return MaintainPermission
+	case accessLevel >= gitlab.DeveloperPermissions:
+		return WritePermission
+	case accessLevel >= gitlab.GuestPermissions:
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetRepositoryMirrors on GitLab returns the push mirrors of the project
+func (client *GitLabClient) GetRepositoryMirrors(ctx context.Context, owner, repository string) ([]RepositoryMirrorInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryMirrors")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	mirrors, _, err := client.glClient.ProjectMirrors.ListProjectMirror(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]RepositoryMirrorInfo, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		results = append(results, RepositoryMirrorInfo{URL: mirror.URL, Enabled: mirror.Enabled, OnlyProtectedBranches: mirror.OnlyProtectedBranches})
+	}
+	return results, nil
+}
+
+// SetRepositoryMirror on GitLab.
+// The credentials in the URLs of the existing mirrors are masked, so the mirror to update is matched by its URL without the credentials.
+func (client *GitLabClient) SetRepositoryMirror(ctx context.Context, owner, repository string, mirror RepositoryMirrorInfo) error {
+	ctx = labelAPICalls(ctx, "SetRepositoryMirror")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "URL": mirror.URL}); err != nil {
+		return err
+	}
+	projectID := getProjectID(owner, repository)
+	mirrors, _, err := client.glClient.ProjectMirrors.ListProjectMirror(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	for _, existingMirror := range mirrors {
+		if removeURLCredentials(existingMirror.URL) == removeURLCredentials(mirror.URL) {
+			_, _, err = client.glClient.ProjectMirrors.EditProjectMirror(projectID, existingMirror.ID, &gitlab.EditProjectMirrorOptions{
+				Enabled:               &mirror.Enabled,
+				OnlyProtectedBranches: &mirror.OnlyProtectedBranches,
+			}, gitlab.WithContext(ctx))
+			return err
+		}
+	}
+	_, _, err = client.glClient.ProjectMirrors.AddProjectMirror(projectID, &gitlab.AddProjectMirrorOptions{
+		URL:                   &mirror.URL,
+		Enabled:               &mirror.Enabled,
+		OnlyProtectedBranches: &mirror.OnlyProtectedBranches,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// removeURLCredentials returns the URL without its user info
+func removeURLCredentials(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsedURL.User = nil
+	return parsedURL.String()
+}
+
 // GetCommitBySha on GitLab
 func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitBySha")
 	err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -607,8 +1799,63 @@ func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repositor
 	return mapGitLabCommitToCommitInfo(commit), nil
 }
 
+// GetCommitDiff on GitLab
+func (client *GitLabClient) GetCommitDiff(ctx context.Context, owner, repository, sha string) (CommitDiffInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCommitDiff")
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitDiffInfo{}, err
+	}
+
+	var diff strings.Builder
+	for pageID := 1; ; pageID++ {
+		options := &gitlab.GetCommitDiffOptions{ListOptions: gitlab.ListOptions{Page: pageID, PerPage: gitlabCommitDiffPageSize}}
+		fileDiffs, response, err := client.glClient.Commits.GetCommitDiff(getProjectID(owner, repository), sha, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return CommitDiffInfo{}, err
+		}
+		for _, fileDiff := range fileDiffs {
+			diff.WriteString(buildGitLabFileDiff(fileDiff))
+		}
+		if pageID >= response.TotalPages {
+			break
+		}
+	}
+	return CommitDiffInfo{Diff: diff.String(), Files: parseUnifiedDiffFiles(diff.String())}, nil
+}
+
+// GitLab returns the diff hunks of each file without the git diff headers, so the headers are added to build a git unified diff
+func buildGitLabFileDiff(fileDiff *gitlab.Diff) string {
+	var diff strings.Builder
+	diff.WriteString(fmt.Sprintf("diff --git a/%s b/%s\n", fileDiff.OldPath, fileDiff.NewPath))
+	oldPath, newPath := "a/"+fileDiff.OldPath, "b/"+fileDiff.NewPath
+	switch {
+	case fileDiff.NewFile:
+		diff.WriteString(fmt.Sprintf("new file mode %s\n", fileDiff.BMode))
+		oldPath = "/dev/null"
+	case fileDiff.DeletedFile:
+		diff.WriteString(fmt.Sprintf("deleted file mode %s\n", fileDiff.AMode))
+		newPath = "/dev/null"
+	case fileDiff.RenamedFile:
+		diff.WriteString(fmt.Sprintf("rename from %s\nrename to %s\n", fileDiff.OldPath, fileDiff.NewPath))
+	}
+	if fileDiff.Diff != "" {
+		diff.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldPath, newPath))
+		diff.WriteString(fileDiff.Diff)
+		if !strings.HasSuffix(fileDiff.Diff, "\n") {
+			diff.WriteString("\n")
+		}
+	}
+	return diff.String()
+}
+
 // CreateLabel on GitLab
 func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	ctx = labelAPICalls(ctx, "CreateLabel")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
 	if err != nil {
 		return err
@@ -625,6 +1872,7 @@ func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository s
 
 // GetLabel on GitLub
 func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	ctx = labelAPICalls(ctx, "GetLabel")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
 	if err != nil {
 		return nil, err
@@ -650,6 +1898,7 @@ func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, nam
 
 // ListPullRequestLabels on GitLab
 func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	ctx = labelAPICalls(ctx, "ListPullRequestLabels")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return []string{}, err
@@ -660,11 +1909,26 @@ func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, re
 		return []string{}, err
 	}
 
-	return mergeRequest.Labels, nil
+	return orderNames(client.vcsInfo, mergeRequest.Labels), nil
+}
+
+// LabelPullRequest on GitLab
+func (client *GitLabClient) LabelPullRequest(ctx context.Context, owner, repository, label string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "LabelPullRequest")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "label": label})
+	if err != nil {
+		return err
+	}
+	labels := gitlab.LabelOptions{label}
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
+		AddLabels: &labels,
+	}, gitlab.WithContext(ctx))
+	return err
 }
 
 // UnlabelPullRequest on GitLab
 func (client *GitLabClient) UnlabelPullRequest(ctx context.Context, owner, repository, label string, pullRequestID int) error {
+	ctx = labelAPICalls(ctx, "UnlabelPullRequest")
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return err
@@ -681,9 +1945,250 @@ func (client *GitLabClient) UploadCodeScanning(_ context.Context, _ string, _ st
 	return "", errGitLabCodeScanningNotSupported
 }
 
+// ListCodeScanningAlerts on GitLab lists the vulnerabilities found by the GitLab security scanners.
+// The REST API doesn't filter the vulnerabilities, so all of them are read, and the page is taken from the filtered vulnerabilities.
+func (client *GitLabClient) ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error) {
+	ctx = labelAPICalls(ctx, "ListCodeScanningAlerts")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	state := options.State
+	if state == "" {
+		state = AlertOpen
+	}
+	var alertsInfo []CodeScanningAlertInfo
+	for pageID := 1; ; pageID++ {
+		listOptions := &gitlab.ListProjectVulnerabilitiesOptions{ListOptions: gitlab.ListOptions{Page: pageID, PerPage: gitlabVulnerabilitiesPageSize}}
+		vulnerabilities, response, err := client.glClient.ProjectVulnerabilities.ListProjectVulnerabilities(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("an error occurred while listing the project vulnerabilities: %w", err)
+		}
+		for _, vulnerability := range vulnerabilities {
+			alertInfo := mapGitLabVulnerabilityToCodeScanningAlertInfo(vulnerability)
+			if alertInfo.State != state || (options.Severity != "" && !strings.EqualFold(alertInfo.Severity, options.Severity)) {
+				continue
+			}
+			alertsInfo = append(alertsInfo, alertInfo)
+		}
+		if pageID >= response.TotalPages {
+			return paginate(alertsInfo, options.ListOptions), nil
+		}
+	}
+}
+
+// GetCodeScanningAlert on GitLab gets a vulnerability found by the GitLab security scanners
+func (client *GitLabClient) GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error) {
+	ctx = labelAPICalls(ctx, "GetCodeScanningAlert")
+	vulnerability, err := client.getProjectVulnerability(ctx, owner, repository, alertID)
+	if err != nil {
+		return CodeScanningAlertInfo{}, err
+	}
+	return mapGitLabVulnerabilityToCodeScanningAlertInfo(vulnerability), nil
+}
+
+// getProjectVulnerability gets a vulnerability by its ID.
+// The vulnerabilities API isn't scoped to a project, so an error is returned if the vulnerability belongs to another project.
+func (client *GitLabClient) getProjectVulnerability(ctx context.Context, owner, repository string, alertID int64) (*gitlab.ProjectVulnerability, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// go-gitlab doesn't wrap the single vulnerability API
+	request, err := client.glClient.NewRequest(http.MethodGet, fmt.Sprintf("vulnerabilities/%d", alertID), nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	vulnerability := &gitlab.ProjectVulnerability{}
+	if _, err = client.glClient.Do(request, vulnerability); err != nil {
+		return nil, fmt.Errorf("an error occurred while getting vulnerability %d: %w", alertID, err)
+	}
+	if vulnerability.Project == nil || vulnerability.Project.ID != project.ID {
+		return nil, fmt.Errorf("vulnerability %d was not found in %s/%s", alertID, owner, repository)
+	}
+	return vulnerability, nil
+}
+
+// DismissCodeScanningAlert on GitLab dismisses a vulnerability found by the GitLab security scanners.
+// The REST API doesn't accept a reason and a comment, so they are ignored.
+func (client *GitLabClient) DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, _ AlertDismissalReason, _ string) error {
+	ctx = labelAPICalls(ctx, "DismissCodeScanningAlert")
+	if _, err := client.getProjectVulnerability(ctx, owner, repository, alertID); err != nil {
+		return err
+	}
+	// go-gitlab doesn't wrap the single vulnerability API
+	request, err := client.glClient.NewRequest(http.MethodPost, fmt.Sprintf("vulnerabilities/%d/dismiss", alertID), nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	if _, err = client.glClient.Do(request, nil); err != nil {
+		return fmt.Errorf("an error occurred while dismissing vulnerability %d: %w", alertID, err)
+	}
+	return nil
+}
+
+func mapGitLabVulnerabilityToCodeScanningAlertInfo(vulnerability *gitlab.ProjectVulnerability) CodeScanningAlertInfo {
+	alertInfo := CodeScanningAlertInfo{
+		ID:        int64(vulnerability.ID),
+		Title:     vulnerability.Title,
+		State:     getGitLabVulnerabilityAlertState(vulnerability.State),
+		Severity:  vulnerability.Severity,
+		ToolName:  vulnerability.ReportType,
+		CreatedAt: vcsutils.DefaultIfNotNil(vulnerability.CreatedAt),
+	}
+	if vulnerability.Project != nil && vulnerability.Project.WebURL != "" {
+		alertInfo.URL = fmt.Sprintf("%s/-/security/vulnerabilities/%d", vulnerability.Project.WebURL, vulnerability.ID)
+	}
+	switch alertInfo.State {
+	case AlertFixed:
+		alertInfo.ClosedAt = vcsutils.DefaultIfNotNil(vulnerability.ResolvedAt)
+	case AlertDismissed:
+		alertInfo.ClosedAt = vcsutils.DefaultIfNotNil(vulnerability.DismissedAt)
+	}
+	return alertInfo
+}
+
+// getGitLabVulnerabilityAlertState maps the GitLab vulnerability states - detected, confirmed, dismissed and resolved
+func getGitLabVulnerabilityAlertState(state string) CodeScanningAlertState {
+	switch state {
+	case "dismissed":
+		return AlertDismissed
+	case "resolved":
+		return AlertFixed
+	default:
+		return AlertOpen
+	}
+}
+
+// gitlabDependency is a dependency returned by the GitLab dependencies API, which isn't wrapped by go-gitlab
+type gitlabDependency struct {
+	Name               string                          `json:"name"`
+	Version            string                          `json:"version"`
+	PackageManager     string                          `json:"package_manager"`
+	DependencyFilePath string                          `json:"dependency_file_path"`
+	Vulnerabilities    []gitlabDependencyVulnerability `json:"vulnerabilities"`
+}
+
+type gitlabDependencyVulnerability struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	URL      string `json:"url"`
+}
+
+// ListVulnerabilityAlerts on GitLab lists the vulnerable dependencies found by dependency scanning
+func (client *GitLabClient) ListVulnerabilityAlerts(ctx context.Context, owner, repository string) ([]VulnerabilityAlertInfo, error) {
+	ctx = labelAPICalls(ctx, "ListVulnerabilityAlerts")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var alertsInfo []VulnerabilityAlertInfo
+	for pageID := 1; ; pageID++ {
+		request, err := client.glClient.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/dependencies", gitlab.PathEscape(getProjectID(owner, repository))),
+			&gitlab.ListOptions{Page: pageID}, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		var dependencies []gitlabDependency
+		response, err := client.glClient.Do(request, &dependencies)
+		if err != nil {
+			return nil, fmt.Errorf("an error occurred while listing the project dependencies: %w", err)
+		}
+		for _, dependency := range dependencies {
+			for _, vulnerability := range dependency.Vulnerabilities {
+				alertsInfo = append(alertsInfo, VulnerabilityAlertInfo{
+					ID:             vulnerability.ID,
+					PackageName:    dependency.Name,
+					Ecosystem:      dependency.PackageManager,
+					PackageVersion: dependency.Version,
+					Severity:       vulnerability.Severity,
+					Summary:        vulnerability.Name,
+					ManifestPath:   dependency.DependencyFilePath,
+					URL:            vulnerability.URL,
+				})
+			}
+		}
+		if pageID >= response.TotalPages {
+			return alertsInfo, nil
+		}
+	}
+}
+
 // GetRepositoryEnvironmentInfo on GitLab
-func (client *GitLabClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, errGitLabGetRepoEnvironmentInfoNotSupported
+// The reviewers are the approvers required by the approval rules of the protected environment.
+// An environment which isn't protected has no reviewers.
+func (client *GitLabClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	ctx = labelAPICalls(ctx, "GetRepositoryEnvironmentInfo")
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return RepositoryEnvironmentInfo{}, err
+	}
+	projectID := getProjectID(owner, repository)
+	environments, _, err := client.glClient.Environments.ListEnvironments(projectID, &gitlab.ListEnvironmentsOptions{Name: &name}, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryEnvironmentInfo{}, err
+	}
+	var environmentID int
+	for _, environment := range environments {
+		if environment.Name == name {
+			environmentID = environment.ID
+			break
+		}
+	}
+	if environmentID == 0 {
+		return RepositoryEnvironmentInfo{}, fmt.Errorf("environment %s wasn't found in %s", name, projectID)
+	}
+	environment, _, err := client.glClient.Environments.GetEnvironment(projectID, environmentID, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryEnvironmentInfo{}, err
+	}
+	reviewers, err := client.getEnvironmentReviewers(ctx, projectID, name)
+	if err != nil {
+		return RepositoryEnvironmentInfo{}, err
+	}
+	return RepositoryEnvironmentInfo{
+		Name:      environment.Name,
+		Url:       environment.ExternalURL,
+		Reviewers: reviewers,
+	}, nil
+}
+
+// CreateOrUpdateEnvironment on GitLab
+func (client *GitLabClient) CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error {
+	return errGitLabCreateOrUpdateEnvironmentNotSupported
+}
+
+// getEnvironmentReviewers returns the approvers of a protected environment.
+// Approval rules granted to a group are returned as teams, and approval rules granted to an access level as roles.
+func (client *GitLabClient) getEnvironmentReviewers(ctx context.Context, projectID, name string) ([]EnvironmentReviewer, error) {
+	protectedEnvironment, response, err := client.glClient.ProtectedEnvironments.GetProtectedEnvironment(projectID, name, gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return []EnvironmentReviewer{}, nil
+		}
+		return nil, err
+	}
+	reviewers := []EnvironmentReviewer{}
+	for _, rule := range protectedEnvironment.ApprovalRules {
+		switch {
+		case rule.UserID != 0:
+			user, _, err := client.glClient.Users.GetUser(rule.UserID, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			reviewers = append(reviewers, EnvironmentReviewer{Type: EnvironmentReviewerUser, Name: user.Username, ID: int64(rule.UserID)})
+		case rule.GroupID != 0:
+			reviewers = append(reviewers, EnvironmentReviewer{Type: EnvironmentReviewerTeam, Name: rule.AccessLevelDescription, ID: int64(rule.GroupID)})
+		default:
+			reviewers = append(reviewers, EnvironmentReviewer{Type: EnvironmentReviewerRole, Name: rule.AccessLevelDescription, ID: int64(rule.AccessLevel)})
+		}
+	}
+	return reviewers, nil
 }
 
 // DownloadFileFromRepo on GitLab
@@ -706,7 +2211,52 @@ func (client *GitLabClient) DownloadFileFromRepo(_ context.Context, owner, repos
 	return content, statusCode, err
 }
 
-func (client *GitLabClient) GetModifiedFiles(_ context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+// DownloadFileFromRepoStream on GitLab
+func (client *GitLabClient) DownloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) error {
+	ctx = labelAPICalls(ctx, "DownloadFileFromRepoStream")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "path": path}); err != nil {
+		return err
+	}
+	// The raw file API of go-gitlab buffers the file, so the request is sent directly, and its body is written to the writer
+	request, err := client.glClient.NewRequest(http.MethodGet,
+		fmt.Sprintf("projects/%s/repository/files/%s/raw", gitlab.PathEscape(getProjectID(owner, repository)), gitlab.PathEscape(path)),
+		&gitlab.GetRawFileOptions{Ref: &branch}, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Do(request, newLimitedWriter(writer, maxBytes))
+	return err
+}
+
+// FileExistsInRepo on GitLab
+func (client *GitLabClient) FileExistsInRepo(ctx context.Context, owner, repository, branch, path string) (bool, error) {
+	ctx = labelAPICalls(ctx, "FileExistsInRepo")
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "path": path}); err != nil {
+		return false, err
+	}
+	// The metadata of the file is returned in the headers of a HEAD request
+	_, response, err := client.glClient.RepositoryFiles.GetFileMetaData(getProjectID(owner, repository), path, &gitlab.GetFileMetaDataOptions{Ref: &branch}, gitlab.WithContext(ctx))
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (client *GitLabClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFiles")
+	modifiedFiles, err := client.GetModifiedFilesWithDetails(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	return getModifiedFileNames(modifiedFiles), nil
+}
+
+// GetModifiedFilesWithDetails on GitLab
+func (client *GitLabClient) GetModifiedFilesWithDetails(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error) {
+	ctx = labelAPICalls(ctx, "GetModifiedFilesWithDetails")
 	if err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
 		"repository": repository,
@@ -721,20 +2271,32 @@ func (client *GitLabClient) GetModifiedFiles(_ context.Context, owner, repositor
 	compare, _, err := client.glClient.Repositories.Compare(
 		getProjectID(owner, repository),
 		&gitlab.CompareOptions{From: &refBefore, To: &refAfter},
+		gitlab.WithContext(ctx),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	modifiedFiles := make([]ModifiedFileInfo, 0, len(compare.Diffs))
 	for _, diff := range compare.Diffs {
-		fileNamesSet.Add(diff.NewPath)
-		fileNamesSet.Add(diff.OldPath)
+		modifiedFile := ModifiedFileInfo{Path: diff.NewPath, Status: FileModified}
+		switch {
+		case diff.NewFile:
+			modifiedFile.Status = FileAdded
+		case diff.DeletedFile:
+			modifiedFile.Status = FileRemoved
+			modifiedFile.Path = diff.OldPath
+		case diff.RenamedFile || diff.OldPath != diff.NewPath:
+			modifiedFile.Status = FileRenamed
+			modifiedFile.PreviousPath = diff.OldPath
+		}
+		// The added and deleted lines are counted in the diff, which GitLab returns without the file headers
+		if files := parseUnifiedDiffFiles(buildGitLabFileDiff(diff)); len(files) == 1 {
+			modifiedFile.Additions, modifiedFile.Deletions = files[0].Additions, files[0].Deletions
+		}
+		modifiedFiles = append(modifiedFiles, modifiedFile)
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
+	return sortModifiedFiles(modifiedFiles), nil
 }
 
 func getProjectID(owner, project string) string {
@@ -788,6 +2350,7 @@ func mapGitLabCommitToCommitInfo(commit *gitlab.Commit) CommitInfo {
 		AuthorName:    commit.AuthorName,
 		CommitterName: commit.CommitterName,
 		Url:           commit.WebURL,
+		HTMLURL:       commit.WebURL,
 		Timestamp:     commit.CommittedDate.UTC().Unix(),
 		Message:       commit.Message,
 		ParentHashes:  commit.ParentIDs,
@@ -797,12 +2360,18 @@ func mapGitLabCommitToCommitInfo(commit *gitlab.Commit) CommitInfo {
 
 func mapGitLabNotesToCommentInfoList(notes []*gitlab.Note, discussionId string) (res []CommentInfo) {
 	for _, note := range notes {
-		res = append(res, CommentInfo{
+		commentInfo := CommentInfo{
 			ID:       int64(note.ID),
 			ThreadID: discussionId,
 			Content:  note.Body,
 			Created:  *note.CreatedAt,
-		})
+			Author:   note.Author.Username,
+		}
+		if note.Position != nil {
+			commentInfo.FilePath = note.Position.NewPath
+			commentInfo.Line = note.Position.NewLine
+		}
+		res = append(res, commentInfo)
 	}
 	return
 }
@@ -839,15 +2408,26 @@ func (client *GitLabClient) mapGitLabMergeRequestToPullRequestInfo(mergeRequest
 			Repository: repository,
 			Owner:      sourceOwner,
 		},
-		URL: mergeRequest.WebURL,
+		URL:     mergeRequest.WebURL,
+		HTMLURL: mergeRequest.WebURL,
 		Target: BranchInfo{
 			Name:       mergeRequest.TargetBranch,
 			Repository: repository,
 			Owner:      owner,
 		},
+		HeadCommitHash: mergeRequest.SHA,
+		Author:         getGitLabMergeRequestAuthor(mergeRequest),
+		UpdatedAt:      vcsutils.DefaultIfNotNil(mergeRequest.UpdatedAt),
 	}, nil
 }
 
+func getGitLabMergeRequestAuthor(mergeRequest *gitlab.MergeRequest) string {
+	if mergeRequest.Author == nil {
+		return ""
+	}
+	return mergeRequest.Author.Username
+}
+
 func (client *GitLabClient) getProjectOwnerByID(projectID int) (string, error) {
 	project, glResponse, err := client.glClient.Projects.GetProject(projectID, &gitlab.GetProjectOptions{})
 	if err != nil {
@@ -2,14 +2,31 @@ package vcsclient
 
 import (
 	"errors"
+	"regexp"
 )
 
 var errGitLabCodeScanningNotSupported = errors.New("code scanning is not supported on Gitlab")
-var errGitLabGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is currently not supported on Bitbucket")
+var errGitLabCheckRunsNotSupported = errors.New("check runs are not supported on Gitlab")
+var errGitLabRebaseMergeNotSupported = errors.New("merging by rebase is not supported on Gitlab, where the merge method is set by the project")
+var errGitLabBypassPoliciesNotSupported = errors.New("bypassing the merge checks is not supported on Gitlab")
+var errGitLabCreateOrUpdateEnvironmentNotSupported = errors.New("creating or updating environments is not supported on Gitlab")
+var errGitLabOutsideCollaboratorsNotSupported = errors.New("listing the outside collaborators is not supported on Gitlab, where the collaborators are the members of the project")
+var errGitLabWriteRepositoryDeployTokensNotSupported = errors.New("deploy tokens with the write repository scope are not supported on Gitlab, where pushing requires a deploy key or a project access token")
+
+// Matches markdown links to files uploaded to a GitLab project, such as [report.json](/uploads/<secret>/report.json)
+var gitlabUploadMarkdownRegexp = regexp.MustCompile(`!?\[([^\]]*)\]\((/uploads/[0-9a-f]+/[^)\s]+)\)`)
 
 const (
 	// https://docs.gitlab.com/ee/api/merge_requests.html#create-mr
 	gitlabMergeRequestDetailsSizeLimit = 1048576
 	// https://docs.gitlab.com/ee/api/notes.html#create-new-merge-request-note
 	gitlabMergeRequestCommentSizeLimit = 1000000
+	// https://docs.gitlab.com/ee/api/rest/index.html#pagination
+	gitlabDiscussionsPageSize = 100
+	// https://docs.gitlab.com/ee/api/rest/index.html#pagination
+	gitlabCommitDiffPageSize = 100
+	// https://docs.gitlab.com/ee/api/rest/index.html#pagination
+	gitlabNotesPageSize = 100
+	// https://docs.gitlab.com/ee/api/rest/index.html#pagination
+	gitlabVulnerabilitiesPageSize = 100
 )
@@ -0,0 +1,6 @@
+package vcsclient
+
+import "errors"
+
+// ErrGroupWebhooksNotSupported is returned by CreateGroupWebhook, UpdateGroupWebhook and DeleteGroupWebhook on the providers without webhooks of a group of repositories
+var ErrGroupWebhooksNotSupported = errors.New("group webhooks are not supported by the VCS provider, create a webhook for each of the repositories instead")
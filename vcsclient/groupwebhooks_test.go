@@ -0,0 +1,87 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupWebhooksGitLab(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/v4/groups/jfrog%2Fsecurity/hooks",
+			r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v4/groups/jfrog%2Fsecurity/hooks/7":
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "https://jfrog.com/webhook", body["url"])
+			assert.Equal(t, true, body["merge_requests_events"])
+			assert.Equal(t, true, body["push_events"])
+			assert.Equal(t, "main", body["push_events_branch_filter"])
+			assert.NotEmpty(t, body["token"])
+			_, err := w.Write([]byte(`{"id": 7}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodDelete && r.URL.EscapedPath() == "/api/v4/groups/jfrog%2Fsecurity/hooks/7":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	id, token, err := client.CreateGroupWebhook(ctx, "jfrog/security", "main", "https://jfrog.com/webhook", vcsutils.PrOpened, vcsutils.Push)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", id)
+	assert.NotEmpty(t, token)
+	assert.NoError(t, client.UpdateGroupWebhook(ctx, "jfrog/security", "main", "https://jfrog.com/webhook", token, id, vcsutils.PrOpened, vcsutils.Push))
+	assert.NoError(t, client.DeleteGroupWebhook(ctx, "jfrog/security", id))
+}
+
+func TestGroupWebhooksGitHub(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == "/orgs/jfrog/hooks":
+			var hook struct {
+				Events []string               `json:"events"`
+				Config map[string]interface{} `json:"config"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+			assert.Equal(t, []string{"pull_request"}, hook.Events)
+			assert.Equal(t, "https://jfrog.com/webhook", hook.Config["url"])
+			_, err := w.Write([]byte(`{"id": 8}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPatch && r.RequestURI == "/orgs/jfrog/hooks/8":
+			_, err := w.Write([]byte(`{"id": 8}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodDelete && r.RequestURI == "/orgs/jfrog/hooks/8":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	id, token, err := client.CreateGroupWebhook(ctx, owner, "", "https://jfrog.com/webhook", vcsutils.PrOpened)
+	assert.NoError(t, err)
+	assert.Equal(t, "8", id)
+	assert.NotEmpty(t, token)
+	assert.NoError(t, client.UpdateGroupWebhook(ctx, owner, "", "https://jfrog.com/webhook", token, id, vcsutils.PrOpened))
+	assert.NoError(t, client.DeleteGroupWebhook(ctx, owner, id))
+}
+
+func TestGroupWebhooksNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint("https://bitbucket.example.com").Build()
+	assert.NoError(t, err)
+	_, _, err = client.CreateGroupWebhook(context.Background(), owner, "", "https://jfrog.com/webhook", vcsutils.Push)
+	assert.ErrorIs(t, err, ErrGroupWebhooksNotSupported)
+	assert.ErrorIs(t, client.UpdateGroupWebhook(context.Background(), owner, "", "https://jfrog.com/webhook", "token", "1"), ErrGroupWebhooksNotSupported)
+	assert.ErrorIs(t, client.DeleteGroupWebhook(context.Background(), owner, "1"), ErrGroupWebhooksNotSupported)
+}
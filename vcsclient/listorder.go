@@ -0,0 +1,115 @@
+package vcsclient
+
+import (
+	"sort"
+)
+
+// The results of the list APIs are sorted into the orders documented by VcsClient, since each provider returns them in a different order.
+// The sorts are stable, so results the order doesn't distinguish keep the order of the provider.
+// Setting VcsInfo.ProviderNativeOrder keeps the order of the provider in all the results.
+
+// orderNames sorts names, such as branches, labels or file paths, lexicographically
+func orderNames(vcsInfo VcsInfo, names []string) []string {
+	if !vcsInfo.ProviderNativeOrder {
+		sort.Strings(names)
+	}
+	return names
+}
+
+// orderRepositories sorts the repositories of each owner lexicographically
+func orderRepositories(vcsInfo VcsInfo, repositories map[string][]string) map[string][]string {
+	for _, ownerRepositories := range repositories {
+		orderNames(vcsInfo, ownerRepositories)
+	}
+	return repositories
+}
+
+// orderRepositoryListings sorts the repositories by their owners, and then by their names
+func orderRepositoryListings(vcsInfo VcsInfo, repositories []RepositoryListing) []RepositoryListing {
+	if !vcsInfo.ProviderNativeOrder {
+		sort.SliceStable(repositories, func(i, j int) bool {
+			if repositories[i].Owner != repositories[j].Owner {
+				return repositories[i].Owner < repositories[j].Owner
+			}
+			return repositories[i].Name < repositories[j].Name
+		})
+	}
+	return repositories
+}
+
+// orderNamespaces sorts the namespaces by their names, after the personal namespace of the authenticated user
+func orderNamespaces(vcsInfo VcsInfo, namespaces []NamespaceInfo) []NamespaceInfo {
+	if !vcsInfo.ProviderNativeOrder {
+		sort.SliceStable(namespaces, func(i, j int) bool {
+			if isFirstUser, isSecondUser := namespaces[i].Kind == UserNamespace, namespaces[j].Kind == UserNamespace; isFirstUser != isSecondUser {
+				return isFirstUser
+			}
+			return namespaces[i].Name < namespaces[j].Name
+		})
+	}
+	return namespaces
+}
+
+// orderPullRequests sorts the pull requests by their IDs, from the newest to the oldest.
+// ListPullRequests is sorted by its options instead, by the API of the provider or by sortPullRequests.
+func orderPullRequests(vcsInfo VcsInfo, pullRequests []PullRequestInfo) []PullRequestInfo {
+	if !vcsInfo.ProviderNativeOrder {
+		sortPullRequests(pullRequests, PullRequestListOptions{})
+	}
+	return pullRequests
+}
+
+// orderIssues sorts the issues by their IDs, from the newest to the oldest
+func orderIssues(vcsInfo VcsInfo, issues []IssueInfo) []IssueInfo {
+	if !vcsInfo.ProviderNativeOrder {
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].ID > issues[j].ID
+		})
+	}
+	return issues
+}
+
+// orderComments sorts the comments by their threads, from the oldest thread to the newest, and then by their creation time and their IDs.
+// The threads are ordered by their first comments, so the replies follow the comments they reply to.
+func orderComments(vcsInfo VcsInfo, comments []CommentInfo) []CommentInfo {
+	if vcsInfo.ProviderNativeOrder {
+		return comments
+	}
+	threadsStart := make(map[string]CommentInfo)
+	for _, comment := range comments {
+		if first, found := threadsStart[comment.ThreadID]; comment.ThreadID != "" && (!found || isCommentBefore(comment, first)) {
+			threadsStart[comment.ThreadID] = comment
+		}
+	}
+	getThreadStart := func(comment CommentInfo) CommentInfo {
+		if first, found := threadsStart[comment.ThreadID]; found {
+			return first
+		}
+		return comment
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		firstThreadStart, secondThreadStart := getThreadStart(comments[i]), getThreadStart(comments[j])
+		if firstThreadStart.ID != secondThreadStart.ID || firstThreadStart.ThreadID != secondThreadStart.ThreadID {
+			return isCommentBefore(firstThreadStart, secondThreadStart)
+		}
+		return isCommentBefore(comments[i], comments[j])
+	})
+	return comments
+}
+
+func isCommentBefore(first, second CommentInfo) bool {
+	if !first.Created.Equal(second.Created) {
+		return first.Created.Before(second.Created)
+	}
+	return first.ID < second.ID
+}
+
+// orderCommits sorts the commits by their timestamps, from the newest to the oldest
+func orderCommits(vcsInfo VcsInfo, commits []CommitInfo) []CommitInfo {
+	if !vcsInfo.ProviderNativeOrder {
+		sort.SliceStable(commits, func(i, j int) bool {
+			return commits[i].Timestamp > commits[j].Timestamp
+		})
+	}
+	return commits
+}
@@ -0,0 +1,60 @@
+package vcsclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderNames(t *testing.T) {
+	assert.Equal(t, []string{"dev", "feature/a", "main"}, orderNames(VcsInfo{}, []string{"main", "feature/a", "dev"}))
+	assert.Equal(t, []string{"main", "feature/a", "dev"}, orderNames(VcsInfo{ProviderNativeOrder: true}, []string{"main", "feature/a", "dev"}))
+}
+
+func TestOrderRepositories(t *testing.T) {
+	repositories := orderRepositories(VcsInfo{}, map[string][]string{"jfrog": {"repo-2", "repo-1"}, "frogs": {"b", "a"}})
+	assert.Equal(t, map[string][]string{"jfrog": {"repo-1", "repo-2"}, "frogs": {"a", "b"}}, repositories)
+
+	listings := orderRepositoryListings(VcsInfo{}, []RepositoryListing{{Owner: "jfrog", Name: "b"}, {Owner: "frogs", Name: "c"}, {Owner: "jfrog", Name: "a"}})
+	assert.Equal(t, []RepositoryListing{{Owner: "frogs", Name: "c"}, {Owner: "jfrog", Name: "a"}, {Owner: "jfrog", Name: "b"}}, listings)
+}
+
+func TestOrderNamespaces(t *testing.T) {
+	namespaces := orderNamespaces(VcsInfo{}, []NamespaceInfo{{Name: "jfrog", Kind: OrganizationNamespace}, {Name: "~FROGGER", Kind: UserNamespace}, {Name: "frogs", Kind: OrganizationNamespace}})
+	assert.Equal(t, []NamespaceInfo{{Name: "~FROGGER", Kind: UserNamespace}, {Name: "frogs", Kind: OrganizationNamespace}, {Name: "jfrog", Kind: OrganizationNamespace}}, namespaces)
+}
+
+func TestOrderPullRequestsAndIssues(t *testing.T) {
+	pullRequests := orderPullRequests(VcsInfo{}, []PullRequestInfo{{ID: 2}, {ID: 3}, {ID: 1}})
+	assert.Equal(t, []PullRequestInfo{{ID: 3}, {ID: 2}, {ID: 1}}, pullRequests)
+	pullRequests = orderPullRequests(VcsInfo{ProviderNativeOrder: true}, []PullRequestInfo{{ID: 2}, {ID: 3}, {ID: 1}})
+	assert.Equal(t, []PullRequestInfo{{ID: 2}, {ID: 3}, {ID: 1}}, pullRequests)
+
+	issues := orderIssues(VcsInfo{}, []IssueInfo{{ID: 1}, {ID: 3}, {ID: 2}})
+	assert.Equal(t, []IssueInfo{{ID: 3}, {ID: 2}, {ID: 1}}, issues)
+}
+
+func TestOrderComments(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	comments := orderComments(VcsInfo{}, []CommentInfo{
+		{ID: 4, ThreadID: "2", Created: start.Add(3 * time.Hour)},
+		{ID: 2, ThreadID: "2", Created: start.Add(time.Hour)},
+		{ID: 3, ThreadID: "1", Created: start.Add(2 * time.Hour)},
+		{ID: 1, ThreadID: "1", Created: start},
+		{ID: 6, Created: start.Add(90 * time.Minute)},
+		{ID: 5, Created: start.Add(90 * time.Minute)},
+	})
+	var ids []int64
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	// The replies follow the first comments of their threads
+	assert.Equal(t, []int64{1, 3, 2, 4, 5, 6}, ids)
+}
+
+func TestOrderCommits(t *testing.T) {
+	commits := orderCommits(VcsInfo{}, []CommitInfo{{Hash: "a", Timestamp: 1}, {Hash: "b", Timestamp: 3}, {Hash: "c", Timestamp: 1}, {Hash: "d", Timestamp: 2}})
+	// Commits with equal timestamps keep the order of the provider
+	assert.Equal(t, []CommitInfo{{Hash: "b", Timestamp: 3}, {Hash: "d", Timestamp: 2}, {Hash: "a", Timestamp: 1}, {Hash: "c", Timestamp: 1}}, commits)
+}
@@ -0,0 +1,88 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+type apiCallMethodKey struct{}
+
+// Metrics receives the measurements of the API calls sent to the VCS provider, for example to export them as Prometheus metrics.
+// It's called from the goroutines sending the calls, so it should be safe for concurrent use.
+type Metrics interface {
+	ObserveAPICall(call APICall)
+}
+
+// APICall is the measurement of a single HTTP request sent to the VCS provider.
+// A client method may send multiple API calls, such as the pages of a list, or the retries of a rejected request.
+type APICall struct {
+	Provider vcsutils.VcsProvider
+	// The name of the client method sending the request, such as ListRepositories, or the label set on its context by WithAPICallMethod
+	Method string
+	// The HTTP method of the request
+	HTTPMethod string
+	// The status code of the response, or 0 if no response was received
+	StatusCode int
+	Latency    time.Duration
+	// The remaining rate limit returned by the VCS provider, or -1 if it wasn't returned
+	RateLimitRemaining int
+}
+
+// metricsTransport reports the API calls it sends to the metrics
+type metricsTransport struct {
+	// The transport sending the requests, or http.DefaultTransport if nil
+	http.RoundTripper
+	provider vcsutils.VcsProvider
+	metrics  Metrics
+}
+
+func (transport *metricsTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	start := time.Now()
+	response, err := roundTripper.RoundTrip(request)
+	call := APICall{
+		Provider:           transport.provider,
+		Method:             getAPICallMethod(request.Context()),
+		HTTPMethod:         request.Method,
+		Latency:            time.Since(start),
+		RateLimitRemaining: -1,
+	}
+	if err == nil {
+		call.StatusCode = response.StatusCode
+		if remaining, parseErr := strconv.Atoi(vcsutils.GetRateLimitRemaining(response.Header)); parseErr == nil {
+			call.RateLimitRemaining = remaining
+		}
+	}
+	transport.metrics.ObserveAPICall(call)
+	return response, err
+}
+
+// WithAPICallMethod returns a context labeling the API calls sent with it, by the name of the operation sending them.
+// The label is reported to the metrics as the Method of the calls, instead of the name of the client method sending them.
+// Note that the Bitbucket Cloud SDK doesn't send the requests with their context, so its calls are reported without a label,
+// except for the calls the client sends by itself.
+func WithAPICallMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, apiCallMethodKey{}, method)
+}
+
+// getAPICallMethod returns the label set on the context by WithAPICallMethod, or an empty string if it wasn't set
+func getAPICallMethod(ctx context.Context) string {
+	method, _ := ctx.Value(apiCallMethodKey{}).(string)
+	return method
+}
+
+// labelAPICalls labels the API calls of a client method by its name. The calls already labeled, by WithAPICallMethod
+// or by another client method calling the method, keep their label.
+func labelAPICalls(ctx context.Context, method string) context.Context {
+	if getAPICallMethod(ctx) != "" {
+		return ctx
+	}
+	return WithAPICallMethod(ctx, method)
+}
@@ -0,0 +1,112 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingMetrics records the observed API calls
+type recordingMetrics struct {
+	mutex sync.Mutex
+	calls []APICall
+}
+
+func (metrics *recordingMetrics) ObserveAPICall(call APICall) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.calls = append(metrics.calls, call)
+}
+
+func TestClientBuilderMetrics(t *testing.T) {
+	tests := []struct {
+		vcsProvider   vcsutils.VcsProvider
+		response      interface{}
+		expectedURI   string
+		createHandler createHandlerFunc
+		basicAuth     bool
+	}{
+		{vcsProvider: vcsutils.GitHub, response: "It's Not Easy Being Green", expectedURI: "/zen", createHandler: createGitHubHandler},
+		{vcsProvider: vcsutils.GitLab, response: []interface{}{}, expectedURI: "/api/v4/projects", createHandler: createGitLabHandler},
+		{vcsProvider: vcsutils.BitbucketServer, response: map[string]interface{}{}, expectedURI: "/rest/api/1.0/admin/users?limit=1", createHandler: createBitbucketServerHandler},
+		{vcsProvider: vcsutils.BitbucketCloud, response: map[string]interface{}{}, expectedURI: "/user", createHandler: createBitbucketCloudHandler, basicAuth: true},
+		{vcsProvider: vcsutils.AzureRepos, response: "", expectedURI: "", createHandler: createAzureReposHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vcsProvider.String(), func(t *testing.T) {
+			response, err := json.Marshal(tt.response)
+			assert.NoError(t, err)
+			server := httptest.NewServer(tt.createHandler(t, tt.expectedURI, response, http.StatusOK))
+			defer server.Close()
+
+			metrics := &recordingMetrics{}
+			clientBuilder := NewClientBuilder(tt.vcsProvider).ApiEndpoint(server.URL).Token(token).Metrics(metrics)
+			if tt.basicAuth {
+				clientBuilder = clientBuilder.Username(username)
+			}
+			client, err := clientBuilder.Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotEmpty(t, metrics.calls)
+			expectedMethod := "TestConnection"
+			if tt.vcsProvider == vcsutils.BitbucketCloud {
+				// The requests of the Bitbucket Cloud SDK are sent without their context
+				expectedMethod = ""
+			}
+			for _, call := range metrics.calls {
+				assert.Equal(t, tt.vcsProvider, call.Provider)
+				assert.Equal(t, expectedMethod, call.Method)
+				assert.Equal(t, http.StatusOK, call.StatusCode)
+				assert.NotEmpty(t, call.HTTPMethod)
+				assert.Equal(t, -1, call.RateLimitRemaining)
+				assert.Positive(t, call.Latency)
+			}
+		})
+	}
+}
+
+func TestLabelAPICalls(t *testing.T) {
+	ctx := labelAPICalls(context.Background(), "GetPullRequestByID")
+	assert.Equal(t, "GetPullRequestByID", getAPICallMethod(ctx))
+	// The label of the calling client method, or of the caller, is kept
+	assert.Equal(t, "GetPullRequestByID", getAPICallMethod(labelAPICalls(ctx, "GetCommitBySha")))
+	assert.Equal(t, "Scan", getAPICallMethod(labelAPICalls(WithAPICallMethod(context.Background(), "Scan"), "GetCommitBySha")))
+}
+
+func TestMetricsTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4998")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	metrics := &recordingMetrics{}
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).Metrics(metrics).Build()
+	assert.NoError(t, err)
+
+	// The calls are labeled by the operation set on the context, rather than by the client method
+	_, err = client.UploadCodeScanning(WithAPICallMethod(context.Background(), "Scan"), owner, repo1, "master", "{}")
+	assert.Error(t, err)
+	assert.Equal(t, []APICall{{
+		Provider:           vcsutils.GitHub,
+		Method:             "Scan",
+		HTTPMethod:         http.MethodGet,
+		StatusCode:         http.StatusNotFound,
+		Latency:            metrics.calls[0].Latency,
+		RateLimitRemaining: 4998,
+	}}, metrics.calls)
+
+	// Calls which failed without a response are reported without a status
+	metrics.calls = nil
+	server.Close()
+	_, err = client.GetRepositoryInfo(context.Background(), owner, repo1)
+	assert.Error(t, err)
+	assert.Len(t, metrics.calls, 1)
+	assert.Equal(t, "GetRepositoryInfo", metrics.calls[0].Method)
+	assert.Zero(t, metrics.calls[0].StatusCode)
+}
@@ -0,0 +1,41 @@
+package vcsclient
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrMilestonesNotSupported is returned by ListMilestones and SetPullRequestMilestone on the providers without milestones
+var ErrMilestonesNotSupported = errors.New("milestones are not supported by the VCS provider")
+
+// MilestoneState is the state of a milestone. The provider specific states are normalized to open and closed.
+type MilestoneState string
+
+const (
+	MilestoneOpen   MilestoneState = "open"
+	MilestoneClosed MilestoneState = "closed"
+)
+
+// MilestoneInfo contains the details of a milestone of a repository
+type MilestoneInfo struct {
+	// The number of the milestone on GitHub, and its global ID on GitLab, which identifies it in SetPullRequestMilestone
+	ID          int64
+	Title       string
+	Description string
+	State       MilestoneState
+	// The due date of the milestone, or nil if it has none
+	DueDate *time.Time
+	URL     string
+}
+
+// sortMilestonesByDueDate sorts milestones by their due dates, from the earliest, followed by the milestones without a due date
+func sortMilestonesByDueDate(milestones []MilestoneInfo) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		first, second := milestones[i].DueDate, milestones[j].DueDate
+		if first == nil || second == nil {
+			return first != nil && second == nil
+		}
+		return first.Before(*second)
+	})
+}
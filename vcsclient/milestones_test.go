@@ -0,0 +1,131 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMilestonesGitHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/milestones", r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		var response string
+		if r.URL.Query().Get("page") == "2" {
+			response = `[{"number": 3, "title": "v1.2.0", "state": "open", "due_on": "2026-11-01T07:00:00Z"}]`
+		} else {
+			w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+			response = `[{"number": 1, "title": "Backlog", "state": "open"},
+				{"number": 2, "title": "v1.3.0", "description": "Next release", "state": "open", "due_on": "2026-12-01T08:00:00Z",
+				"html_url": "https://github.com/jfrog/repo-1/milestone/2"}]`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	milestones, err := client.ListMilestones(context.Background(), owner, repo1, "")
+	assert.NoError(t, err)
+	if assert.Len(t, milestones, 3) {
+		// The milestones are sorted by their due dates, followed by the milestones without a due date
+		assert.Equal(t, []int64{3, 2, 1}, []int64{milestones[0].ID, milestones[1].ID, milestones[2].ID})
+		assert.Equal(t, MilestoneInfo{
+			ID:          2,
+			Title:       "v1.3.0",
+			Description: "Next release",
+			State:       MilestoneOpen,
+			DueDate:     vcsutils.PointerOf(time.Date(2026, 12, 1, 8, 0, 0, 0, time.UTC)),
+			URL:         "https://github.com/jfrog/repo-1/milestone/2",
+		}, milestones[1])
+		assert.Nil(t, milestones[2].DueDate)
+	}
+
+	_, err = createBadGitHubClient(t).ListMilestones(context.Background(), owner, repo1, MilestoneOpen)
+	assert.Error(t, err)
+}
+
+func TestSetPullRequestMilestoneGitHub(t *testing.T) {
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/repos/jfrog/repo-1/issues/1", r.RequestURI)
+		requestBody = nil
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestBody))
+		_, err := w.Write([]byte(`{"number": 1}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	assert.NoError(t, client.SetPullRequestMilestone(context.Background(), owner, repo1, 1, 2))
+	assert.Equal(t, map[string]interface{}{"milestone": float64(2)}, requestBody)
+
+	assert.NoError(t, client.SetPullRequestMilestone(context.Background(), owner, repo1, 1, 0))
+	assert.Equal(t, map[string]interface{}{"milestone": nil}, requestBody)
+}
+
+func TestListMilestonesGitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/jfrog%2Frepo-1/milestones", r.URL.EscapedPath())
+		assert.Equal(t, "closed", r.URL.Query().Get("state"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_parent_milestones"))
+		_, err := w.Write([]byte(`[{"id": 12, "iid": 3, "title": "v1.0.0", "state": "closed",
+			"web_url": "https://gitlab.com/jfrog/repo-1/-/milestones/3"},
+			{"id": 11, "iid": 2, "title": "v0.9.0", "description": "Beta", "state": "closed", "due_date": "2026-06-30"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	milestones, err := client.ListMilestones(context.Background(), owner, repo1, MilestoneClosed)
+	assert.NoError(t, err)
+	assert.Equal(t, []MilestoneInfo{{
+		ID:          11,
+		Title:       "v0.9.0",
+		Description: "Beta",
+		State:       MilestoneClosed,
+		DueDate:     vcsutils.PointerOf(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)),
+	}, {
+		ID:    12,
+		Title: "v1.0.0",
+		State: MilestoneClosed,
+		URL:   "https://gitlab.com/jfrog/repo-1/-/milestones/3",
+	}}, milestones)
+}
+
+func TestSetPullRequestMilestoneGitLab(t *testing.T) {
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.True(t, strings.HasPrefix(r.URL.EscapedPath(), "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1"))
+		requestBody = nil
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestBody))
+		_, err := w.Write([]byte(`{"iid": 1}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	assert.NoError(t, client.SetPullRequestMilestone(context.Background(), owner, repo1, 1, 12))
+	assert.Equal(t, map[string]interface{}{"milestone_id": float64(12)}, requestBody)
+
+	// A zero milestone ID unassigns the milestone
+	assert.NoError(t, client.SetPullRequestMilestone(context.Background(), owner, repo1, 1, 0))
+	assert.Equal(t, map[string]interface{}{"milestone_id": float64(0)}, requestBody)
+}
+
+func TestMilestonesNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.ListMilestones(context.Background(), owner, repo1, MilestoneOpen)
+	assert.ErrorIs(t, err, ErrMilestonesNotSupported)
+	assert.ErrorIs(t, client.SetPullRequestMilestone(context.Background(), owner, repo1, 1, 2), ErrMilestonesNotSupported)
+}
@@ -0,0 +1,47 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The interval between two polls of an asynchronous update of a pull request branch
+const pullRequestBranchUpdatePollInterval = time.Second
+
+// ErrPullRequestBranchUpdateNotSupported is returned by UpdatePullRequestBranch on the providers which can't update a pull request branch on the server
+var ErrPullRequestBranchUpdateNotSupported = errors.New("updating a pull request branch is not supported by the VCS provider")
+
+// PullRequestBranchConflictError is returned by UpdatePullRequestBranch if the target branch can't be merged into the source branch,
+// or the source branch can't be rebased onto the target branch, because of conflicts. The conflicts have to be resolved locally.
+type PullRequestBranchConflictError struct {
+	PullRequestID int
+	// The reason returned by the VCS provider, if any
+	Message string
+}
+
+func (e *PullRequestBranchConflictError) Error() string {
+	message := fmt.Sprintf("the branch of pull request %d can't be updated with its target branch because of conflicts", e.PullRequestID)
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	return message
+}
+
+// waitForPullRequestBranchUpdate polls an asynchronous update of a pull request branch, until isDone returns true
+func waitForPullRequestBranchUpdate(ctx context.Context, pullRequestID int, isDone func() (bool, error)) error {
+	for {
+		done, err := isDone()
+		if err != nil || done {
+			return err
+		}
+		timer := time.NewTimer(pullRequestBranchUpdatePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("stopped waiting for the update of the branch of pull request %d: %w", pullRequestID, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
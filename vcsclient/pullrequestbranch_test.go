@@ -0,0 +1,164 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePullRequestBranchGitHub(t *testing.T) {
+	var statusCode int
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/repos/jfrog/repo-1/pulls/1/update-branch", r.RequestURI)
+		w.WriteHeader(statusCode)
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	statusCode, response = http.StatusAccepted, `{"message": "Updating pull request branch.", "url": "https://github.com/repos/jfrog/repo-1/pulls/1"}`
+	assert.NoError(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1))
+
+	statusCode, response = http.StatusUnprocessableEntity, `{"message": "There are no new commits on the base branch."}`
+	assert.NoError(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1))
+
+	statusCode, response = http.StatusUnprocessableEntity, `{"message": "merge conflict between base and head"}`
+	err := client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1)
+	var conflictErr *PullRequestBranchConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Equal(t, 1, conflictErr.PullRequestID)
+		assert.Equal(t, "merge conflict between base and head", conflictErr.Message)
+	}
+
+	statusCode, response = http.StatusForbidden, `{"message": "Resource not accessible by integration"}`
+	err = client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &conflictErr))
+}
+
+func TestUpdatePullRequestBranchGitLab(t *testing.T) {
+	var requests []string
+	var hasConflicts bool
+	var mergeError string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.URL.EscapedPath(), "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1"))
+		var response string
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(t, "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/rebase", r.URL.EscapedPath())
+			requests = append(requests, "rebase")
+			w.WriteHeader(http.StatusAccepted)
+			response = `{"rebase_in_progress": true}`
+		default:
+			requests = append(requests, "get "+r.URL.RawQuery)
+			response = `{"iid": 1, "rebase_in_progress": false, "has_conflicts": ` + strconv.FormatBool(hasConflicts) + `, "merge_error": "` + mergeError + `"}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	assert.NoError(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1))
+	assert.Equal(t, []string{"get ", "rebase", "get include_rebase_in_progress=true"}, requests)
+
+	mergeError = "Rebase failed: Rebase locally, resolve all conflicts, then push the branch."
+	var conflictErr *PullRequestBranchConflictError
+	if assert.ErrorAs(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1), &conflictErr) {
+		assert.Equal(t, mergeError, conflictErr.Message)
+	}
+
+	// A merge request with conflicts isn't rebased
+	requests = nil
+	hasConflicts = true
+	assert.ErrorAs(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1), &conflictErr)
+	assert.Equal(t, []string{"get "}, requests)
+}
+
+func TestUpdatePullRequestBranchAzureRepos(t *testing.T) {
+	const sourceHead, targetHead, mergeCommit = "1111111111111111111111111111111111111111", "2222222222222222222222222222222222222222", "3333333333333333333333333333333333333333"
+	var mergeBase, mergeStatus string
+	var refUpdates []git.GitRefUpdate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.Contains(r.URL.Path, "/getPullRequests/1"):
+			response = `{"pullRequestId": 1, "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main"}`
+		case strings.HasSuffix(r.URL.Path, "/getCommits"):
+			head := sourceHead
+			if r.URL.Query().Get("searchCriteria.itemVersion.version") == "main" {
+				head = targetHead
+			}
+			response = `{"count": 1, "value": [{"commitId": "` + head + `"}]}`
+		case strings.HasSuffix(r.URL.Path, "/mergeBases"):
+			assert.Equal(t, "/_apis/ResourceAreas/commits/"+targetHead+"/mergeBases", r.URL.Path)
+			assert.Equal(t, sourceHead, r.URL.Query().Get("otherCommitId"))
+			response = `{"count": 1, "value": [{"commitId": "` + mergeBase + `"}]}`
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/merges"):
+			var mergeParameters git.GitMergeParameters
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&mergeParameters))
+			assert.Equal(t, []string{sourceHead, targetHead}, *mergeParameters.Parents)
+			assert.Equal(t, "Merge branch 'main' into feature", *mergeParameters.Comment)
+			response = `{"mergeOperationId": 7, "status": "queued"}`
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/merges/7"):
+			response = `{"mergeOperationId": 7, "status": "` + mergeStatus + `", "detailedStatus": {"mergeCommitId": "` + mergeCommit + `", "failureMessage": "The merge has conflicts"}}`
+		case strings.HasSuffix(r.URL.Path, "/refs"):
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&refUpdates))
+			response = `{"count": 1, "value": [{"name": "refs/heads/feature", "success": true}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	// The target branch is already merged into the source branch
+	mergeBase = targetHead
+	assert.NoError(t, client.UpdatePullRequestBranch(context.Background(), "", repo1, 1))
+	assert.Empty(t, refUpdates)
+
+	mergeBase, mergeStatus = "0000000000000000000000000000000000000000", "completed"
+	assert.NoError(t, client.UpdatePullRequestBranch(context.Background(), "", repo1, 1))
+	if assert.Len(t, refUpdates, 1) {
+		assert.Equal(t, "refs/heads/feature", *refUpdates[0].Name)
+		assert.Equal(t, sourceHead, *refUpdates[0].OldObjectId)
+		assert.Equal(t, mergeCommit, *refUpdates[0].NewObjectId)
+	}
+
+	refUpdates = nil
+	mergeStatus = "failed"
+	var conflictErr *PullRequestBranchConflictError
+	if assert.ErrorAs(t, client.UpdatePullRequestBranch(context.Background(), "", repo1, 1), &conflictErr) {
+		assert.Equal(t, "The merge has conflicts", conflictErr.Message)
+	}
+	assert.Empty(t, refUpdates)
+}
+
+func TestUpdatePullRequestBranchNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint("https://bitbucket.example.com").Build()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, client.UpdatePullRequestBranch(context.Background(), owner, repo1, 1), ErrPullRequestBranchUpdateNotSupported)
+}
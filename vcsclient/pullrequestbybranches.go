@@ -0,0 +1,36 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned, wrapped, when a looked up resource doesn't exist
+var ErrNotFound = errors.New("not found")
+
+// GetPullRequestInfoBySourceTarget returns the open pull request from the source branch into the target branch,
+// for flows updating the existing pull request of a branch instead of creating another one.
+// The pull requests are filtered by the branches using ListOpenPullRequestsWithQueryOptions, so they are filtered by the VCS provider when supported.
+// If several open pull requests match, the first listed one is returned.
+// Returns an error wrapping ErrNotFound if there is no open pull request between the branches.
+func GetPullRequestInfoBySourceTarget(ctx context.Context, client VcsClient, owner, repository, sourceBranch, targetBranch string) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"sourceBranch": sourceBranch, "targetBranch": targetBranch})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequests, err := client.ListOpenPullRequestsWithQueryOptions(ctx, owner, repository, PullRequestsQueryOptions{
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+		WithBody:     true,
+	})
+	if err != nil {
+		return PullRequestInfo{}, fmt.Errorf("failed to list the open pull requests: %w", err)
+	}
+	for _, pullRequest := range pullRequests {
+		if pullRequest.Source.Name == sourceBranch && pullRequest.Target.Name == targetBranch {
+			return pullRequest, nil
+		}
+	}
+	return PullRequestInfo{}, fmt.Errorf("open pull request from %s into %s in %s: %w", sourceBranch, targetBranch, repository, ErrNotFound)
+}
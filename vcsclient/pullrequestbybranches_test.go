@@ -0,0 +1,40 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPullRequestInfoBySourceTarget(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/pulls", r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		response := "[]"
+		if r.URL.Query().Get("head") == "jfrog:frogbot-update" && r.URL.Query().Get("base") == "main" {
+			response = `[{"number": 7, "body": "Upgrade lodash",
+				"head": {"ref": "frogbot-update", "label": "jfrog:frogbot-update", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}},
+				"base": {"ref": "main", "label": "jfrog:main", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}}]`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	pullRequest, err := GetPullRequestInfoBySourceTarget(ctx, client, owner, repo1, "frogbot-update", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), pullRequest.ID)
+	assert.Equal(t, "Upgrade lodash", pullRequest.Body)
+
+	_, err = GetPullRequestInfoBySourceTarget(ctx, client, owner, repo1, "frogbot-update", "release")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = GetPullRequestInfoBySourceTarget(ctx, client, owner, repo1, "", "main")
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrCommitRangePullRequestsNotSupported is returned by ListCommitsBetween, ListMergedPullRequestsOfCommit and GetPullRequestsByCommitRange
+// on the providers which can't look up the pull requests of a commit
+var ErrCommitRangePullRequestsNotSupported = errors.New("getting the pull requests of a commit range is not supported by the VCS provider")
+
+// GetPullRequestsByCommitRange returns the pull requests merged between two commits, such as the commits of two releases.
+// The pull requests of each of the commits reachable from toRef but not from fromRef are looked up, at most concurrency commits at a time.
+// A non-positive concurrency defaults to 5. Pull requests whose merge commit isn't in the range, such as pull requests merged
+// into another branch containing one of the commits, are excluded. Each pull request is returned once, with its merge commit hash,
+// from the newest to the oldest. Supported on GitHub and GitLab. Returns ErrCommitRangePullRequestsNotSupported on the other providers,
+// and a *BatchError with the error of each of the commits whose pull requests weren't looked up, if any.
+func GetPullRequestsByCommitRange(ctx context.Context, client VcsClient, owner, repository, fromRef, toRef string, concurrency int) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"fromRef": fromRef, "toRef": toRef}); err != nil {
+		return nil, err
+	}
+	commits, err := client.ListCommitsBetween(ctx, owner, repository, fromRef, toRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", fromRef, toRef, err)
+	}
+	commitsInRange := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		commitsInRange[commit] = true
+	}
+
+	commitsPullRequests, errs := runConcurrently(ctx, commits, concurrency, func(commit string) ([]PullRequestInfo, error) {
+		return client.ListMergedPullRequestsOfCommit(ctx, owner, repository, commit)
+	})
+	pullRequests := make(map[int64]PullRequestInfo)
+	lookupErrors := map[string]error{}
+	for i, commit := range commits {
+		if errs[i] != nil {
+			lookupErrors[commit] = errs[i]
+		}
+		for _, pullRequest := range commitsPullRequests[i] {
+			if commitsInRange[pullRequest.MergeCommitHash] {
+				pullRequests[pullRequest.ID] = pullRequest
+			}
+		}
+	}
+	if err = newBatchError("get the pull requests of the commits", lookupErrors); err != nil {
+		return nil, err
+	}
+
+	results := make([]PullRequestInfo, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		results = append(results, pullRequest)
+	}
+	sortPullRequests(results, PullRequestListOptions{})
+	return results, nil
+}
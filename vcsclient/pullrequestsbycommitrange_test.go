@@ -0,0 +1,89 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPullRequestsByCommitRange(t *testing.T) {
+	pullRequest := `{"number": %d, "merged_at": %s, "merge_commit_sha": "%s",
+		"head": {"label": "jfrog:feature-%[1]d", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}},
+		"base": {"label": "jfrog:main", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.URL.Path == "/repos/jfrog/repo-1/compare/v1.0.0...v1.1.0":
+			response = `{"commits": [{"sha": "sha-1"}, {"sha": "sha-2"}, {"sha": "sha-3"}]}`
+		case r.URL.Path == "/repos/jfrog/repo-1/commits/sha-1/pulls":
+			response = "[" + fmt.Sprintf(pullRequest, 1, `"2024-01-01T00:00:00Z"`, "sha-1") + "]"
+		case r.URL.Path == "/repos/jfrog/repo-1/commits/sha-2/pulls":
+			response = "[" + fmt.Sprintf(pullRequest, 2, `"2024-01-02T00:00:00Z"`, "sha-3") + "]"
+		case r.URL.Path == "/repos/jfrog/repo-1/commits/sha-3/pulls":
+			// Merged into another branch, and open
+			response = "[" + strings.Join([]string{
+				fmt.Sprintf(pullRequest, 2, `"2024-01-02T00:00:00Z"`, "sha-3"),
+				fmt.Sprintf(pullRequest, 3, `"2024-01-03T00:00:00Z"`, "sha-other"),
+				fmt.Sprintf(pullRequest, 4, "null", ""),
+			}, ",") + "]"
+		default:
+			assert.Fail(t, "Unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	pullRequests, err := GetPullRequestsByCommitRange(context.Background(), client, owner, repo1, "v1.0.0", "v1.1.0", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, pullRequests, 2) {
+		assert.Equal(t, int64(2), pullRequests[0].ID)
+		assert.Equal(t, "sha-3", pullRequests[0].MergeCommitHash)
+		assert.Equal(t, "feature-2", pullRequests[0].Source.Name)
+		assert.Equal(t, int64(1), pullRequests[1].ID)
+		assert.Equal(t, "sha-1", pullRequests[1].MergeCommitHash)
+	}
+}
+
+func TestGetPullRequestsByCommitRangeGitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/jfrog%2Frepo-1/repository/compare":
+			assert.Equal(t, "v1.0.0", r.URL.Query().Get("from"))
+			assert.Equal(t, "v1.1.0", r.URL.Query().Get("to"))
+			response = `{"commits": [{"id": "sha-1"}, {"id": "sha-2"}]}`
+		case "/api/v4/projects/jfrog%2Frepo-1/repository/commits/sha-1/merge_requests":
+			response = `[{"iid": 1, "state": "merged", "sha": "sha-1", "squash_commit_sha": "sha-2"}, {"iid": 2, "state": "opened", "sha": "sha-1"}]`
+		case "/api/v4/projects/jfrog%2Frepo-1/repository/commits/sha-2/merge_requests":
+			response = `[{"iid": 1, "state": "merged", "sha": "sha-1", "squash_commit_sha": "sha-2"}]`
+		default:
+			assert.Fail(t, "Unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	pullRequests, err := GetPullRequestsByCommitRange(context.Background(), client, owner, repo1, "v1.0.0", "v1.1.0", 0)
+	assert.NoError(t, err)
+	if assert.Len(t, pullRequests, 1) {
+		assert.Equal(t, int64(1), pullRequests[0].ID)
+		assert.Equal(t, "sha-2", pullRequests[0].MergeCommitHash)
+	}
+}
+
+func TestGetPullRequestsByCommitRangeNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint("https://bitbucket.example.com").Build()
+	assert.NoError(t, err)
+	_, err = GetPullRequestsByCommitRange(context.Background(), client, owner, repo1, "v1.0.0", "v1.1.0", 0)
+	assert.ErrorIs(t, err, ErrCommitRangePullRequestsNotSupported)
+}
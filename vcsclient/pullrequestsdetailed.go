@@ -0,0 +1,83 @@
+package vcsclient
+
+import (
+	"context"
+	"sort"
+)
+
+// MergeableState is whether a pull request can be merged without conflicts
+type MergeableState string
+
+const (
+	Mergeable   MergeableState = "mergeable"
+	Conflicting MergeableState = "conflicting"
+	// The mergeability isn't computed yet by the provider, or isn't returned by it
+	MergeableUnknown MergeableState = "unknown"
+)
+
+// DetailedPullRequestInfo is an open pull request with its metadata
+type DetailedPullRequestInfo struct {
+	PullRequestInfo
+	// The title of the pull request. Returned on GitHub only.
+	Title string
+	// The labels of the pull request, sorted lexicographically
+	Labels []string
+	// The usernames of the requested reviewers, sorted lexicographically. Requested teams are returned by their slugs.
+	Reviewers []string
+	Mergeable MergeableState
+	// The combined state of the statuses of the head commit, as returned by GetCombinedCommitStatus
+	HeadCommitStatus CommitStatus
+}
+
+// detailedPullRequestsLister is implemented by the clients which list the open pull requests with their metadata in bulk
+type detailedPullRequestsLister interface {
+	ListOpenPullRequestsDetailed(ctx context.Context, owner, repository string) ([]DetailedPullRequestInfo, error)
+}
+
+// ListOpenPullRequestsDetailed returns the open pull requests of a repository, with their bodies, labels, reviewers,
+// mergeability and the combined status of their head commits, sorted from the newest to the oldest.
+// On GitHub, all the details are fetched by a GraphQL query per 50 pull requests, instead of several REST calls per pull request.
+// On the other providers, the labels and the statuses are fetched for each of the pull requests, the reviewers are returned
+// where the pull requests include them, and the mergeability is MergeableUnknown.
+func ListOpenPullRequestsDetailed(ctx context.Context, client VcsClient, owner, repository string) ([]DetailedPullRequestInfo, error) {
+	var pullRequests []DetailedPullRequestInfo
+	var err error
+	if lister, ok := getOptionalMethods[detailedPullRequestsLister](client); ok {
+		pullRequests, err = lister.ListOpenPullRequestsDetailed(ctx, owner, repository)
+	} else {
+		pullRequests, err = listOpenPullRequestsDetailed(ctx, client, owner, repository)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pullRequests, func(i, j int) bool {
+		return pullRequests[i].ID > pullRequests[j].ID
+	})
+	return pullRequests, nil
+}
+
+// listOpenPullRequestsDetailed fetches the details of each of the open pull requests by the methods of the client
+func listOpenPullRequestsDetailed(ctx context.Context, client VcsClient, owner, repository string) ([]DetailedPullRequestInfo, error) {
+	pullRequests, err := client.ListOpenPullRequestsWithBody(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	detailedPullRequests := make([]DetailedPullRequestInfo, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		detailedPullRequest := DetailedPullRequestInfo{PullRequestInfo: pullRequest, Mergeable: MergeableUnknown}
+		if detailedPullRequest.Labels, err = client.ListPullRequestLabels(ctx, owner, repository, int(pullRequest.ID)); err != nil {
+			return nil, err
+		}
+		for _, participant := range pullRequest.Participants {
+			if participant.Role == ReviewerRole {
+				detailedPullRequest.Reviewers = append(detailedPullRequest.Reviewers, participant.Username)
+			}
+		}
+		sort.Strings(detailedPullRequest.Reviewers)
+		if detailedPullRequest.HeadCommitStatus, err = GetCombinedCommitStatus(ctx, client, owner, repository, pullRequest.HeadCommitHash); err != nil {
+			return nil, err
+		}
+		detailedPullRequests = append(detailedPullRequests, detailedPullRequest)
+	}
+	return detailedPullRequests, nil
+}
@@ -0,0 +1,127 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOpenPullRequestsDetailedGitHub(t *testing.T) {
+	var cursors []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.RequestURI)
+		var graphQLRequest struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&graphQLRequest))
+		assert.Contains(t, graphQLRequest.Query, "pullRequests(states: OPEN")
+		assert.Equal(t, owner, graphQLRequest.Variables["owner"])
+		assert.Equal(t, repo1, graphQLRequest.Variables["repository"])
+		cursors = append(cursors, graphQLRequest.Variables["cursor"])
+		response := `{"data": {"repository": {"pullRequests": {"pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29yOjE="}, "nodes": [
+			{"number": 1, "title": "Fix", "body": "Fixes a bug", "url": "https://github.com/jfrog/repo-1/pull/1", "updatedAt": "2023-05-01T10:00:00Z",
+			"mergeable": "CONFLICTING", "headRefName": "fix", "headRefOid": "abc123", "baseRefName": "main", "author": {"login": "frogger"},
+			"headRepository": {"name": "repo-1", "owner": {"login": "forker"}}, "baseRepository": {"name": "repo-1", "owner": {"login": "jfrog"}},
+			"labels": {"nodes": [{"name": "security"}, {"name": "bug"}]},
+			"reviewRequests": {"nodes": [{"requestedReviewer": {"slug": "maintainers"}}, {"requestedReviewer": {"login": "toad"}}]},
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}}]}}}}`
+		if len(cursors) > 1 {
+			response = `{"data": {"repository": {"pullRequests": {"pageInfo": {"hasNextPage": false, "endCursor": null}, "nodes": [
+				{"number": 2, "title": "Feature", "url": "https://github.com/jfrog/repo-1/pull/2", "updatedAt": "2023-05-02T10:00:00Z",
+				"mergeable": "MERGEABLE", "headRefName": "feature", "headRefOid": "def456", "baseRefName": "main", "author": {"login": "frogger"},
+				"headRepository": {"name": "repo-1", "owner": {"login": "jfrog"}}, "baseRepository": {"name": "repo-1", "owner": {"login": "jfrog"}},
+				"labels": {"nodes": []}, "reviewRequests": {"nodes": []}, "commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}}]}}}}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	pullRequests, err := ListOpenPullRequestsDetailed(context.Background(), client, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, "Y3Vyc29yOjE="}, cursors)
+	assert.Equal(t, []DetailedPullRequestInfo{
+		{
+			PullRequestInfo: PullRequestInfo{
+				ID:             2,
+				URL:            "https://github.com/jfrog/repo-1/pull/2",
+				HTMLURL:        "https://github.com/jfrog/repo-1/pull/2",
+				Source:         BranchInfo{Name: "feature", Repository: repo1, Owner: owner},
+				Target:         BranchInfo{Name: "main", Repository: repo1, Owner: owner},
+				HeadCommitHash: "def456",
+				Author:         "frogger",
+				UpdatedAt:      time.Date(2023, time.May, 2, 10, 0, 0, 0, time.UTC),
+			},
+			Title:            "Feature",
+			Labels:           []string{},
+			Reviewers:        []string{},
+			Mergeable:        Mergeable,
+			HeadCommitStatus: InProgress,
+		},
+		{
+			PullRequestInfo: PullRequestInfo{
+				ID:             1,
+				Body:           "Fixes a bug",
+				URL:            "https://github.com/jfrog/repo-1/pull/1",
+				HTMLURL:        "https://github.com/jfrog/repo-1/pull/1",
+				Source:         BranchInfo{Name: "fix", Repository: repo1, Owner: "forker"},
+				Target:         BranchInfo{Name: "main", Repository: repo1, Owner: owner},
+				HeadCommitHash: "abc123",
+				Author:         "frogger",
+				UpdatedAt:      time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC),
+			},
+			Title:            "Fix",
+			Labels:           []string{"bug", "security"},
+			Reviewers:        []string{"maintainers", "toad"},
+			Mergeable:        Conflicting,
+			HeadCommitStatus: Fail,
+		},
+	}, pullRequests)
+
+	_, err = ListOpenPullRequestsDetailed(context.Background(), createBadGitHubClient(t), owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestListOpenPullRequestsDetailedBitbucketCloud(t *testing.T) {
+	const headCommitHash = "b1fbbe453dbb0e1f2a3b4c5d6e7f8091a2b3c4d5"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/repositories/jfrog/repo-1/pullrequests/":
+			response = `{"values": [{"id": 3, "description": "hello", "links": {"html": {"href": "https://bitbucket.org/jfrog/repo-1/pull-requests/3"}},
+				"source": {"branch": {"name": "feature"}, "commit": {"hash": "` + headCommitHash + `"}, "repository": {"name": "repo-1"}},
+				"destination": {"branch": {"name": "main"}, "repository": {"name": "repo-1"}}, "author": {"nickname": "frogger"}}]}`
+		case "/repositories/jfrog/repo-1/pullrequests/3":
+			response = `{"id": 3, "description": "hello\n[comment]: <> (froggit-labels: [\"security\"])",
+				"source": {"branch": {"name": "feature"}, "repository": {"full_name": "jfrog/repo-1"}},
+				"destination": {"branch": {"name": "main"}, "repository": {"full_name": "jfrog/repo-1"}}}`
+		case "/repositories/jfrog/repo-1/commit/" + headCommitHash + "/statuses":
+			response = `{"values": [{"state": "SUCCESSFUL", "key": "build"}, {"state": "INPROGRESS", "key": "scan"}]}`
+		default:
+			assert.Fail(t, "unexpected request "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketCloud, true, server)
+
+	pullRequests, err := ListOpenPullRequestsDetailed(context.Background(), client, owner, repo1)
+	assert.NoError(t, err)
+	if assert.Len(t, pullRequests, 1) {
+		assert.Equal(t, int64(3), pullRequests[0].ID)
+		assert.Equal(t, "hello", pullRequests[0].Body)
+		assert.Equal(t, []string{"security"}, pullRequests[0].Labels)
+		assert.Empty(t, pullRequests[0].Reviewers)
+		assert.Equal(t, MergeableUnknown, pullRequests[0].Mergeable)
+		assert.Equal(t, InProgress, pullRequests[0].HeadCommitStatus)
+	}
+}
@@ -0,0 +1,22 @@
+package vcsclient
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ErrRepositoryTopicsNotSupported is returned by GetRepositoryTopics and SetRepositoryTopics on the providers without repository topics
+var ErrRepositoryTopicsNotSupported = errors.New("repository topics are not supported by the VCS provider")
+
+// normalizeRepositoryTopics returns the trimmed lowercase topics, sorted and without duplicates or empty topics
+func normalizeRepositoryTopics(topics []string) []string {
+	normalizedTopics := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if topic = strings.ToLower(strings.TrimSpace(topic)); topic != "" {
+			normalizedTopics = append(normalizedTopics, topic)
+		}
+	}
+	slices.Sort(normalizedTopics)
+	return slices.Compact(normalizedTopics)
+}
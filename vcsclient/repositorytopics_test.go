@@ -0,0 +1,97 @@
+package vcsclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryTopicsGitHub(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/topics", r.RequestURI)
+		if r.Method == http.MethodPut {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"names": ["security", "team-frogbot"]}`, string(body))
+		}
+		_, err := w.Write([]byte(`{"names": ["team-frogbot", "security"]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	topics, err := client.GetRepositoryTopics(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"security", "team-frogbot"}, topics)
+	assert.NoError(t, client.SetRepositoryTopics(ctx, owner, repo1, []string{"Team-Frogbot", "security", "security", " "}))
+}
+
+func TestRepositoryTopicsGitLab(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/jfrog%2Frepo-1", r.URL.EscapedPath())
+		if r.Method == http.MethodPut {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"topics": []}`, string(body))
+		}
+		_, err := w.Write([]byte(`{"id": 1, "topics": ["team-frogbot"]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	topics, err := client.GetRepositoryTopics(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team-frogbot"}, topics)
+	assert.NoError(t, client.SetRepositoryTopics(ctx, owner, repo1, nil))
+}
+
+func TestRepositoryTopicsBitbucketServer(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/jfrog/repos/repo-1/labels":
+			if r.URL.Query().Get("start") == "0" {
+				response = `{"isLastPage": false, "nextPageStart": 1, "values": [{"name": "team-frogbot"}]}`
+			} else {
+				response = `{"isLastPage": true, "values": [{"name": "legacy"}]}`
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/1.0/projects/jfrog/repos/repo-1/labels":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			requests = append(requests, "add "+string(body))
+		case r.Method == http.MethodDelete && r.URL.Path == "/rest/api/1.0/projects/jfrog/repos/repo-1/labels/legacy":
+			requests = append(requests, "remove legacy")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.BitbucketServer, false, server)
+
+	topics, err := client.GetRepositoryTopics(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"legacy", "team-frogbot"}, topics)
+	assert.NoError(t, client.SetRepositoryTopics(ctx, owner, repo1, []string{"team-frogbot", "security"}))
+	assert.Equal(t, []string{"add {\"name\":\"security\"}", "remove legacy"}, requests)
+}
+
+func TestRepositoryTopicsNotSupported(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.GetRepositoryTopics(context.Background(), owner, repo1)
+	assert.ErrorIs(t, err, ErrRepositoryTopicsNotSupported)
+	assert.ErrorIs(t, client.SetRepositoryTopics(context.Background(), owner, repo1, []string{"security"}), ErrRepositoryTopicsNotSupported)
+}
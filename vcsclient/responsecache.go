@@ -0,0 +1,179 @@
+package vcsclient
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// The default maximal number of responses kept by a ResponseCache
+const defaultResponseCacheMaxEntries = 1000
+
+// ResponseCacheOptions configures a ResponseCache
+type ResponseCacheOptions struct {
+	// The time a cached response is served without sending a request to the VCS provider.
+	// After it, the response is revalidated by a conditional request. Defaults to 0, revalidating the response on each read.
+	TTL time.Duration
+	// The maximal number of cached responses, above which the least recently used responses are evicted. Defaults to 1000.
+	MaxEntries int
+}
+
+// ResponseCache is an in-memory cache of the JSON responses of the GET requests sent to the VCS provider, keyed by the provider and the URL.
+// A cached response is revalidated by a conditional request, with the If-None-Match and If-Modified-Since headers,
+// and a 304 Not Modified response is served from the cache. On GitHub, the 304 responses don't count against the rate limit.
+// The responses are cached per credentials, so a cache can be shared by several clients. It's safe for concurrent use.
+// Reads sent with a context returned from WithoutCache fetch fresh responses, and reads sent with a context
+// returned from WithCacheIndication report whether they were served from the cache.
+type ResponseCache struct {
+	options ResponseCacheOptions
+	mutex   sync.Mutex
+	// The cached responses by their keys, whose elements are in the order of their usage, from the most recently used
+	entries map[string]*list.Element
+	usage   *list.List
+}
+
+type cachedResponse struct {
+	key      string
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// NewResponseCache creates an empty ResponseCache, to set by the ResponseCache method of the ClientBuilder
+func NewResponseCache(options ResponseCacheOptions) *ResponseCache {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = defaultResponseCacheMaxEntries
+	}
+	return &ResponseCache{options: options, entries: make(map[string]*list.Element), usage: list.New()}
+}
+
+func (cache *ResponseCache) get(key string) (cachedResponse, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, exists := cache.entries[key]
+	if !exists {
+		return cachedResponse{}, false
+	}
+	cache.usage.MoveToFront(element)
+	return *element.Value.(*cachedResponse), true
+}
+
+func (cache *ResponseCache) put(response cachedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, exists := cache.entries[response.key]; exists {
+		element.Value = &response
+		cache.usage.MoveToFront(element)
+		return
+	}
+	cache.entries[response.key] = cache.usage.PushFront(&response)
+	for cache.usage.Len() > cache.options.MaxEntries {
+		oldest := cache.usage.Back()
+		cache.usage.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// responseCachingTransport serves the GET requests it sends from the response cache, and revalidates the cached responses by conditional requests
+type responseCachingTransport struct {
+	// The transport sending the requests, or http.DefaultTransport if nil
+	http.RoundTripper
+	provider vcsutils.VcsProvider
+	cache    *ResponseCache
+}
+
+func (transport *responseCachingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	// Conditional requests of the caller are sent as is, so their 304 responses reach the caller
+	if request.Method != http.MethodGet || request.Header.Get("If-None-Match") != "" || request.Header.Get("If-Modified-Since") != "" {
+		return roundTripper.RoundTrip(request)
+	}
+	ctx := request.Context()
+	key := transport.getKey(request)
+	cached, exists := transport.cache.get(key)
+	if exists && !isCacheBypassed(ctx) {
+		if time.Since(cached.storedAt) < transport.cache.options.TTL {
+			indicateCacheUsage(ctx, true)
+			return cached.toResponse(request), nil
+		}
+		// A RoundTripper must not modify the original request
+		request = request.Clone(ctx)
+		if etag := cached.header.Get("ETag"); etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.header.Get("Last-Modified"); lastModified != "" {
+			request.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+	response, err := roundTripper.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	if exists && response.StatusCode == http.StatusNotModified {
+		if err = vcsutils.DiscardResponseBody(response); err != nil {
+			return nil, err
+		}
+		if err = response.Body.Close(); err != nil {
+			return nil, err
+		}
+		cached.storedAt = time.Now()
+		transport.cache.put(cached)
+		indicateCacheUsage(ctx, true)
+		return cached.toResponse(request), nil
+	}
+	indicateCacheUsage(ctx, false)
+	if !isCacheableResponse(response, transport.cache.options.TTL) {
+		return response, nil
+	}
+	body, err := io.ReadAll(response.Body)
+	if closeErr := response.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	transport.cache.put(cachedResponse{key: key, header: response.Header.Clone(), body: body, storedAt: time.Now()})
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return response, nil
+}
+
+// getKey returns the key of the cached response of a request, by the provider, the URL and the hash of the credentials of the request.
+// GitLab sends the access tokens in the PRIVATE-TOKEN and JOB-TOKEN headers, instead of the Authorization header.
+func (transport *responseCachingTransport) getKey(request *http.Request) string {
+	credentials := sha256.Sum256([]byte(request.Header.Get("Authorization") + "\n" + request.Header.Get("PRIVATE-TOKEN") + "\n" + request.Header.Get("JOB-TOKEN")))
+	return transport.provider.String() + " " + request.URL.String() + " " + hex.EncodeToString(credentials[:])
+}
+
+// isCacheableResponse returns true for successful JSON responses, which can be revalidated or are served for a positive TTL.
+// Other responses, such as repository archives and raw files, aren't cached.
+func isCacheableResponse(response *http.Response, ttl time.Duration) bool {
+	if response.StatusCode != http.StatusOK || !strings.Contains(response.Header.Get("Content-Type"), "json") {
+		return false
+	}
+	return ttl > 0 || response.Header.Get("ETag") != "" || response.Header.Get("Last-Modified") != ""
+}
+
+func (cached cachedResponse) toResponse(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       request,
+	}
+}
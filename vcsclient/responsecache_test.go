@@ -0,0 +1,87 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCache(t *testing.T) {
+	ctx := context.Background()
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1", r.RequestURI)
+		requests++
+		if r.Header.Get("If-None-Match") == `"etag-1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"etag-1"`)
+		_, err := w.Write([]byte(`{"name": "repo-1", "default_branch": "main"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).ResponseCache(NewResponseCache(ResponseCacheOptions{})).Build()
+	assert.NoError(t, err)
+
+	var gotFromCache bool
+	for i := 0; i < 3; i++ {
+		defaultBranch, err := client.GetDefaultBranch(WithCacheIndication(ctx, &gotFromCache), owner, repo1)
+		assert.NoError(t, err)
+		assert.Equal(t, "main", defaultBranch)
+		assert.Equal(t, i > 0, gotFromCache)
+	}
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 2, notModified)
+
+	// A read without the cache doesn't send a conditional request
+	_, err = client.GetDefaultBranch(WithCacheIndication(WithoutCache(ctx), &gotFromCache), owner, repo1)
+	assert.NoError(t, err)
+	assert.False(t, gotFromCache)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, 2, notModified)
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err := w.Write([]byte(`{"name": "repo-1", "default_branch": "main"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).ResponseCache(NewResponseCache(ResponseCacheOptions{TTL: time.Hour})).Build()
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+		assert.NoError(t, err)
+		assert.Equal(t, "main", defaultBranch)
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestResponseCacheEviction(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheOptions{MaxEntries: 2})
+	cache.put(cachedResponse{key: "first"})
+	cache.put(cachedResponse{key: "second"})
+	_, exists := cache.get("first")
+	assert.True(t, exists)
+	cache.put(cachedResponse{key: "third"})
+
+	_, exists = cache.get("second")
+	assert.False(t, exists, "The least recently used response should be evicted")
+	_, exists = cache.get("first")
+	assert.True(t, exists)
+	_, exists = cache.get("third")
+	assert.True(t, exists)
+}
@@ -0,0 +1,18 @@
+package vcsclient
+
+import "context"
+
+type noRetryKey struct{}
+
+// WithNoRetry returns a context disabling the retries of the requests sent with it, such as the retries of requests rejected for exceeding the rate limit.
+// Useful for latency-sensitive operations, such as autocompletion, which prefer failing fast to waiting for the rate limit to reset.
+// The requests to Bitbucket and Azure Repos aren't retried by the client, so the context changes nothing there.
+func WithNoRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// isRetryDisabled returns true if the context was returned from WithNoRetry
+func isRetryDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return disabled
+}
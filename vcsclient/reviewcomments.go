@@ -0,0 +1,106 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ReviewCommentsSyncReport is the result of SyncPullRequestReviewComments
+type ReviewCommentsSyncReport struct {
+	// The desired comments which had no existing comment, and were added
+	Added []PullRequestComment
+	// The existing comments which match desired comments, and were kept
+	Unchanged []CommentInfo
+	// The existing comments which match no desired comment, and were deleted
+	Deleted []CommentInfo
+	// The existing comments which match no desired comment, and were resolved instead of deleted
+	Resolved []CommentInfo
+}
+
+// reviewCommentsResolver is implemented by the clients which can resolve the threads of review comments.
+// Resolving is preferred over deleting, since it keeps the discussion in the pull request.
+type reviewCommentsResolver interface {
+	ResolvePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error
+}
+
+// SyncPullRequestReviewComments updates the review comments of a pull request to the desired comments, by the minimal set of operations.
+// Desired comments without an existing comment are added, and existing comments without a desired comment are deleted.
+// On providers supporting thread resolution (Azure Repos), the stale comments are resolved instead of deleted, and resolved comments are ignored.
+// Only the existing comments containing the marker are managed by the sync, and the marker is appended to the desired comments missing it.
+// The comments are matched by their file path, their line in the new version of the file and their content, ignoring leading and trailing white spaces.
+// Returns a report of the operations, which includes the comments added before a failure to delete the stale comments.
+func SyncPullRequestReviewComments(ctx context.Context, client VcsClient, owner, repository string, pullRequestID int, desired []PullRequestComment, marker string) (ReviewCommentsSyncReport, error) {
+	var report ReviewCommentsSyncReport
+	if strings.TrimSpace(marker) == "" {
+		return report, errors.New("a marker identifying the synced review comments is required")
+	}
+	existingComments, err := client.ListPullRequestReviewComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return report, fmt.Errorf("failed to list the review comments of pull request %d: %w", pullRequestID, err)
+	}
+
+	// The existing managed comments by location and content. Identical comments are matched one to one.
+	staleComments := make(map[reviewCommentKey][]CommentInfo)
+	for _, existingComment := range existingComments {
+		if !existingComment.Resolved && strings.Contains(existingComment.Content, marker) {
+			key := newReviewCommentKey(existingComment.FilePath, existingComment.Line, existingComment.Content)
+			staleComments[key] = append(staleComments[key], existingComment)
+		}
+	}
+	for _, desiredComment := range desired {
+		if !strings.Contains(desiredComment.Content, marker) {
+			desiredComment.Content += "\n\n" + marker
+		}
+		key := newReviewCommentKey(desiredComment.NewFilePath, desiredComment.NewStartLine, desiredComment.Content)
+		if matchingComments := staleComments[key]; len(matchingComments) > 0 {
+			report.Unchanged = append(report.Unchanged, matchingComments[0])
+			staleComments[key] = matchingComments[1:]
+			continue
+		}
+		report.Added = append(report.Added, desiredComment)
+	}
+	// Keep the order of the existing comments
+	for _, existingComment := range existingComments {
+		key := newReviewCommentKey(existingComment.FilePath, existingComment.Line, existingComment.Content)
+		if matchingComments := staleComments[key]; len(matchingComments) > 0 && matchingComments[0].ID == existingComment.ID {
+			report.Deleted = append(report.Deleted, existingComment)
+			staleComments[key] = matchingComments[1:]
+		}
+	}
+
+	// The new comments are added first, so that a failure leaves the stale comments rather than no comments at all
+	if len(report.Added) > 0 {
+		if err = client.AddPullRequestReviewComments(ctx, owner, repository, pullRequestID, report.Added...); err != nil {
+			return ReviewCommentsSyncReport{Unchanged: report.Unchanged}, fmt.Errorf("failed to add the review comments of pull request %d: %w", pullRequestID, err)
+		}
+	}
+	if resolver, ok := getOptionalMethods[reviewCommentsResolver](client); ok && len(report.Deleted) > 0 {
+		if err = resolver.ResolvePullRequestReviewComments(ctx, owner, repository, pullRequestID, report.Deleted...); err != nil {
+			return ReviewCommentsSyncReport{Added: report.Added, Unchanged: report.Unchanged}, fmt.Errorf("failed to resolve the stale review comments of pull request %d: %w", pullRequestID, err)
+		}
+		report.Resolved, report.Deleted = report.Deleted, nil
+	}
+	if len(report.Deleted) > 0 {
+		if err = client.DeletePullRequestReviewComments(ctx, owner, repository, pullRequestID, report.Deleted...); err != nil {
+			return ReviewCommentsSyncReport{Added: report.Added, Unchanged: report.Unchanged}, fmt.Errorf("failed to delete the stale review comments of pull request %d: %w", pullRequestID, err)
+		}
+	}
+	return report, nil
+}
+
+// reviewCommentKey identifies identical review comments, which are positioned on the same line and have the same content
+type reviewCommentKey struct {
+	filePath string
+	line     int
+	content  string
+}
+
+func newReviewCommentKey(filePath string, line int, content string) reviewCommentKey {
+	if filePath != "" {
+		filePath = path.Clean(strings.TrimPrefix(filePath, "/"))
+	}
+	return reviewCommentKey{filePath: filePath, line: line, content: strings.TrimSpace(content)}
+}
@@ -0,0 +1,150 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	marker := "<!-- frogbot -->"
+	var requests, addedComments []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/pulls/1/comments?page=1&per_page=100":
+			response = `[
+				{"id": 1, "body": "Vulnerable dependency lodash\n\n<!-- frogbot -->", "path": "package.json", "line": 3},
+				{"id": 2, "body": "Vulnerable dependency minimist\n\n<!-- frogbot -->", "path": "package.json", "start_line": 4, "line": 6},
+				{"id": 3, "body": "Vulnerable dependency minimist", "path": "package.json", "line": 4},
+				{"id": 4, "body": "Vulnerable dependency lodash\n\n<!-- frogbot -->\n", "path": "package-lock.json", "line": 3}
+			]`
+		case "GET /repos/jfrog/repo-1/pulls/1/commits":
+			response = `[{"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}]`
+		case "POST /repos/jfrog/repo-1/pulls/1/comments":
+			var comment github.PullRequestComment
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+			addedComments = append(addedComments, comment.GetBody())
+			w.WriteHeader(http.StatusCreated)
+			response = `{"id": 5}`
+		case "DELETE /repos/jfrog/repo-1/pulls/comments/1", "DELETE /repos/jfrog/repo-1/pulls/comments/2", "DELETE /repos/jfrog/repo-1/pulls/comments/4":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	desired := []PullRequestComment{
+		{CommentInfo: CommentInfo{Content: "Vulnerable dependency lodash"}, PullRequestDiff: PullRequestDiff{NewFilePath: "package.json", NewStartLine: 3, NewEndLine: 3}},
+		{CommentInfo: CommentInfo{Content: "Vulnerable dependency axios\n\n" + marker}, PullRequestDiff: PullRequestDiff{NewFilePath: "package.json", NewStartLine: 5, NewEndLine: 5}},
+	}
+	report, err := SyncPullRequestReviewComments(ctx, client, owner, repo1, 1, desired, marker)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, getCommentIDs(report.Unchanged))
+	// Comment 3 doesn't contain the marker, so it isn't managed by the sync, and comment 4 has the same content as the desired comment on another file
+	assert.Equal(t, []int64{2, 4}, getCommentIDs(report.Deleted))
+	assert.Len(t, report.Added, 1)
+	assert.Equal(t, []string{"Vulnerable dependency axios\n\n" + marker}, addedComments)
+	assert.Equal(t, []string{
+		"GET /repos/jfrog/repo-1/pulls/1/comments?page=1&per_page=100",
+		"GET /repos/jfrog/repo-1/pulls/1/commits",
+		"POST /repos/jfrog/repo-1/pulls/1/comments",
+		"DELETE /repos/jfrog/repo-1/pulls/comments/2",
+		"DELETE /repos/jfrog/repo-1/pulls/comments/4",
+	}, requests)
+
+	// The marker is appended to the desired comments missing it
+	requests, addedComments = nil, nil
+	desired = []PullRequestComment{{CommentInfo: CommentInfo{Content: "Vulnerable dependency minimist"}, PullRequestDiff: PullRequestDiff{NewFilePath: "package.json", NewStartLine: 4, NewEndLine: 6}}}
+	report, err = SyncPullRequestReviewComments(ctx, client, owner, repo1, 1, desired, marker)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, getCommentIDs(report.Unchanged))
+	assert.Empty(t, report.Added)
+	assert.Equal(t, []int64{1, 4}, getCommentIDs(report.Deleted))
+	assert.Empty(t, addedComments)
+
+	// A comment with the same content on another line is replaced
+	requests, addedComments = nil, nil
+	desired = []PullRequestComment{{CommentInfo: CommentInfo{Content: "Vulnerable dependency minimist"}, PullRequestDiff: PullRequestDiff{NewFilePath: "package.json", NewStartLine: 5, NewEndLine: 5}}}
+	report, err = SyncPullRequestReviewComments(ctx, client, owner, repo1, 1, desired, marker)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Unchanged)
+	assert.Len(t, report.Added, 1)
+	assert.Equal(t, []int64{1, 2, 4}, getCommentIDs(report.Deleted))
+
+	_, err = SyncPullRequestReviewComments(ctx, client, owner, repo1, 1, desired, " ")
+	assert.Error(t, err)
+}
+
+func getCommentIDs(comments []CommentInfo) []int64 {
+	var ids []int64
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	return ids
+}
+
+func TestSyncPullRequestReviewCommentsResolvesAzureReposThreads(t *testing.T) {
+	ctx := context.Background()
+	marker := "<!-- frogbot -->"
+	var updatedThreads []git.GitPullRequestCommentThread
+	var createdThreads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.Method == http.MethodGet:
+			response = `{"count": 3, "value": [
+				{"id": 1, "status": "active", "publishedDate": "2024-01-01T00:00:00Z", "comments": [{"id": 1, "content": "Vulnerable dependency lodash\n\n<!-- frogbot -->", "author": {"displayName": "frogbot"}}]},
+				{"id": 2, "status": "fixed", "publishedDate": "2024-01-01T00:00:00Z", "comments": [{"id": 1, "content": "Vulnerable dependency minimist\n\n<!-- frogbot -->", "author": {"displayName": "frogbot"}}]},
+				{"id": 3, "status": "active", "publishedDate": "2024-01-01T00:00:00Z", "comments": [{"id": 1, "content": "Vulnerable dependency axios", "author": {"displayName": "frogbot"}}]}
+			]}`
+		case r.Method == http.MethodPost:
+			createdThreads++
+			response = `{"id": 4}`
+		default:
+			assert.Equal(t, http.MethodPatch, r.Method)
+			var thread git.GitPullRequestCommentThread
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&thread))
+			updatedThreads = append(updatedThreads, thread)
+			response = `{"id": 1}`
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	desired := []PullRequestComment{{CommentInfo: CommentInfo{Content: "Vulnerable dependency minimist"}, PullRequestDiff: PullRequestDiff{NewFilePath: "package.json", NewStartLine: 4, NewEndLine: 4}}}
+	report, err := SyncPullRequestReviewComments(ctx, client, "", repo1, 1, desired, marker)
+	assert.NoError(t, err)
+	assert.Len(t, report.Added, 1)
+	assert.Equal(t, 1, createdThreads)
+	// The resolved thread 2 and the unmanaged thread 3 are ignored, and the stale thread 1 is resolved rather than deleted
+	assert.Empty(t, report.Deleted)
+	assert.Equal(t, []int64{1}, getCommentIDs(report.Resolved))
+	if assert.Len(t, updatedThreads, 1) {
+		assert.Equal(t, git.CommentThreadStatusValues.Fixed, *updatedThreads[0].Status)
+	}
+}
@@ -0,0 +1,58 @@
+package vcsclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/version"
+)
+
+// serverVersionResolver fetches the version of the VCS provider server once, and caches it for the next calls
+type serverVersionResolver struct {
+	mutex    sync.Mutex
+	version  string
+	resolved bool
+}
+
+// resolve returns the cached server version, or fetches it if it wasn't fetched successfully yet or the cache is bypassed.
+// fetchVersion - Fetches the version from the server. Returns an empty version on SaaS providers, which are always up-to-date.
+func (resolver *serverVersionResolver) resolve(ctx context.Context, fetchVersion func(ctx context.Context) (string, error)) (string, error) {
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	if resolver.resolved && !isCacheBypassed(ctx) {
+		indicateCacheUsage(ctx, true)
+		return resolver.version, nil
+	}
+	serverVersion, err := fetchVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	resolver.version, resolver.resolved = serverVersion, true
+	indicateCacheUsage(ctx, false)
+	return serverVersion, nil
+}
+
+// isServerVersionAtLeast returns true if the server version is at least the minimal version.
+// An empty server version is of a SaaS provider, which is always up-to-date.
+// Suffixes of the server version, such as '-ee' of GitLab Enterprise Edition, are ignored.
+func isServerVersionAtLeast(serverVersion, minimalVersion string) bool {
+	if serverVersion == "" {
+		return true
+	}
+	serverVersion, _, _ = strings.Cut(serverVersion, "-")
+	return version.NewVersion(serverVersion).AtLeast(minimalVersion)
+}
+
+// supportsServerVersion returns true if the server version is at least the minimal version.
+// If the version is unavailable, the server is assumed to be up-to-date.
+// getServerVersion - The GetServerVersion method of the VCS client
+func supportsServerVersion(ctx context.Context, getServerVersion func(ctx context.Context) (string, error), minimalVersion string, logger vcsutils.Log) bool {
+	serverVersion, err := getServerVersion(ctx)
+	if err != nil {
+		logger.Debug("Couldn't get the server version, assuming version", minimalVersion, "or above:", err.Error())
+		return true
+	}
+	return isServerVersionAtLeast(serverVersion, minimalVersion)
+}
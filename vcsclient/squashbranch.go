@@ -0,0 +1,7 @@
+package vcsclient
+
+import "errors"
+
+// ErrBranchSquashNotSupported is returned by SquashBranchIntoSingleCommit on the providers which can't squash a branch on the server.
+// The commits of the branch can be squashed when merging its pull request by the SquashMerge method instead.
+var ErrBranchSquashNotSupported = errors.New("squashing a branch is not supported by the VCS provider, merge its pull request by the squash merge method instead")
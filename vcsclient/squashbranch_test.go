@@ -0,0 +1,64 @@
+package vcsclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquashBranchIntoSingleCommit(t *testing.T) {
+	ctx := context.Background()
+	aheadBy := 2
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/branches/fix":
+			response = `{"name": "fix", "commit": {"sha": "head-sha", "commit": {"tree": {"sha": "tree-sha"}}}}`
+		case r.Method == http.MethodGet && r.RequestURI == "/repos/jfrog/repo-1/compare/main...head-sha?page=1&per_page=50":
+			response = `{"ahead_by": 2, "merge_base_commit": {"sha": "base-sha"},
+				"commits": [{"sha": "first-sha", "commit": {"message": "First fix"}}, {"sha": "head-sha", "commit": {"message": "Second fix"}}]}`
+			if aheadBy == 1 {
+				response = `{"ahead_by": 1, "merge_base_commit": {"sha": "base-sha"}, "commits": [{"sha": "head-sha"}]}`
+			}
+		case r.Method == http.MethodPost && r.RequestURI == "/repos/jfrog/repo-1/git/commits",
+			r.Method == http.MethodPatch && r.RequestURI == "/repos/jfrog/repo-1/git/refs/heads/fix":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			requests = append(requests, string(body))
+			response = `{"sha": "squashed-sha"}`
+		default:
+			assert.Fail(t, "unexpected request "+r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	head, err := client.SquashBranchIntoSingleCommit(ctx, owner, repo1, "fix", "main", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "squashed-sha", head)
+	assert.Equal(t, []string{
+		`{"message":"First fix\n\nSecond fix","tree":"tree-sha","parents":["base-sha"]}` + "\n",
+		`{"sha":"squashed-sha","force":true}` + "\n",
+	}, requests)
+
+	// A branch with a single commit is kept as is
+	aheadBy = 1
+	requests = nil
+	head, err = client.SquashBranchIntoSingleCommit(ctx, owner, repo1, "fix", "main", "Fix")
+	assert.NoError(t, err)
+	assert.Equal(t, "head-sha", head)
+	assert.Empty(t, requests)
+
+	gitLabClient, err := NewClientBuilder(vcsutils.GitLab).Build()
+	assert.NoError(t, err)
+	_, err = gitLabClient.SquashBranchIntoSingleCommit(ctx, owner, repo1, "fix", "main", "")
+	assert.ErrorIs(t, err, ErrBranchSquashNotSupported)
+}
@@ -0,0 +1,54 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// pullRequestActivityResolver is implemented by the clients whose listed pull requests don't include the time of their last update.
+type pullRequestActivityResolver interface {
+	GetPullRequestLastActivity(ctx context.Context, owner, repository string, pullRequestID int) (time.Time, error)
+}
+
+// FindStalePullRequests returns the open pull requests which weren't updated for the inactivity period, for bots closing or nudging them.
+// The last activity of a pull request is the time of its last update, as returned by ListOpenPullRequests.
+// On providers not returning the time of the last update (Azure Repos), the last activity is refined from the comment threads of the candidates only,
+// so the number of requests doesn't grow with the number of active pull requests.
+// inactiveFor - The minimal period without activity of a stale pull request
+// author      - Optional. Only the pull requests of the author are returned, if specified.
+func FindStalePullRequests(ctx context.Context, client VcsClient, owner, repository string, inactiveFor time.Duration, author string) ([]PullRequestInfo, error) {
+	if inactiveFor <= 0 {
+		return nil, errors.New("the inactivity period must be positive")
+	}
+	pullRequests, err := client.ListOpenPullRequests(ctx, owner, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the open pull requests: %w", err)
+	}
+	threshold := time.Now().Add(-inactiveFor)
+	resolver, hasResolver := getOptionalMethods[pullRequestActivityResolver](client)
+	var stalePullRequests []PullRequestInfo
+	for _, pullRequest := range pullRequests {
+		if author != "" && pullRequest.Author != author {
+			continue
+		}
+		if !pullRequest.UpdatedAt.Before(threshold) {
+			continue
+		}
+		if hasResolver {
+			lastActivity, err := resolver.GetPullRequestLastActivity(ctx, owner, repository, int(pullRequest.ID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the last activity of pull request %d: %w", pullRequest.ID, err)
+			}
+			if !lastActivity.Before(threshold) {
+				continue
+			}
+			if lastActivity.After(pullRequest.UpdatedAt) {
+				pullRequest.UpdatedAt = lastActivity
+			}
+		}
+		stalePullRequests = append(stalePullRequests, pullRequest)
+	}
+	return stalePullRequests, nil
+}
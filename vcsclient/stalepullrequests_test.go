@@ -0,0 +1,99 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStalePullRequests(t *testing.T) {
+	staleTime := time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recentTime := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	gitHubPullRequest := `{"number": %d, "user": {"login": "%s"}, "updated_at": "%s",
+		"head": {"ref": "feature", "label": "jfrog:feature", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}},
+		"base": {"ref": "main", "label": "jfrog:main", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}}`
+	var requestsCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsCount++
+		assert.Equal(t, "/repos/jfrog/repo-1/pulls", r.URL.Path)
+		_, err := fmt.Fprintf(w, "[%s, %s, %s]",
+			fmt.Sprintf(gitHubPullRequest, 1, "frogger", staleTime),
+			fmt.Sprintf(gitHubPullRequest, 2, "frogger", recentTime),
+			fmt.Sprintf(gitHubPullRequest, 3, "renovate", staleTime))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	stalePullRequests, err := FindStalePullRequests(context.Background(), client, owner, repo1, 7*24*time.Hour, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 1}, getPullRequestIDs(stalePullRequests))
+	// The pull requests are listed once, without a request per pull request
+	assert.Equal(t, 1, requestsCount)
+
+	stalePullRequests, err = FindStalePullRequests(context.Background(), client, owner, repo1, 7*24*time.Hour, username)
+	assert.NoError(t, err)
+	if assert.Equal(t, []int64{1}, getPullRequestIDs(stalePullRequests)) {
+		assert.Equal(t, username, stalePullRequests[0].Author)
+	}
+
+	_, err = FindStalePullRequests(context.Background(), client, owner, repo1, 0, "")
+	assert.Error(t, err)
+}
+
+func TestFindStalePullRequestsRefinesAzureReposActivity(t *testing.T) {
+	staleTime := time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recentTime := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	var threadRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.RequestURI == "/_apis/ResourceAreas/pullRequestComments":
+			threadRequests++
+			// The threads are listed in the order of the pull requests, from the newest. Pull request 2 has no recent thread, and pull request 1 was commented recently.
+			lastUpdated := staleTime
+			if threadRequests == 2 {
+				lastUpdated = recentTime
+			}
+			response = fmt.Sprintf(`{"count": 1, "value": [{"id": 1, "lastUpdatedDate": "%s", "comments": []}]}`, lastUpdated)
+		default:
+			response = fmt.Sprintf(`{"count": 3, "value": [
+				{"pullRequestId": 1, "creationDate": "%[1]s", "sourceRefName": "refs/heads/feature", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "frogger"}},
+				{"pullRequestId": 2, "creationDate": "%[1]s", "sourceRefName": "refs/heads/fix", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "frogger"}},
+				{"pullRequestId": 3, "creationDate": "%[2]s", "sourceRefName": "refs/heads/new", "targetRefName": "refs/heads/main", "createdBy": {"uniqueName": "frogger"}}
+			]}`, staleTime, recentTime)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	stalePullRequests, err := FindStalePullRequests(context.Background(), client, "", repo1, 7*24*time.Hour, username)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, getPullRequestIDs(stalePullRequests))
+	// Only the threads of the candidates are listed
+	assert.Equal(t, 2, threadRequests)
+}
+
+func getPullRequestIDs(pullRequests []PullRequestInfo) []int64 {
+	var ids []int64
+	for _, pullRequest := range pullRequests {
+		ids = append(ids, pullRequest.ID)
+	}
+	return ids
+}
@@ -0,0 +1,42 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// TracePropagator injects the trace context of a request into its headers, such as the W3C traceparent and tracestate headers.
+// Allows correlating the logs of the VCS provider with the traces of the consumer of the client.
+// The OpenTelemetry propagator, for example, is adapted by:
+//
+//	vcsclient.TracePropagatorFunc(func(ctx context.Context, header http.Header) {
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+//	})
+type TracePropagator interface {
+	Inject(ctx context.Context, header http.Header)
+}
+
+// TracePropagatorFunc adapts a function to a TracePropagator
+type TracePropagatorFunc func(ctx context.Context, header http.Header)
+
+func (propagatorFunc TracePropagatorFunc) Inject(ctx context.Context, header http.Header) {
+	propagatorFunc(ctx, header)
+}
+
+// tracePropagationTransport injects the trace context of the requests it sends, taken from the contexts of the requests
+type tracePropagationTransport struct {
+	// The transport sending the requests, or http.DefaultTransport if nil
+	http.RoundTripper
+	propagator TracePropagator
+}
+
+func (transport *tracePropagationTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	// A RoundTripper must not modify the original request
+	request = request.Clone(request.Context())
+	transport.propagator.Inject(request.Context(), request.Header)
+	return roundTripper.RoundTrip(request)
+}
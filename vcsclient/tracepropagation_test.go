@@ -0,0 +1,90 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type traceParentKey struct{}
+
+// injectTestTraceParent injects the traceparent set in the context, like the OpenTelemetry W3C propagator
+var injectTestTraceParent = TracePropagatorFunc(func(ctx context.Context, header http.Header) {
+	if traceParent, found := ctx.Value(traceParentKey{}).(string); found {
+		header.Set("traceparent", traceParent)
+	}
+})
+
+func TestClientBuilderTracePropagator(t *testing.T) {
+	tests := []struct {
+		vcsProvider         vcsutils.VcsProvider
+		response            interface{}
+		expectedURI         string
+		createHandler       createHandlerFunc
+		basicAuth           bool
+		expectedTraceParent string
+	}{
+		{vcsProvider: vcsutils.GitHub, response: "It's Not Easy Being Green", expectedURI: "/zen", createHandler: createGitHubHandler, expectedTraceParent: testTraceParent},
+		{vcsProvider: vcsutils.GitLab, response: []interface{}{}, expectedURI: "/api/v4/projects", createHandler: createGitLabHandler, expectedTraceParent: testTraceParent},
+		{vcsProvider: vcsutils.BitbucketServer, response: map[string]interface{}{}, expectedURI: "/rest/api/1.0/admin/users?limit=1", createHandler: createBitbucketServerHandler, expectedTraceParent: testTraceParent},
+		// The requests of the Bitbucket Cloud SDK don't carry the context
+		{vcsProvider: vcsutils.BitbucketCloud, response: map[string]interface{}{}, expectedURI: "/user", createHandler: createBitbucketCloudHandler, basicAuth: true},
+		{vcsProvider: vcsutils.AzureRepos, response: "", expectedURI: "", createHandler: createAzureReposHandler, expectedTraceParent: testTraceParent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vcsProvider.String(), func(t *testing.T) {
+			response, err := json.Marshal(tt.response)
+			assert.NoError(t, err)
+			var mutex sync.Mutex
+			var traceParents []string
+			handler := tt.createHandler(t, tt.expectedURI, response, http.StatusOK)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				traceParents = append(traceParents, r.Header.Get("traceparent"))
+				mutex.Unlock()
+				handler(w, r)
+			}))
+			defer server.Close()
+
+			clientBuilder := NewClientBuilder(tt.vcsProvider).ApiEndpoint(server.URL).Token(token).TracePropagator(injectTestTraceParent)
+			if tt.basicAuth {
+				clientBuilder = clientBuilder.Username(username)
+			}
+			client, err := clientBuilder.Build()
+			assert.NoError(t, err)
+			ctx := context.WithValue(context.Background(), traceParentKey{}, testTraceParent)
+			assert.NoError(t, client.TestConnection(ctx))
+			assert.NotEmpty(t, traceParents)
+			for _, traceParent := range traceParents {
+				assert.Equal(t, tt.expectedTraceParent, traceParent)
+			}
+		})
+	}
+}
+
+func TestTracePropagationTransport(t *testing.T) {
+	var receivedHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHeaders = r.Header
+	}))
+	defer server.Close()
+	transport := &tracePropagationTransport{propagator: injectTestTraceParent}
+
+	ctx := context.WithValue(context.Background(), traceParentKey{}, testTraceParent)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	response, err := transport.RoundTrip(request)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, testTraceParent, receivedHeaders.Get("traceparent"))
+	// The original request isn't modified
+	assert.Empty(t, request.Header.Get("traceparent"))
+}
@@ -0,0 +1,265 @@
+package vcsclient
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	gitdiff "github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// The context lines around the changes in the unified diffs built by formatUnifiedDiff, as in git
+const unifiedDiffContextLines = 3
+
+// Matches a hunk header, such as '@@ -12,7 +12,8 @@'. A lines count of 1 may be omitted.
+var unifiedDiffHunkHeaderRegexp = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// Matches a hunk header with the start lines of its ranges, such as '@@ -12,7 +12,8 @@'
+var unifiedDiffHunkRangesRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseUnifiedDiffFiles returns the files changed in a git unified diff, with their added and deleted lines count
+func parseUnifiedDiffFiles(diff string) (files []FileDiffInfo) {
+	// The lines left in the current hunk, in the old and new versions of the file
+	var oldLinesLeft, newLinesLeft int
+	for _, line := range strings.Split(diff, "\n") {
+		if oldLinesLeft > 0 || newLinesLeft > 0 {
+			file := &files[len(files)-1]
+			switch {
+			case strings.HasPrefix(line, "+"):
+				file.Additions++
+				newLinesLeft--
+			case strings.HasPrefix(line, "-"):
+				file.Deletions++
+				oldLinesLeft--
+			case strings.HasPrefix(line, `\`):
+				// '\ No newline at end of file'
+			default:
+				oldLinesLeft--
+				newLinesLeft--
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "diff --git ") {
+			previousPath, path := parseDiffGitHeaderPaths(line)
+			files = append(files, FileDiffInfo{Path: path, PreviousPath: previousPath})
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+		file := &files[len(files)-1]
+		switch {
+		case strings.HasPrefix(line, "rename from "):
+			file.PreviousPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			file.Path = strings.TrimPrefix(line, "rename to ")
+		default:
+			if match := unifiedDiffHunkHeaderRegexp.FindStringSubmatch(line); match != nil {
+				oldLinesLeft, newLinesLeft = getHunkLinesCount(match[1]), getHunkLinesCount(match[2])
+			}
+		}
+	}
+	// The previous path is relevant only for renamed files
+	for i := range files {
+		if files[i].PreviousPath == files[i].Path {
+			files[i].PreviousPath = ""
+		}
+	}
+	return
+}
+
+// Parses the paths of a 'diff --git a/<previous path> b/<path>' header
+func parseDiffGitHeaderPaths(header string) (previousPath, path string) {
+	paths := strings.TrimPrefix(header, "diff --git ")
+	separatorIndex := strings.Index(paths, " b/")
+	if !strings.HasPrefix(paths, "a/") || separatorIndex < 0 {
+		return "", ""
+	}
+	return paths[len("a/"):separatorIndex], paths[separatorIndex+len(" b/"):]
+}
+
+func getHunkLinesCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	linesCount, err := strconv.Atoi(count)
+	if err != nil {
+		return 0
+	}
+	return linesCount
+}
+
+// mapUnifiedDiffNewLine returns the line in the old version of a file matching a line in its new version, according to the hunks of the diff of the file.
+// Returns 0 if the line was added. Lines outside the hunks are unchanged, and are shifted by the lines added and deleted before them.
+func mapUnifiedDiffNewLine(fileDiff string, newLine int) int {
+	// The difference between the old and the new line numbers after the processed lines
+	offset := 0
+	// The next lines in the old and new versions of the file, and the lines left in the current hunk
+	var oldCurrent, newCurrent, oldLinesLeft, newLinesLeft int
+	for _, line := range strings.Split(fileDiff, "\n") {
+		if oldLinesLeft > 0 || newLinesLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if newCurrent == newLine {
+					return 0
+				}
+				newCurrent++
+				newLinesLeft--
+			case strings.HasPrefix(line, "-"):
+				oldCurrent++
+				oldLinesLeft--
+			case strings.HasPrefix(line, `\`):
+				// '\ No newline at end of file'
+			default:
+				if newCurrent == newLine {
+					return oldCurrent
+				}
+				oldCurrent++
+				newCurrent++
+				oldLinesLeft--
+				newLinesLeft--
+			}
+			offset = oldCurrent - newCurrent
+			continue
+		}
+		match := unifiedDiffHunkRangesRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		oldLinesLeft, newLinesLeft = getHunkLinesCount(match[2]), getHunkLinesCount(match[4])
+		oldCurrent, newCurrent = getHunkStartLine(match[1], oldLinesLeft), getHunkStartLine(match[3], newLinesLeft)
+		if newLine < newCurrent {
+			break
+		}
+		offset = oldCurrent - newCurrent
+	}
+	return newLine + offset
+}
+
+// getHunkStartLine returns the first line of a hunk range. An empty range starts after the line in its header.
+func getHunkStartLine(start string, linesCount int) int {
+	startLine, err := strconv.Atoi(start)
+	if err != nil {
+		return 0
+	}
+	if linesCount == 0 {
+		return startLine + 1
+	}
+	return startLine
+}
+
+// unifiedDiffFile is a changed file, of which the contents before and after the change are known.
+// The content is nil in the version in which the file doesn't exist.
+type unifiedDiffFile struct {
+	previousPath    string
+	path            string
+	previousContent []byte
+	content         []byte
+}
+
+// formatUnifiedDiff returns the git unified diff of the files, for providers which don't return the diff itself
+func formatUnifiedDiff(files []unifiedDiffFile) (string, error) {
+	patch := unifiedDiffPatch{}
+	for _, file := range files {
+		patch = append(patch, newUnifiedDiffFilePatch(file))
+	}
+	var diff strings.Builder
+	err := fdiff.NewUnifiedEncoder(&diff, unifiedDiffContextLines).Encode(patch)
+	return diff.String(), err
+}
+
+// unifiedDiffPatch implements the patch encoded by the go-git unified encoder
+type unifiedDiffPatch []fdiff.FilePatch
+
+func (patch unifiedDiffPatch) FilePatches() []fdiff.FilePatch {
+	return patch
+}
+
+func (patch unifiedDiffPatch) Message() string {
+	return ""
+}
+
+type unifiedDiffFilePatch struct {
+	from     fdiff.File
+	to       fdiff.File
+	isBinary bool
+	chunks   []fdiff.Chunk
+}
+
+func newUnifiedDiffFilePatch(file unifiedDiffFile) *unifiedDiffFilePatch {
+	filePatch := &unifiedDiffFilePatch{
+		isBinary: bytes.IndexByte(file.previousContent, 0) >= 0 || bytes.IndexByte(file.content, 0) >= 0,
+	}
+	if file.previousContent != nil {
+		filePatch.from = newUnifiedDiffFileVersion(file.previousPath, file.previousContent)
+	}
+	if file.content != nil {
+		filePatch.to = newUnifiedDiffFileVersion(file.path, file.content)
+	}
+	if filePatch.isBinary {
+		return filePatch
+	}
+	for _, lineDiff := range gitdiff.Do(string(file.previousContent), string(file.content)) {
+		operation := fdiff.Equal
+		switch lineDiff.Type {
+		case diffmatchpatch.DiffInsert:
+			operation = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			operation = fdiff.Delete
+		}
+		filePatch.chunks = append(filePatch.chunks, unifiedDiffChunk{content: lineDiff.Text, operation: operation})
+	}
+	return filePatch
+}
+
+func (filePatch *unifiedDiffFilePatch) IsBinary() bool {
+	return filePatch.isBinary
+}
+
+func (filePatch *unifiedDiffFilePatch) Files() (from, to fdiff.File) {
+	return filePatch.from, filePatch.to
+}
+
+func (filePatch *unifiedDiffFilePatch) Chunks() []fdiff.Chunk {
+	return filePatch.chunks
+}
+
+// unifiedDiffFileVersion is a version of a file in the diff, which is identified by its git blob hash
+type unifiedDiffFileVersion struct {
+	path string
+	hash plumbing.Hash
+}
+
+func newUnifiedDiffFileVersion(path string, content []byte) *unifiedDiffFileVersion {
+	return &unifiedDiffFileVersion{path: path, hash: plumbing.ComputeHash(plumbing.BlobObject, content)}
+}
+
+func (file *unifiedDiffFileVersion) Hash() plumbing.Hash {
+	return file.hash
+}
+
+func (file *unifiedDiffFileVersion) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (file *unifiedDiffFileVersion) Path() string {
+	return file.path
+}
+
+type unifiedDiffChunk struct {
+	content   string
+	operation fdiff.Operation
+}
+
+func (chunk unifiedDiffChunk) Content() string {
+	return chunk.content
+}
+
+func (chunk unifiedDiffChunk) Type() fdiff.Operation {
+	return chunk.operation
+}
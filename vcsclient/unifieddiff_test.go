@@ -0,0 +1,76 @@
+package vcsclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnifiedDiffFiles(t *testing.T) {
+	diff, err := os.ReadFile(filepath.Join("testdata", "commit_diff.patch"))
+	assert.NoError(t, err)
+	assert.Equal(t, []FileDiffInfo{
+		{Path: "README.md", Additions: 2, Deletions: 1},
+		{Path: "src/new.go", PreviousPath: "src/old.go", Additions: 1, Deletions: 1},
+		{Path: "deleted.txt", Deletions: 2},
+	}, parseUnifiedDiffFiles(string(diff)))
+
+	assert.Empty(t, parseUnifiedDiffFiles(""))
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	diff, err := formatUnifiedDiff([]unifiedDiffFile{
+		{previousPath: "src/old.go", path: "src/new.go", previousContent: []byte("line 1\nline 2\n"), content: []byte("line 1\nline 2a\n")},
+		{path: "added.txt", content: []byte("line 1\n")},
+		{previousPath: "deleted.txt", path: "deleted.txt", previousContent: []byte("line 1\nline 2\n")},
+		{previousPath: "image.png", path: "image.png", previousContent: []byte{0, 1}, content: []byte{0, 2}},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/src/old.go b/src/new.go\nrename from src/old.go\nrename to src/new.go\n")
+	assert.Contains(t, diff, "@@ -1,2 +1,2 @@\n line 1\n-line 2\n+line 2a\n")
+	assert.Contains(t, diff, "Binary files a/image.png and b/image.png differ")
+	assert.Equal(t, []FileDiffInfo{
+		{Path: "src/new.go", PreviousPath: "src/old.go", Additions: 1, Deletions: 1},
+		{Path: "added.txt", Additions: 1},
+		{Path: "deleted.txt", Deletions: 2},
+		{Path: "image.png"},
+	}, parseUnifiedDiffFiles(diff))
+}
+
+func TestParseDiffGitHeaderPaths(t *testing.T) {
+	previousPath, path := parseDiffGitHeaderPaths("diff --git a/docs/old name.md b/docs/new name.md")
+	assert.Equal(t, "docs/old name.md", previousPath)
+	assert.Equal(t, "docs/new name.md", path)
+
+	previousPath, path = parseDiffGitHeaderPaths("diff --git old new")
+	assert.Empty(t, previousPath)
+	assert.Empty(t, path)
+}
+
+func TestMapUnifiedDiffNewLine(t *testing.T) {
+	// Line 2 is replaced by two lines, and lines 10 and 11 are deleted after inserting a line after line 5
+	fileDiff := "@@ -1,3 +1,4 @@\n line 1\n-line 2\n+line 2a\n+line 2b\n line 3\n@@ -5,0 +7 @@ line 5\n+line 5a\n@@ -10,2 +11,0 @@\n-line 10\n-line 11\n\\ No newline at end of file\n"
+	tests := []struct {
+		newLine         int
+		expectedOldLine int
+	}{
+		{newLine: 1, expectedOldLine: 1},
+		{newLine: 2, expectedOldLine: 0},
+		{newLine: 3, expectedOldLine: 0},
+		{newLine: 4, expectedOldLine: 3},
+		{newLine: 6, expectedOldLine: 5},
+		{newLine: 7, expectedOldLine: 0},
+		{newLine: 8, expectedOldLine: 6},
+		{newLine: 10, expectedOldLine: 8},
+		{newLine: 11, expectedOldLine: 9},
+		{newLine: 12, expectedOldLine: 12},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expectedOldLine, mapUnifiedDiffNewLine(fileDiff, tt.newLine), "new line %d", tt.newLine)
+	}
+
+	// Without hunks, the lines are unchanged
+	assert.Equal(t, 4, mapUnifiedDiffNewLine("", 4))
+}
@@ -250,3 +250,28 @@ func assertMissingParam(t *testing.T, err error, missingParam ...string) {
 		assert.Contains(t, message, fmt.Sprintf("required parameter '%s' is missing", param))
 	}
 }
+
+func TestRequiredParams_UpdatePullRequestCommentInvalidPayload(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		repo          string
+		content       string
+		missingParams []string
+	}{
+		{name: "all empty", missingParams: []string{"owner", "repository", "content"}},
+		{name: "empty owner", repo: "repo", content: "content", missingParams: []string{"owner"}},
+		{name: "empty repo", owner: "owner", content: "content", missingParams: []string{"repository"}},
+		{name: "empty content", owner: "owner", repo: "repo", missingParams: []string{"content"}},
+	}
+
+	for _, p := range getAllProviders() {
+		for _, tt := range tests {
+			t.Run(p.String()+" "+tt.name, func(t *testing.T) {
+				ctx, client := createClientAndContext(t, p)
+				err := client.UpdatePullRequestComment(ctx, tt.owner, tt.repo, 1, 1, tt.content)
+				assertMissingParam(t, err, tt.missingParams...)
+			})
+		}
+	}
+}
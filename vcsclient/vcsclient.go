@@ -2,11 +2,17 @@ package vcsclient
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/datastructures"
 )
 
 // CommitStatus the status of the commit in the VCS
@@ -23,6 +29,40 @@ const (
 	InProgress
 )
 
+// CheckRunStatus is the status of a check run
+type CheckRunStatus int
+
+const (
+	// CheckRunQueued means that the check run is waiting to start
+	CheckRunQueued CheckRunStatus = iota
+	// CheckRunInProgress means that the check run is running
+	CheckRunInProgress
+	// CheckRunCompleted means that the check run is done, and its conclusion is set
+	CheckRunCompleted
+)
+
+// CheckRunConclusion is the final result of a completed check run
+type CheckRunConclusion int
+
+const (
+	CheckRunSuccess CheckRunConclusion = iota
+	CheckRunFailure
+	CheckRunNeutral
+	CheckRunCancelled
+	CheckRunSkipped
+	CheckRunTimedOut
+	CheckRunActionRequired
+)
+
+// AnnotationSeverity is the severity of a check run annotation
+type AnnotationSeverity int
+
+const (
+	AnnotationNotice AnnotationSeverity = iota
+	AnnotationWarning
+	AnnotationFailure
+)
+
 // Permission the ssh key permission on the VCS repository
 type Permission int
 
@@ -33,6 +73,22 @@ const (
 	ReadWrite
 )
 
+// RepositoryPermission the permission level of a user on the VCS repository, ordered from the lowest to the highest level
+type RepositoryPermission int
+
+const (
+	// No access to the repository
+	NoPermission RepositoryPermission = iota
+	// Read and clone the repository
+	ReadPermission
+	// Push to the repository
+	WritePermission
+	// Manage the repository without access to sensitive or destructive settings
+	MaintainPermission
+	// Full access to the repository, including its settings
+	AdminPermission
+)
+
 // RepositoryVisibility the visibility level of the repository
 type RepositoryVisibility int
 
@@ -52,17 +108,130 @@ type VcsInfo struct {
 	Token       string
 	// Project name is relevant for Azure Repos
 	Project string
+	// TLS configuration for all the requests sent to the VCS provider, such as client certificates for mTLS
+	TLSConfig *tls.Config
+	// Skip the creation of the .git folder when downloading a repository.
+	// Saves the I/O and the API call fetching the repository clone URL, when the downloaded content isn't used as a git repository.
+	SkipDotGitCreation bool
+	// OAuth2 consumer key and secret, relevant for Bitbucket Cloud.
+	// The access token is fetched by the client credentials grant, and fetched again when it expires.
+	OAuthClientID     string
+	OAuthClientSecret string
+	// OAuth2 refresh token, relevant for Bitbucket Cloud.
+	// If set with the OAuth2 consumer, the access token is fetched and refreshed by the refresh token grant.
+	OAuthRefreshToken string
+	// Disables the retries of requests rejected for exceeding the rate limit, relevant for GitHub.
+	// Useful on GitHub Enterprise Server instances, on which rate limiting is disabled.
+	// To disable the retries of specific calls only, use a context returned from WithNoRetry.
+	DisableRateLimitRetries bool
+	// Provides Azure AD access tokens, relevant for Azure Repos.
+	// If set, the requests are authenticated by a bearer token from the provider instead of the personal access token.
+	TokenProvider TokenProvider
+	// Logs the method, URL, status, duration and remaining rate limit of each HTTP request at the debug level.
+	// Useful for debugging requests rejected by the VCS provider.
+	HTTPTracing bool
+	// Receives the measurements of the API calls sent to the VCS provider, such as their latency and the remaining rate limit
+	Metrics Metrics
+	// Injects the trace context of each HTTP request into its headers, such as the W3C traceparent header.
+	// The trace context is taken from the context passed to the client method. The requests sent by the
+	// Bitbucket Cloud SDK don't carry the contexts, so they're sent without the trace context.
+	TracePropagator TracePropagator
+	// Keeps the results of the list APIs in the order returned by the VCS provider, instead of the order documented by each of the VcsClient methods.
+	// Useful for consumers which depend on the provider-specific order, and for saving the sorting of large results.
+	ProviderNativeOrder bool
+	// Caches the JSON responses of the GET requests, and revalidates them by conditional requests.
+	// The cache can be shared by several clients, and is bypassed by the reads sent with a context returned from WithoutCache.
+	ResponseCache *ResponseCache
+}
+
+// TokenProvider returns an access token, such as an Azure AD token of a service principal or a federated (workload identity) credential.
+// It's called before each request, so it should cache the token and refresh it before it expires.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// DeployTokenScope is an access scope of a token created by CreateDeployToken
+type DeployTokenScope string
+
+const (
+	// Clone and pull the repository
+	ReadRepositoryScope DeployTokenScope = "read_repository"
+	// Push to the repository. Supported on Bitbucket Server only.
+	WriteRepositoryScope DeployTokenScope = "write_repository"
+	// Pull the images of the container registry of the repository. Supported on GitLab only.
+	ReadRegistryScope DeployTokenScope = "read_registry"
+	// Push images to the container registry of the repository. Supported on GitLab only.
+	WriteRegistryScope DeployTokenScope = "write_registry"
+	// Read the packages of the package registry of the repository. Supported on GitLab only.
+	ReadPackageRegistryScope DeployTokenScope = "read_package_registry"
+	// Publish packages to the package registry of the repository. Supported on GitLab only.
+	WritePackageRegistryScope DeployTokenScope = "write_package_registry"
+)
+
+// DeployTokenInfo is a token created by CreateDeployToken
+type DeployTokenInfo struct {
+	ID   string
+	Name string
+	// The username authenticating git requests with the token, as the password
+	Username string
+	// The secret of the token, which is returned once, when the token is created
+	Token string
+	// The expiration time of the token, or the zero time if it doesn't expire
+	ExpiresAt time.Time
 }
 
 // RepositoryEnvironmentInfo is the environment details configured for a repository
 type RepositoryEnvironmentInfo struct {
 	Name      string
 	Url       string
-	Reviewers []string
+	Reviewers []EnvironmentReviewer
+	// The minutes to wait before the deployments to the environment proceed. Supported on GitHub.
+	WaitTimer int
+	// The branches allowed to deploy to the environment. Nil when all the branches are allowed. Supported on GitHub.
+	DeploymentBranchPolicy *DeploymentBranchPolicy
+}
+
+// EnvironmentReviewerType is the type of the reviewer of an environment
+type EnvironmentReviewerType string
+
+const (
+	EnvironmentReviewerUser EnvironmentReviewerType = "User"
+	EnvironmentReviewerTeam EnvironmentReviewerType = "Team"
+	// A role, such as the maintainers of the project, which is granted to approve the deployments. Supported on GitLab.
+	EnvironmentReviewerRole EnvironmentReviewerType = "Role"
+)
+
+// EnvironmentReviewer is a user, a team or a role required to approve the deployments to an environment
+type EnvironmentReviewer struct {
+	Type EnvironmentReviewerType
+	// The login of a user, the slug of a team on GitHub or the name of a group on GitLab, or the description of a role
+	Name string
+	// The ID of the user or the team, or the access level of the role. When creating or updating an environment,
+	// a zero ID is resolved by the name of the reviewer.
+	ID int64
+}
+
+// DeploymentBranchPolicy restricts the branches allowed to deploy to an environment
+type DeploymentBranchPolicy struct {
+	// Only the protected branches are allowed to deploy
+	ProtectedBranches bool
+	// The name patterns of the branches allowed to deploy, such as "release/*". Ignored if ProtectedBranches is set.
+	CustomBranchPatterns []string
+}
+
+// RepositoryBranchInfo contains the details of a repository branch, as returned by GetBranchInfo
+type RepositoryBranchInfo struct {
+	Name string
+	// The hash of the head commit of the branch
+	HeadCommitHash string
+	// True if the branch is protected by rules restricting the pushes or the merges to it.
+	// On Bitbucket, the restrictions matching the branch by a pattern or by the branching model aren't considered.
+	Protected bool
+	// True if the branch is the default branch of the repository
+	Default bool
 }
 
 // CommitStatusInfo status which is then reflected in pull requests involving those commits
 // State         - One of success, pending, failure, or error
+// Context       - The name identifying the status, which is the title passed to SetCommitStatus
 // Description   - Description of the commit status
 // DetailsUrl    - The URL for component status link
 // Creator       - The creator of the status
@@ -70,6 +239,7 @@ type RepositoryEnvironmentInfo struct {
 // LastUpdatedAt - Date of status last update time.
 type CommitStatusInfo struct {
 	State         CommitStatus
+	Context       string
 	Description   string
 	DetailsUrl    string
 	Creator       string
@@ -77,19 +247,180 @@ type CommitStatusInfo struct {
 	LastUpdatedAt time.Time
 }
 
-// VcsClient is a base class of all Vcs clients - GitHub, GitLab, Bitbucket server and cloud clients
+// CheckRunInfo contains the details of a check run
+type CheckRunInfo struct {
+	Name string
+	// The SHA of the checked commit
+	HeadSHA string
+	Status  CheckRunStatus
+	// Relevant only when the status is CheckRunCompleted
+	Conclusion CheckRunConclusion
+	// The URL for the full details of the check
+	DetailsURL string
+	// The title, summary and text are shown in the check run output
+	Title       string
+	Summary     string
+	Text        string
+	Annotations []CheckRunAnnotation
+}
+
+// CheckRunAnnotation is a message attached to specific lines in a file
+type CheckRunAnnotation struct {
+	Path      string
+	StartLine int
+	// Defaults to the start line
+	EndLine  int
+	Severity AnnotationSeverity
+	Title    string
+	Message  string
+}
+
+// CodeScanningAlertState is the state of a code scanning alert
+type CodeScanningAlertState string
+
+const (
+	AlertOpen      CodeScanningAlertState = "open"
+	AlertDismissed CodeScanningAlertState = "dismissed"
+	AlertFixed     CodeScanningAlertState = "fixed"
+)
+
+// AlertDismissalReason is the reason of dismissing a code scanning alert
+type AlertDismissalReason string
+
+const (
+	DismissFalsePositive AlertDismissalReason = "false positive"
+	DismissWontFix       AlertDismissalReason = "won't fix"
+	DismissUsedInTests   AlertDismissalReason = "used in tests"
+)
+
+// CodeScanningAlertInfo contains the details of a code scanning alert.
+// On GitLab, the alert is a vulnerability found by the GitLab security scanners.
+type CodeScanningAlertInfo struct {
+	ID       int64
+	RuleID   string
+	Title    string
+	State    CodeScanningAlertState
+	Severity string
+	ToolName string
+	// The location of the most recent alert instance, if provided by the VCS provider
+	Path      string
+	StartLine int
+	EndLine   int
+	URL       string
+	CreatedAt time.Time
+	// Date of the alert being fixed or dismissed
+	ClosedAt time.Time
+}
+
+// CodeScanningAlertsQueryOptions specifies the optional parameters for the code scanning alerts list
+type CodeScanningAlertsQueryOptions struct {
+	// Defaults to AlertOpen
+	State CodeScanningAlertState
+	// For example: critical, high, medium, low
+	Severity string
+	// Relevant only for GitHub
+	Branch string
+	// Relevant only for GitHub
+	ToolName string
+	ListOptions
+}
+
+// VulnerabilityAlertInfo is a normalized advisory of a vulnerable dependency in a repository
+type VulnerabilityAlertInfo struct {
+	ID          int64
+	PackageName string
+	// The package ecosystem or package manager as provided by the VCS provider, for example: npm, pip, maven
+	Ecosystem string
+	// The installed version of the package. Provided by GitLab only.
+	PackageVersion string
+	// The range of the vulnerable versions, for example: < 4.17.21. Provided by GitHub only.
+	VulnerableVersionRange string
+	// The first version fixing the vulnerability. Provided by GitHub only.
+	FixedVersion string
+	Severity     string
+	CVE          string
+	Summary      string
+	// The path of the manifest or lock file declaring the dependency
+	ManifestPath string
+	URL          string
+}
+
+// VcsClient is a base class of all Vcs clients - GitHub, GitLab, Bitbucket server and cloud clients.
+// The list methods return their results in the same documented order on all the providers, unless VcsInfo.ProviderNativeOrder is set.
 type VcsClient interface {
 	// TestConnection Returns nil if connection and authorization established successfully
 	TestConnection(ctx context.Context) error
 
-	// ListRepositories Returns a map between all accessible owners to their list of repositories
+	// GetServerVersion Returns the version of the self-hosted VCS provider server.
+	// Returns an empty version on SaaS providers without a version, such as GitHub.com and Bitbucket Cloud, which are always up-to-date.
+	// On Azure Repos, returns the maximal REST API version supported by the server, such as 7.1.
+	// The version is fetched once, and cached for the next calls.
+	GetServerVersion(ctx context.Context) (string, error)
+
+	// Capabilities Returns the capabilities supported by the VCS provider, considering the version of its server
+	Capabilities(ctx context.Context) ([]Capability, error)
+
+	// GetAuthenticatedUser Returns the details of the user authenticated by the client.
+	// Useful to identify the comments and commits of the client itself.
+	GetAuthenticatedUser(ctx context.Context) (UserInfo, error)
+
+	// ListRepositories Returns a map between all accessible owners to their list of repositories, sorted lexicographically
 	ListRepositories(ctx context.Context) (map[string][]string, error)
 
-	// ListBranches Lists all branches under the input repository
+	// ListRepositoryListings Returns all accessible repositories, with the type of their owner, their visibility and their default branch.
+	// Unlike ListRepositories, it tells whether the owner of a repository is a user, an organization, a group, a workspace or a project.
+	// The repositories are sorted by their owners, and then by their names.
+	ListRepositoryListings(ctx context.Context) ([]RepositoryListing, error)
+
+	// SearchRepositories Returns the accessible repositories matching the search filters, by the search of the VCS provider.
+	// Searching by topic is supported on GitHub and GitLab only.
+	// options - The search filters. At least one filter is required.
+	SearchRepositories(ctx context.Context, options RepositorySearchOptions) ([]RepositoryListing, error)
+
+	// SearchCode Returns the matches of a query in the files of the accessible repositories, by the code search of the VCS provider.
+	// Code search isn't supported on Azure Repos. On Bitbucket Cloud, the owner is required.
+	// query - The search query, in the search syntax of the VCS provider
+	// options - Optional filters, scoping the search to an owner or a repository
+	SearchCode(ctx context.Context, query string, options CodeSearchOptions) ([]CodeSearchResult, error)
+
+	// ListNamespaces Returns the namespaces accessible by the client, which own repositories.
+	// GitHub organizations, GitLab groups and subgroups, Bitbucket Cloud workspaces, Bitbucket Server projects and Azure DevOps projects.
+	// The personal namespace of the authenticated user is included on GitHub, GitLab and Bitbucket Server, before the other namespaces sorted by their names.
+	ListNamespaces(ctx context.Context) ([]NamespaceInfo, error)
+
+	// ListBranches Lists all branches under the input repository, sorted lexicographically
 	// owner      - User or organization
 	// repository - VCS repository name
 	ListBranches(ctx context.Context, owner, repository string) ([]string, error)
 
+	// ListBranchesWithOptions Lists the branches under the input repository, filtered by ListBranchesOptions, sorted lexicographically
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - Optional filters of the branches, such as listing only the protected branches
+	ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListBranchesOptions) ([]string, error)
+
+	// GetBranchInfo Returns the head commit of a branch, whether the branch is protected and whether it's the default branch of the repository.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The branch name
+	GetBranchInfo(ctx context.Context, owner, repository, branch string) (RepositoryBranchInfo, error)
+
+	// GetDefaultBranch Returns the name of the default branch of a repository, or an empty string if the repository has no branches
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetDefaultBranch(ctx context.Context, owner, repository string) (string, error)
+
+	// SquashBranchIntoSingleCommit Squashes the commits of a branch since it diverged from the base branch into a single commit,
+	// such as before opening a pull request from a fix branch. The branch is force-updated to the new commit on the server, without cloning the repository.
+	// Supported on GitHub. Returns ErrBranchSquashNotSupported on the other providers.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch to squash
+	// baseBranch - The name of the branch the branch diverged from
+	// message    - The message of the commit, or empty to join the messages of the squashed commits
+	// Returns the hash of the new head commit of the branch.
+	SquashBranchIntoSingleCommit(ctx context.Context, owner, repository, branch, baseBranch, message string) (string, error)
+
 	// CreateWebhook Creates a webhook
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -115,6 +446,31 @@ type VcsClient interface {
 	// webhookID    - The webhook ID returned from a previous CreateWebhook command
 	DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error
 
+	// CreateGroupWebhook Creates a webhook of all the repositories of a group, instead of a webhook for each of the repositories.
+	// The payloads of the webhook are the same as the payloads of the repository webhooks, and are parsed by the webhookparser package.
+	// Supported on GitLab, where the group is a group or a subgroup, and on GitHub, where the group is an organization.
+	// Returns ErrGroupWebhooksNotSupported on the other providers.
+	// group         - The path of the GitLab group, or the GitHub organization
+	// branch        - The branch of the push events, or empty for all the branches. Ignored on GitHub.
+	// payloadURL    - URL to send the payload when a webhook event occurs
+	// webhookEvents - The event type
+	// Return the webhook ID, token and an error, if occurred
+	CreateGroupWebhook(ctx context.Context, group, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error)
+
+	// UpdateGroupWebhook Updates a webhook of a group, which was created by CreateGroupWebhook
+	// group         - The path of the GitLab group, or the GitHub organization
+	// branch        - The branch of the push events, or empty for all the branches. Ignored on GitHub.
+	// payloadURL    - URL to send the payload when a webhook event occurs
+	// token         - A token used to validate identity of the incoming webhook
+	// webhookID     - The webhook ID returned from a previous CreateGroupWebhook command
+	// webhookEvents - The event type
+	UpdateGroupWebhook(ctx context.Context, group, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error
+
+	// DeleteGroupWebhook Deletes a webhook of a group, which was created by CreateGroupWebhook
+	// group        - The path of the GitLab group, or the GitHub organization
+	// webhookID    - The webhook ID returned from a previous CreateGroupWebhook command
+	DeleteGroupWebhook(ctx context.Context, group, webhookID string) error
+
 	// SetCommitStatus Sets commit status
 	// commitStatus - One of Pass, Fail, Error, or InProgress
 	// owner        - User or organization
@@ -125,12 +481,37 @@ type VcsClient interface {
 	// detailsUrl   - The URL for component status link
 	SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error
 
-	// GetCommitStatuses Gets all statuses for a specific commit
+	// SetCommitStatusWithOptions Sets commit status, considering the provider specific CommitStatusOptions provided by the user.
+	// commitStatus - One of Pass, Fail, Error, or InProgress
 	// owner        - User or organization
 	// repository   - VCS repository name
 	// ref          - SHA, a branch name, or a tag name.
+	// title        - Title of the commit status
+	// description  - Description of the commit status
+	// detailsUrl   - The URL for component status link
+	// options      - Optional parameters of the status, such as the key updating an existing status on Bitbucket
+	SetCommitStatusWithOptions(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string, options CommitStatusOptions) error
+
+	// GetCommitStatuses Gets all statuses for a specific commit
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// ref          - SHA, a branch name, or a tag name on GitHub.
+	//                On the other providers, a branch name is resolved to the SHA of its head commit.
 	GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error)
 
+	// CreateCheckRun Creates a check run on a commit, and returns its ID
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// checkRun     - The check run details, including its annotations
+	CreateCheckRun(ctx context.Context, owner, repository string, checkRun CheckRunInfo) (int64, error)
+
+	// UpdateCheckRun Updates an existing check run
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// checkRunID   - The check run ID returned from CreateCheckRun
+	// checkRun     - The check run details. The annotations are added to the existing annotations of the check run.
+	UpdateCheckRun(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo) error
+
 	// DownloadRepository Downloads and extracts a VCS repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -138,6 +519,14 @@ type VcsClient interface {
 	// localPath  - Local file system path
 	DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error
 
+	// DownloadRepositoryWithOptions Downloads and extracts a VCS repository, considering DownloadRepositoryOptions provided by the user.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - VCS branch name
+	// localPath  - Local file system path
+	// options    - Optional parameters for the download, such as the filter of the extracted files
+	DownloadRepositoryWithOptions(ctx context.Context, owner, repository, branch, localPath string, options DownloadRepositoryOptions) error
+
 	// CreatePullRequest Creates a pull request between 2 different branches in the same repository
 	// owner        - User or organization
 	// repository   - VCS repository name
@@ -157,6 +546,44 @@ type VcsClient interface {
 	// state				    - Pull request state
 	UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error
 
+	// ClosePullRequest Closes an open pull request, without changing its other details
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	ClosePullRequest(ctx context.Context, owner, repository string, pullRequestID int) error
+
+	// ReopenPullRequest Reopens a closed pull request, without changing its other details
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	ReopenPullRequest(ctx context.Context, owner, repository string, pullRequestID int) error
+
+	// EnablePullRequestAutoMerge Sets an open pull request to be merged automatically, once its required checks and approvals pass
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// mergeMethod   - The method of merging the pull request, or empty for the default method of the repository
+	EnablePullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod MergeMethod) error
+
+	// MergePullRequest Merges an open pull request
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// options       - The merge options. The zero value merges by the defaults of the repository.
+	MergePullRequest(ctx context.Context, owner, repository string, pullRequestID int, options MergeOptions) error
+
+	// UpdatePullRequestBranch Updates the source branch of a pull request with the latest changes of its target branch, to keep it mergeable.
+	// On GitHub and Azure Repos, the target branch is merged into the source branch by a merge commit.
+	// On GitLab, the source branch is rebased onto the target branch.
+	// On GitHub, the update is completed in the background after returning. On the other providers, it's completed before returning.
+	// A branch which is already up to date isn't changed.
+	// Returns a *PullRequestBranchConflictError if the branch can't be updated because of conflicts,
+	// and ErrPullRequestBranchUpdateNotSupported on Bitbucket.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	UpdatePullRequestBranch(ctx context.Context, owner, repository string, pullRequestID int) error
+
 	// AddPullRequestComment Adds a new comment on the requested pull request
 	// owner          - User or organization
 	// repository     - VCS repository name
@@ -164,14 +591,16 @@ type VcsClient interface {
 	// pullRequestID  - Pull request ID
 	AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error
 
-	// AddPullRequestReviewComments Adds a new review comment on the requested pull request
+	// AddPullRequestReviewComments Adds a new review comment on the requested pull request.
+	// Comments with Blocking set have to be resolved before the pull request can be merged, see PullRequestComment.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestID  - Pull request ID
 	// comment        - The new comment details defined in PullRequestComment
 	AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error
 
-	// ListPullRequestReviewComments Gets all pull request review comments
+	// ListPullRequestReviewComments Gets all pull request review comments.
+	// The comments are sorted by their threads, from the oldest thread, and then by their creation time.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestID  - Pull request ID
@@ -185,6 +614,7 @@ type VcsClient interface {
 	DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error
 
 	// ListPullRequestComments Gets all comments assigned to a pull request.
+	// The comments are sorted by their threads, from the oldest thread, and then by their creation time.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestID  - Pull request ID
@@ -197,40 +627,133 @@ type VcsClient interface {
 	// commentID 	  - The ID of the comment
 	DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error
 
-	// ListOpenPullRequestsWithBody Gets all open pull requests ids and the pull request body.
+	// UpdatePullRequestComment Updates the content of an existing pull request comment.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// commentID 	  - The ID of the comment
+	// content        - The new comment content
+	UpdatePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error
+
+	// UpdatePullRequestReviewComment Updates the content of an existing pull request review comment.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// comment        - The comment to update, as returned from ListPullRequestReviewComments
+	// content        - The new comment content
+	UpdatePullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, comment CommentInfo, content string) error
+
+	// ReplyToPullRequestReviewComment Adds a reply to the thread of an existing pull request review comment.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// threadID       - The ThreadID of the comment to reply to, as returned from ListPullRequestReviewComments
+	// content        - The reply content
+	ReplyToPullRequestReviewComment(ctx context.Context, owner, repository string, pullRequestID int, threadID, content string) error
+
+	// UploadPullRequestAttachment Uploads a file and attaches it to a pull request.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// fileName       - The name of the attached file
+	// content        - The content of the attached file
+	UploadPullRequestAttachment(ctx context.Context, owner, repository string, pullRequestID int, fileName string, content []byte) (PullRequestAttachmentInfo, error)
+
+	// ListPullRequestAttachments Gets all the files attached to a pull request.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	ListPullRequestAttachments(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestAttachmentInfo, error)
+
+	// ListOpenPullRequestsWithBody Gets all open pull requests ids and the pull request body, from the newest to the oldest.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error)
 
-	// ListOpenPullRequests Gets all open pull requests ids.
+	// ListOpenPullRequests Gets all open pull requests ids, from the newest to the oldest.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error)
 
+	// ListOpenPullRequestsWithQueryOptions Gets the open pull requests, filtered by branches and paginated by the options, from the newest to the oldest.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// options        - Optional parameters for filtering and paginating the pull requests
+	ListOpenPullRequestsWithQueryOptions(ctx context.Context, owner, repository string, options PullRequestsQueryOptions) ([]PullRequestInfo, error)
+
+	// ListPullRequests Gets the pull requests in a state, filtered by branches and author, sorted and paginated by the options.
+	// The filters are applied by the API of the VCS provider when supported, and by the client otherwise.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - The filters, the sort order and the pagination of the pull requests. The zero value lists the open pull requests.
+	ListPullRequests(ctx context.Context, owner, repository string, options PullRequestListOptions) ([]PullRequestInfo, error)
+
 	// GetPullRequestByID Gets pull request info by ID.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestId  - ID of the pull request
 	GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error)
 
+	// CreateIssue Creates an issue in a repository, and returns its details.
+	// Issues aren't supported on Bitbucket Server and Azure Repos. On Bitbucket Cloud, the issue tracker of the repository must be enabled.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// title      - Issue title
+	// body       - Issue body or description
+	CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error)
+
+	// ListIssues Gets the issues of a repository in a state, from the newest to the oldest. Pull requests aren't included.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// state      - The state of the issues. Defaults to IssueOpen.
+	ListIssues(ctx context.Context, owner, repository string, state IssueState) ([]IssueInfo, error)
+
+	// CommentOnIssue Adds a new comment on an issue
+	// owner      - User or organization
+	// repository - VCS repository name
+	// content    - The new comment content
+	// issueID    - Issue ID
+	CommentOnIssue(ctx context.Context, owner, repository, content string, issueID int) error
+
+	// CloseIssue Closes an open issue, without changing its other details
+	// owner      - User or organization
+	// repository - VCS repository name
+	// issueID    - Issue ID
+	CloseIssue(ctx context.Context, owner, repository string, issueID int) error
+
 	// GetLatestCommit Gets the most recent commit of a branch
 	// owner      - User or organization
 	// repository - VCS repository name
 	// branch     - The name of the branch
 	GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error)
 
-	// GetCommits Gets the most recent commit of a branch
+	// GetCommits Gets the most recent commits of a branch, from the newest to the oldest
 	// owner      - User or organization
 	// repository - VCS repository name
 	// branch     - The name of the branch
 	GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error)
 
-	// GetCommitsWithQueryOptions Gets repository commits considering GitCommitsQueryOptions provided by the user.
+	// GetCommitsWithQueryOptions Gets repository commits considering GitCommitsQueryOptions provided by the user, from the newest to the oldest.
 	// owner       - User or organization
 	// repository  - VCS repository name
 	// listOptions - Optional parameters for the 'ListCommits' method
 	GetCommitsWithQueryOptions(ctx context.Context, owner, repository string, options GitCommitsQueryOptions) ([]CommitInfo, error)
 
+	// ListCommitsBetween Returns the hashes of the commits reachable from toRef but not from fromRef, from the oldest to the newest.
+	// Supported on GitHub and GitLab. Returns ErrCommitRangePullRequestsNotSupported on the other providers.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// fromRef    - A VCS reference: commit SHA, branch name, tag name
+	// toRef      - A VCS reference: commit SHA, branch name, tag name
+	ListCommitsBetween(ctx context.Context, owner, repository, fromRef, toRef string) ([]string, error)
+
+	// ListMergedPullRequestsOfCommit Returns the merged pull requests associated with a commit, with their merge commit hashes.
+	// Supported on GitHub and GitLab. Returns ErrCommitRangePullRequestsNotSupported on the other providers.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The commit hash
+	ListMergedPullRequestsOfCommit(ctx context.Context, owner, repository, sha string) ([]PullRequestInfo, error)
+
 	// AddSshKeyToRepository Adds a public ssh key to a repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -239,17 +762,91 @@ type VcsClient interface {
 	// permission - Access permission of the key: read or readWrite
 	AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error
 
+	// CreateDeployToken Creates a token authenticating git requests to a single repository, such as a read-only clone credential.
+	// The secret of the token is returned once, and can't be read again.
+	// Supported on GitLab, by project deploy tokens, and on Bitbucket Server, by repository HTTP access tokens.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// name       - Name of the token
+	// scopes     - The access scopes of the token
+	// expiresAt  - The expiration time of the token. The zero time creates a token which doesn't expire, if allowed by the server.
+	CreateDeployToken(ctx context.Context, owner, repository, name string, scopes []DeployTokenScope, expiresAt time.Time) (DeployTokenInfo, error)
+
 	// GetRepositoryInfo Returns information about repository.
 	// owner      - User or organization
 	// repository - VCS repository name
 	GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error)
 
+	// ArchiveRepository Archives a repository, making it read-only
+	// owner         - User or organization
+	// repository    - VCS repository name
+	ArchiveRepository(ctx context.Context, owner, repository string) error
+
+	// UnarchiveRepository Unarchives an archived repository
+	// owner         - User or organization
+	// repository    - VCS repository name
+	UnarchiveRepository(ctx context.Context, owner, repository string) error
+
+	// RenameRepository Renames a repository. The providers redirect the old web URL to the renamed repository.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// newName       - The new name of the repository
+	RenameRepository(ctx context.Context, owner, repository, newName string) error
+
+	// GetRepositoryTopics Returns the topics of a repository, sorted lexicographically.
+	// Supported on GitHub and GitLab, and on Bitbucket Server, where the topics are the labels of the repository.
+	// Returns ErrRepositoryTopicsNotSupported on the other providers.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	GetRepositoryTopics(ctx context.Context, owner, repository string) ([]string, error)
+
+	// SetRepositoryTopics Replaces the topics of a repository. An empty list removes all the topics of the repository.
+	// The topics are lowercased and deduplicated, since GitHub and Bitbucket Server accept only lowercase topics.
+	// Returns ErrRepositoryTopicsNotSupported on the providers without repository topics.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// topics        - The new topics of the repository
+	SetRepositoryTopics(ctx context.Context, owner, repository string, topics []string) error
+
+	// GetUserPermissionOnRepo Returns the permission level of a user on a repository.
+	// The provider specific permissions are normalized, so the levels can be compared, for example to WritePermission.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// username      - The username of the user
+	GetUserPermissionOnRepo(ctx context.Context, owner, repository, username string) (RepositoryPermission, error)
+
+	// ListRepositoryCollaborators Returns the collaborators of a repository with their permission levels, sorted by their usernames.
+	// Supported on GitHub, GitLab, where the collaborators are the members of the project, and Bitbucket Server, where they're the users granted a permission.
+	// Returns ErrCollaboratorsNotSupported on the other providers, and an error for an affiliation the provider doesn't support.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// affiliation   - Filters the collaborators by their affiliation, or empty for AllCollaborators
+	ListRepositoryCollaborators(ctx context.Context, owner, repository string, affiliation CollaboratorAffiliation) ([]CollaboratorInfo, error)
+
+	// GetRepositoryMirrors Returns the mirrors of a repository.
+	// On GitLab, the push mirrors the repository is pushed to. On GitHub Enterprise, the repository the repository is mirrored from, if any.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	GetRepositoryMirrors(ctx context.Context, owner, repository string) ([]RepositoryMirrorInfo, error)
+
+	// SetRepositoryMirror Adds a push mirror to a repository, or updates the mirror with the same URL. Supported on GitLab only.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// mirror        - The mirror configuration. The URL may include the credentials of the mirror.
+	SetRepositoryMirror(ctx context.Context, owner, repository string, mirror RepositoryMirrorInfo) error
+
 	// GetCommitBySha Gets the commit by its SHA
 	// owner      - User or organization
 	// repository - VCS repository name
 	// sha        - The commit hash
 	GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error)
 
+	// GetCommitDiff Gets the changes of a commit, compared to its first parent
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sha        - The commit hash
+	GetCommitDiff(ctx context.Context, owner, repository, sha string) (CommitDiffInfo, error)
+
 	// CreateLabel Creates a label in repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -262,12 +859,19 @@ type VcsClient interface {
 	// name       - Label name
 	GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error)
 
-	// ListPullRequestLabels Gets all labels assigned to a pull request.
+	// ListPullRequestLabels Gets all labels assigned to a pull request, sorted lexicographically.
 	// owner         - User or organization
 	// repository    - VCS repository name
 	// pullRequestID - Pull request ID
 	ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error)
 
+	// LabelPullRequest Adds a label to a pull request
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// name          - Label name
+	// pullRequestID - Pull request ID
+	LabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error
+
 	// UnlabelPullRequest Removes a label from a pull request
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -275,6 +879,23 @@ type VcsClient interface {
 	// pullRequestID - Pull request ID
 	UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error
 
+	// ListMilestones Returns the milestones of a repository in a state.
+	// The milestones are sorted by their due dates, from the earliest, followed by the milestones without a due date,
+	// so the current milestone of a release train is the first one. Supported on GitHub and GitLab.
+	// Returns ErrMilestonesNotSupported on the other providers.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// state         - The state of the milestones. Defaults to MilestoneOpen.
+	ListMilestones(ctx context.Context, owner, repository string, state MilestoneState) ([]MilestoneInfo, error)
+
+	// SetPullRequestMilestone Sets the milestone of a pull request, replacing its current milestone.
+	// Supported on GitHub and GitLab. Returns ErrMilestonesNotSupported on the other providers.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// milestoneID   - The ID of the milestone, as returned by ListMilestones. Zero removes the milestone.
+	SetPullRequestMilestone(ctx context.Context, owner, repository string, pullRequestID int, milestoneID int64) error
+
 	// UploadCodeScanning Upload Scanning Analysis uploads a scanning analysis file to the relevant git provider
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -282,6 +903,32 @@ type VcsClient interface {
 	// scan          - Code scanning analysis
 	UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error)
 
+	// ListCodeScanningAlerts Lists the code scanning alerts of a repository
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// options       - Optional parameters for filtering and paginating the alerts
+	ListCodeScanningAlerts(ctx context.Context, owner, repository string, options CodeScanningAlertsQueryOptions) ([]CodeScanningAlertInfo, error)
+
+	// GetCodeScanningAlert Gets a code scanning alert by its ID
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// alertID       - Code scanning alert ID
+	GetCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64) (CodeScanningAlertInfo, error)
+
+	// DismissCodeScanningAlert Dismisses a code scanning alert, such as an alert fixed outside the uploaded results
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// alertID       - Code scanning alert ID
+	// reason        - The reason of the dismissal
+	// comment       - Optional comment describing the dismissal
+	DismissCodeScanningAlert(ctx context.Context, owner, repository string, alertID int64, reason AlertDismissalReason, comment string) error
+
+	// ListVulnerabilityAlerts Lists the open alerts of vulnerable dependencies in a repository,
+	// such as GitHub Dependabot alerts or GitLab dependency scanning results
+	// owner         - User or organization
+	// repository    - VCS repository name
+	ListVulnerabilityAlerts(ctx context.Context, owner, repository string) ([]VulnerabilityAlertInfo, error)
+
 	// DownloadFileFromRepo Downloads a file from path in a repository
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -289,12 +936,45 @@ type VcsClient interface {
 	// path          - The path to the requested file
 	DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error)
 
+	// DownloadFileFromRepoStream Downloads a file from path in a repository into a writer, without buffering the whole file in memory
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - The name of the branch
+	// path          - The path to the requested file
+	// writer        - The writer the file is streamed into
+	// maxBytes      - The maximal size of the file. The download fails with ErrFileTooLarge after writing maxBytes bytes. Non-positive means no limit.
+	DownloadFileFromRepoStream(ctx context.Context, owner, repository, branch, path string, writer io.Writer, maxBytes int64) error
+
+	// FileExistsInRepo Returns whether a file exists in a repository, without downloading its content.
+	// On GitHub, a directory in the path is also considered existing.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - The name of the branch
+	// path          - The path to the file
+	FileExistsInRepo(ctx context.Context, owner, repository, branch, path string) (bool, error)
+
+	// CILint Validates a CI configuration before committing it, such as a generated .gitlab-ci.yml file.
+	// On GitLab, the configuration is validated by the CI lint API of the project, including its includes.
+	// On GitHub, the configuration is a workflow, which is validated by the client against the structure of the workflow syntax,
+	// without checking the actions it uses and the expressions.
+	// Returns ErrCILintNotSupported on the other providers. An invalid configuration is returned as a result which isn't valid, rather than an error.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// content       - The content of the CI configuration file
+	CILint(ctx context.Context, owner, repository, content string) (CILintResult, error)
+
 	// GetRepositoryEnvironmentInfo Gets the environment info configured for a repository
 	// owner         - User or organization
 	// repository    - VCS repository name
 	// name          - The environment name
 	GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error)
 
+	// CreateOrUpdateEnvironment Creates an environment in a repository, or replaces the protection rules of an existing environment
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// environment   - The environment name, the usernames of the reviewers, the wait timer and the deployment branch policy. The URL is ignored.
+	CreateOrUpdateEnvironment(ctx context.Context, owner, repository string, environment RepositoryEnvironmentInfo) error
+
 	// GetModifiedFiles returns list of file names modified between two VCS references
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -302,6 +982,15 @@ type VcsClient interface {
 	// refAfter      - A VCS reference: commit SHA, branch name, tag name
 	GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error)
 
+	// GetModifiedFilesWithDetails returns the files modified between two VCS references, sorted by their paths,
+	// with the kind of change, the path before a rename and the added and deleted lines count.
+	// The lines count isn't returned by Azure Repos, and is always zero.
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// refBefore     - A VCS reference: commit SHA, branch name, tag name
+	// refAfter      - A VCS reference: commit SHA, branch name, tag name
+	GetModifiedFilesWithDetails(ctx context.Context, owner, repository, refBefore, refAfter string) ([]ModifiedFileInfo, error)
+
 	// GetPullRequestCommentSizeLimit returns the maximum size of a pull request comment
 	GetPullRequestCommentSizeLimit() int
 
@@ -317,8 +1006,10 @@ type CommitInfo struct {
 	AuthorName string
 	// The committer's name
 	CommitterName string
-	// The commit URL
+	// The commit URL. On GitHub, Bitbucket Cloud and Azure Repos, the URL of the commit in the REST API.
 	Url string
+	// The web URL of the commit, which can be opened in a browser
+	HTMLURL string
 	// Seconds from epoch
 	Timestamp int64
 	// The commit message
@@ -329,12 +1020,204 @@ type CommitInfo struct {
 	AuthorEmail string
 }
 
+// CommitDiffInfo contains the changes of a commit
+type CommitDiffInfo struct {
+	// The git unified diff of the commit
+	Diff  string
+	Files []FileDiffInfo
+}
+
+// FileDiffInfo contains the changes of a single file in a diff
+type FileDiffInfo struct {
+	Path string
+	// The path of the file before it was renamed. Empty if the file wasn't renamed.
+	PreviousPath string
+	Additions    int
+	Deletions    int
+}
+
+// FileChangeStatus is the kind of change made to a file
+type FileChangeStatus string
+
+const (
+	FileAdded    FileChangeStatus = "added"
+	FileModified FileChangeStatus = "modified"
+	FileRemoved  FileChangeStatus = "removed"
+	FileRenamed  FileChangeStatus = "renamed"
+)
+
+// MergeMethod is the method of merging a pull request
+type MergeMethod string
+
+const (
+	// MergeCommit merges the pull request by a merge commit
+	MergeCommit MergeMethod = "merge"
+	// SquashMerge squashes the commits of the pull request into a single commit
+	SquashMerge MergeMethod = "squash"
+	// RebaseMerge rebases the commits of the pull request onto the target branch, without a merge commit
+	RebaseMerge MergeMethod = "rebase"
+)
+
+// MergeOptions configures MergePullRequest
+type MergeOptions struct {
+	// The merge method, or empty for the default merge method of the repository
+	Method MergeMethod
+	// The title of the merge commit, or empty for the default title
+	CommitTitle string
+	// The body of the merge commit message, following the title
+	CommitBody string
+	// Deletes the source branch of the pull request after merging it
+	DeleteSourceBranch bool
+	// Merges the pull request even if its branch policies aren't met. Supported on Azure Repos only.
+	BypassPolicies bool
+	// The reason for bypassing the branch policies, recorded on the pull request
+	BypassReason string
+}
+
+// commitMessage returns the merge commit message, composed of the commit title and body
+func (options MergeOptions) commitMessage() string {
+	return strings.TrimSpace(options.CommitTitle + "\n\n" + options.CommitBody)
+}
+
+// ModifiedFileInfo contains the details of a file modified between two VCS references
+type ModifiedFileInfo struct {
+	// The path of the file. For a removed file, the path it was removed from.
+	Path   string
+	Status FileChangeStatus
+	// The path of the file before it was renamed. Empty if the file wasn't renamed.
+	PreviousPath string
+	Additions    int
+	Deletions    int
+}
+
 type CommentInfo struct {
-	ID       int64
+	ID int64
+	// The ID of the thread the comment belongs to, used to reply to the comment
 	ThreadID string
 	Content  string
 	Created  time.Time
 	Version  int
+	// The username of the author of the comment, which can be passed to GetUserPermissionOnRepo.
+	// On Bitbucket Cloud, the UUID of the author. On Azure Repos, the unique name of the author of the first comment of the thread.
+	Author string
+	// Whether the thread of the comment is resolved. Populated only on Azure Repos.
+	Resolved bool
+	// The path of the file a review comment is positioned on, relative to the root of the repository. Empty for a general comment.
+	FilePath string
+	// The line in the new version of the file a review comment is positioned on, or its first line if the comment spans several lines.
+	// Zero for a general comment, or for a comment on a deleted line.
+	Line int
+}
+
+// NamespaceKind is the kind of namespace owning repositories
+type NamespaceKind string
+
+const (
+	OrganizationNamespace NamespaceKind = "organization"
+	GroupNamespace        NamespaceKind = "group"
+	WorkspaceNamespace    NamespaceKind = "workspace"
+	ProjectNamespace      NamespaceKind = "project"
+	UserNamespace         NamespaceKind = "user"
+)
+
+// NamespaceInfo contains the details of a namespace owning repositories, such as an organization or a project
+type NamespaceInfo struct {
+	// The name used as the owner of the repositories in the namespace.
+	// On GitLab, the full path of the group, including its parent groups. On Bitbucket Server, the project key.
+	Name        string
+	DisplayName string
+	Kind        NamespaceKind
+}
+
+// RepositoryListing contains the details of an accessible repository, as returned by ListRepositoryListings
+type RepositoryListing struct {
+	// The owner of the repository, as used in the other APIs. On GitLab, the full path of the group. On Bitbucket Server, the project key.
+	Owner     string
+	OwnerType NamespaceKind
+	Name      string
+	// The visibility of the repository. On Azure Repos, the visibility of the project of the repository.
+	Visibility RepositoryVisibility
+	// The default branch of the repository. Empty if the repository has no branches.
+	DefaultBranch string
+}
+
+// RepositorySearchOptions are the filters of SearchRepositories. The repositories match all the specified filters.
+type RepositorySearchOptions struct {
+	// Optional. The owner of the repositories. On Azure Repos, the project of the repositories, which defaults to the project of the client.
+	Owner string
+	// Optional. A part of the name of the repositories, matched case-insensitively.
+	Name string
+	// Optional. The topic of the repositories. Supported on GitHub and GitLab only.
+	Topic string
+}
+
+func (options RepositorySearchOptions) validate() error {
+	if options.Owner == "" && options.Name == "" && options.Topic == "" {
+		return errors.New("at least one of the owner, name or topic search filters is required")
+	}
+	return nil
+}
+
+// CodeSearchOptions scope the search of SearchCode
+type CodeSearchOptions struct {
+	// Optional. The owner of the searched repositories.
+	Owner string
+	// Optional. The searched repository. Requires the owner.
+	Repository string
+}
+
+func (options CodeSearchOptions) validate(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return errors.New("the code search query is required")
+	}
+	if options.Repository != "" && options.Owner == "" {
+		return errors.New("the owner of the searched repository is required")
+	}
+	return nil
+}
+
+// CodeSearchResult is a match of a code search in a file
+type CodeSearchResult struct {
+	Owner      string
+	Repository string
+	// The path of the file, relative to the root of the repository
+	Path string
+	// The number of the first line of the fragment. Zero on GitHub, which doesn't return line numbers.
+	Line int
+	// The lines of the file around the match
+	Fragment string
+}
+
+// UserInfo contains the details of a VCS user
+type UserInfo struct {
+	// The ID of the user. On Bitbucket Cloud, the UUID of the user, and on Azure Repos, the ID of the identity.
+	ID string
+	// The username. On Azure Repos, the account name, which is usually the email of the user.
+	Login       string
+	DisplayName string
+	// The email of the user. Empty if the email is private, or can't be read by the token of the client.
+	Email string
+}
+
+// IssueState is the state of an issue. The provider specific states are normalized to open and closed.
+type IssueState string
+
+const (
+	IssueOpen   IssueState = "open"
+	IssueClosed IssueState = "closed"
+)
+
+// IssueInfo contains the details of an issue
+type IssueInfo struct {
+	// The number of the issue in the repository. On GitLab, the internal ID of the issue.
+	ID    int64
+	Title string
+	Body  string
+	State IssueState
+	URL   string
+	// The username of the author
+	Author    string
+	CreatedAt time.Time
 }
 
 type PullRequestInfo struct {
@@ -343,6 +1226,41 @@ type PullRequestInfo struct {
 	URL    string
 	Source BranchInfo
 	Target BranchInfo
+	// The web URL of the pull request, which can be opened in a browser
+	HTMLURL string
+	// The hash of the head commit of the source branch. On Bitbucket Cloud, the abbreviated hash.
+	HeadCommitHash string
+	// The username of the author. On Azure Repos, the unique name of the author, which is usually an email.
+	Author string
+	// The time of the last update of the pull request, such as a push or a comment.
+	// On Azure Repos, which doesn't return the time of the last update, the creation time.
+	UpdatedAt time.Time
+	// The reviewers and the participants of the pull request, with their approvals. Returned by GetPullRequestByID on Bitbucket Cloud.
+	Participants []PullRequestParticipant
+	// The hash of the commit which merged the pull request, such as the merge commit or the squashed commit.
+	// Returned by GetPullRequestsByCommitRange.
+	MergeCommitHash string
+}
+
+// PullRequestParticipantRole is the role of a participant of a pull request
+type PullRequestParticipantRole string
+
+const (
+	// A reviewer requested by the author
+	ReviewerRole PullRequestParticipantRole = "reviewer"
+	// A user who commented on or approved the pull request, without being requested as a reviewer
+	ParticipantRole PullRequestParticipantRole = "participant"
+)
+
+// PullRequestParticipant is a reviewer or a participant of a pull request
+type PullRequestParticipant struct {
+	// The username of the participant
+	Username string
+	Role     PullRequestParticipantRole
+	// True if the participant approved the pull request
+	Approved bool
+	// True if the participant requested changes in the pull request
+	ChangesRequested bool
 }
 
 type BranchInfo struct {
@@ -357,6 +1275,11 @@ type BranchInfo struct {
 type PullRequestComment struct {
 	CommentInfo
 	PullRequestDiff
+	// Create the comment as a blocking item, which has to be resolved before the pull request can be merged.
+	// On Bitbucket Server the comment is created as a task, and on Bitbucket Cloud a task is attached to the comment.
+	// GitLab and Azure Repos review comments are always created as resolvable threads, which block merging if the repository requires resolving all the threads.
+	// Ignored on GitHub.
+	Blocking bool
 }
 
 // PullRequestDiff contains the details of the pull request diff
@@ -383,12 +1306,30 @@ type PullRequestDiff struct {
 	NewEndColumn        int
 }
 
+// PullRequestAttachmentInfo contains the details of a file attached to a pull request
+type PullRequestAttachmentInfo struct {
+	Name string
+	// The URL to download the attachment from
+	URL string
+	// A markdown link to the attachment, ready to be added to a pull request description or comment
+	Markdown string
+}
+
 // RepositoryInfo contains general information about the repository.
 type RepositoryInfo struct {
 	CloneInfo            CloneInfo
 	RepositoryVisibility RepositoryVisibility
 }
 
+// RepositoryMirrorInfo contains the mirroring configuration of a repository
+type RepositoryMirrorInfo struct {
+	// The URL of the mirror. The credentials of the mirror are masked or omitted in the returned URLs.
+	URL     string
+	Enabled bool
+	// Mirror only the protected branches. GitLab only.
+	OnlyProtectedBranches bool
+}
+
 // CloneInfo contains URLs that can be used to clone the repository.
 type CloneInfo struct {
 	// HTTP is a URL string to clone repository using HTTP(S)) protocol.
@@ -412,6 +1353,118 @@ type GitCommitsQueryOptions struct {
 	ListOptions
 }
 
+// PullRequestsQueryOptions specifies the optional parameters for the open pull requests list.
+type PullRequestsQueryOptions struct {
+	// The name of the branch the pull requests are merged from
+	SourceBranch string
+	// The name of the branch the pull requests are merged into
+	TargetBranch string
+	// Include the pull requests body in the response
+	WithBody bool
+	ListOptions
+}
+
+// PullRequestListState is the state of the pull requests listed by ListPullRequests
+type PullRequestListState string
+
+const (
+	PullRequestsOpen PullRequestListState = "open"
+	// Closed without being merged. Declined on Bitbucket and abandoned on Azure Repos.
+	PullRequestsClosed PullRequestListState = "closed"
+	PullRequestsMerged PullRequestListState = "merged"
+	PullRequestsAll    PullRequestListState = "all"
+)
+
+// PullRequestSort is the field the pull requests listed by ListPullRequests are sorted by
+type PullRequestSort string
+
+const (
+	SortByCreated PullRequestSort = "created"
+	SortByUpdated PullRequestSort = "updated"
+)
+
+// PullRequestListOptions specifies the filters, the sort order and the pagination of ListPullRequests.
+type PullRequestListOptions struct {
+	// Defaults to PullRequestsOpen
+	State PullRequestListState
+	// The name of the branch the pull requests are merged from
+	SourceBranch string
+	// The name of the branch the pull requests are merged into
+	TargetBranch string
+	// The username of the author, as returned in PullRequestInfo
+	Author string
+	// Defaults to SortByCreated, from the newest to the oldest
+	Sort PullRequestSort
+	// Sorts from the oldest to the newest
+	Ascending bool
+	// Include the pull requests body in the response
+	WithBody bool
+	ListOptions
+}
+
+// sortPullRequests sorts the pull requests by the options, for providers which don't sort them by the API.
+// The pull request IDs are increasing, so the pull requests are sorted by their IDs for SortByCreated.
+func sortPullRequests(pullRequests []PullRequestInfo, options PullRequestListOptions) {
+	sort.SliceStable(pullRequests, func(i, j int) bool {
+		first, second := pullRequests[i], pullRequests[j]
+		if !options.Ascending {
+			first, second = second, first
+		}
+		if options.Sort == SortByUpdated {
+			return first.UpdatedAt.Before(second.UpdatedAt)
+		}
+		return first.ID < second.ID
+	})
+}
+
+// CommitStatusOptions specifies the optional parameters of a commit status, which are provider specific.
+type CommitStatusOptions struct {
+	// The key identifying the status. Setting a status with the key of an existing status updates it, instead of adding another status.
+	// On Bitbucket, the build key, which defaults to the title. On Azure Repos, the name of the status context, which defaults to the owner.
+	// GitHub and GitLab identify the statuses by their title, and ignore the key.
+	Key string
+	// GitLab only - The code coverage percentage of the commit
+	Coverage *float64
+	// GitLab only - The ID of the pipeline to set the status in, required when the commit has several pipelines
+	PipelineID int
+	// Machine-readable metadata of the status, such as the ID of a scan, which is read back by DecodeCommitStatusMetadata.
+	// The metadata is appended to the description as a JSON object, so it's visible in the UI of the provider,
+	// and counts toward the description length limit of the provider, such as 140 characters on GitHub.
+	Metadata map[string]string
+}
+
+// ListBranchesOptions specifies the optional filters of the listed branches.
+type ListBranchesOptions struct {
+	// List only the protected branches, as detected by GetBranchInfo, by the protection rules of the repository.
+	// On Bitbucket, the restrictions matching the branches by a pattern or by the branching model aren't considered.
+	ProtectedOnly bool
+}
+
+// DownloadRepositoryOptions specifies the optional parameters for the repository download.
+type DownloadRepositoryOptions struct {
+	// Filter of the extracted files, such as excluding vendored node_modules and vendor directories.
+	// On GitLab and Bitbucket Server, a single included directory is also used to download only the directory archive.
+	Filter vcsutils.PathFilter
+	// Called after each file extracted from the repository archive, with the statistics of the extraction so far
+	ExtractionProgress vcsutils.ExtractionProgress
+	// If set, filled with the result of the download, such as the statistics of the extracted files
+	Result *DownloadRepositoryResult
+}
+
+// DownloadRepositoryResult is the result of a repository download
+type DownloadRepositoryResult struct {
+	// The statistics of the files extracted from the repository archive
+	Extraction vcsutils.ExtractionStats
+}
+
+// reportRepositoryExtraction logs the statistics of the files extracted from the repository archive, and sets them in the result of the download, if requested
+func reportRepositoryExtraction(logger vcsutils.Log, options DownloadRepositoryOptions, stats vcsutils.ExtractionStats) {
+	logger.Debug(fmt.Sprintf("Extracted %d files (%d bytes) from the repository archive in %s", stats.Files, stats.Bytes, stats.Duration))
+	if options.Result != nil {
+		options.Result.Extraction = stats
+	}
+}
+
 // ListOptions specifies the optional parameters to various List methods that support offset pagination.
 type ListOptions struct {
 	// For paginated result sets, page of results to retrieve.
@@ -420,6 +1473,19 @@ type ListOptions struct {
 	PerPage int
 }
 
+// paginate returns the page of the items, for providers which don't filter the items by the API.
+// Returns all the items if no page size is specified.
+func paginate[T any](items []T, options ListOptions) []T {
+	if options.PerPage <= 0 {
+		return items
+	}
+	start := (max(options.Page, 1) - 1) * options.PerPage
+	if start >= len(items) {
+		return nil
+	}
+	return items[start:min(start+options.PerPage, len(items))]
+}
+
 func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	var errorMessages []string
 	for k, v := range paramNameValueMap {
@@ -433,6 +1499,42 @@ func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	return nil
 }
 
+// Validates that a pull request can be moved from its current state to the target state.
+// Merged pull requests can be neither closed nor reopened.
+func validatePullRequestStateTransition(pullRequestID int, merged bool, currentState, targetState vcsutils.PullRequestState) error {
+	if merged {
+		return fmt.Errorf("pull request %d is merged and its state can't be changed", pullRequestID)
+	}
+	if currentState == targetState {
+		return fmt.Errorf("pull request %d is already %s", pullRequestID, currentState)
+	}
+	return nil
+}
+
+func newPullRequestAttachmentInfo(name, url string) PullRequestAttachmentInfo {
+	return PullRequestAttachmentInfo{Name: name, URL: url, Markdown: fmt.Sprintf("[%s](%s)", name, url)}
+}
+
+// getModifiedFileNames returns the sorted paths of the modified files, including the paths of the renamed files before they were renamed
+func getModifiedFileNames(modifiedFiles []ModifiedFileInfo) []string {
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, modifiedFile := range modifiedFiles {
+		fileNamesSet.Add(modifiedFile.Path)
+		fileNamesSet.Add(modifiedFile.PreviousPath)
+	}
+	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList
+}
+
+func sortModifiedFiles(modifiedFiles []ModifiedFileInfo) []ModifiedFileInfo {
+	sort.SliceStable(modifiedFiles, func(i, j int) bool {
+		return modifiedFiles[i].Path < modifiedFiles[j].Path
+	})
+	return modifiedFiles
+}
+
 // commitStatusAsStringToStatus maps status as string to CommitStatus
 // Handles all the different statuses for every VCS provider
 func commitStatusAsStringToStatus(rawStatus string) CommitStatus {
@@ -454,3 +1556,29 @@ func extractTimeWithFallback(timeObject *time.Time) time.Time {
 	}
 	return timeObject.UTC()
 }
+
+// newHTTPClient creates an HTTP client which uses the TLS configuration of the VcsInfo, if provided.
+// If the HTTP tracing is enabled, the requests are logged by the logger, and if the metrics are set, the requests are reported to them.
+// If the response cache is set, the GET requests are served from it, or revalidated by conditional requests.
+// If the trace propagator is set, the trace context is injected into the requests before they're logged.
+func newHTTPClient(vcsProvider vcsutils.VcsProvider, vcsInfo VcsInfo, logger vcsutils.Log) *http.Client {
+	httpClient := &http.Client{}
+	if vcsInfo.TLSConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = vcsInfo.TLSConfig.Clone()
+		httpClient.Transport = transport
+	}
+	if vcsInfo.HTTPTracing {
+		httpClient.Transport = vcsutils.NewHTTPTracingTransport(httpClient.Transport, logger)
+	}
+	if vcsInfo.Metrics != nil {
+		httpClient.Transport = &metricsTransport{RoundTripper: httpClient.Transport, provider: vcsProvider, metrics: vcsInfo.Metrics}
+	}
+	if vcsInfo.ResponseCache != nil {
+		httpClient.Transport = &responseCachingTransport{RoundTripper: httpClient.Transport, provider: vcsProvider, cache: vcsInfo.ResponseCache}
+	}
+	if vcsInfo.TracePropagator != nil {
+		httpClient.Transport = &tracePropagationTransport{RoundTripper: httpClient.Transport, propagator: vcsInfo.TracePropagator}
+	}
+	return httpClient
+}
@@ -0,0 +1,92 @@
+package vcsutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ConventionalCommitRule identifies a rule of the conventional commits specification - https://www.conventionalcommits.org
+type ConventionalCommitRule string
+
+const (
+	// The header should match the '<type>[(scope)][!]: <subject>' format
+	HeaderFormatRule ConventionalCommitRule = "header-format"
+	// The type should be one of the conventional commit types
+	TypeEnumRule ConventionalCommitRule = "type-enum"
+	// The header should not exceed the maximum length
+	HeaderMaxLengthRule ConventionalCommitRule = "header-max-length"
+	// The body should be separated from the header by a blank line
+	BodyLeadingBlankRule ConventionalCommitRule = "body-leading-blank"
+
+	// The maximal commit message header length, as commonly enforced by commitlint
+	conventionalCommitHeaderMaxLength = 100
+)
+
+// ConventionalCommitTypes are the commit types accepted by ValidateCommitMessage and ValidatePullRequestTitle
+var ConventionalCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+var conventionalCommitHeaderRegexp = regexp.MustCompile(`^(\w+)(?:\(([^()\r\n]+)\))?!?: \S.*$`)
+
+// The maximal pull request title length accepted by each VCS provider
+var pullRequestTitleMaxLength = map[VcsProvider]int{
+	GitHub:          256,
+	GitLab:          255,
+	BitbucketServer: 255,
+	BitbucketCloud:  255,
+	AzureRepos:      400,
+}
+
+// ConventionalCommitViolation describes a rule of the conventional commits specification, which a commit message or a pull request title breaks
+type ConventionalCommitViolation struct {
+	Rule    ConventionalCommitRule
+	Message string
+}
+
+func (violation ConventionalCommitViolation) String() string {
+	return fmt.Sprintf("%s: %s", violation.Rule, violation.Message)
+}
+
+// ValidateCommitMessage validates a commit message against the conventional commits specification.
+// Returns the violations found in the message, or nil if the message is valid.
+func ValidateCommitMessage(message string) []ConventionalCommitViolation {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	violations := validateConventionalCommitHeader(lines[0], conventionalCommitHeaderMaxLength)
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		violations = append(violations, ConventionalCommitViolation{Rule: BodyLeadingBlankRule, Message: "the body must be separated from the header by a blank line"})
+	}
+	return violations
+}
+
+// ValidatePullRequestTitle validates a pull request title against the conventional commits header format,
+// and against the maximal title length of the VCS provider.
+// Returns the violations found in the title, or nil if the title is valid.
+func ValidatePullRequestTitle(provider VcsProvider, title string) []ConventionalCommitViolation {
+	maxLength, exists := pullRequestTitleMaxLength[provider]
+	if !exists {
+		maxLength = conventionalCommitHeaderMaxLength
+	}
+	return validateConventionalCommitHeader(title, maxLength)
+}
+
+func validateConventionalCommitHeader(header string, maxLength int) (violations []ConventionalCommitViolation) {
+	if length := len([]rune(header)); length > maxLength {
+		violations = append(violations, ConventionalCommitViolation{
+			Rule:    HeaderMaxLengthRule,
+			Message: fmt.Sprintf("the header is %d characters long, while the maximal length is %d", length, maxLength),
+		})
+	}
+	match := conventionalCommitHeaderRegexp.FindStringSubmatch(header)
+	if match == nil {
+		return append(violations, ConventionalCommitViolation{Rule: HeaderFormatRule, Message: "the header must match the '<type>[(scope)][!]: <subject>' format"})
+	}
+	if !slices.Contains(ConventionalCommitTypes, match[1]) {
+		violations = append(violations, ConventionalCommitViolation{
+			Rule:    TypeEnumRule,
+			Message: fmt.Sprintf("the type '%s' must be one of [%s]", match[1], strings.Join(ConventionalCommitTypes, ", ")),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,50 @@
+package vcsutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCommitMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		expectedRules []ConventionalCommitRule
+	}{
+		{name: "valid", message: "fix: handle empty responses"},
+		{name: "valid with scope and breaking change", message: "feat(github)!: remove deprecated API"},
+		{name: "valid with body", message: "docs: update README\n\nAdd the check runs section\n"},
+		{name: "missing type", message: "handle empty responses", expectedRules: []ConventionalCommitRule{HeaderFormatRule}},
+		{name: "missing subject", message: "fix: ", expectedRules: []ConventionalCommitRule{HeaderFormatRule}},
+		{name: "unknown type", message: "bugfix: handle empty responses", expectedRules: []ConventionalCommitRule{TypeEnumRule}},
+		{name: "long header", message: "fix: " + strings.Repeat("a", 100), expectedRules: []ConventionalCommitRule{HeaderMaxLengthRule}},
+		{name: "body without a blank line", message: "fix: handle empty responses\nbody", expectedRules: []ConventionalCommitRule{BodyLeadingBlankRule}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedRules, getViolatedRules(ValidateCommitMessage(test.message)))
+		})
+	}
+}
+
+func TestValidatePullRequestTitle(t *testing.T) {
+	title := "feat: " + strings.Repeat("a", 300)
+	assert.Equal(t, []ConventionalCommitRule{HeaderMaxLengthRule}, getViolatedRules(ValidatePullRequestTitle(GitHub, title)))
+	assert.Equal(t, []ConventionalCommitRule{HeaderMaxLengthRule}, getViolatedRules(ValidatePullRequestTitle(GitLab, title)))
+	assert.Empty(t, ValidatePullRequestTitle(AzureRepos, title))
+	assert.Empty(t, ValidatePullRequestTitle(BitbucketCloud, "chore(deps): upgrade go-github"))
+
+	violations := ValidatePullRequestTitle(BitbucketServer, "Upgrade go-github")
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "header-format: the header must match the '<type>[(scope)][!]: <subject>' format", violations[0].String())
+	}
+}
+
+func getViolatedRules(violations []ConventionalCommitViolation) (rules []ConventionalCommitRule) {
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package vcsutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The number of leading bytes searched for a NUL byte to detect binary content, as done by git
+const binaryDetectionBytes = 8000
+
+// FileEncoding is the detected character encoding of a text file
+type FileEncoding string
+
+const (
+	UTF8Encoding    FileEncoding = "UTF-8"
+	UTF16LEEncoding FileEncoding = "UTF-16LE"
+	UTF16BEEncoding FileEncoding = "UTF-16BE"
+	// Text which isn't valid UTF-8, decoded byte by byte
+	Latin1Encoding FileEncoding = "ISO-8859-1"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// FileContentInfo is the metadata of the content of a file, with the content of a text file normalized to UTF-8
+type FileContentInfo struct {
+	// True if the content is binary, which is detected by a NUL byte in its first 8000 bytes, as done by git
+	IsBinary bool
+	// The detected encoding of a text file. Empty for binary files.
+	Encoding FileEncoding
+	// True if the content starts with a byte order mark
+	HasBOM bool
+	// The content of a text file decoded to UTF-8, without the byte order mark. Nil for binary files.
+	NormalizedContent []byte
+}
+
+// DetectFileContent detects whether the content of a file is binary, and the encoding of a text file, which is normalized to UTF-8.
+// Text files are detected as UTF-16 by their byte order mark, and as UTF-8 if they are valid UTF-8. The rest are decoded as ISO-8859-1.
+func DetectFileContent(content []byte) FileContentInfo {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return detectUTF8Content(content[len(utf8BOM):], true)
+	case bytes.HasPrefix(content, utf16LEBOM):
+		return FileContentInfo{Encoding: UTF16LEEncoding, HasBOM: true, NormalizedContent: decodeUTF16(content[len(utf16LEBOM):], binary.LittleEndian)}
+	case bytes.HasPrefix(content, utf16BEBOM):
+		return FileContentInfo{Encoding: UTF16BEEncoding, HasBOM: true, NormalizedContent: decodeUTF16(content[len(utf16BEBOM):], binary.BigEndian)}
+	case bytes.IndexByte(content[:min(len(content), binaryDetectionBytes)], 0) >= 0:
+		return FileContentInfo{IsBinary: true}
+	default:
+		return detectUTF8Content(content, false)
+	}
+}
+
+func detectUTF8Content(content []byte, hasBOM bool) FileContentInfo {
+	if utf8.Valid(content) {
+		return FileContentInfo{Encoding: UTF8Encoding, HasBOM: hasBOM, NormalizedContent: content}
+	}
+	normalizedContent := make([]byte, 0, len(content))
+	for _, b := range content {
+		normalizedContent = utf8.AppendRune(normalizedContent, rune(b))
+	}
+	return FileContentInfo{Encoding: Latin1Encoding, HasBOM: hasBOM, NormalizedContent: normalizedContent}
+}
+
+// decodeUTF16 decodes UTF-16 content to UTF-8. A trailing odd byte is ignored.
+func decodeUTF16(content []byte, byteOrder binary.ByteOrder) []byte {
+	codeUnits := make([]uint16, len(content)/2)
+	for i := range codeUnits {
+		codeUnits[i] = byteOrder.Uint16(content[2*i:])
+	}
+	normalizedContent := make([]byte, 0, len(content))
+	for _, r := range utf16.Decode(codeUnits) {
+		normalizedContent = utf8.AppendRune(normalizedContent, r)
+	}
+	return normalizedContent
+}
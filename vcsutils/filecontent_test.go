@@ -0,0 +1,28 @@
+package vcsutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFileContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		expected FileContentInfo
+	}{
+		{name: "UTF-8", content: []byte(`{"name": "café"}`), expected: FileContentInfo{Encoding: UTF8Encoding, NormalizedContent: []byte(`{"name": "café"}`)}},
+		{name: "UTF-8 with BOM", content: []byte("\xEF\xBB\xBF{}"), expected: FileContentInfo{Encoding: UTF8Encoding, HasBOM: true, NormalizedContent: []byte("{}")}},
+		{name: "UTF-16LE", content: []byte("\xFF\xFE{\x00\xE9\x00}\x00"), expected: FileContentInfo{Encoding: UTF16LEEncoding, HasBOM: true, NormalizedContent: []byte("{é}")}},
+		{name: "UTF-16BE", content: []byte("\xFE\xFF\x00{\x00\xE9\x00}"), expected: FileContentInfo{Encoding: UTF16BEEncoding, HasBOM: true, NormalizedContent: []byte("{é}")}},
+		{name: "ISO-8859-1", content: []byte("caf\xE9"), expected: FileContentInfo{Encoding: Latin1Encoding, NormalizedContent: []byte("café")}},
+		{name: "binary", content: []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), expected: FileContentInfo{IsBinary: true}},
+		{name: "empty", content: []byte{}, expected: FileContentInfo{Encoding: UTF8Encoding, NormalizedContent: []byte{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DetectFileContent(test.content))
+		})
+	}
+}
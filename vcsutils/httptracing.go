@@ -0,0 +1,56 @@
+package vcsutils
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The headers of the remaining rate limit: X-RateLimit-Remaining on GitHub, Bitbucket Cloud and Azure Repos, and RateLimit-Remaining on GitLab
+var rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+
+// GetRateLimitRemaining returns the remaining rate limit from the headers of a response, or an empty string if the VCS provider didn't return it
+func GetRateLimitRemaining(header http.Header) string {
+	for _, name := range rateLimitRemainingHeaders {
+		if remaining := header.Get(name); remaining != "" {
+			return remaining
+		}
+	}
+	return ""
+}
+
+// HTTPTracingTransport logs the method, URL, status, duration and remaining rate limit of each HTTP request at the debug level.
+// The headers and the bodies of the requests aren't logged, so the credentials aren't exposed in the logs.
+type HTTPTracingTransport struct {
+	// The transport sending the requests, or http.DefaultTransport if nil
+	RoundTripper http.RoundTripper
+	Logger       Log
+}
+
+// NewHTTPTracingTransport wraps the transport with an HTTPTracingTransport logging its requests to the logger
+func NewHTTPTracingTransport(roundTripper http.RoundTripper, logger Log) *HTTPTracingTransport {
+	return &HTTPTracingTransport{RoundTripper: roundTripper, Logger: logger}
+}
+
+func (transport *HTTPTracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper := transport.RoundTripper
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	start := time.Now()
+	response, err := roundTripper.RoundTrip(request)
+	duration := time.Since(start).Round(time.Millisecond)
+	// The user info of the URL may hold credentials
+	requestURL := request.URL.Redacted()
+	if err != nil {
+		// Includes the cancellation and the deadline of the request context
+		transport.Logger.Debug(fmt.Sprintf("HTTP request: method=%s url=%s duration=%s error=%q", request.Method, requestURL, duration, err.Error()))
+		return response, err
+	}
+	message := fmt.Sprintf("HTTP request: method=%s url=%s status=%d duration=%s", request.Method, requestURL, response.StatusCode, duration)
+	if remaining := GetRateLimitRemaining(response.Header); remaining != "" {
+		message += " rate_limit_remaining=" + remaining
+	}
+	transport.Logger.Debug(message)
+	return response, err
+}
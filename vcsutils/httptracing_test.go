@@ -0,0 +1,57 @@
+package vcsutils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type debugRecordingLogger struct {
+	EmptyLogger
+	messages []string
+}
+
+func (logger *debugRecordingLogger) Debug(a ...interface{}) {
+	logger.messages = append(logger.messages, fmt.Sprint(a...))
+}
+
+func TestHTTPTracingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.Header().Set("RateLimit-Remaining", "42")
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	logger := &debugRecordingLogger{}
+	httpClient := &http.Client{Transport: NewHTTPTracingTransport(nil, logger)}
+
+	response, err := httpClient.Get(server.URL + "/repos?page=1")
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Len(t, logger.messages, 1)
+	assert.Regexp(t, fmt.Sprintf(`^HTTP request: method=GET url=%s/repos\?page=1 status=404 duration=\S+$`, server.URL), logger.messages[0])
+
+	response, err = httpClient.Get(server.URL + "/limited")
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Len(t, logger.messages, 2)
+	assert.Contains(t, logger.messages[1], "status=404")
+	assert.Contains(t, logger.messages[1], "rate_limit_remaining=42")
+
+	// The failed requests are logged with their error, and without the credentials of the URL
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://user:secret@"+server.Listener.Addr().String()+"/repos", nil)
+	assert.NoError(t, err)
+	_, err = httpClient.Do(request)
+	assert.Error(t, err)
+	assert.Len(t, logger.messages, 3)
+	assert.Contains(t, logger.messages[2], "method=POST")
+	assert.Contains(t, logger.messages[2], "error=")
+	assert.NotContains(t, logger.messages[2], "secret")
+}
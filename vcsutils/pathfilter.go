@@ -0,0 +1,81 @@
+package vcsutils
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PathFilter filters the files extracted from a repository archive by glob patterns, similarly to .gitignore patterns.
+// A pattern without a slash, such as "node_modules" or "*.go", matches a file or a directory name in any depth.
+// A pattern with a slash, such as "/vendor" or "src/*/testdata", matches a path relative to the repository root.
+// A pattern matching a directory applies to all the files in the directory.
+// The patterns syntax is described in https://pkg.go.dev/path#Match.
+type PathFilter struct {
+	// If not empty, only files matching one of the patterns are extracted
+	Include []string
+	// Files matching one of the patterns are not extracted
+	Exclude []string
+}
+
+// IsEmpty returns true if the filter extracts all the files
+func (filter PathFilter) IsEmpty() bool {
+	return len(filter.Include) == 0 && len(filter.Exclude) == 0
+}
+
+// ShouldExtract returns true if the file in the relative path should be extracted
+func (filter PathFilter) ShouldExtract(relativePath string) bool {
+	relativePath = strings.Trim(filepath.ToSlash(relativePath), "/")
+	for _, pattern := range filter.Exclude {
+		if matchPathPattern(pattern, relativePath) {
+			return false
+		}
+	}
+	if len(filter.Include) == 0 {
+		return true
+	}
+	for _, pattern := range filter.Include {
+		if matchPathPattern(pattern, relativePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIncludedDir returns the directory included by the filter, if it includes a single directory without glob characters.
+// VCS providers supporting archives of a single directory use it to avoid downloading the files that aren't extracted anyway.
+func (filter PathFilter) GetIncludedDir() string {
+	if len(filter.Include) != 1 {
+		return ""
+	}
+	pattern := strings.TrimSuffix(filepath.ToSlash(filter.Include[0]), "/")
+	if !isAnchoredPattern(pattern) || strings.ContainsAny(pattern, `*?[\`) {
+		return ""
+	}
+	return strings.TrimPrefix(pattern, "/")
+}
+
+// The path matches the pattern, if the pattern matches the path or one of its parent directories
+func matchPathPattern(pattern, relativePath string) bool {
+	pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+	components := strings.Split(relativePath, "/")
+	if !isAnchoredPattern(pattern) {
+		for _, component := range components {
+			if matched, _ := path.Match(pattern, component); matched {
+				return true
+			}
+		}
+		return false
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	for i := range components {
+		if matched, _ := path.Match(pattern, strings.Join(components[:i+1], "/")); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isAnchoredPattern(pattern string) bool {
+	return strings.Contains(pattern, "/")
+}
@@ -0,0 +1,41 @@
+package vcsutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathFilterShouldExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   PathFilter
+		path     string
+		expected bool
+	}{
+		{name: "empty filter", filter: PathFilter{}, path: "src/main.go", expected: true},
+		{name: "excluded directory name", filter: PathFilter{Exclude: []string{"node_modules"}}, path: "web/node_modules/lodash/index.js", expected: false},
+		{name: "excluded directory name with trailing slash", filter: PathFilter{Exclude: []string{"node_modules/"}}, path: "node_modules/lodash/index.js", expected: false},
+		{name: "not excluded", filter: PathFilter{Exclude: []string{"node_modules"}}, path: "web/index.js", expected: true},
+		{name: "anchored exclude", filter: PathFilter{Exclude: []string{"/vendor"}}, path: "vendor/github.com/a/a.go", expected: false},
+		{name: "anchored exclude in a subdirectory", filter: PathFilter{Exclude: []string{"/vendor"}}, path: "src/vendor/a.go", expected: true},
+		{name: "included file name", filter: PathFilter{Include: []string{"*.go"}}, path: "src/main.go", expected: true},
+		{name: "not included", filter: PathFilter{Include: []string{"*.go"}}, path: "README.md", expected: false},
+		{name: "included directory", filter: PathFilter{Include: []string{"src/*/testdata"}}, path: "src/utils/testdata/a.json", expected: true},
+		{name: "included and excluded", filter: PathFilter{Include: []string{"*.go"}, Exclude: []string{"vendor"}}, path: "vendor/a.go", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.filter.ShouldExtract(test.path))
+		})
+	}
+}
+
+func TestPathFilterGetIncludedDir(t *testing.T) {
+	assert.Equal(t, "src/main", PathFilter{Include: []string{"/src/main/"}}.GetIncludedDir())
+	assert.Equal(t, "src/main", PathFilter{Include: []string{"src/main"}, Exclude: []string{"testdata"}}.GetIncludedDir())
+	assert.Empty(t, PathFilter{Include: []string{"src"}}.GetIncludedDir())
+	assert.Empty(t, PathFilter{Include: []string{"src/*"}}.GetIncludedDir())
+	assert.Empty(t, PathFilter{Include: []string{"/src", "/docs"}}.GetIncludedDir())
+	assert.Empty(t, PathFilter{}.GetIncludedDir())
+}
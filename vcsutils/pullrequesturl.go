@@ -0,0 +1,105 @@
+package vcsutils
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PullRequestURLInfo contains the details of a pull request, parsed from its web URL
+type PullRequestURLInfo struct {
+	Provider VcsProvider
+	// The web URL of the VCS server, including the context path of self-hosted servers, for example: https://github.com
+	// On Azure Repos, the URL of the organization or collection, for example: https://dev.azure.com/jfrog
+	BaseURL string
+	// User, organization or group. On GitLab, subgroups are included, for example: jfrog/frogbot.
+	// On Bitbucket Server, the project key, which is '~' followed by the username in personal projects.
+	// On Azure Repos, the organization or collection.
+	Owner string
+	// The project of the repository. Relevant only for Azure Repos.
+	Project       string
+	Repository    string
+	PullRequestID int
+}
+
+// ParsePullRequestURL parses the web URL of a pull request of any supported VCS provider.
+// The provider is identified by the shape of the URL path, so the URLs of self-hosted servers are supported:
+// GitHub           - https://github.com/{owner}/{repository}/pull/{id}
+// GitLab           - https://gitlab.com/{group}/{subgroup}/{repository}/-/merge_requests/{id}
+// Bitbucket Server - https://bitbucket.example.com/projects/{key}/repos/{repository}/pull-requests/{id}
+// Bitbucket Server - https://bitbucket.example.com/users/{username}/repos/{repository}/pull-requests/{id}
+// Bitbucket Cloud  - https://bitbucket.org/{workspace}/{repository}/pull-requests/{id}
+// Azure Repos      - https://dev.azure.com/{organization}/{project}/_git/{repository}/pullrequest/{id}
+// Trailing path segments, such as '/files' or '/diffs', queries and fragments are ignored.
+func ParsePullRequestURL(pullRequestURL string) (PullRequestURLInfo, error) {
+	parsedURL, err := url.Parse(strings.TrimSpace(pullRequestURL))
+	if err != nil {
+		return PullRequestURLInfo{}, err
+	}
+	if parsedURL.Host == "" {
+		return PullRequestURLInfo{}, fmt.Errorf("the pull request URL %q has no host", pullRequestURL)
+	}
+	serverURL := parsedURL.Scheme + "://" + parsedURL.Host
+	segments := strings.FieldsFunc(parsedURL.Path, func(r rune) bool { return r == '/' })
+	for i, segment := range segments {
+		pullRequestID, err := strconv.Atoi(getSegment(segments, i+1))
+		if err != nil || pullRequestID <= 0 {
+			continue
+		}
+		info := PullRequestURLInfo{BaseURL: serverURL, PullRequestID: pullRequestID}
+		switch {
+		case segment == "pull" && i == 2:
+			info.Provider, info.Owner, info.Repository = GitHub, segments[0], segments[1]
+			return info, nil
+		case segment == "merge_requests":
+			// The '-' separator is missing in the URLs of old GitLab versions
+			repositoryIndex := i - 1
+			if getSegment(segments, repositoryIndex) == "-" {
+				repositoryIndex--
+			}
+			if repositoryIndex < 1 {
+				continue
+			}
+			info.Provider, info.Owner, info.Repository = GitLab, strings.Join(segments[:repositoryIndex], "/"), segments[repositoryIndex]
+			return info, nil
+		case segment == "pull-requests" && i >= 4 && segments[i-2] == "repos" && (segments[i-4] == "projects" || segments[i-4] == "users"):
+			info.Provider, info.Owner, info.Repository = BitbucketServer, segments[i-3], segments[i-1]
+			if segments[i-4] == "users" {
+				info.Owner = NormalizeBitbucketServerOwner("~" + info.Owner)
+			}
+			info.BaseURL = joinURLPath(serverURL, segments[:i-4])
+			return info, nil
+		case segment == "pull-requests" && i == 2:
+			info.Provider, info.Owner, info.Repository = BitbucketCloud, segments[0], segments[1]
+			return info, nil
+		case segment == "pullrequest" && i >= 3 && segments[i-2] == "_git":
+			info.Provider, info.Project, info.Repository = AzureRepos, segments[i-3], segments[i-1]
+			if organization, isLegacyURL := strings.CutSuffix(parsedURL.Hostname(), ".visualstudio.com"); isLegacyURL && i == 3 {
+				// https://{organization}.visualstudio.com/{project}/_git/{repository}/pullrequest/{id}
+				info.Owner = organization
+				return info, nil
+			}
+			if i < 4 {
+				continue
+			}
+			info.Owner, info.BaseURL = segments[i-4], joinURLPath(serverURL, segments[:i-3])
+			return info, nil
+		}
+	}
+	return PullRequestURLInfo{}, fmt.Errorf("the URL %q isn't a pull request URL of a supported VCS provider", pullRequestURL)
+}
+
+func getSegment(segments []string, index int) string {
+	if index < 0 || index >= len(segments) {
+		return ""
+	}
+	return segments[index]
+}
+
+func joinURLPath(serverURL string, segments []string) string {
+	if len(segments) == 0 {
+		return serverURL
+	}
+	return serverURL + "/" + strings.Join(segments, "/")
+}
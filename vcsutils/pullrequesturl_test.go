@@ -0,0 +1,92 @@
+package vcsutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePullRequestURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		expectedInfo PullRequestURLInfo
+	}{
+		{
+			name:         "GitHub",
+			url:          "https://github.com/jfrog/froggit-go/pull/123",
+			expectedInfo: PullRequestURLInfo{Provider: GitHub, BaseURL: "https://github.com", Owner: "jfrog", Repository: "froggit-go", PullRequestID: 123},
+		},
+		{
+			name:         "GitHub Enterprise with a trailing path",
+			url:          "https://github.example.com/jfrog/froggit-go/pull/123/files?diff=split#r1",
+			expectedInfo: PullRequestURLInfo{Provider: GitHub, BaseURL: "https://github.example.com", Owner: "jfrog", Repository: "froggit-go", PullRequestID: 123},
+		},
+		{
+			name:         "GitLab with subgroups",
+			url:          "https://gitlab.com/jfrog/security/froggit-go/-/merge_requests/7/diffs",
+			expectedInfo: PullRequestURLInfo{Provider: GitLab, BaseURL: "https://gitlab.com", Owner: "jfrog/security", Repository: "froggit-go", PullRequestID: 7},
+		},
+		{
+			name:         "Self-hosted GitLab without a separator",
+			url:          "http://gitlab.example.com/jfrog/froggit-go/merge_requests/7",
+			expectedInfo: PullRequestURLInfo{Provider: GitLab, BaseURL: "http://gitlab.example.com", Owner: "jfrog", Repository: "froggit-go", PullRequestID: 7},
+		},
+		{
+			name:         "Bitbucket Server with a context path",
+			url:          "https://git.example.com/bitbucket/projects/JFROG/repos/froggit-go/pull-requests/45/overview",
+			expectedInfo: PullRequestURLInfo{Provider: BitbucketServer, BaseURL: "https://git.example.com/bitbucket", Owner: "JFROG", Repository: "froggit-go", PullRequestID: 45},
+		},
+		{
+			name:         "Bitbucket Server personal project",
+			url:          "https://git.example.com/users/frogger/repos/froggit-go/pull-requests/45",
+			expectedInfo: PullRequestURLInfo{Provider: BitbucketServer, BaseURL: "https://git.example.com", Owner: "~FROGGER", Repository: "froggit-go", PullRequestID: 45},
+		},
+		{
+			name:         "Bitbucket Cloud",
+			url:          "https://bitbucket.org/jfrog/froggit-go/pull-requests/9/diff",
+			expectedInfo: PullRequestURLInfo{Provider: BitbucketCloud, BaseURL: "https://bitbucket.org", Owner: "jfrog", Repository: "froggit-go", PullRequestID: 9},
+		},
+		{
+			name:         "Azure Repos",
+			url:          "https://dev.azure.com/jfrog/froggit/_git/froggit-go/pullrequest/47",
+			expectedInfo: PullRequestURLInfo{Provider: AzureRepos, BaseURL: "https://dev.azure.com/jfrog", Owner: "jfrog", Project: "froggit", Repository: "froggit-go", PullRequestID: 47},
+		},
+		{
+			name:         "Azure DevOps Server",
+			url:          "https://tfs.example.com/tfs/DefaultCollection/froggit/_git/froggit-go/pullrequest/47?_a=files",
+			expectedInfo: PullRequestURLInfo{Provider: AzureRepos, BaseURL: "https://tfs.example.com/tfs/DefaultCollection", Owner: "DefaultCollection", Project: "froggit", Repository: "froggit-go", PullRequestID: 47},
+		},
+		{
+			name:         "Legacy Azure DevOps",
+			url:          "https://jfrog.visualstudio.com/froggit/_git/froggit-go/pullrequest/47",
+			expectedInfo: PullRequestURLInfo{Provider: AzureRepos, BaseURL: "https://jfrog.visualstudio.com", Owner: "jfrog", Project: "froggit", Repository: "froggit-go", PullRequestID: 47},
+		},
+		{
+			name:         "GitLab repository named pull",
+			url:          "https://gitlab.com/jfrog/tools/pull/-/merge_requests/3",
+			expectedInfo: PullRequestURLInfo{Provider: GitLab, BaseURL: "https://gitlab.com", Owner: "jfrog/tools", Repository: "pull", PullRequestID: 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := ParsePullRequestURL(test.url)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedInfo, info)
+		})
+	}
+}
+
+func TestParsePullRequestURLErrors(t *testing.T) {
+	for _, url := range []string{
+		"",
+		"github.com/jfrog/froggit-go/pull/123",
+		"https://github.com/jfrog/froggit-go",
+		"https://github.com/jfrog/froggit-go/pull/new",
+		"https://github.com/jfrog/froggit-go/issues/123",
+		"https://dev.azure.com/jfrog/_git/froggit-go/pullrequest/47",
+	} {
+		_, err := ParsePullRequestURL(url)
+		assert.Error(t, err, url)
+	}
+}
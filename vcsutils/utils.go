@@ -34,7 +34,42 @@ func CreateToken() string {
 // destDir             - Destination folder
 // reader              - Reader for the tar.gz file
 // shouldRemoveBaseDir - True if should remove the base directory
-func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err error) {
+func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) error {
+	return UntarWithFilter(destDir, reader, shouldRemoveBaseDir, PathFilter{})
+}
+
+// UntarWithFilter untars the files matching the filter to the given destination
+// destDir             - Destination folder
+// reader              - Reader for the tar.gz file
+// shouldRemoveBaseDir - True if should remove the base directory
+// filter              - Filter of the extracted files
+func UntarWithFilter(destDir string, reader io.Reader, shouldRemoveBaseDir bool, filter PathFilter) error {
+	_, err := UntarWithStats(destDir, reader, shouldRemoveBaseDir, filter, nil)
+	return err
+}
+
+// ExtractionStats are the statistics of the files extracted from an archive
+type ExtractionStats struct {
+	// The number of extracted files, without the directories
+	Files int
+	// The total size of the extracted files
+	Bytes    int64
+	Duration time.Duration
+}
+
+// ExtractionProgress is called after each file extracted from an archive, with the statistics of the extraction so far
+type ExtractionProgress func(stats ExtractionStats)
+
+// UntarWithStats untars the files matching the filter to the given destination, and returns the statistics of the extracted files.
+// The statistics of the files extracted before a failure are returned with the error.
+// destDir             - Destination folder
+// reader              - Reader for the tar.gz file
+// shouldRemoveBaseDir - True if should remove the base directory
+// filter              - Filter of the extracted files
+// progress            - Called after each extracted file, if not nil
+func UntarWithStats(destDir string, reader io.Reader, shouldRemoveBaseDir bool, filter PathFilter, progress ExtractionProgress) (stats ExtractionStats, err error) {
+	start := time.Now()
+	defer func() { stats.Duration = time.Since(start) }()
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
 		return
@@ -76,8 +111,12 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 		// Check the file type
 		switch header.Typeflag {
 
-		// If it's a dir, and it doesn't exist create it
+		// If it's a dir, and it doesn't exist create it.
+		// When filtering, only the directories of the extracted files are created.
 		case tar.TypeDir:
+			if !filter.IsEmpty() {
+				continue
+			}
 			err = makeDirIfMissing(target)
 			if err != nil {
 				return
@@ -85,6 +124,12 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 
 		// If it's a file create it
 		case tar.TypeReg:
+			if !filter.ShouldExtract(filePath) {
+				continue
+			}
+			if err = makeDirIfMissing(filepath.Dir(target)); err != nil {
+				return
+			}
 			var targetFile *os.File
 			targetFile, err = os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode)) // #nosec G115
 			if err != nil {
@@ -92,7 +137,10 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 			}
 
 			// Copy file contents
-			if err = safeCopy(targetFile, tarEntryReader); err != nil {
+			var written int64
+			written, err = safeCopy(targetFile, tarEntryReader)
+			stats.Bytes += written
+			if err != nil {
 				return
 			}
 
@@ -101,6 +149,8 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 			if err = targetFile.Close(); err != nil {
 				return
 			}
+			stats.Files++
+			reportExtractionProgress(progress, stats, start)
 		}
 	}
 	return
@@ -131,18 +181,28 @@ func sanitizeExtractionPath(filePath string, destination string) (string, error)
 	return target, nil
 }
 
-func safeCopy(targetFile *os.File, reader io.Reader) error {
+// safeCopy copies the reader to the file in chunks, and returns the number of copied bytes
+func safeCopy(targetFile *os.File, reader io.Reader) (int64, error) {
+	var written int64
 	for {
-		_, err := io.CopyN(targetFile, reader, 1024)
+		n, err := io.CopyN(targetFile, reader, 1024)
+		written += n
 		if err != nil {
 			if err == io.EOF {
-				return nil
+				return written, nil
 			}
-			return err
+			return written, err
 		}
 	}
 }
 
+func reportExtractionProgress(progress ExtractionProgress, stats ExtractionStats, start time.Time) {
+	if progress != nil {
+		stats.Duration = time.Since(start)
+		progress(stats)
+	}
+}
+
 // DiscardResponseBody prepare http response body for closing
 func DiscardResponseBody(resp *http.Response) error {
 	if resp != nil {
@@ -184,6 +244,15 @@ func PointerOf[T any](v T) *T {
 	return &v
 }
 
+// NormalizeBitbucketServerOwner returns the owner of a Bitbucket Server repository in the form of the project keys returned by the API.
+// Personal project keys are the username prefixed by '~', which Bitbucket Server keys in upper case.
+func NormalizeBitbucketServerOwner(owner string) string {
+	if strings.HasPrefix(owner, "~") {
+		return strings.ToUpper(owner)
+	}
+	return owner
+}
+
 // AddBranchPrefix adds a branchPrefix to a branch name if it is not already present.
 func AddBranchPrefix(branch string) string {
 	if branch != "" && !strings.HasPrefix(branch, branchPrefix) {
@@ -193,48 +262,73 @@ func AddBranchPrefix(branch string) string {
 }
 
 // Unzip a file to dest path
-func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
+func Unzip(zipFileContent []byte, destinationToUnzip string) error {
+	return UnzipWithFilter(zipFileContent, destinationToUnzip, PathFilter{})
+}
+
+// UnzipWithFilter unzips the files matching the filter to dest path
+func UnzipWithFilter(zipFileContent []byte, destinationToUnzip string, filter PathFilter) error {
+	_, err := UnzipWithStats(zipFileContent, destinationToUnzip, filter, nil)
+	return err
+}
+
+// UnzipWithStats unzips the files matching the filter to dest path, and returns the statistics of the extracted files.
+// The statistics of the files extracted before a failure are returned with the error.
+// progress - Called after each extracted file, if not nil
+func UnzipWithStats(zipFileContent []byte, destinationToUnzip string, filter PathFilter, progress ExtractionProgress) (stats ExtractionStats, err error) {
+	start := time.Now()
+	defer func() { stats.Duration = time.Since(start) }()
 	zf, err := zip.NewReader(bytes.NewReader(zipFileContent), int64(len(zipFileContent)))
 	if err != nil {
-		return err
+		return
 	}
 	// Get the absolute destination path
 	destinationToUnzip, err = filepath.Abs(destinationToUnzip)
 	if err != nil {
-		return err
+		return
 	}
 
 	// Iterate over zip files inside the archive and unzip each of them
 	for _, f := range zf.File {
-		err = unzipFile(f, destinationToUnzip)
+		// When filtering, only the directories of the extracted files are created
+		if !filter.IsEmpty() && (f.FileInfo().IsDir() || !filter.ShouldExtract(f.Name)) {
+			continue
+		}
+		var written int64
+		written, err = unzipFile(f, destinationToUnzip)
+		stats.Bytes += written
 		if err != nil {
-			return err
+			return
+		}
+		if !f.FileInfo().IsDir() {
+			stats.Files++
+			reportExtractionProgress(progress, stats, start)
 		}
 	}
-
-	return nil
+	return
 }
 
-func unzipFile(f *zip.File, destination string) (err error) {
+// unzipFile unzips a file or a directory, and returns the number of the bytes written to the file
+func unzipFile(f *zip.File, destination string) (written int64, err error) {
 	// Check if file paths are not vulnerable to Zip Slip
 	fullFilePath, err := sanitizeExtractionPath(f.Name, destination)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	// Create directory tree
 	if f.FileInfo().IsDir() {
 		if e := os.MkdirAll(fullFilePath, 0700); err == nil {
-			return e
+			return 0, e
 		}
-		return nil
+		return 0, nil
 	} else if err = os.MkdirAll(filepath.Dir(fullFilePath), 0700); err != nil {
-		return err
+		return 0, err
 	}
 
 	// Create a destination file for unzipped content
 	destinationFile, err := os.OpenFile(filepath.Clean(fullFilePath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		err = errors.Join(err, destinationFile.Close())
@@ -242,7 +336,7 @@ func unzipFile(f *zip.File, destination string) (err error) {
 	// Unzip the content of a file and copy it to the destination file
 	zippedFile, err := f.Open()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		err = errors.Join(err, zippedFile.Close())
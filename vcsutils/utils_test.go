@@ -43,6 +43,47 @@ func TestUntarRemoveBaseDir(t *testing.T) {
 	assert.Equal(t, "b", fileinfo[0].Name())
 }
 
+func TestUntarWithFilter(t *testing.T) {
+	destDir, tarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, tarball.Close())
+	}()
+
+	err := UntarWithFilter(destDir, tarball, true, PathFilter{Include: []string{"/b/c"}})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "b", "c", "file"))
+
+	excludedDestDir, excludedTarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, excludedTarball.Close())
+	}()
+	err = UntarWithFilter(excludedDestDir, excludedTarball, false, PathFilter{Exclude: []string{"c"}})
+	assert.NoError(t, err)
+	fileinfo, err := os.ReadDir(excludedDestDir)
+	assert.NoError(t, err)
+	assert.Empty(t, fileinfo)
+}
+
+func TestUntarWithStats(t *testing.T) {
+	destDir, tarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, tarball.Close())
+	}()
+
+	var progress []ExtractionStats
+	stats, err := UntarWithStats(destDir, tarball, true, PathFilter{}, func(stats ExtractionStats) {
+		progress = append(progress, stats)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, int64(6), stats.Bytes)
+	assert.Positive(t, stats.Duration)
+	if assert.Len(t, progress, 1) {
+		assert.Equal(t, 1, progress[0].Files)
+		assert.Equal(t, int64(6), progress[0].Bytes)
+	}
+}
+
 func TestUntarError(t *testing.T) {
 	err := Untar("", io.MultiReader(), false)
 	assert.Error(t, err)
@@ -58,7 +99,7 @@ func TestSanitizeExtractionPath(t *testing.T) {
 }
 
 func TestSafeCopyError(t *testing.T) {
-	err := safeCopy(nil, nil)
+	_, err := safeCopy(nil, nil)
 	assert.Error(t, err)
 }
 
@@ -144,6 +185,45 @@ func TestUnzip(t *testing.T) {
 	assert.Equal(t, "README.md", fileinfo[0].Name())
 }
 
+func TestUnzipWithFilter(t *testing.T) {
+	destDir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, RemoveTempDir(destDir))
+	}()
+	zipFileContent, err := os.ReadFile(filepath.Join("testdata", "hello_world.zip"))
+	assert.NoError(t, err)
+
+	err = UnzipWithFilter(zipFileContent, destDir, PathFilter{Exclude: []string{"*.md"}})
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+
+	err = UnzipWithFilter(zipFileContent, destDir, PathFilter{Include: []string{"*.md"}})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "README.md"))
+}
+
+func TestUnzipWithStats(t *testing.T) {
+	destDir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, RemoveTempDir(destDir))
+	}()
+	zipFileContent, err := os.ReadFile(filepath.Join("testdata", "hello_world.zip"))
+	assert.NoError(t, err)
+
+	stats, err := UnzipWithStats(zipFileContent, destDir, PathFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, int64(7174), stats.Bytes)
+	assert.Positive(t, stats.Duration)
+
+	stats, err = UnzipWithStats(zipFileContent, destDir, PathFilter{Exclude: []string{"*.md"}}, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, stats.Files)
+	assert.Zero(t, stats.Bytes)
+}
+
 func TestAddBranchPrefix(t *testing.T) {
 	branch := "sampleBranch"
 	branchWithPrefix := AddBranchPrefix(branch)
@@ -152,6 +232,12 @@ func TestAddBranchPrefix(t *testing.T) {
 	assert.Equal(t, branchWithPrefix, "refs/heads/sampleBranch")
 }
 
+func TestNormalizeBitbucketServerOwner(t *testing.T) {
+	assert.Equal(t, "~FROGGER", NormalizeBitbucketServerOwner("~frogger"))
+	assert.Equal(t, "~FROGGER", NormalizeBitbucketServerOwner("~FROGGER"))
+	assert.Equal(t, "jfrog", NormalizeBitbucketServerOwner("jfrog"))
+}
+
 func TestGetZeroValue(t *testing.T) {
 	assert.Equal(t, 0, GetZeroValue[int]())
 	assert.Equal(t, "", GetZeroValue[string]())
@@ -43,14 +43,25 @@ func (webhook *bitbucketServerWebhookParser) validatePayload(_ context.Context,
 
 	expectedSignature := request.Header.Get(sha256Signature)
 	if len(token) > 0 || len(expectedSignature) > 0 {
-		actualSignature := calculatePayloadSignature(payload.Bytes(), token)
-		if expectedSignature != "sha256="+actualSignature {
-			return nil, errors.New("payload signature mismatch")
+		if err := ValidateBitbucketServerPayloadSignature(payload.Bytes(), expectedSignature, token); err != nil {
+			return nil, err
 		}
 	}
 	return payload.Bytes(), nil
 }
 
+// ValidateBitbucketServerPayloadSignature validates the signature of an incoming Bitbucket server webhook payload.
+// payload   - The raw body of the webhook request
+// signature - The value of the X-Hub-Signature header, which is 'sha256=' followed by the hex encoded HMAC of the payload
+// secret    - The secret of the webhook, which is the token returned by CreateWebhook
+func ValidateBitbucketServerPayloadSignature(payload []byte, signature string, secret []byte) error {
+	expectedSignature := "sha256=" + calculatePayloadSignature(payload, secret)
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return errors.New("payload signature mismatch")
+	}
+	return nil
+}
+
 func (webhook *bitbucketServerWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
 	bitbucketServerWebHook := &bitbucketServerWebHook{}
 	err := json.Unmarshal(payload, bitbucketServerWebHook)
@@ -164,7 +175,7 @@ func (webhook *bitbucketServerWebhookParser) parseTagEvent(hook *bitbucketServer
 func (webhook *bitbucketServerWebhookParser) getRepositoryDetails(repository bitbucketv1.Repository) WebHookInfoRepoDetails {
 	return WebHookInfoRepoDetails{
 		Name:  repository.Slug,
-		Owner: repository.Project.Key,
+		Owner: vcsutils.NormalizeBitbucketServerOwner(repository.Project.Key),
 	}
 }
 
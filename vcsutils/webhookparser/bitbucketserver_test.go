@@ -379,6 +379,15 @@ func TestBitbucketServerPayloadMismatchSignature(t *testing.T) {
 	assert.EqualError(t, err, "payload signature mismatch")
 }
 
+func TestValidateBitbucketServerPayloadSignature(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pushpayload.json"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, ValidateBitbucketServerPayloadSignature(payload, "sha256="+bitbucketServerPushSha256, token))
+	assert.EqualError(t, ValidateBitbucketServerPayloadSignature(payload, bitbucketServerPushSha256, token), "payload signature mismatch")
+	assert.EqualError(t, ValidateBitbucketServerPayloadSignature(payload, "sha256="+bitbucketServerPushSha256, []byte("rotated")), "payload signature mismatch")
+}
+
 func formatOwnerForBitbucketServer(owner string) string {
 	return fmt.Sprintf("~%s", strings.ToUpper(owner))
 }
@@ -0,0 +1,169 @@
+package webhookparser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+var (
+	// ErrUnsupportedWebhookEvent is returned when the webhook is of an event which isn't parsed, such as a comment
+	ErrUnsupportedWebhookEvent = errors.New("the webhook event is not supported")
+	// ErrNotPullRequestEvent is returned by ParsePullRequestEventPayload when the webhook isn't of a pull request event
+	ErrNotPullRequestEvent = errors.New("the webhook event is not a pull request event")
+)
+
+// WebhookEventPayload is the typed payload of a webhook event, which is one of *PushEventPayload, *PullRequestEventPayload or *TagEventPayload
+type WebhookEventPayload interface {
+	// The event type of the webhook
+	WebhookEvent() vcsutils.WebhookEvent
+}
+
+// PushEventPayload is the payload of a push to a branch
+type PushEventPayload struct {
+	Repository WebHookInfoRepoDetails
+	Branch     string
+	// Whether the branch was created, updated or deleted by the push
+	BranchStatus WebHookInfoBranchStatus
+	// The head commit of the branch after the push
+	Commit WebHookInfoCommit
+	// The head commit of the branch before the push
+	BeforeCommit WebHookInfoCommit
+	TriggeredBy  WebHookInfoUser
+	Committer    WebHookInfoUser
+	Author       WebHookInfoUser
+	// HTML URL to see the git comparison between the commits
+	CompareUrl string
+	// Seconds from epoch
+	Timestamp int64
+}
+
+func (payload *PushEventPayload) WebhookEvent() vcsutils.WebhookEvent {
+	return vcsutils.Push
+}
+
+// PullRequestEventPayload is the payload of a pull request which was opened, edited, merged or rejected
+type PullRequestEventPayload struct {
+	// One of PrOpened, PrEdited, PrMerged or PrRejected
+	Event vcsutils.WebhookEvent
+	// The pull request, which can be passed as is to the pull request methods of the VcsClient
+	PullRequest vcsclient.PullRequestInfo
+	Title       string
+	// The hash of the head commit of the target branch
+	TargetHash  string
+	TriggeredBy WebHookInfoUser
+}
+
+func (payload *PullRequestEventPayload) WebhookEvent() vcsutils.WebhookEvent {
+	return payload.Event
+}
+
+// TagEventPayload is the payload of a pushed or removed tag
+type TagEventPayload struct {
+	// One of TagPushed or TagRemoved
+	Event vcsutils.WebhookEvent
+	Tag   WebhookInfoTag
+}
+
+func (payload *TagEventPayload) WebhookEvent() vcsutils.WebhookEvent {
+	return payload.Event
+}
+
+// EventPayload returns the typed payload of the event of the webhook, by its event type
+func (webhook *WebhookInfo) EventPayload() (WebhookEventPayload, error) {
+	switch webhook.Event {
+	case vcsutils.Push:
+		return &PushEventPayload{
+			Repository:   webhook.TargetRepositoryDetails,
+			Branch:       webhook.TargetBranch,
+			BranchStatus: webhook.BranchStatus,
+			Commit:       webhook.Commit,
+			BeforeCommit: webhook.BeforeCommit,
+			TriggeredBy:  webhook.TriggeredBy,
+			Committer:    webhook.Committer,
+			Author:       webhook.Author,
+			CompareUrl:   webhook.CompareUrl,
+			Timestamp:    webhook.Timestamp,
+		}, nil
+	case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected:
+		return webhook.pullRequestEventPayload(), nil
+	case vcsutils.TagPushed, vcsutils.TagRemoved:
+		if webhook.Tag == nil {
+			return nil, ErrUnsupportedWebhookEvent
+		}
+		return &TagEventPayload{Event: webhook.Event, Tag: *webhook.Tag}, nil
+	default:
+		return nil, ErrUnsupportedWebhookEvent
+	}
+}
+
+func (webhook *WebhookInfo) pullRequestEventPayload() *PullRequestEventPayload {
+	payload := &PullRequestEventPayload{
+		Event: webhook.Event,
+		PullRequest: vcsclient.PullRequestInfo{
+			ID:     int64(webhook.PullRequestId),
+			Source: vcsclient.BranchInfo{Name: webhook.SourceBranch, Repository: webhook.SourceRepositoryDetails.Name, Owner: webhook.SourceRepositoryDetails.Owner},
+			Target: vcsclient.BranchInfo{Name: webhook.TargetBranch, Repository: webhook.TargetRepositoryDetails.Name, Owner: webhook.TargetRepositoryDetails.Owner},
+		},
+	}
+	if pullRequest := webhook.PullRequest; pullRequest != nil {
+		// The compare URLs are the web URLs of the pull requests, or the web URLs of their diffs
+		htmlURL := strings.TrimSuffix(strings.TrimSuffix(pullRequest.CompareUrl, "/files"), "/diff")
+		payload.PullRequest.URL = htmlURL
+		payload.PullRequest.HTMLURL = htmlURL
+		payload.PullRequest.HeadCommitHash = pullRequest.SourceHash
+		payload.PullRequest.Author = pullRequest.Author.Login
+		if pullRequest.Timestamp > 0 {
+			payload.PullRequest.UpdatedAt = time.Unix(pullRequest.Timestamp, 0).UTC()
+		}
+		payload.Title = pullRequest.Title
+		payload.TargetHash = pullRequest.TargetHash
+		payload.TriggeredBy = pullRequest.TriggeredBy
+	}
+	return payload
+}
+
+// ParseWebhookEventPayload parses an incoming webhook HTTP request into the typed payload of its event.
+// Use a type switch on the returned payload to handle each of the events.
+// Returns ErrUnsupportedWebhookEvent if the event of the webhook isn't parsed, such as a comment.
+// ctx - Go context
+// logger - Used to log any trace about the parsing
+// origin - Information about the hook origin
+// request - Received HTTP request
+func ParseWebhookEventPayload(ctx context.Context, logger vcsutils.Log, origin WebhookOrigin, request *http.Request) (WebhookEventPayload, error) {
+	webhook, err := ParseIncomingWebhook(ctx, logger, origin, request)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, ErrUnsupportedWebhookEvent
+	}
+	return webhook.EventPayload()
+}
+
+// ParsePullRequestEventPayload parses an incoming webhook HTTP request of a pull request event,
+// whose pull request can be passed to the pull request methods of the VcsClient.
+// Returns ErrNotPullRequestEvent if the webhook isn't of a pull request event.
+// ctx - Go context
+// logger - Used to log any trace about the parsing
+// origin - Information about the hook origin
+// request - Received HTTP request
+func ParsePullRequestEventPayload(ctx context.Context, logger vcsutils.Log, origin WebhookOrigin, request *http.Request) (*PullRequestEventPayload, error) {
+	payload, err := ParseWebhookEventPayload(ctx, logger, origin, request)
+	if errors.Is(err, ErrUnsupportedWebhookEvent) {
+		return nil, ErrNotPullRequestEvent
+	}
+	if err != nil {
+		return nil, err
+	}
+	pullRequestPayload, ok := payload.(*PullRequestEventPayload)
+	if !ok {
+		return nil, ErrNotPullRequestEvent
+	}
+	return pullRequestPayload, nil
+}
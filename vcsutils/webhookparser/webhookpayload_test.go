@@ -0,0 +1,81 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+func createGitHubWebhookRequest(t *testing.T, payloadFilename, payloadSha, event string) *http.Request {
+	payload, err := os.ReadFile(filepath.Join("testdata", "github", payloadFilename))
+	assert.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	request.Header.Add(githubSha256Header, "sha256="+payloadSha)
+	request.Header.Add(githubEventHeader, event)
+	return request
+}
+
+func TestParsePullRequestEventPayload(t *testing.T) {
+	origin := WebhookOrigin{VcsProvider: vcsutils.GitHub, Token: token}
+	request := createGitHubWebhookRequest(t, "prmergepayload", githubPrMergeSha256, "pull_request")
+	payload, err := ParsePullRequestEventPayload(context.Background(), vcsutils.EmptyLogger{}, origin, request)
+	assert.NoError(t, err)
+	assert.Equal(t, &PullRequestEventPayload{
+		Event: vcsutils.PrMerged,
+		PullRequest: vcsclient.PullRequestInfo{
+			ID:             gitHubExpectedPrID,
+			URL:            "https://github.com/yahavi/hello-world/pull/2",
+			HTMLURL:        "https://github.com/yahavi/hello-world/pull/2",
+			Source:         vcsclient.BranchInfo{Name: expectedSourceBranch, Repository: expectedRepoName, Owner: expectedOwner},
+			Target:         vcsclient.BranchInfo{Name: expectedBranch, Repository: expectedRepoName, Owner: expectedOwner},
+			HeadCommitHash: "92e9b0a232117eccf28c2ef4c0021bd33f2fb2a4",
+			Author:         "yahavi",
+			UpdatedAt:      time.Unix(githubPrMergeExpectedTime, 0).UTC(),
+		},
+		Title:      "Update+README.md+now",
+		TargetHash: "9d497bd67a395a8063774f200338769ccbcee916",
+		TriggeredBy: WebHookInfoUser{
+			Login:     "yahavi",
+			AvatarUrl: "https://avatars.githubusercontent.com/u/11367982?v=4",
+		},
+	}, payload)
+
+	request = createGitHubWebhookRequest(t, "pushpayload", githubPushSha256, "push")
+	_, err = ParsePullRequestEventPayload(context.Background(), vcsutils.EmptyLogger{}, origin, request)
+	assert.ErrorIs(t, err, ErrNotPullRequestEvent)
+}
+
+func TestParseWebhookEventPayload(t *testing.T) {
+	origin := WebhookOrigin{VcsProvider: vcsutils.GitHub, Token: token}
+	request := createGitHubWebhookRequest(t, "pushpayload", githubPushSha256, "push")
+	payload, err := ParseWebhookEventPayload(context.Background(), vcsutils.EmptyLogger{}, origin, request)
+	assert.NoError(t, err)
+	if pushPayload, ok := payload.(*PushEventPayload); assert.True(t, ok) {
+		assert.Equal(t, vcsutils.Push, pushPayload.WebhookEvent())
+		assert.Equal(t, expectedBranch, pushPayload.Branch)
+		assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, pushPayload.Repository)
+		assert.Equal(t, "9d497bd67a395a8063774f200338769ccbcee916", pushPayload.Commit.Hash)
+	}
+
+	request = createGitHubWebhookRequest(t, "tagcreatepayload.json", githubTagPushSha256, "push")
+	payload, err = ParseWebhookEventPayload(context.Background(), vcsutils.EmptyLogger{}, origin, request)
+	assert.NoError(t, err)
+	if tagPayload, ok := payload.(*TagEventPayload); assert.True(t, ok) {
+		assert.Equal(t, vcsutils.TagPushed, tagPayload.WebhookEvent())
+		assert.Equal(t, "tag_intg", tagPayload.Tag.Name)
+	}
+
+	_, err = (&WebhookInfo{}).EventPayload()
+	assert.ErrorIs(t, err, ErrUnsupportedWebhookEvent)
+}